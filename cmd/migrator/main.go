@@ -1,36 +1,266 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"os"
+	"strconv"
 
+	"github.com/Artemiadze/gRPC-Service/internal/lib/secrets"
+	"github.com/Artemiadze/gRPC-Service/internal/migrations"
 	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/lib/pq"
 )
 
+const usage = `Usage: migrator [flags] <command> [args]
+
+Commands:
+  up [N]      apply all or N pending migrations (default)
+  down [N]    roll back all or N applied migrations
+  goto V      migrate up or down to version V
+  version     print the current version
+  force V     set version V without running migrations (use -1 to reset)
+  status      list applied and pending migrations
+
+Flags:
+`
+
 func main() {
-	var storagePath, migrationPath, migrationTable string
+	var (
+		storagePath, migrationPath, migrationTable string
+		dryRun                                     bool
+	)
 
-	flag.StringVar(&storagePath, "storage", os.Getenv("DB_URL"), "PostgreSQL DSN (or via env DB_URL)")
-	flag.StringVar(&migrationPath, "migration", "migrations", "Path to the migration files")
-	flag.StringVar(&migrationTable, "table", "migrations", "Name of the migration table")
+	flag.StringVar(&storagePath, "storage", os.Getenv("DB_URL"), "PostgreSQL DSN or a secret reference like file:///run/secrets/dsn (or via env DB_URL)")
+	flag.StringVar(&migrationPath, "migration", "", "Path to the migration files (default: migrations built into the binary)")
+	flag.StringVar(&migrationTable, "table", defaultTable(), "Name of the migration table (or via env MIGRATIONS_TABLE, as migrations_table of the service)")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print the SQL of up, down and goto instead of running it")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
-	if storagePath == "" || migrationPath == "" {
-		panic("Missing required flags: storage or migration")
+	if storagePath == "" {
+		fail(errors.New("missing required flag: storage"))
+	}
+	storagePath, err := secrets.Resolve(context.Background(), storagePath)
+	if err != nil {
+		fail(err)
 	}
 
-	migrator, err := migrate.New(
-		"file://"+migrationPath,
-		storagePath,
-	)
+	command, args := "up", flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	db, err := sql.Open("postgres", storagePath)
+	if err != nil {
+		fail(fmt.Errorf("failed to open database: %w", err))
+	}
+
+	var fsys fs.FS = migrations.FS
+	if migrationPath != "" {
+		fsys = os.DirFS(migrationPath)
+	}
+
+	// Имя таблицы передаётся драйверу явно: migrate.New из DSN его не берёт.
+	migrator, err := migrations.New(fsys, db, migrationTable)
+	if err != nil {
+		fail(fmt.Errorf("failed to create migrator: %w", err))
+	}
+	defer migrator.Close()
+
+	r := runner{
+		migrator: migrator,
+		fsys:     fsys,
+		dryRun:   dryRun,
+	}
+	if err := r.run(command, args); err != nil {
+		fail(err)
+	}
+}
+
+type runner struct {
+	migrator *migrate.Migrate
+	fsys     fs.FS
+	dryRun   bool
+}
+
+func (r runner) run(command string, args []string) error {
+	switch command {
+	case "up", "down":
+		n, err := optionalCount(args)
+		if err != nil {
+			return err
+		}
+		if r.dryRun {
+			return r.plan(func(files []migrationFile, current int) ([]step, error) {
+				if command == "up" {
+					return planUp(files, current, n), nil
+				}
+				return planDown(files, current, n), nil
+			})
+		}
+		return r.apply(func() error {
+			switch {
+			case command == "up" && n == 0:
+				return r.migrator.Up()
+			case command == "up":
+				return r.migrator.Steps(n)
+			case n == 0:
+				return r.migrator.Down()
+			default:
+				return r.migrator.Steps(-n)
+			}
+		})
+
+	case "goto":
+		v, err := requiredInt(args)
+		if err != nil {
+			return err
+		}
+		if v < 0 {
+			return fmt.Errorf("goto: version must not be negative")
+		}
+		if r.dryRun {
+			return r.plan(func(files []migrationFile, current int) ([]step, error) {
+				return planGoto(files, current, uint(v))
+			})
+		}
+		return r.apply(func() error { return r.migrator.Migrate(uint(v)) })
+
+	case "force":
+		v, err := requiredInt(args)
+		if err != nil {
+			return err
+		}
+		if err := r.migrator.Force(v); err != nil {
+			return fmt.Errorf("failed to force version: %w", err)
+		}
+		fmt.Printf("version forced to %d\n", v)
+		return nil
+
+	case "version":
+		current, dirty, err := r.version()
+		if err != nil {
+			return err
+		}
+		if current == noVersion {
+			fmt.Println("none")
+			return nil
+		}
+		fmt.Printf("%d (dirty: %t)\n", current, dirty)
+		return nil
+
+	case "status":
+		current, dirty, err := r.version()
+		if err != nil {
+			return err
+		}
+		files, err := loadMigrations(r.fsys)
+		if err != nil {
+			return fmt.Errorf("failed to read migrations: %w", err)
+		}
+		return printStatus(os.Stdout, files, current, dirty)
+
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// apply выполняет миграцию. Отсутствие изменений ошибкой не считается.
+func (r runner) apply(fn func() error) error {
+	err := fn()
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("no change")
+		return nil
+	}
 	if err != nil {
-		panic(fmt.Sprintf("failed to create migrator: %v", err))
+		return fmt.Errorf("failed to apply migrations: %w", err)
 	}
 
-	if err := migrator.Up(); err != nil && err.Error() != "no change" {
-		panic(fmt.Sprintf("failed to apply migrations: %v", err))
+	current, _, err := r.version()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("now at version %d\n", current)
+	return nil
+}
+
+// plan печатает SQL, который выполнила бы команда, не трогая базу.
+func (r runner) plan(build func(files []migrationFile, current int) ([]step, error)) error {
+	current, dirty, err := r.version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return migrate.ErrDirty{Version: current}
+	}
+
+	files, err := loadMigrations(r.fsys)
+	if err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
 	}
+
+	steps, err := build(files, current)
+	if err != nil {
+		return err
+	}
+
+	return printPlan(os.Stdout, r.fsys, steps)
+}
+
+// version возвращает текущую версию базы или noVersion, если миграций ещё не было.
+func (r runner) version() (int, bool, error) {
+	v, dirty, err := r.migrator.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return noVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read version: %w", err)
+	}
+	return int(v), dirty, nil
+}
+
+func optionalCount(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid count %q: must be a positive number", args[0])
+	}
+	return n, nil
+}
+
+func requiredInt(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, errors.New("missing version argument")
+	}
+	v, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid version %q", args[0])
+	}
+	return v, nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "migrator:", err)
+	os.Exit(1)
+}
+
+// defaultTable - таблица версии схемы из MIGRATIONS_TABLE, той же переменной,
+// что и migrations_table сервиса, иначе migrations.DefaultTable.
+func defaultTable() string {
+	if table := os.Getenv("MIGRATIONS_TABLE"); table != "" {
+		return table
+	}
+
+	return migrations.DefaultTable
 }
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// noVersion - текущая версия базы, в которой ещё не применена ни одна миграция.
+const noVersion = -1
+
+// migrationFile - пара файлов одной версии миграции.
+type migrationFile struct {
+	Version uint
+	Up      string
+	Down    string
+}
+
+// step - одна миграция из плана: версия и файл, который будет выполнен.
+type step struct {
+	File migrationFile
+	Down bool
+}
+
+func (s step) name() string {
+	if s.Down {
+		return s.File.Down
+	}
+	return s.File.Up
+}
+
+// loadMigrations читает список миграций из каталога, отсортированный по версии.
+// Файлы, не похожие на миграции, пропускаются.
+func loadMigrations(fsys fs.FS) ([]migrationFile, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*migrationFile)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		m, err := source.Parse(e.Name())
+		if err != nil {
+			continue
+		}
+
+		f, ok := byVersion[m.Version]
+		if !ok {
+			f = &migrationFile{Version: m.Version}
+			byVersion[m.Version] = f
+		}
+		switch m.Direction {
+		case source.Up:
+			f.Up = m.Raw
+		case source.Down:
+			f.Down = m.Raw
+		}
+	}
+
+	files := make([]migrationFile, 0, len(byVersion))
+	for _, f := range byVersion {
+		files = append(files, *f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Version < files[j].Version })
+
+	return files, nil
+}
+
+// planUp возвращает до limit неприменённых миграций (0 - все).
+func planUp(files []migrationFile, current int, limit int) []step {
+	var steps []step
+	for _, f := range files {
+		if int(f.Version) <= current {
+			continue
+		}
+		if limit > 0 && len(steps) == limit {
+			break
+		}
+		steps = append(steps, step{File: f})
+	}
+	return steps
+}
+
+// planDown возвращает до limit применённых миграций в обратном порядке (0 - все).
+func planDown(files []migrationFile, current int, limit int) []step {
+	var steps []step
+	for i := len(files) - 1; i >= 0; i-- {
+		if int(files[i].Version) > current {
+			continue
+		}
+		if limit > 0 && len(steps) == limit {
+			break
+		}
+		steps = append(steps, step{File: files[i], Down: true})
+	}
+	return steps
+}
+
+// planGoto возвращает миграции, которые переведут базу в версию target.
+func planGoto(files []migrationFile, current int, target uint) ([]step, error) {
+	idx := sort.Search(len(files), func(i int) bool { return files[i].Version >= target })
+	if idx == len(files) || files[idx].Version != target {
+		return nil, fmt.Errorf("no migration with version %d", target)
+	}
+
+	var steps []step
+	if int(target) >= current {
+		for _, s := range planUp(files, current, 0) {
+			if s.File.Version > target {
+				break
+			}
+			steps = append(steps, s)
+		}
+		return steps, nil
+	}
+
+	for _, s := range planDown(files, current, 0) {
+		if s.File.Version <= target {
+			break
+		}
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+// printPlan печатает SQL миграций из плана, не выполняя их.
+func printPlan(w io.Writer, fsys fs.FS, steps []step) error {
+	if len(steps) == 0 {
+		fmt.Fprintln(w, "-- no change")
+		return nil
+	}
+
+	for _, s := range steps {
+		name := s.name()
+		if name == "" {
+			fmt.Fprintf(w, "-- version %d: no down migration, only the version will change\n\n", s.File.Version)
+			continue
+		}
+
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "-- %s\n%s\n\n", name, body)
+	}
+
+	return nil
+}
+
+// printStatus печатает применённые и ожидающие миграции.
+func printStatus(w io.Writer, files []migrationFile, current int, dirty bool) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	if current == noVersion {
+		fmt.Fprintln(tw, "version:\tnone")
+	} else {
+		fmt.Fprintf(tw, "version:\t%d\n", current)
+	}
+	if dirty {
+		fmt.Fprintln(tw, "dirty:\ttrue (fix the schema and run force)")
+	}
+	fmt.Fprintln(tw)
+
+	for _, f := range files {
+		state := "pending"
+		if int(f.Version) < current || (int(f.Version) == current && !dirty) {
+			state = "applied"
+		} else if int(f.Version) == current {
+			state = "dirty"
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", state, f.Up)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Artemiadze/gRPC-Service/internal/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = fstest.MapFS{
+	"001_create_users.up.sql":   {Data: []byte("CREATE TABLE users();")},
+	"001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	"002_add_apps.up.sql":       {Data: []byte("CREATE TABLE apps();")},
+	"003_add_index.up.sql":      {Data: []byte("CREATE INDEX i ON users(id);")},
+	"003_add_index.down.sql":    {Data: []byte("DROP INDEX i;")},
+	"README.md":                 {Data: []byte("not a migration")},
+}
+
+func versions(steps []step) []uint {
+	var res []uint
+	for _, s := range steps {
+		res = append(res, s.File.Version)
+	}
+	return res
+}
+
+func TestPlan(t *testing.T) {
+	files, err := loadMigrations(testMigrations)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	assert.Equal(t, "002_add_apps.up.sql", files[1].Up)
+	assert.Empty(t, files[1].Down)
+
+	assert.Equal(t, []uint{1, 2, 3}, versions(planUp(files, noVersion, 0)))
+	assert.Equal(t, []uint{2}, versions(planUp(files, 1, 1)))
+	assert.Empty(t, planUp(files, 3, 0))
+
+	assert.Equal(t, []uint{3, 2, 1}, versions(planDown(files, 3, 0)))
+	assert.Equal(t, []uint{2}, versions(planDown(files, 2, 1)))
+
+	steps, err := planGoto(files, noVersion, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, versions(steps))
+
+	steps, err = planGoto(files, 3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{3, 2}, versions(steps))
+
+	_, err = planGoto(files, 1, 7)
+	assert.Error(t, err)
+}
+
+func TestPrintPlan(t *testing.T) {
+	files, err := loadMigrations(testMigrations)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, printPlan(&buf, testMigrations, planDown(files, 3, 2)))
+	assert.Contains(t, buf.String(), "-- 003_add_index.down.sql\nDROP INDEX i;")
+	assert.Contains(t, buf.String(), "version 2: no down migration")
+
+	buf.Reset()
+	require.NoError(t, printStatus(&buf, files, 2, false))
+	assert.Contains(t, buf.String(), "applied  002_add_apps.up.sql")
+	assert.Contains(t, buf.String(), "pending  003_add_index.up.sql")
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	files, err := loadMigrations(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, f := range files {
+		assert.NotEmpty(t, f.Up, "version %d has no up migration", f.Version)
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -16,58 +18,111 @@ const (
 	envProd  = "prod"
 )
 
+var printConfig = flag.Bool("print-config", false, "Print the resulting config with secrets redacted and exit")
+
 func main() {
 	// для запуска go run cmd/sso/main.go --config=./config/local.yaml
 
 	// загрузка конфигурации
 	cfg := config.MustLoad()
 
+	if *printConfig {
+		out, err := cfg.YAML()
+		if err != nil {
+			panic("cannot print config: " + err.Error())
+		}
+		fmt.Print(out)
+		return
+	}
+
 	// инициализация логгера
-	logger := setupLogger(cfg.Env)
+	logger, level := setupLogger(cfg)
 	defer logger.Sync() // flush буфера
 
 	logger.Info("starting service",
 		zap.String("env", cfg.Env),
-		zap.String("storage_path", cfg.DSN),
+		zap.String("storage_path", config.RedactDSN(cfg.DSN)),
 	)
 	//logger.Debug("Debug message")
 
 	// инициализация приложения (app)
-	application := app.New(logger, cfg.GRPC.Port, cfg.DSN, cfg.TokenTTL)
+	application := app.New(logger, cfg)
 
 	// Go-routine для запуска gRPC сервера
 	go application.GRPCServer.MustRun()
 
+	// SIGHUP перечитывает конфиг без перезапуска
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+
 	// ожидание сигнала остановки
 	// для graceful shutdown
 	// (например, при нажатии Ctrl+C)
 	gracefulShutdown := make(chan os.Signal, 1)
 	signal.Notify(gracefulShutdown, syscall.SIGTERM, syscall.SIGINT)
 
-	signal := <-gracefulShutdown
-	logger.Info("stopping application",
-		zap.String("signal", signal.String()),
-		zap.String("env", cfg.Env))
+	for {
+		select {
+		case <-reload:
+			cfg = reloadConfig(logger, level, application, cfg)
+		case signal := <-gracefulShutdown:
+			logger.Info("stopping application",
+				zap.String("signal", signal.String()),
+				zap.String("env", cfg.Env))
+
+			application.Stop()
+			logger.Info("application stopped")
+			return
+		}
+	}
+}
+
+// reloadConfig перечитывает конфиг и применяет настройки, которые можно
+// менять на ходу. Если конфиг не прошёл проверку, остаются прежние настройки.
+func reloadConfig(logger *zap.Logger, level zap.AtomicLevel, application *app.App, cfg *config.Config) *config.Config {
+	next, err := cfg.Reload()
+	if err != nil {
+		logger.Error("failed to reload config, keeping the current one", zap.Error(err))
+		return cfg
+	}
 
-	application.GRPCServer.Stop()
-	logger.Info("application stopped")
+	if fields := cfg.RestartRequired(next); len(fields) > 0 {
+		logger.Warn("config changes require a restart and are ignored", zap.Strings("fields", fields))
+	}
 
+	level.SetLevel(next.Level())
+	application.Reload(next)
+	logger.Info("config reloaded",
+		zap.Stringer("log_level", next.Level()),
+		zap.Duration("token_ttl", next.TokenTTL),
+		zap.String("token_issuer", next.TokenIssuer),
+	)
+
+	// Не применённые настройки остаются прежними до перезапуска
+	cfg.LogLevel, cfg.TokenTTL, cfg.TokenIssuer = next.LogLevel, next.TokenTTL, next.TokenIssuer
+
+	return cfg
 }
 
-func setupLogger(env string) *zap.Logger {
+func setupLogger(appCfg *config.Config) (*zap.Logger, zap.AtomicLevel) {
 	var logger *zap.Logger
 	var err error
 
-	switch env {
+	level := zap.NewAtomicLevelAt(appCfg.Level())
+
+	switch appCfg.Env {
 	case envLocal:
 		cfg := zap.NewDevelopmentConfig()
+		cfg.Level = level
 		logger, err = cfg.Build()
 	case envDev:
 		cfg := zap.NewDevelopmentConfig()
 		cfg.Encoding = "json"
+		cfg.Level = level
 		logger, err = cfg.Build()
 	case envProd:
 		cfg := zap.NewProductionConfig()
+		cfg.Level = level
 		logger, err = cfg.Build()
 	default:
 		logger = zap.NewExample() // fallback logger
@@ -78,5 +133,5 @@ func setupLogger(env string) *zap.Logger {
 		panic("cannot initialize zap logger: " + err.Error())
 	}
 
-	return logger
+	return logger, level
 }
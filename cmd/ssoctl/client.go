@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/yaml.v3"
+)
+
+// profile - настройки подключения к серверу. Хранятся в файле профилей
+// и переопределяются переменными окружения и флагами.
+type profile struct {
+	Addr       string        `yaml:"addr"`
+	TLS        bool          `yaml:"tls"`
+	CA         string        `yaml:"ca"`          // PEM с корневыми сертификатами, пусто - системные
+	ServerName string        `yaml:"server_name"` // имя для проверки сертификата, если отличается от addr
+	Insecure   bool          `yaml:"insecure"`    // не проверять сертификат сервера
+	Token      string        `yaml:"token"`
+	Timeout    time.Duration `yaml:"timeout"`
+	Output     string        `yaml:"output"` // table, json
+}
+
+// profilesFile - файл профилей, по умолчанию ~/.config/ssoctl/profiles.yaml:
+//
+//	current: local
+//	profiles:
+//	  local:
+//	    addr: localhost:50051
+//	  prod:
+//	    addr: sso.example.com:443
+//	    tls: true
+//	    token: eyJhbGciOi...
+type profilesFile struct {
+	Current  string             `yaml:"current"`
+	Profiles map[string]profile `yaml:"profiles"`
+}
+
+var defaultProfile = profile{
+	Addr:    "localhost:50051",
+	Timeout: 10 * time.Second,
+	Output:  "table",
+}
+
+// connFlags - флаги подключения, общие для всех команд, работающих через gRPC.
+type connFlags struct {
+	fs       *flag.FlagSet
+	file     string
+	name     string
+	override profile
+}
+
+func newConnFlags(fs *flag.FlagSet) *connFlags {
+	c := &connFlags{fs: fs}
+
+	fs.StringVar(&c.file, "profiles", envOr("SSOCTL_PROFILES", defaultProfilesPath()), "Profiles file (or via env SSOCTL_PROFILES)")
+	fs.StringVar(&c.name, "profile", os.Getenv("SSOCTL_PROFILE"), "Profile name (or via env SSOCTL_PROFILE, default: current in the file)")
+	fs.StringVar(&c.override.Addr, "addr", "", "Server address host:port")
+	fs.BoolVar(&c.override.TLS, "tls", false, "Connect over TLS")
+	fs.StringVar(&c.override.CA, "ca", "", "PEM file with CA certificates for TLS")
+	fs.StringVar(&c.override.ServerName, "server-name", "", "Override the TLS server name")
+	fs.BoolVar(&c.override.Insecure, "insecure", false, "Skip TLS certificate verification")
+	fs.StringVar(&c.override.Token, "token", "", "Bearer token (or via env SSOCTL_TOKEN)")
+	fs.DurationVar(&c.override.Timeout, "timeout", 0, "Request timeout")
+	fs.StringVar(&c.override.Output, "o", "", "Output format: table or json")
+
+	return c
+}
+
+// resolve собирает итоговые настройки: значения по умолчанию < профиль < окружение < флаги.
+func (c *connFlags) resolve() (profile, error) {
+	p := defaultProfile
+
+	stored, err := c.loadProfile()
+	if err != nil {
+		return profile{}, err
+	}
+	p.merge(stored)
+
+	if token := os.Getenv("SSOCTL_TOKEN"); token != "" {
+		p.Token = token
+	}
+
+	set := map[string]bool{}
+	c.fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if set["addr"] {
+		p.Addr = c.override.Addr
+	}
+	if set["tls"] {
+		p.TLS = c.override.TLS
+	}
+	if set["ca"] {
+		p.CA = c.override.CA
+	}
+	if set["server-name"] {
+		p.ServerName = c.override.ServerName
+	}
+	if set["insecure"] {
+		p.Insecure = c.override.Insecure
+	}
+	if set["token"] {
+		p.Token = c.override.Token
+	}
+	if set["timeout"] {
+		p.Timeout = c.override.Timeout
+	}
+	if set["o"] {
+		p.Output = c.override.Output
+	}
+
+	if p.Output != "table" && p.Output != "json" {
+		return profile{}, fmt.Errorf("unknown output format %q: use table or json", p.Output)
+	}
+
+	return p, nil
+}
+
+// loadProfile читает профиль из файла. Отсутствие файла не ошибка,
+// если профиль не указан явно.
+func (c *connFlags) loadProfile() (profile, error) {
+	raw, err := os.ReadFile(c.file)
+	if errors.Is(err, os.ErrNotExist) && c.name == "" {
+		return profile{}, nil
+	}
+	if err != nil {
+		return profile{}, fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	var file profilesFile
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return profile{}, fmt.Errorf("failed to parse %s: %w", c.file, err)
+	}
+
+	name := c.name
+	if name == "" {
+		name = file.Current
+	}
+	if name == "" {
+		return profile{}, nil
+	}
+
+	p, ok := file.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("profile %q not found in %s", name, c.file)
+	}
+
+	return p, nil
+}
+
+// merge переносит в p непустые поля other.
+func (p *profile) merge(other profile) {
+	if other.Addr != "" {
+		p.Addr = other.Addr
+	}
+	if other.CA != "" {
+		p.CA = other.CA
+	}
+	if other.ServerName != "" {
+		p.ServerName = other.ServerName
+	}
+	if other.Token != "" {
+		p.Token = other.Token
+	}
+	if other.Timeout != 0 {
+		p.Timeout = other.Timeout
+	}
+	if other.Output != "" {
+		p.Output = other.Output
+	}
+	p.TLS = p.TLS || other.TLS
+	p.Insecure = p.Insecure || other.Insecure
+}
+
+// session - подключение к серверу на время одной команды.
+type session struct {
+	conn    *grpc.ClientConn
+	profile profile
+	out     printer
+}
+
+// connect подключается к серверу по настройкам из флагов и профиля.
+func (c *connFlags) connect() (*session, error) {
+	p, err := c.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := p.credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(p.Addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", p.Addr, err)
+	}
+
+	return &session{conn: conn, profile: p, out: newPrinter(p.Output)}, nil
+}
+
+func (s *session) Close() error {
+	return s.conn.Close()
+}
+
+// context возвращает контекст запроса с таймаутом и bearer токеном, если он задан.
+func (s *session) context() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.profile.Timeout)
+	if s.profile.Token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+s.profile.Token)
+	}
+
+	return ctx, cancel
+}
+
+// streamContext - контекст долгих потоковых команд (импорт и выгрузка):
+// без таймаута, но отменяется по Ctrl+C.
+func (s *session) streamContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	if s.profile.Token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+s.profile.Token)
+	}
+
+	return ctx, cancel
+}
+
+func (p profile) credentials() (credentials.TransportCredentials, error) {
+	if !p.TLS {
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg := &tls.Config{
+		ServerName:         p.ServerName,
+		InsecureSkipVerify: p.Insecure, //nolint:gosec // явно запрошено флагом -insecure
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if p.CA != "" {
+		pem, err := os.ReadFile(p.CA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", p.CA)
+		}
+		cfg.RootCAs = pool
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+func defaultProfilesPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "ssoctl.yaml"
+	}
+
+	return filepath.Join(dir, "ssoctl", "profiles.yaml")
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+
+	return fallback
+}
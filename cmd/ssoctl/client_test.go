@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnFlags_Resolve(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "profiles.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+current: local
+profiles:
+  local:
+    addr: localhost:50051
+  prod:
+    addr: sso.example.com:443
+    tls: true
+    token: profile-token
+    output: json
+`), 0o600))
+
+	resolve := func(args ...string) (profile, error) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		c := newConnFlags(fs)
+		require.NoError(t, fs.Parse(append([]string{"-profiles", file}, args...)))
+		return c.resolve()
+	}
+
+	t.Setenv("SSOCTL_TOKEN", "")
+
+	p, err := resolve()
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:50051", p.Addr, "current profile")
+	assert.Equal(t, "table", p.Output)
+	assert.Equal(t, 10*time.Second, p.Timeout, "default")
+
+	p, err = resolve("-profile", "prod", "-o", "table")
+	require.NoError(t, err)
+	assert.Equal(t, "sso.example.com:443", p.Addr)
+	assert.True(t, p.TLS)
+	assert.Equal(t, "profile-token", p.Token)
+	assert.Equal(t, "table", p.Output, "flag overrides profile")
+
+	t.Setenv("SSOCTL_TOKEN", "env-token")
+	p, err = resolve("-profile", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "env-token", p.Token, "env overrides profile")
+
+	p, err = resolve("-profile", "prod", "-token", "flag-token")
+	require.NoError(t, err)
+	assert.Equal(t, "flag-token", p.Token, "flag overrides env")
+
+	_, err = resolve("-profile", "missing")
+	assert.Error(t, err)
+
+	_, err = resolve("-o", "yaml")
+	assert.Error(t, err)
+}
+
+func TestDecodeClaims(t *testing.T) {
+	// {"alg":"HS256","typ":"JWT"}.{"app_id":1,"exp":1700000000,"uid":42}
+	token := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+		"eyJhcHBfaWQiOjEsImV4cCI6MTcwMDAwMDAwMCwidWlkIjo0Mn0." +
+		"signature"
+
+	claims, err := decodeClaims(token)
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, claims["uid"])
+
+	rows := claimRows(claims)
+	assert.Equal(t, []string{"exp", "1700000000 (2023-11-14T22:13:20Z)"}, rows[2])
+
+	_, err = decodeClaims("not-a-token")
+	assert.Error(t, err)
+}
@@ -0,0 +1,1298 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// rpc разбирает флаги команды вместе с флагами подключения,
+// подключается к серверу и вызывает fn с оставшимися позиционными аргументами.
+func rpc(fs *flag.FlagSet, args []string, fn func(ctx context.Context, s *session, args []string) error) error {
+	conn := newConnFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := conn.connect()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	return fn(ctx, s, fs.Args())
+}
+
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ssoctl %s\n\nFlags:\n", usage)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// passwordFlags - пароль из флага или из первой строки stdin,
+// чтобы он не попадал в историю команд.
+type passwordFlags struct {
+	value string
+	stdin bool
+}
+
+func newPasswordFlags(fs *flag.FlagSet) *passwordFlags {
+	p := &passwordFlags{}
+	fs.StringVar(&p.value, "password", "", "Password")
+	fs.BoolVar(&p.stdin, "password-stdin", false, "Read the password from stdin")
+
+	return p
+}
+
+func (p *passwordFlags) get() (string, error) {
+	if !p.stdin {
+		if p.value == "" {
+			return "", errors.New("-password or -password-stdin is required")
+		}
+		return p.value, nil
+	}
+
+	return readStdinLine("password")
+}
+
+// readStdinLine читает первую строку stdin без перевода строки.
+func readStdinLine(what string) (string, error) {
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read %s from stdin: %w", what, err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func runRegister(args []string) error {
+	fs := newFlagSet("register", "register [-org ORG] -email EMAIL -password PASSWORD")
+	org := fs.String("org", "", "Organization slug (default organization if empty)")
+	email := fs.String("email", "", "Email of the new user")
+	pass := newPasswordFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		password, err := pass.get()
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAuthClient(s.conn).Register(ctx, &ssov1.RegisterRequest{
+			Org:      *org,
+			Email:    *email,
+			Password: password,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"user_id"},
+			{strconv.FormatInt(resp.GetUserId(), 10)},
+		})
+	})
+}
+
+func runLogin(args []string) error {
+	fs := newFlagSet("login", "login [-org ORG] -email EMAIL -password PASSWORD -app-id ID [-decode]")
+	org := fs.String("org", "", "Organization slug (default organization if empty)")
+	email := fs.String("email", "", "Email")
+	appID := fs.Int64("app-id", 0, "ID of the app to log in to")
+	decode := fs.Bool("decode", false, "Print the token claims instead of the token (the signature is not verified)")
+	pass := newPasswordFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		password, err := pass.get()
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAuthClient(s.conn).Login(ctx, &ssov1.LoginRequest{
+			Org:      *org,
+			Email:    *email,
+			Password: password,
+			AppId:    *appID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !*decode {
+			// Токен печатается без оформления, чтобы его было удобно подставить в -token
+			if s.out.json {
+				return s.out.message(resp, nil)
+			}
+			_, err := fmt.Fprintln(s.out.w, resp.GetToken())
+			return err
+		}
+
+		claims, err := decodeClaims(resp.GetToken())
+		if err != nil {
+			return err
+		}
+
+		return s.out.value(claims, claimRows(claims))
+	})
+}
+
+func runPasswordlessStart(args []string) error {
+	fs := newFlagSet("passwordless start", "passwordless start [-org ORG] -email EMAIL -app-id ID [-link]")
+	org := fs.String("org", "", "Organization slug (default organization if empty)")
+	email := fs.String("email", "", "Email")
+	appID := fs.Int64("app-id", 0, "ID of the app to log in to")
+	link := fs.Bool("link", false, "Email a magic link instead of a code")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		method := "code"
+		if *link {
+			method = "magic_link"
+		}
+
+		resp, err := ssov1.NewAuthClient(s.conn).StartPasswordlessLogin(ctx, &ssov1.StartPasswordlessLoginRequest{
+			Org:    *org,
+			Email:  *email,
+			AppId:  *appID,
+			Method: method,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"login_id", "expires_at"},
+			{resp.GetLoginId(), resp.GetExpiresAt().AsTime().Format(time.RFC3339)},
+		})
+	})
+}
+
+func runPasswordlessComplete(args []string) error {
+	fs := newFlagSet("passwordless complete", "passwordless complete [-login-id ID] CODE_OR_TOKEN")
+	loginID := fs.String("login-id", "", "Login ID printed by passwordless start (not needed for a magic link token)")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("code or token is required")
+		}
+
+		resp, err := ssov1.NewAuthClient(s.conn).CompletePasswordlessLogin(ctx, &ssov1.CompletePasswordlessLoginRequest{
+			LoginId:     *loginID,
+			CodeOrToken: args[0],
+		})
+		if err != nil {
+			return err
+		}
+
+		if s.out.json {
+			return s.out.message(resp, nil)
+		}
+		_, err = fmt.Fprintln(s.out.w, resp.GetToken())
+		return err
+	})
+}
+
+func runExternalLoginStart(args []string) error {
+	fs := newFlagSet("external-login start", "external-login start -app-id ID PROVIDER\n"+
+		"Prints the login page of the provider. After login the provider redirects to the\n"+
+		"redirect URI with code and state: pass them to external-login finish.")
+	appID := fs.Int64("app-id", 0, "ID of the app to log in to")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("provider is required")
+		}
+
+		resp, err := ssov1.NewAuthClient(s.conn).StartExternalLogin(ctx, &ssov1.StartExternalLoginRequest{
+			AppId:    *appID,
+			Provider: args[0],
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"state", "expires_at", "authorization_url"},
+			{resp.GetState(), resp.GetExpiresAt().AsTime().Format(time.RFC3339), resp.GetAuthorizationUrl()},
+		})
+	})
+}
+
+func runExternalLoginFinish(args []string) error {
+	fs := newFlagSet("external-login finish", "external-login finish -state STATE CODE")
+	state := fs.String("state", "", "State from the redirect URI")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("code is required")
+		}
+
+		resp, err := ssov1.NewAuthClient(s.conn).FinishExternalLogin(ctx, &ssov1.FinishExternalLoginRequest{
+			State: *state,
+			Code:  args[0],
+		})
+		if err != nil {
+			return err
+		}
+
+		if s.out.json {
+			return s.out.message(resp, nil)
+		}
+		_, err = fmt.Fprintln(s.out.w, resp.GetToken())
+		return err
+	})
+}
+
+// decodeClaims раскодирует полезную нагрузку JWT без проверки подписи:
+// секрет приложения у клиента обычно отсутствует.
+func decodeClaims(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token payload: %w", err)
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode token payload: %w", err)
+	}
+
+	return claims, nil
+}
+
+func claimRows(claims map[string]any) [][]string {
+	keys := make([]string, 0, len(claims))
+	for k := range claims {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := [][]string{{"claim", "value"}}
+	for _, k := range keys {
+		value := fmt.Sprint(claims[k])
+		if n, ok := claims[k].(float64); ok {
+			value = strconv.FormatFloat(n, 'f', -1, 64)
+			if k == "exp" || k == "iat" || k == "nbf" {
+				value += " (" + time.Unix(int64(n), 0).UTC().Format(time.RFC3339) + ")"
+			}
+		}
+		rows = append(rows, []string{k, value})
+	}
+
+	return rows
+}
+
+func runIsAdmin(args []string) error {
+	fs := newFlagSet("is-admin", "is-admin USER_ID")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("user ID is required")
+		}
+		userID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q", args[0])
+		}
+
+		resp, err := ssov1.NewAuthClient(s.conn).IsAdmin(ctx, &ssov1.IsAdminRequest{UserId: userID})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"user_id", "is_admin"},
+			{args[0], strconv.FormatBool(resp.GetIsAdmin())},
+		})
+	})
+}
+
+func runAppsList(args []string) error {
+	fs := newFlagSet("apps list", "apps list")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		resp, err := ssov1.NewAdminClient(s.conn).ListApps(ctx, &ssov1.ListAppsRequest{})
+		if err != nil {
+			return err
+		}
+
+		rows := [][]string{{"id", "org_id", "name", "access_policy"}}
+		for _, app := range resp.GetApps() {
+			rows = append(rows, []string{
+				strconv.FormatInt(app.GetId(), 10),
+				strconv.FormatInt(app.GetOrgId(), 10),
+				app.GetName(),
+				app.GetAccessPolicy(),
+			})
+		}
+
+		return s.out.message(resp, rows)
+	})
+}
+
+func runAppsCreate(args []string) error {
+	fs := newFlagSet("apps create", "apps create [-org ORG] -name NAME [-secret SECRET] [-access-policy POLICY]")
+	org := fs.String("org", "", "Organization slug (your own organization if empty)")
+	name := fs.String("name", "", "App name")
+	secret := fs.String("secret", "", "App secret (generated if empty)")
+	policy := fs.String("access-policy", "", "open (default), invite_only or approval_required")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		resp, err := ssov1.NewAdminClient(s.conn).CreateApp(ctx, &ssov1.CreateAppRequest{
+			Org:          *org,
+			Name:         *name,
+			Secret:       *secret,
+			AccessPolicy: *policy,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"id", "org_id", "name", "secret"},
+			{
+				strconv.FormatInt(resp.GetApp().GetId(), 10),
+				strconv.FormatInt(resp.GetApp().GetOrgId(), 10),
+				resp.GetApp().GetName(),
+				resp.GetSecret(),
+			},
+		})
+	})
+}
+
+func runAppsRotate(args []string) error {
+	fs := newFlagSet("apps rotate", "apps rotate APP_ID")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("app ID is required")
+		}
+		appID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid app ID %q", args[0])
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).RotateAppSecret(ctx, &ssov1.RotateAppSecretRequest{AppId: appID})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"app_id", "secret"},
+			{args[0], resp.GetSecret()},
+		})
+	})
+}
+
+func runAppsPolicy(args []string) error {
+	fs := newFlagSet("apps policy", "apps policy APP_ID open|invite_only|approval_required")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 2 {
+			fs.Usage()
+			return errors.New("app ID and access policy are required")
+		}
+		appID, err := parseAppID(args[0])
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).SetAppAccessPolicy(ctx, &ssov1.SetAppAccessPolicyRequest{
+			AppId:        appID,
+			AccessPolicy: args[1],
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"app_id", "access_policy"},
+			{args[0], args[1]},
+		})
+	})
+}
+
+func runAppsToken(args []string) error {
+	fs := newFlagSet("apps token",
+		"apps token [-ttl D] [-issuer ISS] [-audience AUD,...] [-claims NAME=ATTR,...] APP_ID\n"+
+			"Replaces all token settings of the app: omitted flags fall back to the global settings.")
+	ttl := fs.Duration("ttl", 0, "Token lifetime")
+	issuer := fs.String("issuer", "", "iss claim")
+	audience := fs.String("audience", "", "Comma-separated aud claim values")
+	claims := fs.String("claims", "", "Comma-separated extra claims NAME=ATTR, where ATTR is a user attribute: user_id, email, org_id, roles, admin, org_admin, display_name, locale, timezone or avatar_url")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("app ID is required")
+		}
+		appID, err := parseAppID(args[0])
+		if err != nil {
+			return err
+		}
+
+		token := &ssov1.TokenSettings{
+			TtlSeconds: int64(ttl.Seconds()),
+			Issuer:     *issuer,
+			Audience:   splitList(*audience),
+		}
+		for _, claim := range splitList(*claims) {
+			name, attr, ok := strings.Cut(claim, "=")
+			if !ok {
+				return fmt.Errorf("invalid claim %q, expected NAME=ATTR", claim)
+			}
+			if token.Claims == nil {
+				token.Claims = make(map[string]string)
+			}
+			token.Claims[name] = attr
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).SetAppTokenSettings(ctx, &ssov1.SetAppTokenSettingsRequest{
+			AppId: appID,
+			Token: token,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"app_id", "ttl", "issuer", "audience", "claims"},
+			{args[0], ttl.String(), *issuer, *audience, *claims},
+		})
+	})
+}
+
+func runAppsPasskeys(args []string) error {
+	fs := newFlagSet("apps passkeys",
+		"apps passkeys [-rp-id DOMAIN -origins URL,... [-rp-name NAME]] APP_ID\n"+
+			"Sets the WebAuthn relying party of the app. Without -rp-id passkey login is turned off.")
+	rpID := fs.String("rp-id", "", "Domain passkeys are bound to, e.g. example.com")
+	rpName := fs.String("rp-name", "", "Name shown by the browser (the app name if empty)")
+	origins := fs.String("origins", "", "Comma-separated origins of login pages, e.g. https://login.example.com")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("app ID is required")
+		}
+		appID, err := parseAppID(args[0])
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).SetAppPasskeySettings(ctx, &ssov1.SetAppPasskeySettingsRequest{
+			AppId: appID,
+			Passkeys: &ssov1.PasskeySettings{
+				RpId:    *rpID,
+				RpName:  *rpName,
+				Origins: splitList(*origins),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"app_id", "rp_id", "rp_name", "origins"},
+			{args[0], *rpID, *rpName, *origins},
+		})
+	})
+}
+
+func runIdPList(args []string) error {
+	fs := newFlagSet("apps idp list", "apps idp list APP_ID")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("app ID is required")
+		}
+		appID, err := parseAppID(args[0])
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).ListAppIdentityProviders(ctx, &ssov1.ListAppIdentityProvidersRequest{AppId: appID})
+		if err != nil {
+			return err
+		}
+
+		rows := [][]string{{"name", "issuer", "client_id", "redirect_uri", "scopes", "trust_email", "link_by_email"}}
+		for _, p := range resp.GetProviders() {
+			rows = append(rows, []string{
+				p.GetName(),
+				p.GetIssuer(),
+				p.GetClientId(),
+				p.GetRedirectUri(),
+				strings.Join(p.GetScopes(), ","),
+				strconv.FormatBool(p.GetTrustEmail()),
+				strconv.FormatBool(p.GetLinkByEmail()),
+			})
+		}
+
+		return s.out.message(resp, rows)
+	})
+}
+
+func runIdPSet(args []string) error {
+	fs := newFlagSet("apps idp set",
+		"apps idp set -name NAME -issuer URL -client-id ID [-client-secret SECRET | -client-secret-stdin]\n"+
+			"    -redirect-uri URL [-scopes SCOPE,...] [-email-claim C] [-verified-claim C] [-name-claim C] [-trust-email] [-link-by-email] APP_ID\n"+
+			"Adds an OpenID Connect provider to the app or replaces the provider with the same name.")
+	name := fs.String("name", "", "Name of the provider in the app, e.g. corp")
+	issuer := fs.String("issuer", "", "Issuer URL of the provider")
+	clientID := fs.String("client-id", "", "Client ID registered at the provider")
+	clientSecret := fs.String("client-secret", "", "Client secret (empty for a public client)")
+	secretStdin := fs.Bool("client-secret-stdin", false, "Read the client secret from stdin")
+	redirectURI := fs.String("redirect-uri", "", "Page of the app the provider redirects to with code and state")
+	scopes := fs.String("scopes", "", "Comma-separated scopes besides openid, e.g. email,profile")
+	emailClaim := fs.String("email-claim", "", "Claim with the email (default email)")
+	verifiedClaim := fs.String("verified-claim", "", "Claim telling the email is verified (default email_verified)")
+	nameClaim := fs.String("name-claim", "", "Claim with the display name (default name)")
+	trustEmail := fs.Bool("trust-email", false, "Treat every email from the provider as verified")
+	linkByEmail := fs.Bool("link-by-email", false, "Link existing non-admin accounts by verified email (global admins only)")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("app ID is required")
+		}
+		appID, err := parseAppID(args[0])
+		if err != nil {
+			return err
+		}
+
+		secret := *clientSecret
+		if *secretStdin {
+			if secret, err = readStdinLine("client secret"); err != nil {
+				return err
+			}
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).SetAppIdentityProvider(ctx, &ssov1.SetAppIdentityProviderRequest{
+			AppId: appID,
+			Provider: &ssov1.IdentityProvider{
+				Name:         *name,
+				Issuer:       *issuer,
+				ClientId:     *clientID,
+				ClientSecret: secret,
+				RedirectUri:  *redirectURI,
+				Scopes:       splitList(*scopes),
+				Claims: &ssov1.ClaimMapping{
+					Email:         *emailClaim,
+					EmailVerified: *verifiedClaim,
+					Name:          *nameClaim,
+				},
+				TrustEmail:  *trustEmail,
+				LinkByEmail: *linkByEmail,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		p := resp.GetProvider()
+		return s.out.message(resp, [][]string{
+			{"app_id", "name", "issuer", "client_id", "redirect_uri"},
+			{args[0], p.GetName(), p.GetIssuer(), p.GetClientId(), p.GetRedirectUri()},
+		})
+	})
+}
+
+func runIdPRemove(args []string) error {
+	fs := newFlagSet("apps idp remove", "apps idp remove APP_ID NAME\n"+
+		"Users who logged in through the provider keep their accounts.")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 2 {
+			fs.Usage()
+			return errors.New("app ID and provider name are required")
+		}
+		appID, err := parseAppID(args[0])
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).DeleteAppIdentityProvider(ctx, &ssov1.DeleteAppIdentityProviderRequest{
+			AppId: appID,
+			Name:  args[1],
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"app_id", "name", "removed"},
+			{args[0], args[1], "true"},
+		})
+	})
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+func runMembersList(args []string) error {
+	fs := newFlagSet("apps members list", "apps members list [-status active|pending] APP_ID")
+	status := fs.String("status", "", "Show only members with this status")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("app ID is required")
+		}
+		appID, err := parseAppID(args[0])
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).ListAppMembers(ctx, &ssov1.ListAppMembersRequest{
+			AppId:  appID,
+			Status: *status,
+		})
+		if err != nil {
+			return err
+		}
+
+		rows := [][]string{{"user_id", "email", "status", "added_by", "updated_at"}}
+		for _, m := range resp.GetMembers() {
+			rows = append(rows, []string{
+				strconv.FormatInt(m.GetUserId(), 10),
+				m.GetEmail(),
+				m.GetStatus(),
+				strconv.FormatInt(m.GetAddedBy(), 10),
+				m.GetUpdatedAt().AsTime().Format(time.RFC3339),
+			})
+		}
+
+		return s.out.message(resp, rows)
+	})
+}
+
+func runMembersInvite(args []string) error {
+	return changeMember("apps members invite", args, func(ctx context.Context, c ssov1.AdminClient, appID int64, ref *ssov1.UserRef) (proto.Message, string, error) {
+		resp, err := c.InviteAppMember(ctx, &ssov1.InviteAppMemberRequest{AppId: appID, User: ref})
+		return resp, resp.GetMember().GetStatus(), err
+	})
+}
+
+func runMembersApprove(args []string) error {
+	return changeMember("apps members approve", args, func(ctx context.Context, c ssov1.AdminClient, appID int64, ref *ssov1.UserRef) (proto.Message, string, error) {
+		resp, err := c.ApproveAppMember(ctx, &ssov1.ApproveAppMemberRequest{AppId: appID, User: ref})
+		return resp, resp.GetMember().GetStatus(), err
+	})
+}
+
+func runMembersRemove(args []string) error {
+	return changeMember("apps members remove", args, func(ctx context.Context, c ssov1.AdminClient, appID int64, ref *ssov1.UserRef) (proto.Message, string, error) {
+		resp, err := c.RemoveAppMember(ctx, &ssov1.RemoveAppMemberRequest{AppId: appID, User: ref})
+		return resp, fmt.Sprintf("removed, %d sessions revoked", resp.GetRevokedSessions()), err
+	})
+}
+
+// changeMember разбирает "APP_ID USER_ID|EMAIL" и вызывает call.
+// call возвращает ответ и итоговый статус участника.
+func changeMember(name string, args []string,
+	call func(ctx context.Context, c ssov1.AdminClient, appID int64, ref *ssov1.UserRef) (proto.Message, string, error),
+) error {
+	fs := newFlagSet(name, name+" [-org ORG] APP_ID USER_ID|EMAIL")
+	org := userFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 2 {
+			fs.Usage()
+			return errors.New("app ID and user ID or email are required")
+		}
+		appID, err := parseAppID(args[0])
+		if err != nil {
+			return err
+		}
+		ref, err := userRef(args[1:], *org)
+		if err != nil {
+			return err
+		}
+
+		resp, result, err := call(ctx, ssov1.NewAdminClient(s.conn), appID, ref)
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{{"app_id", "user", "status"}, {args[0], args[1], result}})
+	})
+}
+
+func parseAppID(arg string) (int64, error) {
+	appID, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid app ID %q", arg)
+	}
+
+	return appID, nil
+}
+
+func runOrgsList(args []string) error {
+	fs := newFlagSet("orgs list", "orgs list")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		resp, err := ssov1.NewAdminClient(s.conn).ListOrgs(ctx, &ssov1.ListOrgsRequest{})
+		if err != nil {
+			return err
+		}
+
+		rows := [][]string{{"id", "slug", "name", "created_at"}}
+		for _, org := range resp.GetOrgs() {
+			rows = append(rows, []string{
+				strconv.FormatInt(org.GetId(), 10),
+				org.GetSlug(),
+				org.GetName(),
+				org.GetCreatedAt().AsTime().Format(time.RFC3339),
+			})
+		}
+
+		return s.out.message(resp, rows)
+	})
+}
+
+func runOrgsCreate(args []string) error {
+	fs := newFlagSet("orgs create", "orgs create -slug SLUG [-name NAME]")
+	slug := fs.String("slug", "", "Organization slug used in login and register requests")
+	name := fs.String("name", "", "Display name (the slug if empty)")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		resp, err := ssov1.NewAdminClient(s.conn).CreateOrg(ctx, &ssov1.CreateOrgRequest{Slug: *slug, Name: *name})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"id", "slug", "name"},
+			{strconv.FormatInt(resp.GetOrg().GetId(), 10), resp.GetOrg().GetSlug(), resp.GetOrg().GetName()},
+		})
+	})
+}
+
+// userFlags - флаги команд users: организация, в которой ищется EMAIL.
+func userFlags(fs *flag.FlagSet) *string {
+	return fs.String("org", "", "Organization of the user given by EMAIL (your own organization if empty)")
+}
+
+// userRef разбирает аргумент "ID или email" команд users.
+func userRef(args []string, org string) (*ssov1.UserRef, error) {
+	if len(args) != 1 {
+		return nil, errors.New("exactly one user ID or email is required")
+	}
+
+	if id, err := strconv.ParseInt(args[0], 10, 64); err == nil {
+		return &ssov1.UserRef{UserId: id}, nil
+	}
+
+	return &ssov1.UserRef{Email: args[0], Org: org}, nil
+}
+
+func runUsersLock(args []string) error {
+	fs := newFlagSet("users lock", "users lock [-org ORG] USER_ID|EMAIL")
+	org := userFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		ref, err := userRef(args, *org)
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).LockUser(ctx, &ssov1.LockUserRequest{User: ref})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"user_id", "locked_at", "revoked_sessions"},
+			{
+				strconv.FormatInt(resp.GetUserId(), 10),
+				resp.GetLockedAt().AsTime().Format(time.RFC3339),
+				strconv.FormatInt(resp.GetRevokedSessions(), 10),
+			},
+		})
+	})
+}
+
+func runUsersUnlock(args []string) error {
+	fs := newFlagSet("users unlock", "users unlock [-org ORG] USER_ID|EMAIL")
+	org := userFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		ref, err := userRef(args, *org)
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).UnlockUser(ctx, &ssov1.UnlockUserRequest{User: ref})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"user_id", "locked"},
+			{strconv.FormatInt(resp.GetUserId(), 10), "false"},
+		})
+	})
+}
+
+func runUsersDisable(args []string) error {
+	fs := newFlagSet("users disable", "users disable [-org ORG] USER_ID|EMAIL")
+	org := userFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		ref, err := userRef(args, *org)
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).DisableUser(ctx, &ssov1.DisableUserRequest{User: ref})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"user_id", "disabled_at", "revoked_sessions"},
+			{
+				strconv.FormatInt(resp.GetUserId(), 10),
+				resp.GetDisabledAt().AsTime().Format(time.RFC3339),
+				strconv.FormatInt(resp.GetRevokedSessions(), 10),
+			},
+		})
+	})
+}
+
+func runUsersEnable(args []string) error {
+	fs := newFlagSet("users enable", "users enable [-org ORG] USER_ID|EMAIL")
+	org := userFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		ref, err := userRef(args, *org)
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).EnableUser(ctx, &ssov1.EnableUserRequest{User: ref})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"user_id", "state"},
+			{strconv.FormatInt(resp.GetUserId(), 10), resp.GetState()},
+		})
+	})
+}
+
+func runUsersErase(args []string) error {
+	fs := newFlagSet("users erase", "users erase [-org ORG] USER_ID|EMAIL")
+	org := userFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		ref, err := userRef(args, *org)
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).EraseUser(ctx, &ssov1.EraseUserRequest{User: ref})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"user_id", "delete_after", "revoked_sessions"},
+			{
+				strconv.FormatInt(resp.GetUserId(), 10),
+				resp.GetDeleteAfter().AsTime().Format(time.RFC3339),
+				strconv.FormatInt(resp.GetRevokedSessions(), 10),
+			},
+		})
+	})
+}
+
+// runUsersExport печатает выгрузку данных пользователя как есть, в JSON,
+// независимо от формата вывода.
+func runUsersExport(args []string) error {
+	fs := newFlagSet("users export", "users export [-org ORG] [-f FILE] USER_ID|EMAIL")
+	org := userFlags(fs)
+	file := fs.String("f", "", "Write the export to FILE instead of stdout")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		ref, err := userRef(args, *org)
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).ExportUserData(ctx, &ssov1.ExportUserDataRequest{User: ref})
+		if err != nil {
+			return err
+		}
+
+		var data bytes.Buffer
+		if err := json.Indent(&data, resp.GetData(), "", "  "); err != nil {
+			return err
+		}
+		data.WriteByte('\n')
+
+		if *file == "" {
+			_, err = os.Stdout.Write(data.Bytes())
+			return err
+		}
+
+		return os.WriteFile(*file, data.Bytes(), 0o600)
+	})
+}
+
+func runUsersPromote(args []string) error {
+	return setAdmin("users promote", args, true)
+}
+
+func runUsersDemote(args []string) error {
+	return setAdmin("users demote", args, false)
+}
+
+// setAdmin меняет права глобального администратора или, с -org-admin,
+// администратора организации.
+func setAdmin(name string, args []string, isAdmin bool) error {
+	fs := newFlagSet(name, name+" [-org-admin] [-org ORG] USER_ID|EMAIL")
+	org := userFlags(fs)
+	orgAdmin := fs.Bool("org-admin", false, "Change organization admin rights instead of global admin rights")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		ref, err := userRef(args, *org)
+		if err != nil {
+			return err
+		}
+
+		if *orgAdmin {
+			resp, err := ssov1.NewAdminClient(s.conn).SetOrgAdmin(ctx, &ssov1.SetOrgAdminRequest{User: ref, OrgAdmin: isAdmin})
+			if err != nil {
+				return err
+			}
+
+			return s.out.message(resp, [][]string{
+				{"user_id", "org_admin"},
+				{strconv.FormatInt(resp.GetUserId(), 10), strconv.FormatBool(isAdmin)},
+			})
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).SetAdmin(ctx, &ssov1.SetAdminRequest{User: ref, IsAdmin: isAdmin})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"user_id", "is_admin"},
+			{strconv.FormatInt(resp.GetUserId(), 10), strconv.FormatBool(isAdmin)},
+		})
+	})
+}
+
+// listFlags - фильтры, сортировка и страница команд users list и users search.
+type listFlags struct {
+	org, emailPrefix, admin, locked string
+	createdAfter, createdBefore     string
+	appID                           int64
+	orderBy, pageToken              string
+	pageSize                        int
+}
+
+func newListFlags(fs *flag.FlagSet) *listFlags {
+	f := &listFlags{}
+	fs.StringVar(&f.org, "org", "", "Organization slug (all organizations you manage if empty)")
+	fs.StringVar(&f.emailPrefix, "email-prefix", "", "Only users whose email starts with this prefix")
+	fs.StringVar(&f.admin, "admin", "", "true or false: only admins or only non-admins")
+	fs.StringVar(&f.locked, "locked", "", "true or false: only locked or only active users")
+	fs.StringVar(&f.createdAfter, "created-after", "", "Only users created at or after this time (RFC 3339 or YYYY-MM-DD)")
+	fs.StringVar(&f.createdBefore, "created-before", "", "Only users created before this time (RFC 3339 or YYYY-MM-DD)")
+	fs.Int64Var(&f.appID, "app", 0, "Only active members of this app")
+	fs.StringVar(&f.orderBy, "order-by", "", `id, email or created_at, optionally followed by " desc"`)
+	fs.IntVar(&f.pageSize, "page-size", 0, "Users per page (server default if 0)")
+	fs.StringVar(&f.pageToken, "page-token", "", "Token of the page printed by the previous call")
+
+	return f
+}
+
+func (f *listFlags) filter() (*ssov1.UserFilter, error) {
+	filter := &ssov1.UserFilter{EmailPrefix: f.emailPrefix, AppId: f.appID}
+
+	for _, b := range []struct {
+		name  string
+		value string
+		dst   **bool
+	}{{"admin", f.admin, &filter.IsAdmin}, {"locked", f.locked, &filter.Locked}} {
+		if b.value == "" {
+			continue
+		}
+		v, err := strconv.ParseBool(b.value)
+		if err != nil {
+			return nil, fmt.Errorf("-%s must be true or false", b.name)
+		}
+		*b.dst = &v
+	}
+
+	for _, t := range []struct {
+		name  string
+		value string
+		dst   **timestamppb.Timestamp
+	}{{"created-after", f.createdAfter, &filter.CreatedAfter}, {"created-before", f.createdBefore, &filter.CreatedBefore}} {
+		if t.value == "" {
+			continue
+		}
+		v, err := time.Parse(time.RFC3339, t.value)
+		if err != nil {
+			if v, err = time.Parse(time.DateOnly, t.value); err != nil {
+				return nil, fmt.Errorf("-%s must be RFC 3339 time or YYYY-MM-DD", t.name)
+			}
+		}
+		*t.dst = timestamppb.New(v)
+	}
+
+	return filter, nil
+}
+
+func runUsersList(args []string) error {
+	fs := newFlagSet("users list", "users list [filter flags] [-order-by FIELD [desc]] [-page-size N] [-page-token TOKEN]")
+	list := newListFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		filter, err := list.filter()
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).ListUsers(ctx, &ssov1.ListUsersRequest{
+			Org:       list.org,
+			Filter:    filter,
+			OrderBy:   list.orderBy,
+			PageSize:  int32(list.pageSize),
+			PageToken: list.pageToken,
+		})
+		if err != nil {
+			return err
+		}
+
+		return printUsers(s, resp, resp.GetUsers(), resp.GetNextPageToken())
+	})
+}
+
+func runUsersSearch(args []string) error {
+	fs := newFlagSet("users search", "users search [filter flags] [-order-by FIELD [desc]] [-page-size N] [-page-token TOKEN] QUERY")
+	list := newListFlags(fs)
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("search query is required")
+		}
+		filter, err := list.filter()
+		if err != nil {
+			return err
+		}
+
+		resp, err := ssov1.NewAdminClient(s.conn).SearchUsers(ctx, &ssov1.SearchUsersRequest{
+			Query:     args[0],
+			Org:       list.org,
+			Filter:    filter,
+			OrderBy:   list.orderBy,
+			PageSize:  int32(list.pageSize),
+			PageToken: list.pageToken,
+		})
+		if err != nil {
+			return err
+		}
+
+		return printUsers(s, resp, resp.GetUsers(), resp.GetNextPageToken())
+	})
+}
+
+// printUsers печатает страницу пользователей. Токен следующей страницы
+// в табличном виде уходит в stderr, чтобы не мешать разбору таблицы.
+func printUsers(s *session, resp proto.Message, users []*ssov1.User, next string) error {
+	rows := [][]string{{"id", "email", "org_id", "display_name", "admin", "org_admin", "state", "locked_at", "created_at"}}
+	for _, u := range users {
+		lockedAt := ""
+		if u.GetLockedAt() != nil {
+			lockedAt = u.GetLockedAt().AsTime().Format(time.RFC3339)
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(u.GetId(), 10),
+			u.GetEmail(),
+			strconv.FormatInt(u.GetOrgId(), 10),
+			u.GetDisplayName(),
+			strconv.FormatBool(u.GetIsAdmin()),
+			strconv.FormatBool(u.GetOrgAdmin()),
+			u.GetState(),
+			lockedAt,
+			u.GetCreatedAt().AsTime().Format(time.RFC3339),
+		})
+	}
+
+	if err := s.out.message(resp, rows); err != nil {
+		return err
+	}
+	if next != "" && !s.out.json {
+		fmt.Fprintf(os.Stderr, "next page: -page-token %s\n", next)
+	}
+
+	return nil
+}
+
+func runProfileGet(args []string) error {
+	fs := newFlagSet("profile get", "profile get [-user USER_ID]")
+	userID := fs.Int64("user", 0, "User ID (the caller if empty)")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		resp, err := ssov1.NewProfileClient(s.conn).GetProfile(ctx, &ssov1.GetProfileRequest{UserId: *userID})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, profileRows(resp.GetProfile()))
+	})
+}
+
+func runProfileUpdate(args []string) error {
+	fs := newFlagSet("profile update",
+		"profile update [-user USER_ID] [-version N] [-display-name NAME] [-locale TAG] [-timezone TZ] [-avatar-url URL] [-metadata JSON]\n"+
+			"Only the given flags are changed; an empty value clears the field.")
+	userID := fs.Int64("user", 0, "User ID (the caller if empty)")
+	version := fs.Int64("version", 0, "Fail if the profile version has changed since (0 skips the check)")
+	in := &ssov1.UserProfile{}
+	fs.StringVar(&in.DisplayName, "display-name", "", "Display name")
+	fs.StringVar(&in.Locale, "locale", "", "BCP 47 language tag, e.g. en-US")
+	fs.StringVar(&in.Timezone, "timezone", "", "IANA time zone, e.g. Europe/Berlin")
+	fs.StringVar(&in.AvatarUrl, "avatar-url", "", "Avatar URL")
+	metadata := fs.String("metadata", "", "JSON object replacing the metadata of the token's app")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		var paths []string
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "display-name", "locale", "timezone", "avatar-url", "metadata":
+				paths = append(paths, strings.ReplaceAll(f.Name, "-", "_"))
+			}
+		})
+		if len(paths) == 0 {
+			fs.Usage()
+			return errors.New("at least one field to change is required")
+		}
+
+		if *metadata != "" {
+			in.Metadata = &structpb.Struct{}
+			if err := protojson.Unmarshal([]byte(*metadata), in.Metadata); err != nil {
+				return fmt.Errorf("invalid -metadata: %w", err)
+			}
+		}
+
+		resp, err := ssov1.NewProfileClient(s.conn).UpdateProfile(ctx, &ssov1.UpdateProfileRequest{
+			UserId:     *userID,
+			Profile:    in,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+			Version:    *version,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, profileRows(resp.GetProfile()))
+	})
+}
+
+func profileRows(p *ssov1.UserProfile) [][]string {
+	metadata, _ := protojson.Marshal(p.GetMetadata())
+
+	return [][]string{
+		{"user_id", "email", "display_name", "locale", "timezone", "avatar_url", "metadata", "version", "updated_at"},
+		{
+			strconv.FormatInt(p.GetUserId(), 10),
+			p.GetEmail(),
+			p.GetDisplayName(),
+			p.GetLocale(),
+			p.GetTimezone(),
+			p.GetAvatarUrl(),
+			string(metadata),
+			strconv.FormatInt(p.GetVersion(), 10),
+			p.GetUpdatedAt().AsTime().Format(time.RFC3339),
+		},
+	}
+}
+
+func runSessionsRevoke(args []string) error {
+	fs := newFlagSet("sessions revoke", "sessions revoke SESSION_ID | sessions revoke -user USER_ID [-keep-current]")
+	userID := fs.Int64("user", 0, "Revoke all sessions of this user instead of one session")
+	keepCurrent := fs.Bool("keep-current", false, "With -user: keep the session of the token used for this request")
+
+	return rpc(fs, args, func(ctx context.Context, s *session, args []string) error {
+		client := ssov1.NewSessionsClient(s.conn)
+
+		if *userID != 0 {
+			resp, err := client.RevokeAllSessions(ctx, &ssov1.RevokeAllSessionsRequest{
+				UserId:      *userID,
+				KeepCurrent: *keepCurrent,
+			})
+			if err != nil {
+				return err
+			}
+
+			return s.out.message(resp, [][]string{
+				{"user_id", "revoked"},
+				{strconv.FormatInt(*userID, 10), strconv.FormatInt(resp.GetRevoked(), 10)},
+			})
+		}
+
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("session ID or -user is required")
+		}
+
+		resp, err := client.RevokeSession(ctx, &ssov1.RevokeSessionRequest{SessionId: args[0]})
+		if err != nil {
+			return err
+		}
+
+		return s.out.message(resp, [][]string{
+			{"session_id", "revoked"},
+			{args[0], "true"},
+		})
+	})
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+)
+
+const (
+	// importMessageSize - пользователей в одном сообщении потока ImportUsers.
+	importMessageSize = 500
+	// Роли в CSV перечисляются через этот разделитель.
+	csvRolesSeparator = ";"
+)
+
+// csvColumns - колонки CSV выгрузки. При импорте колонки ищутся по заголовку,
+// нужны только email и pass_hash, лишние колонки пропускаются.
+var csvColumns = []string{"id", "org_id", "email", "pass_hash", "roles", "display_name"}
+
+// userRecord - пользователь в файле импорта или выгрузки.
+// id и org_id есть только в выгрузке, при импорте они не используются.
+type userRecord struct {
+	ID          int64    `json:"id,omitempty"`
+	OrgID       int64    `json:"org_id,omitempty"`
+	Email       string   `json:"email"`
+	PassHash    string   `json:"pass_hash,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	DisplayName string   `json:"display_name,omitempty"`
+}
+
+// stream - как rpc, но для долгих потоковых команд: без таймаута, с отменой по Ctrl+C.
+func stream(fs *flag.FlagSet, args []string, fn func(ctx context.Context, s *session, args []string) error) error {
+	conn := newConnFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := conn.connect()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	ctx, cancel := s.streamContext()
+	defer cancel()
+
+	return fn(ctx, s, fs.Args())
+}
+
+// fileFormat возвращает формат файла из флага или по расширению.
+func fileFormat(format, path string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".jsonl", ".ndjson":
+			format = "jsonl"
+		default:
+			format = "csv"
+		}
+	}
+	if format != "csv" && format != "jsonl" {
+		return "", errors.New("-format must be csv or jsonl")
+	}
+
+	return format, nil
+}
+
+// runUsersImport загружает пользователей из CSV или JSONL. Файл отправляется
+// частями по -chunk строк, каждая часть - отдельный вызов ImportUsers.
+// После каждой части печатается номер последней обработанной строки:
+// прерванный импорт продолжается с -from, а уже созданные пользователи
+// при повторе просто пропускаются.
+func runUsersImport(args []string) error {
+	fs := newFlagSet("users import", "users import [-org ORG] [-format csv|jsonl] [-from ROW] [-chunk N] FILE")
+	org := fs.String("org", "", "Organization slug (the default one if empty)")
+	format := fs.String("format", "", "File format: csv or jsonl (by file extension if empty)")
+	from := fs.Int64("from", 1, "Skip rows before this one")
+	chunk := fs.Int("chunk", 50000, "Rows per ImportUsers call")
+
+	return stream(fs, args, func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			fs.Usage()
+			return errors.New("file is required")
+		}
+		if *chunk <= 0 {
+			return errors.New("-chunk must be positive")
+		}
+		f, err := fileFormat(*format, args[0])
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		imp := &importer{
+			client: ssov1.NewAdminClient(s.conn),
+			org:    *org,
+			chunk:  *chunk,
+			total:  &ssov1.ImportUsersResponse{},
+		}
+		err = readUsers(file, f, func(row int64, rec userRecord) error {
+			if row < *from {
+				return nil
+			}
+			return imp.add(ctx, row, rec)
+		})
+		if err == nil {
+			err = imp.flush(ctx)
+		}
+		if err != nil {
+			if resume := max(imp.total.GetLastRow()+1, *from); resume > 1 {
+				fmt.Fprintf(os.Stderr, "rows before %d are imported, resume with -from %d\n", resume, resume)
+			}
+			return err
+		}
+
+		rows := [][]string{{"row", "email", "reason"}}
+		for _, e := range imp.total.GetErrors() {
+			rows = append(rows, []string{strconv.FormatInt(e.GetRow(), 10), e.GetEmail(), e.GetReason()})
+		}
+		if err := s.out.message(imp.total, rows); err != nil {
+			return err
+		}
+		if !s.out.json {
+			fmt.Fprintf(os.Stderr, "imported %d, skipped %d existing, failed %d\n",
+				imp.total.GetImported(), imp.total.GetSkipped(), imp.total.GetFailed())
+		}
+
+		return nil
+	})
+}
+
+// importer копит строки и отправляет их вызовами ImportUsers по chunk строк.
+type importer struct {
+	client ssov1.AdminClient
+	org    string
+	chunk  int
+
+	stream  ssov1.Admin_ImportUsersClient
+	pending []*ssov1.ImportedUser
+	sent    int
+	total   *ssov1.ImportUsersResponse
+}
+
+func (imp *importer) add(ctx context.Context, row int64, rec userRecord) error {
+	imp.pending = append(imp.pending, &ssov1.ImportedUser{
+		Row:         row,
+		Email:       rec.Email,
+		PassHash:    rec.PassHash,
+		Roles:       rec.Roles,
+		DisplayName: rec.DisplayName,
+	})
+	if len(imp.pending) == importMessageSize || imp.sent+len(imp.pending) >= imp.chunk {
+		if err := imp.send(ctx); err != nil {
+			return err
+		}
+	}
+	if imp.sent >= imp.chunk {
+		return imp.flush(ctx)
+	}
+
+	return nil
+}
+
+// send отправляет накопленные строки одним сообщением, открывая вызов при необходимости.
+func (imp *importer) send(ctx context.Context) error {
+	if len(imp.pending) == 0 {
+		return nil
+	}
+	if imp.stream == nil {
+		stream, err := imp.client.ImportUsers(ctx)
+		if err != nil {
+			return err
+		}
+		imp.stream = stream
+	}
+
+	req := &ssov1.ImportUsersRequest{Org: imp.org, Users: imp.pending}
+	if err := imp.stream.Send(req); err != nil {
+		// Причину ошибки возвращает CloseAndRecv
+		if errors.Is(err, io.EOF) {
+			_, err = imp.stream.CloseAndRecv()
+		}
+		return err
+	}
+	imp.sent += len(imp.pending)
+	imp.pending = nil
+
+	return nil
+}
+
+// flush завершает текущий вызов и добавляет его итог к общему.
+func (imp *importer) flush(ctx context.Context) error {
+	if err := imp.send(ctx); err != nil {
+		return err
+	}
+	if imp.stream == nil {
+		return nil
+	}
+
+	resp, err := imp.stream.CloseAndRecv()
+	imp.stream, imp.sent = nil, 0
+	if err != nil {
+		return err
+	}
+
+	imp.total.Imported += resp.GetImported()
+	imp.total.Skipped += resp.GetSkipped()
+	imp.total.Failed += resp.GetFailed()
+	imp.total.Errors = append(imp.total.Errors, resp.GetErrors()...)
+	imp.total.LastRow = max(imp.total.LastRow, resp.GetLastRow())
+	fmt.Fprintf(os.Stderr, "rows up to %d: imported %d, skipped %d, failed %d\n",
+		resp.GetLastRow(), resp.GetImported(), resp.GetSkipped(), resp.GetFailed())
+
+	return nil
+}
+
+// readUsers читает пользователей из CSV с заголовком или из JSONL и вызывает fn
+// с номером строки: в CSV строки данных нумеруются с 1 без заголовка,
+// в JSONL номер - номер строки файла.
+func readUsers(r io.Reader, format string, fn func(row int64, rec userRecord) error) error {
+	if format == "jsonl" {
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		var row int64
+		for sc.Scan() {
+			row++
+			line := strings.TrimSpace(sc.Text())
+			if line == "" {
+				continue
+			}
+			var rec userRecord
+			if err := json.Unmarshal([]byte(line), &rec); err != nil {
+				return fmt.Errorf("line %d: %w", row, err)
+			}
+			if err := fn(row, rec); err != nil {
+				return err
+			}
+		}
+		return sc.Err()
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("csv header: %w", err)
+	}
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, required := range []string{"email", "pass_hash"} {
+		if _, ok := col[required]; !ok {
+			return fmt.Errorf("csv header: column %q is missing", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := col[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var row int64
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		row++
+		if err != nil {
+			return fmt.Errorf("row %d: %w", row, err)
+		}
+
+		rec := userRecord{
+			Email:       field(record, "email"),
+			PassHash:    field(record, "pass_hash"),
+			DisplayName: field(record, "display_name"),
+		}
+		if roles := field(record, "roles"); roles != "" {
+			rec.Roles = strings.Split(roles, csvRolesSeparator)
+		}
+		if err := fn(row, rec); err != nil {
+			return err
+		}
+	}
+}
+
+// runUsersDump выгружает пользователей в CSV или JSONL в порядке ID.
+// Прерванную выгрузку можно продолжить с -after.
+func runUsersDump(args []string) error {
+	fs := newFlagSet("users dump", "users dump [-org ORG] [-format csv|jsonl] [-with-hashes] [-after ID] [-f FILE]")
+	org := fs.String("org", "", "Organization slug (all organizations you manage if empty)")
+	format := fs.String("format", "", "File format: csv or jsonl (by file extension if empty, csv for stdout)")
+	withHashes := fs.Bool("with-hashes", false, "Include password hashes (global admins only)")
+	after := fs.Int64("after", 0, "Start after this user ID")
+	path := fs.String("f", "", "Write to FILE instead of stdout")
+
+	return stream(fs, args, func(ctx context.Context, s *session, _ []string) error {
+		f, err := fileFormat(*format, *path)
+		if err != nil {
+			return err
+		}
+
+		out := io.Writer(os.Stdout)
+		if *path != "" {
+			flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+			if *after > 0 {
+				flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+			}
+			file, err := os.OpenFile(*path, flags, 0o600)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+
+		w := newUserWriter(out, f, *after == 0)
+		users, err := ssov1.NewAdminClient(s.conn).ExportUsers(ctx, &ssov1.ExportUsersRequest{
+			Org:             *org,
+			AfterId:         *after,
+			IncludePassHash: *withHashes,
+		})
+		if err != nil {
+			return err
+		}
+
+		var lastID, count int64
+		for {
+			resp, err := users.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err == nil {
+				u := resp.GetUser()
+				err = w.write(userRecord{
+					ID:          u.GetId(),
+					OrgID:       u.GetOrgId(),
+					Email:       u.GetEmail(),
+					PassHash:    resp.GetPassHash(),
+					Roles:       u.GetRoles(),
+					DisplayName: u.GetDisplayName(),
+				})
+			}
+			if err != nil {
+				if flushErr := w.flush(); flushErr == nil && lastID > 0 {
+					fmt.Fprintf(os.Stderr, "users up to ID %d are written, resume with -after %d\n", lastID, lastID)
+				}
+				return err
+			}
+			lastID = resp.GetUser().GetId()
+			count++
+		}
+
+		if err := w.flush(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "exported %d users\n", count)
+
+		return nil
+	})
+}
+
+// userWriter пишет пользователей в CSV или JSONL.
+type userWriter struct {
+	csv    *csv.Writer
+	json   *json.Encoder
+	buf    *bufio.Writer
+	header bool
+}
+
+// newUserWriter создаёт userWriter. header - писать ли заголовок CSV,
+// при продолжении выгрузки в тот же файл он не нужен.
+func newUserWriter(w io.Writer, format string, header bool) *userWriter {
+	buf := bufio.NewWriter(w)
+	if format == "jsonl" {
+		return &userWriter{json: json.NewEncoder(buf), buf: buf}
+	}
+
+	return &userWriter{csv: csv.NewWriter(buf), buf: buf, header: header}
+}
+
+func (w *userWriter) write(rec userRecord) error {
+	if w.json != nil {
+		return w.json.Encode(rec)
+	}
+
+	if w.header {
+		if err := w.csv.Write(csvColumns); err != nil {
+			return err
+		}
+		w.header = false
+	}
+
+	return w.csv.Write([]string{
+		strconv.FormatInt(rec.ID, 10),
+		strconv.FormatInt(rec.OrgID, 10),
+		rec.Email,
+		rec.PassHash,
+		strings.Join(rec.Roles, csvRolesSeparator),
+		rec.DisplayName,
+	})
+}
+
+func (w *userWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	return w.buf.Flush()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadUsers(t *testing.T) {
+	read := func(format, input string) map[int64]userRecord {
+		t.Helper()
+		got := map[int64]userRecord{}
+		require.NoError(t, readUsers(strings.NewReader(input), format, func(row int64, rec userRecord) error {
+			got[row] = rec
+			return nil
+		}))
+		return got
+	}
+
+	csvRows := read("csv", "Email,Pass_Hash,roles,legacy_id\n"+
+		"a@example.com,$2a$10$abc,editor;billing,17\n"+
+		"b@example.com,\"$pbkdf2-sha256$6400$salt$key\",,18\n")
+	assert.Equal(t, userRecord{Email: "a@example.com", PassHash: "$2a$10$abc", Roles: []string{"editor", "billing"}}, csvRows[1])
+	assert.Equal(t, "$pbkdf2-sha256$6400$salt$key", csvRows[2].PassHash)
+
+	jsonRows := read("jsonl", `{"email":"a@example.com","pass_hash":"$2a$10$abc"}`+"\n\n"+`{"email":"b@example.com"}`)
+	assert.Len(t, jsonRows, 2)
+	assert.Equal(t, "b@example.com", jsonRows[3].Email, "rows are file lines")
+
+	err := readUsers(strings.NewReader("email\na@example.com\n"), "csv", nil)
+	assert.ErrorContains(t, err, `"pass_hash" is missing`)
+}
+
+func TestUserWriter_RoundTrip(t *testing.T) {
+	in := []userRecord{
+		{ID: 1, OrgID: 1, Email: "a@example.com", PassHash: "$2a$10$abc", Roles: []string{"editor", "billing"}, DisplayName: "A, B"},
+		{ID: 2, OrgID: 1, Email: "b@example.com"},
+	}
+
+	for _, format := range []string{"csv", "jsonl"} {
+		var buf bytes.Buffer
+		w := newUserWriter(&buf, format, true)
+		for _, rec := range in {
+			require.NoError(t, w.write(rec))
+		}
+		require.NoError(t, w.flush())
+
+		var out []userRecord
+		require.NoError(t, readUsers(&buf, format, func(_ int64, rec userRecord) error {
+			out = append(out, rec)
+			return nil
+		}))
+		require.Len(t, out, 2, format)
+		assert.Equal(t, in[0].Roles, out[0].Roles, format)
+		assert.Equal(t, in[0].DisplayName, out[0].DisplayName, format)
+		assert.Equal(t, in[1].Email, out[1].Email, format)
+	}
+}
@@ -0,0 +1,152 @@
+// ssoctl - утилита администрирования SSO.
+//
+// Команды seed и secrets работают напрямую с базой, остальные - через gRPC API сервиса.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"google.golang.org/grpc/status"
+)
+
+// command - подкоманда ssoctl. Получает аргументы после своего имени.
+// Группа команд (apps, orgs, users, profile, sessions) задаётся через sub.
+type command struct {
+	summary string
+	run     func(args []string) error
+	sub     map[string]command
+}
+
+var commands = map[string]command{
+	"seed":     {summary: "create or update orgs, apps and users from a fixtures file (needs database access)", run: runSeed},
+	"register": {summary: "register a user", run: runRegister},
+	"login":    {summary: "log in and print or decode the token", run: runLogin},
+	"is-admin": {summary: "check whether a user is an admin", run: runIsAdmin},
+	"passwordless": {summary: "log in with a code or link sent by email", sub: map[string]command{
+		"start":    {summary: "email a login code or magic link", run: runPasswordlessStart},
+		"complete": {summary: "exchange the code or link token for a token", run: runPasswordlessComplete},
+	}},
+	"external-login": {summary: "log in through an external identity provider of an app", sub: map[string]command{
+		"start":  {summary: "print the login page of the provider", run: runExternalLoginStart},
+		"finish": {summary: "exchange the code from the provider for a token", run: runExternalLoginFinish},
+	}},
+	"apps": {summary: "manage apps", sub: map[string]command{
+		"list":     {summary: "list apps", run: runAppsList},
+		"create":   {summary: "create an app", run: runAppsCreate},
+		"rotate":   {summary: "replace the app secret", run: runAppsRotate},
+		"policy":   {summary: "set who may log in to the app", run: runAppsPolicy},
+		"token":    {summary: "set token lifetime, issuer, audience and claims of the app", run: runAppsToken},
+		"passkeys": {summary: "set the WebAuthn relying party of the app", run: runAppsPasskeys},
+		"idp": {summary: "manage external OpenID Connect providers of the app", sub: map[string]command{
+			"list":   {summary: "list providers", run: runIdPList},
+			"set":    {summary: "add or replace a provider", run: runIdPSet},
+			"remove": {summary: "remove a provider", run: runIdPRemove},
+		}},
+		"members": {summary: "manage app members and access requests", sub: map[string]command{
+			"list":    {summary: "list members and pending requests", run: runMembersList},
+			"invite":  {summary: "let a user into the app", run: runMembersInvite},
+			"approve": {summary: "approve an access request", run: runMembersApprove},
+			"remove":  {summary: "remove a member or reject a request", run: runMembersRemove},
+		}},
+	}},
+	"orgs": {summary: "manage organizations", sub: map[string]command{
+		"list":   {summary: "list organizations", run: runOrgsList},
+		"create": {summary: "create an organization", run: runOrgsCreate},
+	}},
+	"users": {summary: "manage user accounts", sub: map[string]command{
+		"list":    {summary: "list users with filters, page by page", run: runUsersList},
+		"search":  {summary: "find users by part of email or display name", run: runUsersSearch},
+		"lock":    {summary: "forbid login and revoke all sessions", run: runUsersLock},
+		"unlock":  {summary: "allow login again", run: runUsersUnlock},
+		"disable": {summary: "forbid login until enabled and revoke all sessions", run: runUsersDisable},
+		"enable":  {summary: "re-enable a disabled user or cancel an erase", run: runUsersEnable},
+		"erase":   {summary: "delete a user and anonymize their audit events after a grace period", run: runUsersErase},
+		"export":  {summary: "print everything stored about a user as JSON", run: runUsersExport},
+		"import":  {summary: "create users with password hashes from a CSV or JSONL file", run: runUsersImport},
+		"dump":    {summary: "write all users to a CSV or JSONL file", run: runUsersDump},
+		"promote": {summary: "grant admin or org admin rights", run: runUsersPromote},
+		"demote":  {summary: "revoke admin or org admin rights", run: runUsersDemote},
+	}},
+	"profile": {summary: "show or change user profiles", sub: map[string]command{
+		"get":    {summary: "show a profile", run: runProfileGet},
+		"update": {summary: "change profile fields given as flags", run: runProfileUpdate},
+	}},
+	"sessions": {summary: "manage login sessions", sub: map[string]command{
+		"revoke": {summary: "revoke one session or all sessions of a user", run: runSessionsRevoke},
+	}},
+	"secrets": {summary: "manage encryption of app secrets (needs database access)", sub: map[string]command{
+		"rotate": {summary: "re-encrypt app secrets with the current key", run: runSecretsRotate},
+	}},
+}
+
+func main() {
+	if err := dispatch("ssoctl", commands, os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "ssoctl:", describe(err))
+		os.Exit(1)
+	}
+}
+
+var errUsage = errors.New("usage")
+
+func dispatch(prefix string, cmds map[string]command, args []string) error {
+	if len(args) == 0 {
+		usage(prefix, cmds)
+		return errUsage
+	}
+
+	name := args[0]
+	cmd, ok := cmds[name]
+	if !ok {
+		if name != "help" && name != "-h" && name != "--help" {
+			fmt.Fprintf(os.Stderr, "%s: unknown command %q\n\n", prefix, name)
+		}
+		usage(prefix, cmds)
+		return errUsage
+	}
+
+	if cmd.sub != nil {
+		return dispatch(prefix+" "+name, cmd.sub, args[1:])
+	}
+
+	return cmd.run(args[1:])
+}
+
+func usage(prefix string, cmds map[string]command) {
+	names := make([]string, 0, len(cmds))
+	for name := range cmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", prefix)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, cmds[name].summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for command flags.\n", prefix)
+}
+
+// describe превращает gRPC статус в короткое сообщение с кодом и причиной.
+func describe(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err.Error()
+	}
+
+	msg := st.Code().String() + ": " + st.Message()
+	if reason := errmap.Reason(err); reason != "" {
+		msg += " (" + reason + ")"
+	}
+
+	return msg
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// printer печатает результат команды таблицей или JSON.
+type printer struct {
+	json bool
+	w    io.Writer
+}
+
+func newPrinter(format string) printer {
+	return printer{json: format == "json", w: os.Stdout}
+}
+
+// message печатает ответ сервера. В табличном виде выводятся строки rows,
+// первая из которых - заголовок.
+func (p printer) message(msg proto.Message, rows [][]string) error {
+	if p.json {
+		raw, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, string(raw))
+		return err
+	}
+
+	return p.table(rows)
+}
+
+// value печатает произвольное значение, например раскодированный токен.
+func (p printer) value(v any, rows [][]string) error {
+	if p.json {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	return p.table(rows)
+}
+
+func (p printer) table(rows [][]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	for i, row := range rows {
+		if i == 0 {
+			row = upper(row)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func upper(row []string) []string {
+	res := make([]string, len(row))
+	for i, s := range row {
+		res[i] = strings.ToUpper(s)
+	}
+
+	return res
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Artemiadze/gRPC-Service/internal/app"
+	"github.com/Artemiadze/gRPC-Service/internal/config"
+	postgres "github.com/Artemiadze/gRPC-Service/internal/repository"
+)
+
+// runSecretsRotate перешифровывает секреты приложений и внешних провайдеров
+// текущим ключом из конфига.
+// Запускается после смены secrets.app_secret_key, пока прежний ключ
+// ещё указан в secrets.app_secret_old_keys.
+func runSecretsRotate(args []string) error {
+	fs := flag.NewFlagSet("secrets rotate", flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("CONFIG_PATH"), "Path to the service config (or via env CONFIG_PATH)")
+	dryRun := fs.Bool("dry-run", false, "Only count the secrets that would be re-encrypted")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), "Usage: ssoctl secrets rotate -config config.yaml [-dry-run]\n\n"+
+			"Encrypts app secrets, identity provider secrets and webhook signing secrets\n"+
+			"with the current secrets.app_secret_key.\n"+
+			"Plaintext secrets and secrets encrypted with keys from secrets.app_secret_old_keys\n"+
+			"are re-encrypted.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *configPath == "" {
+		fs.Usage()
+		return errors.New("-config is required")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return err
+	}
+
+	keyring, err := app.NewAppSecretKeyring(cfg.Secrets)
+	if err != nil {
+		return err
+	}
+	if keyring == nil {
+		return errors.New("secrets.app_secret_key is not set")
+	}
+
+	storage, err := postgres.New(cfg.DSN, keyring)
+	if err != nil {
+		return err
+	}
+	defer storage.Stop()
+
+	total, rewrapped, err := storage.RewrapAppSecrets(context.Background(), *dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "re-encrypted"
+	if *dryRun {
+		verb = "would be re-encrypted"
+	}
+	fmt.Printf("%d of %d secrets %s with key %q\n", rewrapped, total, verb, cfg.Secrets.AppSecretKeyID)
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/app"
+	"github.com/Artemiadze/gRPC-Service/internal/config"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	postgres "github.com/Artemiadze/gRPC-Service/internal/repository"
+	"github.com/Artemiadze/gRPC-Service/internal/services/admin"
+	"github.com/Artemiadze/gRPC-Service/internal/services/audit"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// fixtures - содержимое файла для seed.
+type fixtures struct {
+	Orgs  []orgFixture  `yaml:"orgs"`
+	Apps  []appFixture  `yaml:"apps"`
+	Users []userFixture `yaml:"users"`
+}
+
+type orgFixture struct {
+	Slug string `yaml:"slug"`
+	Name string `yaml:"name"`
+}
+
+type appFixture struct {
+	ID     int    `yaml:"id"`     // необязателен, нужен для стабильных ID в тестах
+	Org    string `yaml:"org"`    // пусто - организация по умолчанию
+	Name   string `yaml:"name"`   // ключ для поиска существующего приложения в организации
+	Secret string `yaml:"secret"` // пусто - сгенерировать при создании
+
+	AccessPolicy string           `yaml:"access_policy"` // open, invite_only или approval_required
+	Token        *tokenFixture    `yaml:"token"`         // нет - глобальные настройки при создании, не трогать потом
+	Passkeys     *passkeysFixture `yaml:"passkeys"`      // нет - ключи доступа отключены при создании, не трогать потом
+}
+
+// passkeysFixture - проверяющая сторона WebAuthn приложения.
+type passkeysFixture struct {
+	RPID    string   `yaml:"rp_id"`
+	RPName  string   `yaml:"rp_name"`
+	Origins []string `yaml:"origins"`
+}
+
+func (f *passkeysFixture) settings() *models.PasskeySettings {
+	if f == nil {
+		return nil
+	}
+
+	return &models.PasskeySettings{RPID: f.RPID, RPName: f.RPName, Origins: f.Origins}
+}
+
+// tokenFixture - параметры токенов приложения, пустые поля - глобальные настройки.
+type tokenFixture struct {
+	TTL      time.Duration     `yaml:"ttl"`
+	Issuer   string            `yaml:"issuer"`
+	Audience []string          `yaml:"audience"`
+	Claims   map[string]string `yaml:"claims"` // имя claim -> атрибут пользователя
+}
+
+func (f *tokenFixture) settings() *models.TokenSettings {
+	if f == nil {
+		return nil
+	}
+
+	return &models.TokenSettings{TTL: f.TTL, Issuer: f.Issuer, Audience: f.Audience, Claims: f.Claims}
+}
+
+type userFixture struct {
+	Org      string   `yaml:"org"` // пусто - организация по умолчанию
+	Email    string   `yaml:"email"`
+	Password string   `yaml:"password"`
+	PassHash string   `yaml:"pass_hash"` // готовый хэш в формате PHC вместо password
+	Admin    bool     `yaml:"admin"`
+	OrgAdmin bool     `yaml:"org_admin"`
+	Roles    []string `yaml:"roles"`
+}
+
+func runSeed(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	file := fs.String("f", "", "Path to the fixtures YAML file")
+	configPath := fs.String("config", os.Getenv("CONFIG_PATH"), "Path to the service config (or via env CONFIG_PATH)")
+	verbose := fs.Bool("v", false, "Log service messages to stderr")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), "Usage: ssoctl seed -f fixtures.yaml [-config config.yaml]\n\n"+
+			"Idempotently creates or updates orgs, apps and users. Orgs are matched by slug,\n"+
+			"apps by name and users by email within their org.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" || *configPath == "" {
+		fs.Usage()
+		return errors.New("both -f and -config are required")
+	}
+
+	data, err := readFixtures(*file)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.MustLoadPath(*configPath)
+
+	log := zap.NewNop()
+	if *verbose {
+		log, _ = zap.NewDevelopment()
+	}
+
+	appSecrets, err := app.NewAppSecretKeyring(cfg.Secrets)
+	if err != nil {
+		return err
+	}
+
+	storage, err := postgres.New(cfg.DSN, appSecrets)
+	if err != nil {
+		return err
+	}
+	defer storage.Stop()
+
+	hasher, err := app.NewPasswordHasher(cfg.Password)
+	if err != nil {
+		return err
+	}
+
+	// Записи аудита дописываются в базу при Stop, до закрытия хранилища.
+	recorder := audit.NewRecorder(log, storage, cfg.Audit.BufferSize, cfg.Audit.BatchSize, cfg.Audit.FlushInterval)
+	defer recorder.Stop()
+
+	// seed вызывает только Ensure*, которые права не проверяют
+	return seed(context.Background(), os.Stdout, admin.New(log, storage, hasher, recorder, nil, 0), data)
+}
+
+func readFixtures(path string) (fixtures, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fixtures{}, err
+	}
+
+	var data fixtures
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&data); err != nil && !errors.Is(err, io.EOF) {
+		return fixtures{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return data, nil
+}
+
+// seed применяет фикстуры и печатает, что изменилось.
+// Останавливается на первой ошибке: повторный запуск продолжит с того же места.
+func seed(ctx context.Context, out io.Writer, svc *admin.Service, data fixtures) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	for i, f := range data.Orgs {
+		org, change, err := svc.EnsureOrg(ctx, admin.OrgSpec{Slug: f.Slug, Name: f.Name})
+		if err != nil {
+			return fmt.Errorf("orgs[%d] %q: %w", i, f.Slug, err)
+		}
+		fmt.Fprintf(w, "org\t%s\tid=%d\t%s\n", org.Slug, org.ID, change)
+	}
+
+	for i, f := range data.Apps {
+		a, change, err := svc.EnsureApp(ctx, admin.AppSpec{
+			ID:           f.ID,
+			Org:          f.Org,
+			Name:         f.Name,
+			Secret:       f.Secret,
+			AccessPolicy: f.AccessPolicy,
+			Token:        f.Token.settings(),
+			Passkeys:     f.Passkeys.settings(),
+		})
+		if err != nil {
+			return fmt.Errorf("apps[%d] %q: %w", i, f.Name, err)
+		}
+
+		line := fmt.Sprintf("app\t%s\tid=%d\t%s", a.Name, a.ID, change)
+		// Сгенерированный секрет больше нигде не увидеть
+		if change == admin.Created && f.Secret == "" {
+			line += "\tsecret=" + a.Secret
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	for i, f := range data.Users {
+		spec := admin.UserSpec{
+			Org:      f.Org,
+			Email:    f.Email,
+			Password: f.Password,
+			Admin:    f.Admin,
+			OrgAdmin: f.OrgAdmin,
+			Roles:    f.Roles,
+		}
+		if f.PassHash != "" {
+			spec.PassHash = []byte(f.PassHash)
+		}
+
+		id, change, err := svc.EnsureUser(ctx, spec)
+		if err != nil {
+			return fmt.Errorf("users[%d] %q: %w", i, f.Email, err)
+		}
+		fmt.Fprintf(w, "user\t%s\tid=%d\t%s\n", f.Email, id, change)
+	}
+
+	return nil
+}
@@ -0,0 +1,4183 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: sso/admin.proto
+
+package ssov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type App struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OrgId int64                  `protobuf:"varint,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// open: any user of the organization may log in.
+	// invite_only: only members added by an admin.
+	// approval_required: the first login creates a request that an admin approves.
+	AccessPolicy  string           `protobuf:"bytes,4,opt,name=access_policy,json=accessPolicy,proto3" json:"access_policy,omitempty"`
+	Token         *TokenSettings   `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	Passkeys      *PasskeySettings `protobuf:"bytes,6,opt,name=passkeys,proto3" json:"passkeys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *App) Reset() {
+	*x = App{}
+	mi := &file_sso_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *App) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App) ProtoMessage() {}
+
+func (x *App) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App.ProtoReflect.Descriptor instead.
+func (*App) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *App) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *App) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *App) GetOrgId() int64 {
+	if x != nil {
+		return x.OrgId
+	}
+	return 0
+}
+
+func (x *App) GetAccessPolicy() string {
+	if x != nil {
+		return x.AccessPolicy
+	}
+	return ""
+}
+
+func (x *App) GetToken() *TokenSettings {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *App) GetPasskeys() *PasskeySettings {
+	if x != nil {
+		return x.Passkeys
+	}
+	return nil
+}
+
+// PasskeySettings are the WebAuthn relying party of an app.
+type PasskeySettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RpId          string                 `protobuf:"bytes,1,opt,name=rp_id,json=rpId,proto3" json:"rp_id,omitempty"`       // Domain passkeys are bound to, e.g. example.com. Empty turns passkeys off.
+	RpName        string                 `protobuf:"bytes,2,opt,name=rp_name,json=rpName,proto3" json:"rp_name,omitempty"` // Shown by the browser. The app name if empty.
+	Origins       []string               `protobuf:"bytes,3,rep,name=origins,proto3" json:"origins,omitempty"`             // Exact origins of login pages, e.g. https://login.example.com.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasskeySettings) Reset() {
+	*x = PasskeySettings{}
+	mi := &file_sso_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasskeySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasskeySettings) ProtoMessage() {}
+
+func (x *PasskeySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasskeySettings.ProtoReflect.Descriptor instead.
+func (*PasskeySettings) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PasskeySettings) GetRpId() string {
+	if x != nil {
+		return x.RpId
+	}
+	return ""
+}
+
+func (x *PasskeySettings) GetRpName() string {
+	if x != nil {
+		return x.RpName
+	}
+	return ""
+}
+
+func (x *PasskeySettings) GetOrigins() []string {
+	if x != nil {
+		return x.Origins
+	}
+	return nil
+}
+
+// TokenSettings are the parameters of tokens issued for an app.
+// Zero values mean the global settings of the service.
+type TokenSettings struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TtlSeconds int64                  `protobuf:"varint,1,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	Issuer     string                 `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`     // iss claim.
+	Audience   []string               `protobuf:"bytes,3,rep,name=audience,proto3" json:"audience,omitempty"` // aud claim.
+	// Extra claims: claim name -> user attribute
+	// (user_id, email, org_id, roles, admin, org_admin, display_name,
+	// locale, timezone or avatar_url).
+	Claims        map[string]string `protobuf:"bytes,4,rep,name=claims,proto3" json:"claims,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenSettings) Reset() {
+	*x = TokenSettings{}
+	mi := &file_sso_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenSettings) ProtoMessage() {}
+
+func (x *TokenSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenSettings.ProtoReflect.Descriptor instead.
+func (*TokenSettings) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TokenSettings) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *TokenSettings) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *TokenSettings) GetAudience() []string {
+	if x != nil {
+		return x.Audience
+	}
+	return nil
+}
+
+func (x *TokenSettings) GetClaims() map[string]string {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+type Org struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Org) Reset() {
+	*x = Org{}
+	mi := &file_sso_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Org) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Org) ProtoMessage() {}
+
+func (x *Org) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Org.ProtoReflect.Descriptor instead.
+func (*Org) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Org) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Org) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Org) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Org) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListAppsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAppsRequest) Reset() {
+	*x = ListAppsRequest{}
+	mi := &file_sso_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAppsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppsRequest) ProtoMessage() {}
+
+func (x *ListAppsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppsRequest.ProtoReflect.Descriptor instead.
+func (*ListAppsRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{4}
+}
+
+type ListAppsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Apps          []*App                 `protobuf:"bytes,1,rep,name=apps,proto3" json:"apps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAppsResponse) Reset() {
+	*x = ListAppsResponse{}
+	mi := &file_sso_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAppsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppsResponse) ProtoMessage() {}
+
+func (x *ListAppsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppsResponse.ProtoReflect.Descriptor instead.
+func (*ListAppsResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAppsResponse) GetApps() []*App {
+	if x != nil {
+		return x.Apps
+	}
+	return nil
+}
+
+type CreateAppRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`                                 // Optional. Generated if empty.
+	Org           string                 `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`                                       // Slug of the organization. Empty means the caller's organization.
+	AccessPolicy  string                 `protobuf:"bytes,4,opt,name=access_policy,json=accessPolicy,proto3" json:"access_policy,omitempty"` // Optional. open by default.
+	Token         *TokenSettings         `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`                                   // Optional. Global settings by default.
+	Passkeys      *PasskeySettings       `protobuf:"bytes,6,opt,name=passkeys,proto3" json:"passkeys,omitempty"`                             // Optional. Passkeys are off by default.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAppRequest) Reset() {
+	*x = CreateAppRequest{}
+	mi := &file_sso_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAppRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAppRequest) ProtoMessage() {}
+
+func (x *CreateAppRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAppRequest.ProtoReflect.Descriptor instead.
+func (*CreateAppRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateAppRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAppRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateAppRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *CreateAppRequest) GetAccessPolicy() string {
+	if x != nil {
+		return x.AccessPolicy
+	}
+	return ""
+}
+
+func (x *CreateAppRequest) GetToken() *TokenSettings {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *CreateAppRequest) GetPasskeys() *PasskeySettings {
+	if x != nil {
+		return x.Passkeys
+	}
+	return nil
+}
+
+type CreateAppResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	App           *App                   `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAppResponse) Reset() {
+	*x = CreateAppResponse{}
+	mi := &file_sso_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAppResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAppResponse) ProtoMessage() {}
+
+func (x *CreateAppResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAppResponse.ProtoReflect.Descriptor instead.
+func (*CreateAppResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateAppResponse) GetApp() *App {
+	if x != nil {
+		return x.App
+	}
+	return nil
+}
+
+func (x *CreateAppResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type RotateAppSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateAppSecretRequest) Reset() {
+	*x = RotateAppSecretRequest{}
+	mi := &file_sso_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateAppSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateAppSecretRequest) ProtoMessage() {}
+
+func (x *RotateAppSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateAppSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateAppSecretRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RotateAppSecretRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+type RotateAppSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateAppSecretResponse) Reset() {
+	*x = RotateAppSecretResponse{}
+	mi := &file_sso_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateAppSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateAppSecretResponse) ProtoMessage() {}
+
+func (x *RotateAppSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateAppSecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateAppSecretResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RotateAppSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// UserRef identifies a user by ID or, if user_id is 0, by email
+// within the organization. Empty org means the caller's organization.
+type UserRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Org           string                 `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserRef) Reset() {
+	*x = UserRef{}
+	mi := &file_sso_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRef) ProtoMessage() {}
+
+func (x *UserRef) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRef.ProtoReflect.Descriptor instead.
+func (*UserRef) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UserRef) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserRef) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserRef) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+type LockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserRef               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockUserRequest) Reset() {
+	*x = LockUserRequest{}
+	mi := &file_sso_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockUserRequest) ProtoMessage() {}
+
+func (x *LockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockUserRequest.ProtoReflect.Descriptor instead.
+func (*LockUserRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LockUserRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type LockUserResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LockedAt        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=locked_at,json=lockedAt,proto3" json:"locked_at,omitempty"`
+	RevokedSessions int64                  `protobuf:"varint,3,opt,name=revoked_sessions,json=revokedSessions,proto3" json:"revoked_sessions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LockUserResponse) Reset() {
+	*x = LockUserResponse{}
+	mi := &file_sso_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockUserResponse) ProtoMessage() {}
+
+func (x *LockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockUserResponse.ProtoReflect.Descriptor instead.
+func (*LockUserResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LockUserResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LockUserResponse) GetLockedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LockedAt
+	}
+	return nil
+}
+
+func (x *LockUserResponse) GetRevokedSessions() int64 {
+	if x != nil {
+		return x.RevokedSessions
+	}
+	return 0
+}
+
+type UnlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserRef               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockUserRequest) Reset() {
+	*x = UnlockUserRequest{}
+	mi := &file_sso_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserRequest) ProtoMessage() {}
+
+func (x *UnlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnlockUserRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type UnlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockUserResponse) Reset() {
+	*x = UnlockUserResponse{}
+	mi := &file_sso_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserResponse) ProtoMessage() {}
+
+func (x *UnlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UnlockUserResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type SetAdminRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserRef               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	IsAdmin       bool                   `protobuf:"varint,2,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAdminRequest) Reset() {
+	*x = SetAdminRequest{}
+	mi := &file_sso_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAdminRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAdminRequest) ProtoMessage() {}
+
+func (x *SetAdminRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAdminRequest.ProtoReflect.Descriptor instead.
+func (*SetAdminRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetAdminRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *SetAdminRequest) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+type SetAdminResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAdminResponse) Reset() {
+	*x = SetAdminResponse{}
+	mi := &file_sso_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAdminResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAdminResponse) ProtoMessage() {}
+
+func (x *SetAdminResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAdminResponse.ProtoReflect.Descriptor instead.
+func (*SetAdminResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetAdminResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type SetOrgAdminRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserRef               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	OrgAdmin      bool                   `protobuf:"varint,2,opt,name=org_admin,json=orgAdmin,proto3" json:"org_admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrgAdminRequest) Reset() {
+	*x = SetOrgAdminRequest{}
+	mi := &file_sso_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrgAdminRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrgAdminRequest) ProtoMessage() {}
+
+func (x *SetOrgAdminRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrgAdminRequest.ProtoReflect.Descriptor instead.
+func (*SetOrgAdminRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetOrgAdminRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *SetOrgAdminRequest) GetOrgAdmin() bool {
+	if x != nil {
+		return x.OrgAdmin
+	}
+	return false
+}
+
+type SetOrgAdminResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrgAdminResponse) Reset() {
+	*x = SetOrgAdminResponse{}
+	mi := &file_sso_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrgAdminResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrgAdminResponse) ProtoMessage() {}
+
+func (x *SetOrgAdminResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrgAdminResponse.ProtoReflect.Descriptor instead.
+func (*SetOrgAdminResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetOrgAdminResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type CreateOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgRequest) Reset() {
+	*x = CreateOrgRequest{}
+	mi := &file_sso_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgRequest) ProtoMessage() {}
+
+func (x *CreateOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrgRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateOrgRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateOrgResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           *Org                   `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgResponse) Reset() {
+	*x = CreateOrgResponse{}
+	mi := &file_sso_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgResponse) ProtoMessage() {}
+
+func (x *CreateOrgResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrgResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateOrgResponse) GetOrg() *Org {
+	if x != nil {
+		return x.Org
+	}
+	return nil
+}
+
+type ListOrgsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgsRequest) Reset() {
+	*x = ListOrgsRequest{}
+	mi := &file_sso_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgsRequest) ProtoMessage() {}
+
+func (x *ListOrgsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrgsRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{21}
+}
+
+type ListOrgsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orgs          []*Org                 `protobuf:"bytes,1,rep,name=orgs,proto3" json:"orgs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgsResponse) Reset() {
+	*x = ListOrgsResponse{}
+	mi := &file_sso_admin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgsResponse) ProtoMessage() {}
+
+func (x *ListOrgsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrgsResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListOrgsResponse) GetOrgs() []*Org {
+	if x != nil {
+		return x.Orgs
+	}
+	return nil
+}
+
+type AppMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                   // active or pending
+	AddedBy       int64                  `protobuf:"varint,5,opt,name=added_by,json=addedBy,proto3" json:"added_by,omitempty"` // 0 for requests created by the user
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppMember) Reset() {
+	*x = AppMember{}
+	mi := &file_sso_admin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppMember) ProtoMessage() {}
+
+func (x *AppMember) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppMember.ProtoReflect.Descriptor instead.
+func (*AppMember) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AppMember) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *AppMember) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AppMember) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AppMember) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AppMember) GetAddedBy() int64 {
+	if x != nil {
+		return x.AddedBy
+	}
+	return 0
+}
+
+func (x *AppMember) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AppMember) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SetAppAccessPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	AccessPolicy  string                 `protobuf:"bytes,2,opt,name=access_policy,json=accessPolicy,proto3" json:"access_policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAppAccessPolicyRequest) Reset() {
+	*x = SetAppAccessPolicyRequest{}
+	mi := &file_sso_admin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAppAccessPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAppAccessPolicyRequest) ProtoMessage() {}
+
+func (x *SetAppAccessPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAppAccessPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetAppAccessPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetAppAccessPolicyRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *SetAppAccessPolicyRequest) GetAccessPolicy() string {
+	if x != nil {
+		return x.AccessPolicy
+	}
+	return ""
+}
+
+type SetAppAccessPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAppAccessPolicyResponse) Reset() {
+	*x = SetAppAccessPolicyResponse{}
+	mi := &file_sso_admin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAppAccessPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAppAccessPolicyResponse) ProtoMessage() {}
+
+func (x *SetAppAccessPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAppAccessPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetAppAccessPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{25}
+}
+
+type SetAppTokenSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Token         *TokenSettings         `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // Empty resets the app to the global settings.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAppTokenSettingsRequest) Reset() {
+	*x = SetAppTokenSettingsRequest{}
+	mi := &file_sso_admin_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAppTokenSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAppTokenSettingsRequest) ProtoMessage() {}
+
+func (x *SetAppTokenSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAppTokenSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetAppTokenSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetAppTokenSettingsRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *SetAppTokenSettingsRequest) GetToken() *TokenSettings {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+type SetAppTokenSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAppTokenSettingsResponse) Reset() {
+	*x = SetAppTokenSettingsResponse{}
+	mi := &file_sso_admin_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAppTokenSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAppTokenSettingsResponse) ProtoMessage() {}
+
+func (x *SetAppTokenSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAppTokenSettingsResponse.ProtoReflect.Descriptor instead.
+func (*SetAppTokenSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{27}
+}
+
+type SetAppPasskeySettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Passkeys      *PasskeySettings       `protobuf:"bytes,2,opt,name=passkeys,proto3" json:"passkeys,omitempty"` // Empty turns passkeys off.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAppPasskeySettingsRequest) Reset() {
+	*x = SetAppPasskeySettingsRequest{}
+	mi := &file_sso_admin_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAppPasskeySettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAppPasskeySettingsRequest) ProtoMessage() {}
+
+func (x *SetAppPasskeySettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAppPasskeySettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetAppPasskeySettingsRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetAppPasskeySettingsRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *SetAppPasskeySettingsRequest) GetPasskeys() *PasskeySettings {
+	if x != nil {
+		return x.Passkeys
+	}
+	return nil
+}
+
+type SetAppPasskeySettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAppPasskeySettingsResponse) Reset() {
+	*x = SetAppPasskeySettingsResponse{}
+	mi := &file_sso_admin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAppPasskeySettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAppPasskeySettingsResponse) ProtoMessage() {}
+
+func (x *SetAppPasskeySettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAppPasskeySettingsResponse.ProtoReflect.Descriptor instead.
+func (*SetAppPasskeySettingsResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{29}
+}
+
+// IdentityProvider is an external OpenID Connect provider users can log in to an app with.
+type IdentityProvider struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // Unique within the app, e.g. corp. Passed to StartExternalLogin.
+	Issuer       string                 `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"` // Issuer URL; discovery is read from /.well-known/openid-configuration.
+	ClientId     string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret string                 `protobuf:"bytes,4,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"` // Write only. Empty for a public client.
+	RedirectUri  string                 `protobuf:"bytes,5,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`    // Page of the app the provider returns code and state to.
+	Scopes       []string               `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`                                 // Requested in addition to openid, e.g. email and profile.
+	Claims       *ClaimMapping          `protobuf:"bytes,7,opt,name=claims,proto3" json:"claims,omitempty"`
+	TrustEmail   bool                   `protobuf:"varint,8,opt,name=trust_email,json=trustEmail,proto3" json:"trust_email,omitempty"` // Treat emails as verified even without the email_verified claim.
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Link an existing account with the same verified email on the first login instead of
+	// returning IDENTITY_NOT_LINKED. Only a global admin can set it; admin accounts are never linked this way.
+	LinkByEmail   bool `protobuf:"varint,11,opt,name=link_by_email,json=linkByEmail,proto3" json:"link_by_email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityProvider) Reset() {
+	*x = IdentityProvider{}
+	mi := &file_sso_admin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityProvider) ProtoMessage() {}
+
+func (x *IdentityProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityProvider.ProtoReflect.Descriptor instead.
+func (*IdentityProvider) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *IdentityProvider) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetRedirectUri() string {
+	if x != nil {
+		return x.RedirectUri
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *IdentityProvider) GetClaims() *ClaimMapping {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+func (x *IdentityProvider) GetTrustEmail() bool {
+	if x != nil {
+		return x.TrustEmail
+	}
+	return false
+}
+
+func (x *IdentityProvider) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *IdentityProvider) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *IdentityProvider) GetLinkByEmail() bool {
+	if x != nil {
+		return x.LinkByEmail
+	}
+	return false
+}
+
+// ClaimMapping names the ID token claims user data is taken from.
+// Empty fields mean the standard OIDC claims.
+type ClaimMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                                      // email
+	EmailVerified string                 `protobuf:"bytes,2,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"` // email_verified
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                        // name, the display name of new users
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimMapping) Reset() {
+	*x = ClaimMapping{}
+	mi := &file_sso_admin_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimMapping) ProtoMessage() {}
+
+func (x *ClaimMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimMapping.ProtoReflect.Descriptor instead.
+func (*ClaimMapping) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ClaimMapping) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ClaimMapping) GetEmailVerified() string {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return ""
+}
+
+func (x *ClaimMapping) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SetAppIdentityProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Provider      *IdentityProvider      `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAppIdentityProviderRequest) Reset() {
+	*x = SetAppIdentityProviderRequest{}
+	mi := &file_sso_admin_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAppIdentityProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAppIdentityProviderRequest) ProtoMessage() {}
+
+func (x *SetAppIdentityProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAppIdentityProviderRequest.ProtoReflect.Descriptor instead.
+func (*SetAppIdentityProviderRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetAppIdentityProviderRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *SetAppIdentityProviderRequest) GetProvider() *IdentityProvider {
+	if x != nil {
+		return x.Provider
+	}
+	return nil
+}
+
+type SetAppIdentityProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      *IdentityProvider      `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAppIdentityProviderResponse) Reset() {
+	*x = SetAppIdentityProviderResponse{}
+	mi := &file_sso_admin_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAppIdentityProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAppIdentityProviderResponse) ProtoMessage() {}
+
+func (x *SetAppIdentityProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAppIdentityProviderResponse.ProtoReflect.Descriptor instead.
+func (*SetAppIdentityProviderResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SetAppIdentityProviderResponse) GetProvider() *IdentityProvider {
+	if x != nil {
+		return x.Provider
+	}
+	return nil
+}
+
+type ListAppIdentityProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAppIdentityProvidersRequest) Reset() {
+	*x = ListAppIdentityProvidersRequest{}
+	mi := &file_sso_admin_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAppIdentityProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppIdentityProvidersRequest) ProtoMessage() {}
+
+func (x *ListAppIdentityProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppIdentityProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListAppIdentityProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListAppIdentityProvidersRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+type ListAppIdentityProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*IdentityProvider    `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAppIdentityProvidersResponse) Reset() {
+	*x = ListAppIdentityProvidersResponse{}
+	mi := &file_sso_admin_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAppIdentityProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppIdentityProvidersResponse) ProtoMessage() {}
+
+func (x *ListAppIdentityProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppIdentityProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListAppIdentityProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListAppIdentityProvidersResponse) GetProviders() []*IdentityProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type DeleteAppIdentityProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAppIdentityProviderRequest) Reset() {
+	*x = DeleteAppIdentityProviderRequest{}
+	mi := &file_sso_admin_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAppIdentityProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAppIdentityProviderRequest) ProtoMessage() {}
+
+func (x *DeleteAppIdentityProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAppIdentityProviderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAppIdentityProviderRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteAppIdentityProviderRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *DeleteAppIdentityProviderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteAppIdentityProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAppIdentityProviderResponse) Reset() {
+	*x = DeleteAppIdentityProviderResponse{}
+	mi := &file_sso_admin_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAppIdentityProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAppIdentityProviderResponse) ProtoMessage() {}
+
+func (x *DeleteAppIdentityProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAppIdentityProviderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAppIdentityProviderResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{37}
+}
+
+type ListAppMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // Optional filter: active or pending.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAppMembersRequest) Reset() {
+	*x = ListAppMembersRequest{}
+	mi := &file_sso_admin_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAppMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppMembersRequest) ProtoMessage() {}
+
+func (x *ListAppMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListAppMembersRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListAppMembersRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *ListAppMembersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListAppMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*AppMember           `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAppMembersResponse) Reset() {
+	*x = ListAppMembersResponse{}
+	mi := &file_sso_admin_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAppMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppMembersResponse) ProtoMessage() {}
+
+func (x *ListAppMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListAppMembersResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListAppMembersResponse) GetMembers() []*AppMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type InviteAppMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	User          *UserRef               `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteAppMemberRequest) Reset() {
+	*x = InviteAppMemberRequest{}
+	mi := &file_sso_admin_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteAppMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteAppMemberRequest) ProtoMessage() {}
+
+func (x *InviteAppMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteAppMemberRequest.ProtoReflect.Descriptor instead.
+func (*InviteAppMemberRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *InviteAppMemberRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *InviteAppMemberRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type InviteAppMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *AppMember             `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteAppMemberResponse) Reset() {
+	*x = InviteAppMemberResponse{}
+	mi := &file_sso_admin_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteAppMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteAppMemberResponse) ProtoMessage() {}
+
+func (x *InviteAppMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteAppMemberResponse.ProtoReflect.Descriptor instead.
+func (*InviteAppMemberResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *InviteAppMemberResponse) GetMember() *AppMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type ApproveAppMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	User          *UserRef               `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveAppMemberRequest) Reset() {
+	*x = ApproveAppMemberRequest{}
+	mi := &file_sso_admin_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveAppMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveAppMemberRequest) ProtoMessage() {}
+
+func (x *ApproveAppMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveAppMemberRequest.ProtoReflect.Descriptor instead.
+func (*ApproveAppMemberRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ApproveAppMemberRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *ApproveAppMemberRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ApproveAppMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *AppMember             `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveAppMemberResponse) Reset() {
+	*x = ApproveAppMemberResponse{}
+	mi := &file_sso_admin_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveAppMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveAppMemberResponse) ProtoMessage() {}
+
+func (x *ApproveAppMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveAppMemberResponse.ProtoReflect.Descriptor instead.
+func (*ApproveAppMemberResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ApproveAppMemberResponse) GetMember() *AppMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type RemoveAppMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	User          *UserRef               `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveAppMemberRequest) Reset() {
+	*x = RemoveAppMemberRequest{}
+	mi := &file_sso_admin_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveAppMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAppMemberRequest) ProtoMessage() {}
+
+func (x *RemoveAppMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAppMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveAppMemberRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RemoveAppMemberRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *RemoveAppMemberRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type RemoveAppMemberResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RevokedSessions int64                  `protobuf:"varint,1,opt,name=revoked_sessions,json=revokedSessions,proto3" json:"revoked_sessions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RemoveAppMemberResponse) Reset() {
+	*x = RemoveAppMemberResponse{}
+	mi := &file_sso_admin_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveAppMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAppMemberResponse) ProtoMessage() {}
+
+func (x *RemoveAppMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAppMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveAppMemberResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RemoveAppMemberResponse) GetRevokedSessions() int64 {
+	if x != nil {
+		return x.RevokedSessions
+	}
+	return 0
+}
+
+type User struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email       string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	OrgId       int64                  `protobuf:"varint,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	DisplayName string                 `protobuf:"bytes,4,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	IsAdmin     bool                   `protobuf:"varint,5,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	OrgAdmin    bool                   `protobuf:"varint,6,opt,name=org_admin,json=orgAdmin,proto3" json:"org_admin,omitempty"`
+	Roles       []string               `protobuf:"bytes,7,rep,name=roles,proto3" json:"roles,omitempty"`
+	LockedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=locked_at,json=lockedAt,proto3" json:"locked_at,omitempty"` // Unset if the user is not locked.
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// active, locked, disabled or pending_deletion. Only active users can log in.
+	State         string                 `protobuf:"bytes,10,opt,name=state,proto3" json:"state,omitempty"`
+	DeleteAfter   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=delete_after,json=deleteAfter,proto3" json:"delete_after,omitempty"` // Set if the user is pending deletion.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_sso_admin_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetOrgId() int64 {
+	if x != nil {
+		return x.OrgId
+	}
+	return 0
+}
+
+func (x *User) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *User) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+func (x *User) GetOrgAdmin() bool {
+	if x != nil {
+		return x.OrgAdmin
+	}
+	return false
+}
+
+func (x *User) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *User) GetLockedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LockedAt
+	}
+	return nil
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *User) GetDeleteAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteAfter
+	}
+	return nil
+}
+
+// UserFilter narrows the list of users. Unset fields match all users.
+type UserFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailPrefix   string                 `protobuf:"bytes,1,opt,name=email_prefix,json=emailPrefix,proto3" json:"email_prefix,omitempty"` // Case-insensitive.
+	IsAdmin       *bool                  `protobuf:"varint,2,opt,name=is_admin,json=isAdmin,proto3,oneof" json:"is_admin,omitempty"`
+	Locked        *bool                  `protobuf:"varint,3,opt,name=locked,proto3,oneof" json:"locked,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`    // Inclusive.
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"` // Exclusive.
+	AppId         int64                  `protobuf:"varint,6,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`                        // Only active members of the app.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserFilter) Reset() {
+	*x = UserFilter{}
+	mi := &file_sso_admin_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserFilter) ProtoMessage() {}
+
+func (x *UserFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserFilter.ProtoReflect.Descriptor instead.
+func (*UserFilter) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UserFilter) GetEmailPrefix() string {
+	if x != nil {
+		return x.EmailPrefix
+	}
+	return ""
+}
+
+func (x *UserFilter) GetIsAdmin() bool {
+	if x != nil && x.IsAdmin != nil {
+		return *x.IsAdmin
+	}
+	return false
+}
+
+func (x *UserFilter) GetLocked() bool {
+	if x != nil && x.Locked != nil {
+		return *x.Locked
+	}
+	return false
+}
+
+func (x *UserFilter) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *UserFilter) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *UserFilter) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+type ListUsersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Org    string                 `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"` // Slug of the organization. Empty means all organizations the caller manages.
+	Filter *UserFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// id (default), email or created_at, optionally followed by " desc".
+	OrderBy       string `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	PageSize      int32  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Default 50, max 500.
+	PageToken     string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token from the previous response with the same order_by.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_sso_admin_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListUsersRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetFilter() *UserFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty if there are no more pages.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_sso_admin_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SearchUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"` // At least 3 characters.
+	Org           string                 `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
+	Filter        *UserFilter            `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersRequest) Reset() {
+	*x = SearchUsersRequest{}
+	mi := &file_sso_admin_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersRequest) ProtoMessage() {}
+
+func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SearchUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetFilter() *UserFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SearchUsersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersResponse) Reset() {
+	*x = SearchUsersResponse{}
+	mi := &file_sso_admin_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersResponse) ProtoMessage() {}
+
+func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
+func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SearchUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *SearchUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DisableUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserRef               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableUserRequest) Reset() {
+	*x = DisableUserRequest{}
+	mi := &file_sso_admin_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableUserRequest) ProtoMessage() {}
+
+func (x *DisableUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableUserRequest.ProtoReflect.Descriptor instead.
+func (*DisableUserRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DisableUserRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DisableUserResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DisabledAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=disabled_at,json=disabledAt,proto3" json:"disabled_at,omitempty"`
+	RevokedSessions int64                  `protobuf:"varint,3,opt,name=revoked_sessions,json=revokedSessions,proto3" json:"revoked_sessions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DisableUserResponse) Reset() {
+	*x = DisableUserResponse{}
+	mi := &file_sso_admin_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableUserResponse) ProtoMessage() {}
+
+func (x *DisableUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableUserResponse.ProtoReflect.Descriptor instead.
+func (*DisableUserResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DisableUserResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DisableUserResponse) GetDisabledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisabledAt
+	}
+	return nil
+}
+
+func (x *DisableUserResponse) GetRevokedSessions() int64 {
+	if x != nil {
+		return x.RevokedSessions
+	}
+	return 0
+}
+
+type EnableUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserRef               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableUserRequest) Reset() {
+	*x = EnableUserRequest{}
+	mi := &file_sso_admin_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableUserRequest) ProtoMessage() {}
+
+func (x *EnableUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableUserRequest.ProtoReflect.Descriptor instead.
+func (*EnableUserRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *EnableUserRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type EnableUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"` // locked if the user is still locked.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableUserResponse) Reset() {
+	*x = EnableUserResponse{}
+	mi := &file_sso_admin_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableUserResponse) ProtoMessage() {}
+
+func (x *EnableUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableUserResponse.ProtoReflect.Descriptor instead.
+func (*EnableUserResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *EnableUserResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *EnableUserResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type EraseUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserRef               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserRequest) Reset() {
+	*x = EraseUserRequest{}
+	mi := &file_sso_admin_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserRequest) ProtoMessage() {}
+
+func (x *EraseUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *EraseUserRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type EraseUserResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeleteAfter     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=delete_after,json=deleteAfter,proto3" json:"delete_after,omitempty"`
+	RevokedSessions int64                  `protobuf:"varint,3,opt,name=revoked_sessions,json=revokedSessions,proto3" json:"revoked_sessions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EraseUserResponse) Reset() {
+	*x = EraseUserResponse{}
+	mi := &file_sso_admin_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserResponse) ProtoMessage() {}
+
+func (x *EraseUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *EraseUserResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *EraseUserResponse) GetDeleteAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteAfter
+	}
+	return nil
+}
+
+func (x *EraseUserResponse) GetRevokedSessions() int64 {
+	if x != nil {
+		return x.RevokedSessions
+	}
+	return 0
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserRef               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_sso_admin_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ExportUserDataRequest) GetUser() *UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // JSON document.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_sso_admin_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ExportUserDataResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportedUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int64                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"` // Row number in the source, reported back in errors.
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	PassHash      string                 `protobuf:"bytes,3,opt,name=pass_hash,json=passHash,proto3" json:"pass_hash,omitempty"`
+	Roles         []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,5,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedUser) Reset() {
+	*x = ImportedUser{}
+	mi := &file_sso_admin_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedUser) ProtoMessage() {}
+
+func (x *ImportedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedUser.ProtoReflect.Descriptor instead.
+func (*ImportedUser) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ImportedUser) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportedUser) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportedUser) GetPassHash() string {
+	if x != nil {
+		return x.PassHash
+	}
+	return ""
+}
+
+func (x *ImportedUser) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *ImportedUser) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+type ImportUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           string                 `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"` // Org slug. Empty means the default org. Only read from the first message.
+	Users         []*ImportedUser        `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersRequest) Reset() {
+	*x = ImportUsersRequest{}
+	mi := &file_sso_admin_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersRequest) ProtoMessage() {}
+
+func (x *ImportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ImportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ImportUsersRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *ImportUsersRequest) GetUsers() []*ImportedUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type ImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int64                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_sso_admin_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ImportError) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportError) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImportUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imported      int64                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Skipped       int64                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // Users that already exist.
+	Failed        int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*ImportError         `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`                   // At most 1000; failed has the total.
+	LastRow       int64                  `protobuf:"varint,5,opt,name=last_row,json=lastRow,proto3" json:"last_row,omitempty"` // The largest row number processed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersResponse) Reset() {
+	*x = ImportUsersResponse{}
+	mi := &file_sso_admin_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersResponse) ProtoMessage() {}
+
+func (x *ImportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ImportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ImportUsersResponse) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportUsersResponse) GetLastRow() int64 {
+	if x != nil {
+		return x.LastRow
+	}
+	return 0
+}
+
+type ExportUsersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Org             string                 `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`                                                   // Org slug. Empty means all orgs the caller administers.
+	AfterId         int64                  `protobuf:"varint,2,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`                           // Resume after this user ID.
+	IncludePassHash bool                   `protobuf:"varint,3,opt,name=include_pass_hash,json=includePassHash,proto3" json:"include_pass_hash,omitempty"` // Global admins only.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportUsersRequest) Reset() {
+	*x = ExportUsersRequest{}
+	mi := &file_sso_admin_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsersRequest) ProtoMessage() {}
+
+func (x *ExportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ExportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ExportUsersRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *ExportUsersRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+func (x *ExportUsersRequest) GetIncludePassHash() bool {
+	if x != nil {
+		return x.IncludePassHash
+	}
+	return false
+}
+
+type ExportUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	PassHash      string                 `protobuf:"bytes,2,opt,name=pass_hash,json=passHash,proto3" json:"pass_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsersResponse) Reset() {
+	*x = ExportUsersResponse{}
+	mi := &file_sso_admin_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsersResponse) ProtoMessage() {}
+
+func (x *ExportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sso_admin_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ExportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_sso_admin_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ExportUsersResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *ExportUsersResponse) GetPassHash() string {
+	if x != nil {
+		return x.PassHash
+	}
+	return ""
+}
+
+var File_sso_admin_proto protoreflect.FileDescriptor
+
+const file_sso_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x0fsso/admin.proto\x12\x04auth\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc3\x01\n" +
+	"\x03App\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x15\n" +
+	"\x06org_id\x18\x03 \x01(\x03R\x05orgId\x12#\n" +
+	"\raccess_policy\x18\x04 \x01(\tR\faccessPolicy\x12)\n" +
+	"\x05token\x18\x05 \x01(\v2\x13.auth.TokenSettingsR\x05token\x121\n" +
+	"\bpasskeys\x18\x06 \x01(\v2\x15.auth.PasskeySettingsR\bpasskeys\"Y\n" +
+	"\x0fPasskeySettings\x12\x13\n" +
+	"\x05rp_id\x18\x01 \x01(\tR\x04rpId\x12\x17\n" +
+	"\arp_name\x18\x02 \x01(\tR\x06rpName\x12\x18\n" +
+	"\aorigins\x18\x03 \x03(\tR\aorigins\"\xd8\x01\n" +
+	"\rTokenSettings\x12\x1f\n" +
+	"\vttl_seconds\x18\x01 \x01(\x03R\n" +
+	"ttlSeconds\x12\x16\n" +
+	"\x06issuer\x18\x02 \x01(\tR\x06issuer\x12\x1a\n" +
+	"\baudience\x18\x03 \x03(\tR\baudience\x127\n" +
+	"\x06claims\x18\x04 \x03(\v2\x1f.auth.TokenSettings.ClaimsEntryR\x06claims\x1a9\n" +
+	"\vClaimsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"x\n" +
+	"\x03Org\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x11\n" +
+	"\x0fListAppsRequest\"1\n" +
+	"\x10ListAppsResponse\x12\x1d\n" +
+	"\x04apps\x18\x01 \x03(\v2\t.auth.AppR\x04apps\"\xd3\x01\n" +
+	"\x10CreateAppRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03org\x18\x03 \x01(\tR\x03org\x12#\n" +
+	"\raccess_policy\x18\x04 \x01(\tR\faccessPolicy\x12)\n" +
+	"\x05token\x18\x05 \x01(\v2\x13.auth.TokenSettingsR\x05token\x121\n" +
+	"\bpasskeys\x18\x06 \x01(\v2\x15.auth.PasskeySettingsR\bpasskeys\"H\n" +
+	"\x11CreateAppResponse\x12\x1b\n" +
+	"\x03app\x18\x01 \x01(\v2\t.auth.AppR\x03app\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"/\n" +
+	"\x16RotateAppSecretRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\"1\n" +
+	"\x17RotateAppSecretResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\"J\n" +
+	"\aUserRef\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x10\n" +
+	"\x03org\x18\x03 \x01(\tR\x03org\"4\n" +
+	"\x0fLockUserRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.auth.UserRefR\x04user\"\x8f\x01\n" +
+	"\x10LockUserResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x127\n" +
+	"\tlocked_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\blockedAt\x12)\n" +
+	"\x10revoked_sessions\x18\x03 \x01(\x03R\x0frevokedSessions\"6\n" +
+	"\x11UnlockUserRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.auth.UserRefR\x04user\"-\n" +
+	"\x12UnlockUserResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"O\n" +
+	"\x0fSetAdminRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.auth.UserRefR\x04user\x12\x19\n" +
+	"\bis_admin\x18\x02 \x01(\bR\aisAdmin\"+\n" +
+	"\x10SetAdminResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"T\n" +
+	"\x12SetOrgAdminRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.auth.UserRefR\x04user\x12\x1b\n" +
+	"\torg_admin\x18\x02 \x01(\bR\borgAdmin\".\n" +
+	"\x13SetOrgAdminResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\":\n" +
+	"\x10CreateOrgRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"0\n" +
+	"\x11CreateOrgResponse\x12\x1b\n" +
+	"\x03org\x18\x01 \x01(\v2\t.auth.OrgR\x03org\"\x11\n" +
+	"\x0fListOrgsRequest\"1\n" +
+	"\x10ListOrgsResponse\x12\x1d\n" +
+	"\x04orgs\x18\x01 \x03(\v2\t.auth.OrgR\x04orgs\"\xfa\x01\n" +
+	"\tAppMember\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x19\n" +
+	"\badded_by\x18\x05 \x01(\x03R\aaddedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"W\n" +
+	"\x19SetAppAccessPolicyRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12#\n" +
+	"\raccess_policy\x18\x02 \x01(\tR\faccessPolicy\"\x1c\n" +
+	"\x1aSetAppAccessPolicyResponse\"^\n" +
+	"\x1aSetAppTokenSettingsRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12)\n" +
+	"\x05token\x18\x02 \x01(\v2\x13.auth.TokenSettingsR\x05token\"\x1d\n" +
+	"\x1bSetAppTokenSettingsResponse\"h\n" +
+	"\x1cSetAppPasskeySettingsRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x121\n" +
+	"\bpasskeys\x18\x02 \x01(\v2\x15.auth.PasskeySettingsR\bpasskeys\"\x1f\n" +
+	"\x1dSetAppPasskeySettingsResponse\"\xa2\x03\n" +
+	"\x10IdentityProvider\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06issuer\x18\x02 \x01(\tR\x06issuer\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x04 \x01(\tR\fclientSecret\x12!\n" +
+	"\fredirect_uri\x18\x05 \x01(\tR\vredirectUri\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\x12*\n" +
+	"\x06claims\x18\a \x01(\v2\x12.auth.ClaimMappingR\x06claims\x12\x1f\n" +
+	"\vtrust_email\x18\b \x01(\bR\n" +
+	"trustEmail\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\"\n" +
+	"\rlink_by_email\x18\v \x01(\bR\vlinkByEmail\"_\n" +
+	"\fClaimMapping\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12%\n" +
+	"\x0eemail_verified\x18\x02 \x01(\tR\remailVerified\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"j\n" +
+	"\x1dSetAppIdentityProviderRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x122\n" +
+	"\bprovider\x18\x02 \x01(\v2\x16.auth.IdentityProviderR\bprovider\"T\n" +
+	"\x1eSetAppIdentityProviderResponse\x122\n" +
+	"\bprovider\x18\x01 \x01(\v2\x16.auth.IdentityProviderR\bprovider\"8\n" +
+	"\x1fListAppIdentityProvidersRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\"X\n" +
+	" ListAppIdentityProvidersResponse\x124\n" +
+	"\tproviders\x18\x01 \x03(\v2\x16.auth.IdentityProviderR\tproviders\"M\n" +
+	" DeleteAppIdentityProviderRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"#\n" +
+	"!DeleteAppIdentityProviderResponse\"F\n" +
+	"\x15ListAppMembersRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"C\n" +
+	"\x16ListAppMembersResponse\x12)\n" +
+	"\amembers\x18\x01 \x03(\v2\x0f.auth.AppMemberR\amembers\"R\n" +
+	"\x16InviteAppMemberRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12!\n" +
+	"\x04user\x18\x02 \x01(\v2\r.auth.UserRefR\x04user\"B\n" +
+	"\x17InviteAppMemberResponse\x12'\n" +
+	"\x06member\x18\x01 \x01(\v2\x0f.auth.AppMemberR\x06member\"S\n" +
+	"\x17ApproveAppMemberRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12!\n" +
+	"\x04user\x18\x02 \x01(\v2\r.auth.UserRefR\x04user\"C\n" +
+	"\x18ApproveAppMemberResponse\x12'\n" +
+	"\x06member\x18\x01 \x01(\v2\x0f.auth.AppMemberR\x06member\"R\n" +
+	"\x16RemoveAppMemberRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12!\n" +
+	"\x04user\x18\x02 \x01(\v2\r.auth.UserRefR\x04user\"D\n" +
+	"\x17RemoveAppMemberResponse\x12)\n" +
+	"\x10revoked_sessions\x18\x01 \x01(\x03R\x0frevokedSessions\"\xfd\x02\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x15\n" +
+	"\x06org_id\x18\x03 \x01(\x03R\x05orgId\x12!\n" +
+	"\fdisplay_name\x18\x04 \x01(\tR\vdisplayName\x12\x19\n" +
+	"\bis_admin\x18\x05 \x01(\bR\aisAdmin\x12\x1b\n" +
+	"\torg_admin\x18\x06 \x01(\bR\borgAdmin\x12\x14\n" +
+	"\x05roles\x18\a \x03(\tR\x05roles\x127\n" +
+	"\tlocked_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\blockedAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05state\x18\n" +
+	" \x01(\tR\x05state\x12=\n" +
+	"\fdelete_after\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vdeleteAfter\"\x9f\x02\n" +
+	"\n" +
+	"UserFilter\x12!\n" +
+	"\femail_prefix\x18\x01 \x01(\tR\vemailPrefix\x12\x1e\n" +
+	"\bis_admin\x18\x02 \x01(\bH\x00R\aisAdmin\x88\x01\x01\x12\x1b\n" +
+	"\x06locked\x18\x03 \x01(\bH\x01R\x06locked\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x15\n" +
+	"\x06app_id\x18\x06 \x01(\x03R\x05appIdB\v\n" +
+	"\t_is_adminB\t\n" +
+	"\a_locked\"\xa5\x01\n" +
+	"\x10ListUsersRequest\x12\x10\n" +
+	"\x03org\x18\x01 \x01(\tR\x03org\x12(\n" +
+	"\x06filter\x18\x02 \x01(\v2\x10.auth.UserFilterR\x06filter\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"]\n" +
+	"\x11ListUsersResponse\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".auth.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xbd\x01\n" +
+	"\x12SearchUsersRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x10\n" +
+	"\x03org\x18\x02 \x01(\tR\x03org\x12(\n" +
+	"\x06filter\x18\x03 \x01(\v2\x10.auth.UserFilterR\x06filter\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"_\n" +
+	"\x13SearchUsersResponse\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".auth.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"7\n" +
+	"\x12DisableUserRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.auth.UserRefR\x04user\"\x96\x01\n" +
+	"\x13DisableUserResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12;\n" +
+	"\vdisabled_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"disabledAt\x12)\n" +
+	"\x10revoked_sessions\x18\x03 \x01(\x03R\x0frevokedSessions\"6\n" +
+	"\x11EnableUserRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.auth.UserRefR\x04user\"C\n" +
+	"\x12EnableUserResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"5\n" +
+	"\x10EraseUserRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.auth.UserRefR\x04user\"\x96\x01\n" +
+	"\x11EraseUserResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12=\n" +
+	"\fdelete_after\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vdeleteAfter\x12)\n" +
+	"\x10revoked_sessions\x18\x03 \x01(\x03R\x0frevokedSessions\":\n" +
+	"\x15ExportUserDataRequest\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.auth.UserRefR\x04user\",\n" +
+	"\x16ExportUserDataResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x8c\x01\n" +
+	"\fImportedUser\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1b\n" +
+	"\tpass_hash\x18\x03 \x01(\tR\bpassHash\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x12!\n" +
+	"\fdisplay_name\x18\x05 \x01(\tR\vdisplayName\"P\n" +
+	"\x12ImportUsersRequest\x12\x10\n" +
+	"\x03org\x18\x01 \x01(\tR\x03org\x12(\n" +
+	"\x05users\x18\x02 \x03(\v2\x12.auth.ImportedUserR\x05users\"M\n" +
+	"\vImportError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xa9\x01\n" +
+	"\x13ImportUsersResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x03R\bimported\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x03R\askipped\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12)\n" +
+	"\x06errors\x18\x04 \x03(\v2\x11.auth.ImportErrorR\x06errors\x12\x19\n" +
+	"\blast_row\x18\x05 \x01(\x03R\alastRow\"m\n" +
+	"\x12ExportUsersRequest\x12\x10\n" +
+	"\x03org\x18\x01 \x01(\tR\x03org\x12\x19\n" +
+	"\bafter_id\x18\x02 \x01(\x03R\aafterId\x12*\n" +
+	"\x11include_pass_hash\x18\x03 \x01(\bR\x0fincludePassHash\"R\n" +
+	"\x13ExportUsersResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".auth.UserR\x04user\x12\x1b\n" +
+	"\tpass_hash\x18\x02 \x01(\tR\bpassHash2\xf7\x0f\n" +
+	"\x05Admin\x129\n" +
+	"\bListApps\x12\x15.auth.ListAppsRequest\x1a\x16.auth.ListAppsResponse\x12<\n" +
+	"\tCreateApp\x12\x16.auth.CreateAppRequest\x1a\x17.auth.CreateAppResponse\x12N\n" +
+	"\x0fRotateAppSecret\x12\x1c.auth.RotateAppSecretRequest\x1a\x1d.auth.RotateAppSecretResponse\x129\n" +
+	"\bLockUser\x12\x15.auth.LockUserRequest\x1a\x16.auth.LockUserResponse\x12?\n" +
+	"\n" +
+	"UnlockUser\x12\x17.auth.UnlockUserRequest\x1a\x18.auth.UnlockUserResponse\x129\n" +
+	"\bSetAdmin\x12\x15.auth.SetAdminRequest\x1a\x16.auth.SetAdminResponse\x12B\n" +
+	"\vSetOrgAdmin\x12\x18.auth.SetOrgAdminRequest\x1a\x19.auth.SetOrgAdminResponse\x12<\n" +
+	"\tCreateOrg\x12\x16.auth.CreateOrgRequest\x1a\x17.auth.CreateOrgResponse\x129\n" +
+	"\bListOrgs\x12\x15.auth.ListOrgsRequest\x1a\x16.auth.ListOrgsResponse\x12W\n" +
+	"\x12SetAppAccessPolicy\x12\x1f.auth.SetAppAccessPolicyRequest\x1a .auth.SetAppAccessPolicyResponse\x12K\n" +
+	"\x0eListAppMembers\x12\x1b.auth.ListAppMembersRequest\x1a\x1c.auth.ListAppMembersResponse\x12N\n" +
+	"\x0fInviteAppMember\x12\x1c.auth.InviteAppMemberRequest\x1a\x1d.auth.InviteAppMemberResponse\x12Q\n" +
+	"\x10ApproveAppMember\x12\x1d.auth.ApproveAppMemberRequest\x1a\x1e.auth.ApproveAppMemberResponse\x12N\n" +
+	"\x0fRemoveAppMember\x12\x1c.auth.RemoveAppMemberRequest\x1a\x1d.auth.RemoveAppMemberResponse\x12Z\n" +
+	"\x13SetAppTokenSettings\x12 .auth.SetAppTokenSettingsRequest\x1a!.auth.SetAppTokenSettingsResponse\x12`\n" +
+	"\x15SetAppPasskeySettings\x12\".auth.SetAppPasskeySettingsRequest\x1a#.auth.SetAppPasskeySettingsResponse\x12c\n" +
+	"\x16SetAppIdentityProvider\x12#.auth.SetAppIdentityProviderRequest\x1a$.auth.SetAppIdentityProviderResponse\x12i\n" +
+	"\x18ListAppIdentityProviders\x12%.auth.ListAppIdentityProvidersRequest\x1a&.auth.ListAppIdentityProvidersResponse\x12l\n" +
+	"\x19DeleteAppIdentityProvider\x12&.auth.DeleteAppIdentityProviderRequest\x1a'.auth.DeleteAppIdentityProviderResponse\x12<\n" +
+	"\tListUsers\x12\x16.auth.ListUsersRequest\x1a\x17.auth.ListUsersResponse\x12B\n" +
+	"\vSearchUsers\x12\x18.auth.SearchUsersRequest\x1a\x19.auth.SearchUsersResponse\x12B\n" +
+	"\vDisableUser\x12\x18.auth.DisableUserRequest\x1a\x19.auth.DisableUserResponse\x12?\n" +
+	"\n" +
+	"EnableUser\x12\x17.auth.EnableUserRequest\x1a\x18.auth.EnableUserResponse\x12<\n" +
+	"\tEraseUser\x12\x16.auth.EraseUserRequest\x1a\x17.auth.EraseUserResponse\x12K\n" +
+	"\x0eExportUserData\x12\x1b.auth.ExportUserDataRequest\x1a\x1c.auth.ExportUserDataResponse\x12D\n" +
+	"\vImportUsers\x12\x18.auth.ImportUsersRequest\x1a\x19.auth.ImportUsersResponse(\x01\x12D\n" +
+	"\vExportUsers\x12\x18.auth.ExportUsersRequest\x1a\x19.auth.ExportUsersResponse0\x01B5Z3github.com/Artemiadze/gRPC-Service/gen/go/sso;ssov1b\x06proto3"
+
+var (
+	file_sso_admin_proto_rawDescOnce sync.Once
+	file_sso_admin_proto_rawDescData []byte
+)
+
+func file_sso_admin_proto_rawDescGZIP() []byte {
+	file_sso_admin_proto_rawDescOnce.Do(func() {
+		file_sso_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sso_admin_proto_rawDesc), len(file_sso_admin_proto_rawDesc)))
+	})
+	return file_sso_admin_proto_rawDescData
+}
+
+var file_sso_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
+var file_sso_admin_proto_goTypes = []any{
+	(*App)(nil),                               // 0: auth.App
+	(*PasskeySettings)(nil),                   // 1: auth.PasskeySettings
+	(*TokenSettings)(nil),                     // 2: auth.TokenSettings
+	(*Org)(nil),                               // 3: auth.Org
+	(*ListAppsRequest)(nil),                   // 4: auth.ListAppsRequest
+	(*ListAppsResponse)(nil),                  // 5: auth.ListAppsResponse
+	(*CreateAppRequest)(nil),                  // 6: auth.CreateAppRequest
+	(*CreateAppResponse)(nil),                 // 7: auth.CreateAppResponse
+	(*RotateAppSecretRequest)(nil),            // 8: auth.RotateAppSecretRequest
+	(*RotateAppSecretResponse)(nil),           // 9: auth.RotateAppSecretResponse
+	(*UserRef)(nil),                           // 10: auth.UserRef
+	(*LockUserRequest)(nil),                   // 11: auth.LockUserRequest
+	(*LockUserResponse)(nil),                  // 12: auth.LockUserResponse
+	(*UnlockUserRequest)(nil),                 // 13: auth.UnlockUserRequest
+	(*UnlockUserResponse)(nil),                // 14: auth.UnlockUserResponse
+	(*SetAdminRequest)(nil),                   // 15: auth.SetAdminRequest
+	(*SetAdminResponse)(nil),                  // 16: auth.SetAdminResponse
+	(*SetOrgAdminRequest)(nil),                // 17: auth.SetOrgAdminRequest
+	(*SetOrgAdminResponse)(nil),               // 18: auth.SetOrgAdminResponse
+	(*CreateOrgRequest)(nil),                  // 19: auth.CreateOrgRequest
+	(*CreateOrgResponse)(nil),                 // 20: auth.CreateOrgResponse
+	(*ListOrgsRequest)(nil),                   // 21: auth.ListOrgsRequest
+	(*ListOrgsResponse)(nil),                  // 22: auth.ListOrgsResponse
+	(*AppMember)(nil),                         // 23: auth.AppMember
+	(*SetAppAccessPolicyRequest)(nil),         // 24: auth.SetAppAccessPolicyRequest
+	(*SetAppAccessPolicyResponse)(nil),        // 25: auth.SetAppAccessPolicyResponse
+	(*SetAppTokenSettingsRequest)(nil),        // 26: auth.SetAppTokenSettingsRequest
+	(*SetAppTokenSettingsResponse)(nil),       // 27: auth.SetAppTokenSettingsResponse
+	(*SetAppPasskeySettingsRequest)(nil),      // 28: auth.SetAppPasskeySettingsRequest
+	(*SetAppPasskeySettingsResponse)(nil),     // 29: auth.SetAppPasskeySettingsResponse
+	(*IdentityProvider)(nil),                  // 30: auth.IdentityProvider
+	(*ClaimMapping)(nil),                      // 31: auth.ClaimMapping
+	(*SetAppIdentityProviderRequest)(nil),     // 32: auth.SetAppIdentityProviderRequest
+	(*SetAppIdentityProviderResponse)(nil),    // 33: auth.SetAppIdentityProviderResponse
+	(*ListAppIdentityProvidersRequest)(nil),   // 34: auth.ListAppIdentityProvidersRequest
+	(*ListAppIdentityProvidersResponse)(nil),  // 35: auth.ListAppIdentityProvidersResponse
+	(*DeleteAppIdentityProviderRequest)(nil),  // 36: auth.DeleteAppIdentityProviderRequest
+	(*DeleteAppIdentityProviderResponse)(nil), // 37: auth.DeleteAppIdentityProviderResponse
+	(*ListAppMembersRequest)(nil),             // 38: auth.ListAppMembersRequest
+	(*ListAppMembersResponse)(nil),            // 39: auth.ListAppMembersResponse
+	(*InviteAppMemberRequest)(nil),            // 40: auth.InviteAppMemberRequest
+	(*InviteAppMemberResponse)(nil),           // 41: auth.InviteAppMemberResponse
+	(*ApproveAppMemberRequest)(nil),           // 42: auth.ApproveAppMemberRequest
+	(*ApproveAppMemberResponse)(nil),          // 43: auth.ApproveAppMemberResponse
+	(*RemoveAppMemberRequest)(nil),            // 44: auth.RemoveAppMemberRequest
+	(*RemoveAppMemberResponse)(nil),           // 45: auth.RemoveAppMemberResponse
+	(*User)(nil),                              // 46: auth.User
+	(*UserFilter)(nil),                        // 47: auth.UserFilter
+	(*ListUsersRequest)(nil),                  // 48: auth.ListUsersRequest
+	(*ListUsersResponse)(nil),                 // 49: auth.ListUsersResponse
+	(*SearchUsersRequest)(nil),                // 50: auth.SearchUsersRequest
+	(*SearchUsersResponse)(nil),               // 51: auth.SearchUsersResponse
+	(*DisableUserRequest)(nil),                // 52: auth.DisableUserRequest
+	(*DisableUserResponse)(nil),               // 53: auth.DisableUserResponse
+	(*EnableUserRequest)(nil),                 // 54: auth.EnableUserRequest
+	(*EnableUserResponse)(nil),                // 55: auth.EnableUserResponse
+	(*EraseUserRequest)(nil),                  // 56: auth.EraseUserRequest
+	(*EraseUserResponse)(nil),                 // 57: auth.EraseUserResponse
+	(*ExportUserDataRequest)(nil),             // 58: auth.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),            // 59: auth.ExportUserDataResponse
+	(*ImportedUser)(nil),                      // 60: auth.ImportedUser
+	(*ImportUsersRequest)(nil),                // 61: auth.ImportUsersRequest
+	(*ImportError)(nil),                       // 62: auth.ImportError
+	(*ImportUsersResponse)(nil),               // 63: auth.ImportUsersResponse
+	(*ExportUsersRequest)(nil),                // 64: auth.ExportUsersRequest
+	(*ExportUsersResponse)(nil),               // 65: auth.ExportUsersResponse
+	nil,                                       // 66: auth.TokenSettings.ClaimsEntry
+	(*timestamppb.Timestamp)(nil),             // 67: google.protobuf.Timestamp
+}
+var file_sso_admin_proto_depIdxs = []int32{
+	2,  // 0: auth.App.token:type_name -> auth.TokenSettings
+	1,  // 1: auth.App.passkeys:type_name -> auth.PasskeySettings
+	66, // 2: auth.TokenSettings.claims:type_name -> auth.TokenSettings.ClaimsEntry
+	67, // 3: auth.Org.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: auth.ListAppsResponse.apps:type_name -> auth.App
+	2,  // 5: auth.CreateAppRequest.token:type_name -> auth.TokenSettings
+	1,  // 6: auth.CreateAppRequest.passkeys:type_name -> auth.PasskeySettings
+	0,  // 7: auth.CreateAppResponse.app:type_name -> auth.App
+	10, // 8: auth.LockUserRequest.user:type_name -> auth.UserRef
+	67, // 9: auth.LockUserResponse.locked_at:type_name -> google.protobuf.Timestamp
+	10, // 10: auth.UnlockUserRequest.user:type_name -> auth.UserRef
+	10, // 11: auth.SetAdminRequest.user:type_name -> auth.UserRef
+	10, // 12: auth.SetOrgAdminRequest.user:type_name -> auth.UserRef
+	3,  // 13: auth.CreateOrgResponse.org:type_name -> auth.Org
+	3,  // 14: auth.ListOrgsResponse.orgs:type_name -> auth.Org
+	67, // 15: auth.AppMember.created_at:type_name -> google.protobuf.Timestamp
+	67, // 16: auth.AppMember.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 17: auth.SetAppTokenSettingsRequest.token:type_name -> auth.TokenSettings
+	1,  // 18: auth.SetAppPasskeySettingsRequest.passkeys:type_name -> auth.PasskeySettings
+	31, // 19: auth.IdentityProvider.claims:type_name -> auth.ClaimMapping
+	67, // 20: auth.IdentityProvider.created_at:type_name -> google.protobuf.Timestamp
+	67, // 21: auth.IdentityProvider.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 22: auth.SetAppIdentityProviderRequest.provider:type_name -> auth.IdentityProvider
+	30, // 23: auth.SetAppIdentityProviderResponse.provider:type_name -> auth.IdentityProvider
+	30, // 24: auth.ListAppIdentityProvidersResponse.providers:type_name -> auth.IdentityProvider
+	23, // 25: auth.ListAppMembersResponse.members:type_name -> auth.AppMember
+	10, // 26: auth.InviteAppMemberRequest.user:type_name -> auth.UserRef
+	23, // 27: auth.InviteAppMemberResponse.member:type_name -> auth.AppMember
+	10, // 28: auth.ApproveAppMemberRequest.user:type_name -> auth.UserRef
+	23, // 29: auth.ApproveAppMemberResponse.member:type_name -> auth.AppMember
+	10, // 30: auth.RemoveAppMemberRequest.user:type_name -> auth.UserRef
+	67, // 31: auth.User.locked_at:type_name -> google.protobuf.Timestamp
+	67, // 32: auth.User.created_at:type_name -> google.protobuf.Timestamp
+	67, // 33: auth.User.delete_after:type_name -> google.protobuf.Timestamp
+	67, // 34: auth.UserFilter.created_after:type_name -> google.protobuf.Timestamp
+	67, // 35: auth.UserFilter.created_before:type_name -> google.protobuf.Timestamp
+	47, // 36: auth.ListUsersRequest.filter:type_name -> auth.UserFilter
+	46, // 37: auth.ListUsersResponse.users:type_name -> auth.User
+	47, // 38: auth.SearchUsersRequest.filter:type_name -> auth.UserFilter
+	46, // 39: auth.SearchUsersResponse.users:type_name -> auth.User
+	10, // 40: auth.DisableUserRequest.user:type_name -> auth.UserRef
+	67, // 41: auth.DisableUserResponse.disabled_at:type_name -> google.protobuf.Timestamp
+	10, // 42: auth.EnableUserRequest.user:type_name -> auth.UserRef
+	10, // 43: auth.EraseUserRequest.user:type_name -> auth.UserRef
+	67, // 44: auth.EraseUserResponse.delete_after:type_name -> google.protobuf.Timestamp
+	10, // 45: auth.ExportUserDataRequest.user:type_name -> auth.UserRef
+	60, // 46: auth.ImportUsersRequest.users:type_name -> auth.ImportedUser
+	62, // 47: auth.ImportUsersResponse.errors:type_name -> auth.ImportError
+	46, // 48: auth.ExportUsersResponse.user:type_name -> auth.User
+	4,  // 49: auth.Admin.ListApps:input_type -> auth.ListAppsRequest
+	6,  // 50: auth.Admin.CreateApp:input_type -> auth.CreateAppRequest
+	8,  // 51: auth.Admin.RotateAppSecret:input_type -> auth.RotateAppSecretRequest
+	11, // 52: auth.Admin.LockUser:input_type -> auth.LockUserRequest
+	13, // 53: auth.Admin.UnlockUser:input_type -> auth.UnlockUserRequest
+	15, // 54: auth.Admin.SetAdmin:input_type -> auth.SetAdminRequest
+	17, // 55: auth.Admin.SetOrgAdmin:input_type -> auth.SetOrgAdminRequest
+	19, // 56: auth.Admin.CreateOrg:input_type -> auth.CreateOrgRequest
+	21, // 57: auth.Admin.ListOrgs:input_type -> auth.ListOrgsRequest
+	24, // 58: auth.Admin.SetAppAccessPolicy:input_type -> auth.SetAppAccessPolicyRequest
+	38, // 59: auth.Admin.ListAppMembers:input_type -> auth.ListAppMembersRequest
+	40, // 60: auth.Admin.InviteAppMember:input_type -> auth.InviteAppMemberRequest
+	42, // 61: auth.Admin.ApproveAppMember:input_type -> auth.ApproveAppMemberRequest
+	44, // 62: auth.Admin.RemoveAppMember:input_type -> auth.RemoveAppMemberRequest
+	26, // 63: auth.Admin.SetAppTokenSettings:input_type -> auth.SetAppTokenSettingsRequest
+	28, // 64: auth.Admin.SetAppPasskeySettings:input_type -> auth.SetAppPasskeySettingsRequest
+	32, // 65: auth.Admin.SetAppIdentityProvider:input_type -> auth.SetAppIdentityProviderRequest
+	34, // 66: auth.Admin.ListAppIdentityProviders:input_type -> auth.ListAppIdentityProvidersRequest
+	36, // 67: auth.Admin.DeleteAppIdentityProvider:input_type -> auth.DeleteAppIdentityProviderRequest
+	48, // 68: auth.Admin.ListUsers:input_type -> auth.ListUsersRequest
+	50, // 69: auth.Admin.SearchUsers:input_type -> auth.SearchUsersRequest
+	52, // 70: auth.Admin.DisableUser:input_type -> auth.DisableUserRequest
+	54, // 71: auth.Admin.EnableUser:input_type -> auth.EnableUserRequest
+	56, // 72: auth.Admin.EraseUser:input_type -> auth.EraseUserRequest
+	58, // 73: auth.Admin.ExportUserData:input_type -> auth.ExportUserDataRequest
+	61, // 74: auth.Admin.ImportUsers:input_type -> auth.ImportUsersRequest
+	64, // 75: auth.Admin.ExportUsers:input_type -> auth.ExportUsersRequest
+	5,  // 76: auth.Admin.ListApps:output_type -> auth.ListAppsResponse
+	7,  // 77: auth.Admin.CreateApp:output_type -> auth.CreateAppResponse
+	9,  // 78: auth.Admin.RotateAppSecret:output_type -> auth.RotateAppSecretResponse
+	12, // 79: auth.Admin.LockUser:output_type -> auth.LockUserResponse
+	14, // 80: auth.Admin.UnlockUser:output_type -> auth.UnlockUserResponse
+	16, // 81: auth.Admin.SetAdmin:output_type -> auth.SetAdminResponse
+	18, // 82: auth.Admin.SetOrgAdmin:output_type -> auth.SetOrgAdminResponse
+	20, // 83: auth.Admin.CreateOrg:output_type -> auth.CreateOrgResponse
+	22, // 84: auth.Admin.ListOrgs:output_type -> auth.ListOrgsResponse
+	25, // 85: auth.Admin.SetAppAccessPolicy:output_type -> auth.SetAppAccessPolicyResponse
+	39, // 86: auth.Admin.ListAppMembers:output_type -> auth.ListAppMembersResponse
+	41, // 87: auth.Admin.InviteAppMember:output_type -> auth.InviteAppMemberResponse
+	43, // 88: auth.Admin.ApproveAppMember:output_type -> auth.ApproveAppMemberResponse
+	45, // 89: auth.Admin.RemoveAppMember:output_type -> auth.RemoveAppMemberResponse
+	27, // 90: auth.Admin.SetAppTokenSettings:output_type -> auth.SetAppTokenSettingsResponse
+	29, // 91: auth.Admin.SetAppPasskeySettings:output_type -> auth.SetAppPasskeySettingsResponse
+	33, // 92: auth.Admin.SetAppIdentityProvider:output_type -> auth.SetAppIdentityProviderResponse
+	35, // 93: auth.Admin.ListAppIdentityProviders:output_type -> auth.ListAppIdentityProvidersResponse
+	37, // 94: auth.Admin.DeleteAppIdentityProvider:output_type -> auth.DeleteAppIdentityProviderResponse
+	49, // 95: auth.Admin.ListUsers:output_type -> auth.ListUsersResponse
+	51, // 96: auth.Admin.SearchUsers:output_type -> auth.SearchUsersResponse
+	53, // 97: auth.Admin.DisableUser:output_type -> auth.DisableUserResponse
+	55, // 98: auth.Admin.EnableUser:output_type -> auth.EnableUserResponse
+	57, // 99: auth.Admin.EraseUser:output_type -> auth.EraseUserResponse
+	59, // 100: auth.Admin.ExportUserData:output_type -> auth.ExportUserDataResponse
+	63, // 101: auth.Admin.ImportUsers:output_type -> auth.ImportUsersResponse
+	65, // 102: auth.Admin.ExportUsers:output_type -> auth.ExportUsersResponse
+	76, // [76:103] is the sub-list for method output_type
+	49, // [49:76] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
+}
+
+func init() { file_sso_admin_proto_init() }
+func file_sso_admin_proto_init() {
+	if File_sso_admin_proto != nil {
+		return
+	}
+	file_sso_admin_proto_msgTypes[47].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sso_admin_proto_rawDesc), len(file_sso_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   67,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sso_admin_proto_goTypes,
+		DependencyIndexes: file_sso_admin_proto_depIdxs,
+		MessageInfos:      file_sso_admin_proto_msgTypes,
+	}.Build()
+	File_sso_admin_proto = out.File
+	file_sso_admin_proto_goTypes = nil
+	file_sso_admin_proto_depIdxs = nil
+}
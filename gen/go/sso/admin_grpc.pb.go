@@ -0,0 +1,1200 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.1
+// source: sso/admin.proto
+
+package ssov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Admin_ListApps_FullMethodName                  = "/auth.Admin/ListApps"
+	Admin_CreateApp_FullMethodName                 = "/auth.Admin/CreateApp"
+	Admin_RotateAppSecret_FullMethodName           = "/auth.Admin/RotateAppSecret"
+	Admin_LockUser_FullMethodName                  = "/auth.Admin/LockUser"
+	Admin_UnlockUser_FullMethodName                = "/auth.Admin/UnlockUser"
+	Admin_SetAdmin_FullMethodName                  = "/auth.Admin/SetAdmin"
+	Admin_SetOrgAdmin_FullMethodName               = "/auth.Admin/SetOrgAdmin"
+	Admin_CreateOrg_FullMethodName                 = "/auth.Admin/CreateOrg"
+	Admin_ListOrgs_FullMethodName                  = "/auth.Admin/ListOrgs"
+	Admin_SetAppAccessPolicy_FullMethodName        = "/auth.Admin/SetAppAccessPolicy"
+	Admin_ListAppMembers_FullMethodName            = "/auth.Admin/ListAppMembers"
+	Admin_InviteAppMember_FullMethodName           = "/auth.Admin/InviteAppMember"
+	Admin_ApproveAppMember_FullMethodName          = "/auth.Admin/ApproveAppMember"
+	Admin_RemoveAppMember_FullMethodName           = "/auth.Admin/RemoveAppMember"
+	Admin_SetAppTokenSettings_FullMethodName       = "/auth.Admin/SetAppTokenSettings"
+	Admin_SetAppPasskeySettings_FullMethodName     = "/auth.Admin/SetAppPasskeySettings"
+	Admin_SetAppIdentityProvider_FullMethodName    = "/auth.Admin/SetAppIdentityProvider"
+	Admin_ListAppIdentityProviders_FullMethodName  = "/auth.Admin/ListAppIdentityProviders"
+	Admin_DeleteAppIdentityProvider_FullMethodName = "/auth.Admin/DeleteAppIdentityProvider"
+	Admin_ListUsers_FullMethodName                 = "/auth.Admin/ListUsers"
+	Admin_SearchUsers_FullMethodName               = "/auth.Admin/SearchUsers"
+	Admin_DisableUser_FullMethodName               = "/auth.Admin/DisableUser"
+	Admin_EnableUser_FullMethodName                = "/auth.Admin/EnableUser"
+	Admin_EraseUser_FullMethodName                 = "/auth.Admin/EraseUser"
+	Admin_ExportUserData_FullMethodName            = "/auth.Admin/ExportUserData"
+	Admin_ImportUsers_FullMethodName               = "/auth.Admin/ImportUsers"
+	Admin_ExportUsers_FullMethodName               = "/auth.Admin/ExportUsers"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Admin is service for managing organizations, apps and user accounts.
+// Global admins manage everything; organization admins manage apps and
+// users of their own organization only.
+type AdminClient interface {
+	// ListApps returns apps visible to the caller. Secrets are not returned.
+	ListApps(ctx context.Context, in *ListAppsRequest, opts ...grpc.CallOption) (*ListAppsResponse, error)
+	// CreateApp registers an app. The secret is returned only here.
+	CreateApp(ctx context.Context, in *CreateAppRequest, opts ...grpc.CallOption) (*CreateAppResponse, error)
+	// RotateAppSecret replaces the app secret with a new random one.
+	// Tokens signed with the old secret stop being accepted.
+	RotateAppSecret(ctx context.Context, in *RotateAppSecretRequest, opts ...grpc.CallOption) (*RotateAppSecretResponse, error)
+	// LockUser forbids the user to log in and revokes all their sessions.
+	LockUser(ctx context.Context, in *LockUserRequest, opts ...grpc.CallOption) (*LockUserResponse, error)
+	// UnlockUser allows a locked user to log in again.
+	UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error)
+	// SetAdmin grants or revokes global admin rights. Global admins only.
+	SetAdmin(ctx context.Context, in *SetAdminRequest, opts ...grpc.CallOption) (*SetAdminResponse, error)
+	// SetOrgAdmin grants or revokes admin rights in the user's organization.
+	SetOrgAdmin(ctx context.Context, in *SetOrgAdminRequest, opts ...grpc.CallOption) (*SetOrgAdminResponse, error)
+	// CreateOrg creates an organization. Global admins only.
+	CreateOrg(ctx context.Context, in *CreateOrgRequest, opts ...grpc.CallOption) (*CreateOrgResponse, error)
+	// ListOrgs returns all organizations. Global admins only.
+	ListOrgs(ctx context.Context, in *ListOrgsRequest, opts ...grpc.CallOption) (*ListOrgsResponse, error)
+	// SetAppAccessPolicy sets who may log in to the app.
+	SetAppAccessPolicy(ctx context.Context, in *SetAppAccessPolicyRequest, opts ...grpc.CallOption) (*SetAppAccessPolicyResponse, error)
+	// ListAppMembers returns members of the app and pending access requests.
+	ListAppMembers(ctx context.Context, in *ListAppMembersRequest, opts ...grpc.CallOption) (*ListAppMembersResponse, error)
+	// InviteAppMember lets a user of the app's organization into the app.
+	// A pending request of the user is approved.
+	InviteAppMember(ctx context.Context, in *InviteAppMemberRequest, opts ...grpc.CallOption) (*InviteAppMemberResponse, error)
+	// ApproveAppMember approves a pending access request.
+	ApproveAppMember(ctx context.Context, in *ApproveAppMemberRequest, opts ...grpc.CallOption) (*ApproveAppMemberResponse, error)
+	// RemoveAppMember removes a member or rejects a request and revokes
+	// the user's sessions in the app.
+	RemoveAppMember(ctx context.Context, in *RemoveAppMemberRequest, opts ...grpc.CallOption) (*RemoveAppMemberResponse, error)
+	// SetAppTokenSettings replaces the token settings of the app.
+	// Tokens already issued are not changed.
+	SetAppTokenSettings(ctx context.Context, in *SetAppTokenSettingsRequest, opts ...grpc.CallOption) (*SetAppTokenSettingsResponse, error)
+	// SetAppPasskeySettings sets the WebAuthn relying party of the app.
+	// An empty rp_id turns passkey login off; registered passkeys are kept.
+	SetAppPasskeySettings(ctx context.Context, in *SetAppPasskeySettingsRequest, opts ...grpc.CallOption) (*SetAppPasskeySettingsResponse, error)
+	// SetAppIdentityProvider adds an external OIDC provider to the app or
+	// replaces the provider with the same name.
+	SetAppIdentityProvider(ctx context.Context, in *SetAppIdentityProviderRequest, opts ...grpc.CallOption) (*SetAppIdentityProviderResponse, error)
+	// ListAppIdentityProviders returns the external providers of the app without client secrets.
+	ListAppIdentityProviders(ctx context.Context, in *ListAppIdentityProvidersRequest, opts ...grpc.CallOption) (*ListAppIdentityProvidersResponse, error)
+	// DeleteAppIdentityProvider removes an external provider from the app.
+	// Users created through it and their linked identities are kept.
+	DeleteAppIdentityProvider(ctx context.Context, in *DeleteAppIdentityProviderRequest, opts ...grpc.CallOption) (*DeleteAppIdentityProviderResponse, error)
+	// ListUsers returns a page of users matching the filter.
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// SearchUsers returns a page of users whose email or display name
+	// contains the query (case-insensitive).
+	SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error)
+	// DisableUser forbids login and revokes all sessions of the user until EnableUser.
+	DisableUser(ctx context.Context, in *DisableUserRequest, opts ...grpc.CallOption) (*DisableUserResponse, error)
+	// EnableUser re-enables a disabled user and cancels a scheduled erase.
+	// It does not unlock a locked user.
+	EnableUser(ctx context.Context, in *EnableUserRequest, opts ...grpc.CallOption) (*EnableUserResponse, error)
+	// EraseUser schedules deletion of the user after a grace period and revokes
+	// all sessions. Then the user is deleted and their audit events are anonymized.
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error)
+	// ExportUserData returns everything the service holds about the user as JSON:
+	// account, profile, app metadata and memberships, sessions and audit events.
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	// ImportUsers creates users with ready password hashes (bcrypt, argon2id or
+	// PBKDF2 in passlib format) in one organization. The org is taken from the
+	// first message. Users whose email is already taken are skipped, so an
+	// interrupted import can be repeated or resumed from last_row. Legacy hashes
+	// are upgraded to the current algorithm on the first login.
+	ImportUsers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportUsersRequest, ImportUsersResponse], error)
+	// ExportUsers streams users in ID order. Password hashes are only returned
+	// to global admins who ask for them.
+	ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUsersResponse], error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ListApps(ctx context.Context, in *ListAppsRequest, opts ...grpc.CallOption) (*ListAppsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAppsResponse)
+	err := c.cc.Invoke(ctx, Admin_ListApps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CreateApp(ctx context.Context, in *CreateAppRequest, opts ...grpc.CallOption) (*CreateAppResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAppResponse)
+	err := c.cc.Invoke(ctx, Admin_CreateApp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RotateAppSecret(ctx context.Context, in *RotateAppSecretRequest, opts ...grpc.CallOption) (*RotateAppSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateAppSecretResponse)
+	err := c.cc.Invoke(ctx, Admin_RotateAppSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) LockUser(ctx context.Context, in *LockUserRequest, opts ...grpc.CallOption) (*LockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LockUserResponse)
+	err := c.cc.Invoke(ctx, Admin_LockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockUserResponse)
+	err := c.cc.Invoke(ctx, Admin_UnlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetAdmin(ctx context.Context, in *SetAdminRequest, opts ...grpc.CallOption) (*SetAdminResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAdminResponse)
+	err := c.cc.Invoke(ctx, Admin_SetAdmin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetOrgAdmin(ctx context.Context, in *SetOrgAdminRequest, opts ...grpc.CallOption) (*SetOrgAdminResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOrgAdminResponse)
+	err := c.cc.Invoke(ctx, Admin_SetOrgAdmin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CreateOrg(ctx context.Context, in *CreateOrgRequest, opts ...grpc.CallOption) (*CreateOrgResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrgResponse)
+	err := c.cc.Invoke(ctx, Admin_CreateOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListOrgs(ctx context.Context, in *ListOrgsRequest, opts ...grpc.CallOption) (*ListOrgsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrgsResponse)
+	err := c.cc.Invoke(ctx, Admin_ListOrgs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetAppAccessPolicy(ctx context.Context, in *SetAppAccessPolicyRequest, opts ...grpc.CallOption) (*SetAppAccessPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAppAccessPolicyResponse)
+	err := c.cc.Invoke(ctx, Admin_SetAppAccessPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListAppMembers(ctx context.Context, in *ListAppMembersRequest, opts ...grpc.CallOption) (*ListAppMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAppMembersResponse)
+	err := c.cc.Invoke(ctx, Admin_ListAppMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) InviteAppMember(ctx context.Context, in *InviteAppMemberRequest, opts ...grpc.CallOption) (*InviteAppMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteAppMemberResponse)
+	err := c.cc.Invoke(ctx, Admin_InviteAppMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ApproveAppMember(ctx context.Context, in *ApproveAppMemberRequest, opts ...grpc.CallOption) (*ApproveAppMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveAppMemberResponse)
+	err := c.cc.Invoke(ctx, Admin_ApproveAppMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RemoveAppMember(ctx context.Context, in *RemoveAppMemberRequest, opts ...grpc.CallOption) (*RemoveAppMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveAppMemberResponse)
+	err := c.cc.Invoke(ctx, Admin_RemoveAppMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetAppTokenSettings(ctx context.Context, in *SetAppTokenSettingsRequest, opts ...grpc.CallOption) (*SetAppTokenSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAppTokenSettingsResponse)
+	err := c.cc.Invoke(ctx, Admin_SetAppTokenSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetAppPasskeySettings(ctx context.Context, in *SetAppPasskeySettingsRequest, opts ...grpc.CallOption) (*SetAppPasskeySettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAppPasskeySettingsResponse)
+	err := c.cc.Invoke(ctx, Admin_SetAppPasskeySettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetAppIdentityProvider(ctx context.Context, in *SetAppIdentityProviderRequest, opts ...grpc.CallOption) (*SetAppIdentityProviderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAppIdentityProviderResponse)
+	err := c.cc.Invoke(ctx, Admin_SetAppIdentityProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListAppIdentityProviders(ctx context.Context, in *ListAppIdentityProvidersRequest, opts ...grpc.CallOption) (*ListAppIdentityProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAppIdentityProvidersResponse)
+	err := c.cc.Invoke(ctx, Admin_ListAppIdentityProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DeleteAppIdentityProvider(ctx context.Context, in *DeleteAppIdentityProviderRequest, opts ...grpc.CallOption) (*DeleteAppIdentityProviderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAppIdentityProviderResponse)
+	err := c.cc.Invoke(ctx, Admin_DeleteAppIdentityProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, Admin_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchUsersResponse)
+	err := c.cc.Invoke(ctx, Admin_SearchUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DisableUser(ctx context.Context, in *DisableUserRequest, opts ...grpc.CallOption) (*DisableUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableUserResponse)
+	err := c.cc.Invoke(ctx, Admin_DisableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) EnableUser(ctx context.Context, in *EnableUserRequest, opts ...grpc.CallOption) (*EnableUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnableUserResponse)
+	err := c.cc.Invoke(ctx, Admin_EnableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseUserResponse)
+	err := c.cc.Invoke(ctx, Admin_EraseUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, Admin_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ImportUsers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportUsersRequest, ImportUsersResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[0], Admin_ImportUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportUsersRequest, ImportUsersResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ImportUsersClient = grpc.ClientStreamingClient[ImportUsersRequest, ImportUsersResponse]
+
+func (c *adminClient) ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUsersResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[1], Admin_ExportUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportUsersRequest, ExportUsersResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ExportUsersClient = grpc.ServerStreamingClient[ExportUsersResponse]
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility.
+//
+// Admin is service for managing organizations, apps and user accounts.
+// Global admins manage everything; organization admins manage apps and
+// users of their own organization only.
+type AdminServer interface {
+	// ListApps returns apps visible to the caller. Secrets are not returned.
+	ListApps(context.Context, *ListAppsRequest) (*ListAppsResponse, error)
+	// CreateApp registers an app. The secret is returned only here.
+	CreateApp(context.Context, *CreateAppRequest) (*CreateAppResponse, error)
+	// RotateAppSecret replaces the app secret with a new random one.
+	// Tokens signed with the old secret stop being accepted.
+	RotateAppSecret(context.Context, *RotateAppSecretRequest) (*RotateAppSecretResponse, error)
+	// LockUser forbids the user to log in and revokes all their sessions.
+	LockUser(context.Context, *LockUserRequest) (*LockUserResponse, error)
+	// UnlockUser allows a locked user to log in again.
+	UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error)
+	// SetAdmin grants or revokes global admin rights. Global admins only.
+	SetAdmin(context.Context, *SetAdminRequest) (*SetAdminResponse, error)
+	// SetOrgAdmin grants or revokes admin rights in the user's organization.
+	SetOrgAdmin(context.Context, *SetOrgAdminRequest) (*SetOrgAdminResponse, error)
+	// CreateOrg creates an organization. Global admins only.
+	CreateOrg(context.Context, *CreateOrgRequest) (*CreateOrgResponse, error)
+	// ListOrgs returns all organizations. Global admins only.
+	ListOrgs(context.Context, *ListOrgsRequest) (*ListOrgsResponse, error)
+	// SetAppAccessPolicy sets who may log in to the app.
+	SetAppAccessPolicy(context.Context, *SetAppAccessPolicyRequest) (*SetAppAccessPolicyResponse, error)
+	// ListAppMembers returns members of the app and pending access requests.
+	ListAppMembers(context.Context, *ListAppMembersRequest) (*ListAppMembersResponse, error)
+	// InviteAppMember lets a user of the app's organization into the app.
+	// A pending request of the user is approved.
+	InviteAppMember(context.Context, *InviteAppMemberRequest) (*InviteAppMemberResponse, error)
+	// ApproveAppMember approves a pending access request.
+	ApproveAppMember(context.Context, *ApproveAppMemberRequest) (*ApproveAppMemberResponse, error)
+	// RemoveAppMember removes a member or rejects a request and revokes
+	// the user's sessions in the app.
+	RemoveAppMember(context.Context, *RemoveAppMemberRequest) (*RemoveAppMemberResponse, error)
+	// SetAppTokenSettings replaces the token settings of the app.
+	// Tokens already issued are not changed.
+	SetAppTokenSettings(context.Context, *SetAppTokenSettingsRequest) (*SetAppTokenSettingsResponse, error)
+	// SetAppPasskeySettings sets the WebAuthn relying party of the app.
+	// An empty rp_id turns passkey login off; registered passkeys are kept.
+	SetAppPasskeySettings(context.Context, *SetAppPasskeySettingsRequest) (*SetAppPasskeySettingsResponse, error)
+	// SetAppIdentityProvider adds an external OIDC provider to the app or
+	// replaces the provider with the same name.
+	SetAppIdentityProvider(context.Context, *SetAppIdentityProviderRequest) (*SetAppIdentityProviderResponse, error)
+	// ListAppIdentityProviders returns the external providers of the app without client secrets.
+	ListAppIdentityProviders(context.Context, *ListAppIdentityProvidersRequest) (*ListAppIdentityProvidersResponse, error)
+	// DeleteAppIdentityProvider removes an external provider from the app.
+	// Users created through it and their linked identities are kept.
+	DeleteAppIdentityProvider(context.Context, *DeleteAppIdentityProviderRequest) (*DeleteAppIdentityProviderResponse, error)
+	// ListUsers returns a page of users matching the filter.
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// SearchUsers returns a page of users whose email or display name
+	// contains the query (case-insensitive).
+	SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error)
+	// DisableUser forbids login and revokes all sessions of the user until EnableUser.
+	DisableUser(context.Context, *DisableUserRequest) (*DisableUserResponse, error)
+	// EnableUser re-enables a disabled user and cancels a scheduled erase.
+	// It does not unlock a locked user.
+	EnableUser(context.Context, *EnableUserRequest) (*EnableUserResponse, error)
+	// EraseUser schedules deletion of the user after a grace period and revokes
+	// all sessions. Then the user is deleted and their audit events are anonymized.
+	EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error)
+	// ExportUserData returns everything the service holds about the user as JSON:
+	// account, profile, app metadata and memberships, sessions and audit events.
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+	// ImportUsers creates users with ready password hashes (bcrypt, argon2id or
+	// PBKDF2 in passlib format) in one organization. The org is taken from the
+	// first message. Users whose email is already taken are skipped, so an
+	// interrupted import can be repeated or resumed from last_row. Legacy hashes
+	// are upgraded to the current algorithm on the first login.
+	ImportUsers(grpc.ClientStreamingServer[ImportUsersRequest, ImportUsersResponse]) error
+	// ExportUsers streams users in ID order. Password hashes are only returned
+	// to global admins who ask for them.
+	ExportUsers(*ExportUsersRequest, grpc.ServerStreamingServer[ExportUsersResponse]) error
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServer struct{}
+
+func (UnimplementedAdminServer) ListApps(context.Context, *ListAppsRequest) (*ListAppsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApps not implemented")
+}
+func (UnimplementedAdminServer) CreateApp(context.Context, *CreateAppRequest) (*CreateAppResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApp not implemented")
+}
+func (UnimplementedAdminServer) RotateAppSecret(context.Context, *RotateAppSecretRequest) (*RotateAppSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateAppSecret not implemented")
+}
+func (UnimplementedAdminServer) LockUser(context.Context, *LockUserRequest) (*LockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockUser not implemented")
+}
+func (UnimplementedAdminServer) UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUser not implemented")
+}
+func (UnimplementedAdminServer) SetAdmin(context.Context, *SetAdminRequest) (*SetAdminResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAdmin not implemented")
+}
+func (UnimplementedAdminServer) SetOrgAdmin(context.Context, *SetOrgAdminRequest) (*SetOrgAdminResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOrgAdmin not implemented")
+}
+func (UnimplementedAdminServer) CreateOrg(context.Context, *CreateOrgRequest) (*CreateOrgResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrg not implemented")
+}
+func (UnimplementedAdminServer) ListOrgs(context.Context, *ListOrgsRequest) (*ListOrgsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrgs not implemented")
+}
+func (UnimplementedAdminServer) SetAppAccessPolicy(context.Context, *SetAppAccessPolicyRequest) (*SetAppAccessPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAppAccessPolicy not implemented")
+}
+func (UnimplementedAdminServer) ListAppMembers(context.Context, *ListAppMembersRequest) (*ListAppMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAppMembers not implemented")
+}
+func (UnimplementedAdminServer) InviteAppMember(context.Context, *InviteAppMemberRequest) (*InviteAppMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteAppMember not implemented")
+}
+func (UnimplementedAdminServer) ApproveAppMember(context.Context, *ApproveAppMemberRequest) (*ApproveAppMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveAppMember not implemented")
+}
+func (UnimplementedAdminServer) RemoveAppMember(context.Context, *RemoveAppMemberRequest) (*RemoveAppMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAppMember not implemented")
+}
+func (UnimplementedAdminServer) SetAppTokenSettings(context.Context, *SetAppTokenSettingsRequest) (*SetAppTokenSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAppTokenSettings not implemented")
+}
+func (UnimplementedAdminServer) SetAppPasskeySettings(context.Context, *SetAppPasskeySettingsRequest) (*SetAppPasskeySettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAppPasskeySettings not implemented")
+}
+func (UnimplementedAdminServer) SetAppIdentityProvider(context.Context, *SetAppIdentityProviderRequest) (*SetAppIdentityProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAppIdentityProvider not implemented")
+}
+func (UnimplementedAdminServer) ListAppIdentityProviders(context.Context, *ListAppIdentityProvidersRequest) (*ListAppIdentityProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAppIdentityProviders not implemented")
+}
+func (UnimplementedAdminServer) DeleteAppIdentityProvider(context.Context, *DeleteAppIdentityProviderRequest) (*DeleteAppIdentityProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAppIdentityProvider not implemented")
+}
+func (UnimplementedAdminServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedAdminServer) SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchUsers not implemented")
+}
+func (UnimplementedAdminServer) DisableUser(context.Context, *DisableUserRequest) (*DisableUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableUser not implemented")
+}
+func (UnimplementedAdminServer) EnableUser(context.Context, *EnableUserRequest) (*EnableUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableUser not implemented")
+}
+func (UnimplementedAdminServer) EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (UnimplementedAdminServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedAdminServer) ImportUsers(grpc.ClientStreamingServer[ImportUsersRequest, ImportUsersResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (UnimplementedAdminServer) ExportUsers(*ExportUsersRequest, grpc.ServerStreamingServer[ExportUsersResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsers not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+func (UnimplementedAdminServer) testEmbeddedByValue()               {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_ListApps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAppsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListApps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListApps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListApps(ctx, req.(*ListAppsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CreateApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAppRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CreateApp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_CreateApp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CreateApp(ctx, req.(*CreateAppRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RotateAppSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateAppSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RotateAppSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_RotateAppSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RotateAppSecret(ctx, req.(*RotateAppSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_LockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).LockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_LockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).LockUser(ctx, req.(*LockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_UnlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).UnlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_UnlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).UnlockUser(ctx, req.(*UnlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetAdmin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetAdmin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetAdmin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetAdmin(ctx, req.(*SetAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetOrgAdmin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrgAdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetOrgAdmin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetOrgAdmin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetOrgAdmin(ctx, req.(*SetOrgAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CreateOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CreateOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_CreateOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CreateOrg(ctx, req.(*CreateOrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListOrgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrgsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListOrgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListOrgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListOrgs(ctx, req.(*ListOrgsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetAppAccessPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAppAccessPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetAppAccessPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetAppAccessPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetAppAccessPolicy(ctx, req.(*SetAppAccessPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListAppMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAppMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListAppMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListAppMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListAppMembers(ctx, req.(*ListAppMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_InviteAppMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteAppMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).InviteAppMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_InviteAppMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).InviteAppMember(ctx, req.(*InviteAppMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ApproveAppMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveAppMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ApproveAppMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ApproveAppMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ApproveAppMember(ctx, req.(*ApproveAppMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RemoveAppMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAppMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RemoveAppMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_RemoveAppMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RemoveAppMember(ctx, req.(*RemoveAppMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetAppTokenSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAppTokenSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetAppTokenSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetAppTokenSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetAppTokenSettings(ctx, req.(*SetAppTokenSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetAppPasskeySettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAppPasskeySettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetAppPasskeySettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetAppPasskeySettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetAppPasskeySettings(ctx, req.(*SetAppPasskeySettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetAppIdentityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAppIdentityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetAppIdentityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetAppIdentityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetAppIdentityProvider(ctx, req.(*SetAppIdentityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListAppIdentityProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAppIdentityProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListAppIdentityProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListAppIdentityProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListAppIdentityProviders(ctx, req.(*ListAppIdentityProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DeleteAppIdentityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAppIdentityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DeleteAppIdentityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DeleteAppIdentityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DeleteAppIdentityProvider(ctx, req.(*DeleteAppIdentityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SearchUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SearchUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SearchUsers(ctx, req.(*SearchUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DisableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DisableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DisableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DisableUser(ctx, req.(*DisableUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_EnableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).EnableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_EnableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).EnableUser(ctx, req.(*EnableUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_EraseUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).EraseUser(ctx, req.(*EraseUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ImportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServer).ImportUsers(&grpc.GenericServerStream[ImportUsersRequest, ImportUsersResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ImportUsersServer = grpc.ClientStreamingServer[ImportUsersRequest, ImportUsersResponse]
+
+func _Admin_ExportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).ExportUsers(m, &grpc.GenericServerStream[ExportUsersRequest, ExportUsersResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ExportUsersServer = grpc.ServerStreamingServer[ExportUsersResponse]
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListApps",
+			Handler:    _Admin_ListApps_Handler,
+		},
+		{
+			MethodName: "CreateApp",
+			Handler:    _Admin_CreateApp_Handler,
+		},
+		{
+			MethodName: "RotateAppSecret",
+			Handler:    _Admin_RotateAppSecret_Handler,
+		},
+		{
+			MethodName: "LockUser",
+			Handler:    _Admin_LockUser_Handler,
+		},
+		{
+			MethodName: "UnlockUser",
+			Handler:    _Admin_UnlockUser_Handler,
+		},
+		{
+			MethodName: "SetAdmin",
+			Handler:    _Admin_SetAdmin_Handler,
+		},
+		{
+			MethodName: "SetOrgAdmin",
+			Handler:    _Admin_SetOrgAdmin_Handler,
+		},
+		{
+			MethodName: "CreateOrg",
+			Handler:    _Admin_CreateOrg_Handler,
+		},
+		{
+			MethodName: "ListOrgs",
+			Handler:    _Admin_ListOrgs_Handler,
+		},
+		{
+			MethodName: "SetAppAccessPolicy",
+			Handler:    _Admin_SetAppAccessPolicy_Handler,
+		},
+		{
+			MethodName: "ListAppMembers",
+			Handler:    _Admin_ListAppMembers_Handler,
+		},
+		{
+			MethodName: "InviteAppMember",
+			Handler:    _Admin_InviteAppMember_Handler,
+		},
+		{
+			MethodName: "ApproveAppMember",
+			Handler:    _Admin_ApproveAppMember_Handler,
+		},
+		{
+			MethodName: "RemoveAppMember",
+			Handler:    _Admin_RemoveAppMember_Handler,
+		},
+		{
+			MethodName: "SetAppTokenSettings",
+			Handler:    _Admin_SetAppTokenSettings_Handler,
+		},
+		{
+			MethodName: "SetAppPasskeySettings",
+			Handler:    _Admin_SetAppPasskeySettings_Handler,
+		},
+		{
+			MethodName: "SetAppIdentityProvider",
+			Handler:    _Admin_SetAppIdentityProvider_Handler,
+		},
+		{
+			MethodName: "ListAppIdentityProviders",
+			Handler:    _Admin_ListAppIdentityProviders_Handler,
+		},
+		{
+			MethodName: "DeleteAppIdentityProvider",
+			Handler:    _Admin_DeleteAppIdentityProvider_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _Admin_ListUsers_Handler,
+		},
+		{
+			MethodName: "SearchUsers",
+			Handler:    _Admin_SearchUsers_Handler,
+		},
+		{
+			MethodName: "DisableUser",
+			Handler:    _Admin_DisableUser_Handler,
+		},
+		{
+			MethodName: "EnableUser",
+			Handler:    _Admin_EnableUser_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _Admin_EraseUser_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _Admin_ExportUserData_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportUsers",
+			Handler:       _Admin_ImportUsers_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportUsers",
+			Handler:       _Admin_ExportUsers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sso/admin.proto",
+}
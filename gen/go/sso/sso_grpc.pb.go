@@ -19,10 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Auth_Register_FullMethodName = "/auth.Auth/Register"
-	Auth_Login_FullMethodName    = "/auth.Auth/Login"
-	Auth_IsAdmin_FullMethodName  = "/auth.Auth/IsAdmin"
-	Auth_Logout_FullMethodName   = "/auth.Auth/Logout"
+	Auth_Register_FullMethodName                  = "/auth.Auth/Register"
+	Auth_Login_FullMethodName                     = "/auth.Auth/Login"
+	Auth_IsAdmin_FullMethodName                   = "/auth.Auth/IsAdmin"
+	Auth_Logout_FullMethodName                    = "/auth.Auth/Logout"
+	Auth_Introspect_FullMethodName                = "/auth.Auth/Introspect"
+	Auth_StartPasswordlessLogin_FullMethodName    = "/auth.Auth/StartPasswordlessLogin"
+	Auth_CompletePasswordlessLogin_FullMethodName = "/auth.Auth/CompletePasswordlessLogin"
+	Auth_BeginPasskeyRegistration_FullMethodName  = "/auth.Auth/BeginPasskeyRegistration"
+	Auth_FinishPasskeyRegistration_FullMethodName = "/auth.Auth/FinishPasskeyRegistration"
+	Auth_BeginPasskeyLogin_FullMethodName         = "/auth.Auth/BeginPasskeyLogin"
+	Auth_FinishPasskeyLogin_FullMethodName        = "/auth.Auth/FinishPasskeyLogin"
+	Auth_StartExternalLogin_FullMethodName        = "/auth.Auth/StartExternalLogin"
+	Auth_StartExternalLink_FullMethodName         = "/auth.Auth/StartExternalLink"
+	Auth_FinishExternalLogin_FullMethodName       = "/auth.Auth/FinishExternalLogin"
 )
 
 // AuthClient is the client API for Auth service.
@@ -38,6 +48,37 @@ type AuthClient interface {
 	// IsAdmin checks whether a user is an admin.
 	IsAdmin(ctx context.Context, in *IsAdminRequest, opts ...grpc.CallOption) (*IsAdminResponse, error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	// Introspect checks a token and returns its claims. An invalid, expired
+	// or revoked token is not an error: the response has active = false.
+	Introspect(ctx context.Context, in *IntrospectRequest, opts ...grpc.CallOption) (*IntrospectResponse, error)
+	// StartPasswordlessLogin emails the user a one-time code or a magic link.
+	// The response is the same whether or not the user exists.
+	StartPasswordlessLogin(ctx context.Context, in *StartPasswordlessLoginRequest, opts ...grpc.CallOption) (*StartPasswordlessLoginResponse, error)
+	// CompletePasswordlessLogin exchanges the emailed code or link token
+	// for the same auth token Login returns.
+	CompletePasswordlessLogin(ctx context.Context, in *CompletePasswordlessLoginRequest, opts ...grpc.CallOption) (*CompletePasswordlessLoginResponse, error)
+	// BeginPasskeyRegistration starts registering a passkey for the user and
+	// app of the bearer token. Pass options_json to navigator.credentials.create
+	// (PublicKeyCredential.parseCreationOptionsFromJSON).
+	BeginPasskeyRegistration(ctx context.Context, in *BeginPasskeyRegistrationRequest, opts ...grpc.CallOption) (*BeginPasskeyRegistrationResponse, error)
+	// FinishPasskeyRegistration verifies the new credential and stores its public key.
+	FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationRequest, opts ...grpc.CallOption) (*FinishPasskeyRegistrationResponse, error)
+	// BeginPasskeyLogin starts a passkey login. Pass options_json to
+	// navigator.credentials.get (PublicKeyCredential.parseRequestOptionsFromJSON).
+	BeginPasskeyLogin(ctx context.Context, in *BeginPasskeyLoginRequest, opts ...grpc.CallOption) (*BeginPasskeyLoginResponse, error)
+	// FinishPasskeyLogin verifies the signature and returns the same auth
+	// token Login returns.
+	FinishPasskeyLogin(ctx context.Context, in *FinishPasskeyLoginRequest, opts ...grpc.CallOption) (*FinishPasskeyLoginResponse, error)
+	// StartExternalLogin starts a login through an external OIDC provider of the app.
+	// Send the user to authorization_url; the provider returns them to the
+	// redirect URI of the provider with code and state.
+	StartExternalLogin(ctx context.Context, in *StartExternalLoginRequest, opts ...grpc.CallOption) (*StartExternalLoginResponse, error)
+	// StartExternalLink starts linking an account at an external provider to the
+	// user of the bearer token. It is finished with FinishExternalLogin as well.
+	StartExternalLink(ctx context.Context, in *StartExternalLinkRequest, opts ...grpc.CallOption) (*StartExternalLinkResponse, error)
+	// FinishExternalLogin exchanges the code from the provider, finds, links or
+	// creates the user and returns the same auth token Login returns.
+	FinishExternalLogin(ctx context.Context, in *FinishExternalLoginRequest, opts ...grpc.CallOption) (*FinishExternalLoginResponse, error)
 }
 
 type authClient struct {
@@ -88,6 +129,106 @@ func (c *authClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *authClient) Introspect(ctx context.Context, in *IntrospectRequest, opts ...grpc.CallOption) (*IntrospectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IntrospectResponse)
+	err := c.cc.Invoke(ctx, Auth_Introspect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) StartPasswordlessLogin(ctx context.Context, in *StartPasswordlessLoginRequest, opts ...grpc.CallOption) (*StartPasswordlessLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartPasswordlessLoginResponse)
+	err := c.cc.Invoke(ctx, Auth_StartPasswordlessLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) CompletePasswordlessLogin(ctx context.Context, in *CompletePasswordlessLoginRequest, opts ...grpc.CallOption) (*CompletePasswordlessLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompletePasswordlessLoginResponse)
+	err := c.cc.Invoke(ctx, Auth_CompletePasswordlessLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) BeginPasskeyRegistration(ctx context.Context, in *BeginPasskeyRegistrationRequest, opts ...grpc.CallOption) (*BeginPasskeyRegistrationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeginPasskeyRegistrationResponse)
+	err := c.cc.Invoke(ctx, Auth_BeginPasskeyRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationRequest, opts ...grpc.CallOption) (*FinishPasskeyRegistrationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinishPasskeyRegistrationResponse)
+	err := c.cc.Invoke(ctx, Auth_FinishPasskeyRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) BeginPasskeyLogin(ctx context.Context, in *BeginPasskeyLoginRequest, opts ...grpc.CallOption) (*BeginPasskeyLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeginPasskeyLoginResponse)
+	err := c.cc.Invoke(ctx, Auth_BeginPasskeyLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) FinishPasskeyLogin(ctx context.Context, in *FinishPasskeyLoginRequest, opts ...grpc.CallOption) (*FinishPasskeyLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinishPasskeyLoginResponse)
+	err := c.cc.Invoke(ctx, Auth_FinishPasskeyLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) StartExternalLogin(ctx context.Context, in *StartExternalLoginRequest, opts ...grpc.CallOption) (*StartExternalLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartExternalLoginResponse)
+	err := c.cc.Invoke(ctx, Auth_StartExternalLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) StartExternalLink(ctx context.Context, in *StartExternalLinkRequest, opts ...grpc.CallOption) (*StartExternalLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartExternalLinkResponse)
+	err := c.cc.Invoke(ctx, Auth_StartExternalLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) FinishExternalLogin(ctx context.Context, in *FinishExternalLoginRequest, opts ...grpc.CallOption) (*FinishExternalLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinishExternalLoginResponse)
+	err := c.cc.Invoke(ctx, Auth_FinishExternalLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServer is the server API for Auth service.
 // All implementations must embed UnimplementedAuthServer
 // for forward compatibility.
@@ -101,6 +242,37 @@ type AuthServer interface {
 	// IsAdmin checks whether a user is an admin.
 	IsAdmin(context.Context, *IsAdminRequest) (*IsAdminResponse, error)
 	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	// Introspect checks a token and returns its claims. An invalid, expired
+	// or revoked token is not an error: the response has active = false.
+	Introspect(context.Context, *IntrospectRequest) (*IntrospectResponse, error)
+	// StartPasswordlessLogin emails the user a one-time code or a magic link.
+	// The response is the same whether or not the user exists.
+	StartPasswordlessLogin(context.Context, *StartPasswordlessLoginRequest) (*StartPasswordlessLoginResponse, error)
+	// CompletePasswordlessLogin exchanges the emailed code or link token
+	// for the same auth token Login returns.
+	CompletePasswordlessLogin(context.Context, *CompletePasswordlessLoginRequest) (*CompletePasswordlessLoginResponse, error)
+	// BeginPasskeyRegistration starts registering a passkey for the user and
+	// app of the bearer token. Pass options_json to navigator.credentials.create
+	// (PublicKeyCredential.parseCreationOptionsFromJSON).
+	BeginPasskeyRegistration(context.Context, *BeginPasskeyRegistrationRequest) (*BeginPasskeyRegistrationResponse, error)
+	// FinishPasskeyRegistration verifies the new credential and stores its public key.
+	FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationRequest) (*FinishPasskeyRegistrationResponse, error)
+	// BeginPasskeyLogin starts a passkey login. Pass options_json to
+	// navigator.credentials.get (PublicKeyCredential.parseRequestOptionsFromJSON).
+	BeginPasskeyLogin(context.Context, *BeginPasskeyLoginRequest) (*BeginPasskeyLoginResponse, error)
+	// FinishPasskeyLogin verifies the signature and returns the same auth
+	// token Login returns.
+	FinishPasskeyLogin(context.Context, *FinishPasskeyLoginRequest) (*FinishPasskeyLoginResponse, error)
+	// StartExternalLogin starts a login through an external OIDC provider of the app.
+	// Send the user to authorization_url; the provider returns them to the
+	// redirect URI of the provider with code and state.
+	StartExternalLogin(context.Context, *StartExternalLoginRequest) (*StartExternalLoginResponse, error)
+	// StartExternalLink starts linking an account at an external provider to the
+	// user of the bearer token. It is finished with FinishExternalLogin as well.
+	StartExternalLink(context.Context, *StartExternalLinkRequest) (*StartExternalLinkResponse, error)
+	// FinishExternalLogin exchanges the code from the provider, finds, links or
+	// creates the user and returns the same auth token Login returns.
+	FinishExternalLogin(context.Context, *FinishExternalLoginRequest) (*FinishExternalLoginResponse, error)
 	mustEmbedUnimplementedAuthServer()
 }
 
@@ -123,6 +295,36 @@ func (UnimplementedAuthServer) IsAdmin(context.Context, *IsAdminRequest) (*IsAdm
 func (UnimplementedAuthServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAuthServer) Introspect(context.Context, *IntrospectRequest) (*IntrospectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Introspect not implemented")
+}
+func (UnimplementedAuthServer) StartPasswordlessLogin(context.Context, *StartPasswordlessLoginRequest) (*StartPasswordlessLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartPasswordlessLogin not implemented")
+}
+func (UnimplementedAuthServer) CompletePasswordlessLogin(context.Context, *CompletePasswordlessLoginRequest) (*CompletePasswordlessLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompletePasswordlessLogin not implemented")
+}
+func (UnimplementedAuthServer) BeginPasskeyRegistration(context.Context, *BeginPasskeyRegistrationRequest) (*BeginPasskeyRegistrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyRegistration not implemented")
+}
+func (UnimplementedAuthServer) FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationRequest) (*FinishPasskeyRegistrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyRegistration not implemented")
+}
+func (UnimplementedAuthServer) BeginPasskeyLogin(context.Context, *BeginPasskeyLoginRequest) (*BeginPasskeyLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyLogin not implemented")
+}
+func (UnimplementedAuthServer) FinishPasskeyLogin(context.Context, *FinishPasskeyLoginRequest) (*FinishPasskeyLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyLogin not implemented")
+}
+func (UnimplementedAuthServer) StartExternalLogin(context.Context, *StartExternalLoginRequest) (*StartExternalLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartExternalLogin not implemented")
+}
+func (UnimplementedAuthServer) StartExternalLink(context.Context, *StartExternalLinkRequest) (*StartExternalLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartExternalLink not implemented")
+}
+func (UnimplementedAuthServer) FinishExternalLogin(context.Context, *FinishExternalLoginRequest) (*FinishExternalLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishExternalLogin not implemented")
+}
 func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
 func (UnimplementedAuthServer) testEmbeddedByValue()              {}
 
@@ -216,6 +418,186 @@ func _Auth_Logout_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auth_Introspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntrospectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).Introspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_Introspect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).Introspect(ctx, req.(*IntrospectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_StartPasswordlessLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPasswordlessLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).StartPasswordlessLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_StartPasswordlessLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).StartPasswordlessLogin(ctx, req.(*StartPasswordlessLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_CompletePasswordlessLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompletePasswordlessLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).CompletePasswordlessLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_CompletePasswordlessLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).CompletePasswordlessLogin(ctx, req.(*CompletePasswordlessLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_BeginPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginPasskeyRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).BeginPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_BeginPasskeyRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).BeginPasskeyRegistration(ctx, req.(*BeginPasskeyRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_FinishPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).FinishPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_FinishPasskeyRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).FinishPasskeyRegistration(ctx, req.(*FinishPasskeyRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_BeginPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginPasskeyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).BeginPasskeyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_BeginPasskeyLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).BeginPasskeyLogin(ctx, req.(*BeginPasskeyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_FinishPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).FinishPasskeyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_FinishPasskeyLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).FinishPasskeyLogin(ctx, req.(*FinishPasskeyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_StartExternalLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartExternalLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).StartExternalLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_StartExternalLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).StartExternalLogin(ctx, req.(*StartExternalLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_StartExternalLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartExternalLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).StartExternalLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_StartExternalLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).StartExternalLink(ctx, req.(*StartExternalLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_FinishExternalLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishExternalLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).FinishExternalLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_FinishExternalLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).FinishExternalLogin(ctx, req.(*FinishExternalLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Auth_ServiceDesc is the grpc.ServiceDesc for Auth service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,6 +621,46 @@ var Auth_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _Auth_Logout_Handler,
 		},
+		{
+			MethodName: "Introspect",
+			Handler:    _Auth_Introspect_Handler,
+		},
+		{
+			MethodName: "StartPasswordlessLogin",
+			Handler:    _Auth_StartPasswordlessLogin_Handler,
+		},
+		{
+			MethodName: "CompletePasswordlessLogin",
+			Handler:    _Auth_CompletePasswordlessLogin_Handler,
+		},
+		{
+			MethodName: "BeginPasskeyRegistration",
+			Handler:    _Auth_BeginPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyRegistration",
+			Handler:    _Auth_FinishPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "BeginPasskeyLogin",
+			Handler:    _Auth_BeginPasskeyLogin_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyLogin",
+			Handler:    _Auth_FinishPasskeyLogin_Handler,
+		},
+		{
+			MethodName: "StartExternalLogin",
+			Handler:    _Auth_StartExternalLogin_Handler,
+		},
+		{
+			MethodName: "StartExternalLink",
+			Handler:    _Auth_StartExternalLink_Handler,
+		},
+		{
+			MethodName: "FinishExternalLogin",
+			Handler:    _Auth_FinishExternalLogin_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "sso/sso.proto",
@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.1
+// source: sso/user_events.proto
+
+package ssov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UserEvents_WatchUserEvents_FullMethodName = "/auth.UserEvents/WatchUserEvents"
+)
+
+// UserEventsClient is the client API for UserEvents service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// UserEvents is service for subscribing to user lifecycle events. Admin only.
+type UserEventsClient interface {
+	// WatchUserEvents streams events with id greater than after_id:
+	// first the stored history, then new events as they are published.
+	// Events may be delivered more than once; deduplicate by id.
+	WatchUserEvents(ctx context.Context, in *WatchUserEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserEvent], error)
+}
+
+type userEventsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserEventsClient(cc grpc.ClientConnInterface) UserEventsClient {
+	return &userEventsClient{cc}
+}
+
+func (c *userEventsClient) WatchUserEvents(ctx context.Context, in *WatchUserEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserEvents_ServiceDesc.Streams[0], UserEvents_WatchUserEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchUserEventsRequest, UserEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserEvents_WatchUserEventsClient = grpc.ServerStreamingClient[UserEvent]
+
+// UserEventsServer is the server API for UserEvents service.
+// All implementations must embed UnimplementedUserEventsServer
+// for forward compatibility.
+//
+// UserEvents is service for subscribing to user lifecycle events. Admin only.
+type UserEventsServer interface {
+	// WatchUserEvents streams events with id greater than after_id:
+	// first the stored history, then new events as they are published.
+	// Events may be delivered more than once; deduplicate by id.
+	WatchUserEvents(*WatchUserEventsRequest, grpc.ServerStreamingServer[UserEvent]) error
+	mustEmbedUnimplementedUserEventsServer()
+}
+
+// UnimplementedUserEventsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserEventsServer struct{}
+
+func (UnimplementedUserEventsServer) WatchUserEvents(*WatchUserEventsRequest, grpc.ServerStreamingServer[UserEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUserEvents not implemented")
+}
+func (UnimplementedUserEventsServer) mustEmbedUnimplementedUserEventsServer() {}
+func (UnimplementedUserEventsServer) testEmbeddedByValue()                    {}
+
+// UnsafeUserEventsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserEventsServer will
+// result in compilation errors.
+type UnsafeUserEventsServer interface {
+	mustEmbedUnimplementedUserEventsServer()
+}
+
+func RegisterUserEventsServer(s grpc.ServiceRegistrar, srv UserEventsServer) {
+	// If the following call pancis, it indicates UnimplementedUserEventsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserEvents_ServiceDesc, srv)
+}
+
+func _UserEvents_WatchUserEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUserEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserEventsServer).WatchUserEvents(m, &grpc.GenericServerStream[WatchUserEventsRequest, UserEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserEvents_WatchUserEventsServer = grpc.ServerStreamingServer[UserEvent]
+
+// UserEvents_ServiceDesc is the grpc.ServiceDesc for UserEvents service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserEvents_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.UserEvents",
+	HandlerType: (*UserEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchUserEvents",
+			Handler:       _UserEvents_WatchUserEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sso/user_events.proto",
+}
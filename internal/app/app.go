@@ -1,35 +1,282 @@
 package app
 
 import (
-	"time"
+	"fmt"
+	"io"
+	"net/http"
 
 	grpcapp "github.com/Artemiadze/gRPC-Service/internal/app/grpc"
+	"github.com/Artemiadze/gRPC-Service/internal/config"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/mailer"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/oidc"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/password"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/publisher"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/secretbox"
+	"github.com/Artemiadze/gRPC-Service/internal/migrations"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
 	postgres "github.com/Artemiadze/gRPC-Service/internal/repository"
 	"github.com/Artemiadze/gRPC-Service/internal/services"
+	"github.com/Artemiadze/gRPC-Service/internal/services/admin"
+	"github.com/Artemiadze/gRPC-Service/internal/services/audit"
+	"github.com/Artemiadze/gRPC-Service/internal/services/outbox"
+	"github.com/Artemiadze/gRPC-Service/internal/services/profile"
+	"github.com/Artemiadze/gRPC-Service/internal/services/sessions"
+	"github.com/Artemiadze/gRPC-Service/internal/services/webhooks"
 	"go.uber.org/zap"
 )
 
 type App struct {
 	GRPCServer *grpcapp.App
+	auth       *services.AuthService
+	audit      *audit.Recorder
+	outbox     *outbox.Dispatcher
+	hub        *outbox.Hub
+	notifier   *webhooks.Notifier
+	deliverer  *webhooks.Deliverer
+	eraser     *admin.Eraser
+	closers    []io.Closer
+	storage    interface{ Stop() error }
 }
 
-func New(
-	log *zap.Logger,
-	grpcPort int,
-	dsn string,
-	tokenTTL time.Duration,
-) *App {
+// New собирает сервис по конфигу cfg. Настройки, которые меняет Reload,
+// берутся из cfg только при старте.
+func New(log *zap.Logger, cfg *config.Config) *App {
+	// Миграции до открытия хранилища: сервис не должен работать со старой схемой
+	if cfg.MigrateOnStart {
+		version, err := migrations.Up(cfg.DSN, cfg.MigrationsTable)
+		if err != nil {
+			panic(err)
+		}
+		log.Info("migrations applied", zap.Uint("version", version))
+	}
+
+	appSecrets, err := NewAppSecretKeyring(cfg.Secrets)
+	if err != nil {
+		panic(err)
+	}
+
 	// Инициализация хранилища
-	storage, err := postgres.New(dsn)
+	storage, err := postgres.New(cfg.DSN, appSecrets)
 	if err != nil {
 		panic(err)
 	}
 
-	authService := services.New(log, storage, storage, storage, tokenTTL)
+	hasher, err := NewPasswordHasher(cfg.Password)
+	if err != nil {
+		panic(err)
+	}
+
+	// Журнал аудита и вебхуки приложений пишутся асинхронно, чтобы не замедлять вход
+	auditRecorder := audit.NewRecorder(log, storage, cfg.Audit.BufferSize, cfg.Audit.BatchSize, cfg.Audit.FlushInterval)
+	notifier := webhooks.NewNotifier(log, storage, cfg.Webhooks.BufferSize)
+	auditor := audit.Tee{auditRecorder, notifier}
+
+	deliverer := webhooks.NewDeliverer(
+		log,
+		storage,
+		cfg.Webhooks.Timeout,
+		cfg.Webhooks.PollInterval,
+		cfg.Webhooks.BatchSize,
+		cfg.Webhooks.MaxAttempts,
+	)
+	deliverer.Start()
+
+	authService := services.New(log, storage, storage, storage, hasher, auditor, storage, models.TokenSettings{
+		TTL:    cfg.TokenTTL,
+		Issuer: cfg.TokenIssuer,
+	})
+	// Вход без пароля работает, только если есть чем отправлять письма
+	mail, mailClosers, err := newMailer(cfg.Mail)
+	if err != nil {
+		panic(err)
+	}
+	if mail != nil {
+		authService.EnablePasswordless(storage, mail, services.PasswordlessSettings{
+			CodeTTL:            cfg.Passwordless.CodeTTL,
+			LinkTTL:            cfg.Passwordless.LinkTTL,
+			CodeLength:         cfg.Passwordless.CodeLength,
+			LinkURL:            cfg.Passwordless.LinkURL,
+			PasswordlessLimits: passwordlessLimits(cfg.Passwordless),
+		})
+	}
+
+	authService.EnablePasskeys(storage, cfg.Passkeys.Timeout)
+	authService.EnableFederation(storage, oidc.NewClient(&http.Client{Timeout: cfg.Federation.HTTPTimeout}), cfg.Federation.LoginTTL)
+
+	// Права администратора сервисы проверяют по хранилищу: admin_check
+	// в журнал аудита пишет только публичный RPC IsAdmin
+	auditService := audit.New(log, storage, storage)
+	sessionsService := sessions.New(log, storage, storage, auditor)
+	webhooksService := webhooks.New(log, storage, storage)
+	adminService := admin.New(log, storage, hasher, auditor, storage, cfg.Accounts.EraseGracePeriod)
+	profileService := profile.New(log, storage, auditor)
+
+	// События пользователей публикуются из outbox фоновым диспетчером,
+	// а подписчикам WatchUserEvents их раздаёт hub, читающий outbox сам
+	hub := outbox.NewHub(log, storage, cfg.Outbox.PollInterval)
+	if err := hub.Start(); err != nil {
+		panic(err)
+	}
+	publishers, closers, err := newPublishers(log, cfg.Outbox)
+	if err != nil {
+		panic(err)
+	}
+	dispatcher := outbox.NewDispatcher(log, storage, publishers, cfg.Outbox.PollInterval, cfg.Outbox.BatchSize)
+	dispatcher.Start()
+	watcher := outbox.NewWatcher(log, storage, hub, storage)
+
+	// Пользователи, удаление которых запрошено, удаляются по истечении срока ожидания
+	eraser := admin.NewEraser(log, storage, auditor, cfg.Accounts.ErasePollInterval, cfg.Accounts.EraseBatchSize)
+	eraser.Start()
 
 	// инициализация gRPC сервера
-	grpcApp := grpcapp.New(log, authService, grpcPort)
+	grpcApp := grpcapp.New(
+		log,
+		authService,
+		auditService,
+		sessionsService,
+		watcher,
+		webhooksService,
+		adminService,
+		profileService,
+		authService,
+		cfg.GRPC.Port,
+	)
 	return &App{
 		GRPCServer: grpcApp,
+		auth:       authService,
+		audit:      auditRecorder,
+		outbox:     dispatcher,
+		hub:        hub,
+		notifier:   notifier,
+		deliverer:  deliverer,
+		eraser:     eraser,
+		closers:    append(closers, mailClosers...),
+		storage:    storage,
+	}
+}
+
+// Reload применяет настройки, которые можно менять без перезапуска.
+func (a *App) Reload(cfg *config.Config) {
+	a.auth.SetTokenDefaults(models.TokenSettings{TTL: cfg.TokenTTL, Issuer: cfg.TokenIssuer})
+	a.auth.SetPasswordlessLimits(passwordlessLimits(cfg.Passwordless))
+}
+
+func passwordlessLimits(cfg config.PasswordlessConfig) services.PasswordlessLimits {
+	return services.PasswordlessLimits{
+		MaxAttempts: cfg.MaxAttempts,
+		MaxSends:    cfg.MaxSends,
+		MaxFailures: cfg.MaxFailures,
+		LimitWindow: cfg.LimitWindow,
+	}
+}
+
+// Stop останавливает gRPC сервер и фоновое удаление пользователей, дописывает
+// журнал аудита и очередь вебхуков, останавливает фоновую доставку событий
+// и закрывает соединение с базой.
+func (a *App) Stop() {
+	a.GRPCServer.Stop()
+	a.eraser.Stop()
+	a.audit.Stop()
+	a.notifier.Stop()
+	a.deliverer.Stop()
+	a.outbox.Stop()
+	a.hub.Stop()
+	for _, c := range a.closers {
+		_ = c.Close()
+	}
+	_ = a.storage.Stop()
+}
+
+// newPublishers собирает публикаторы событий пользователей из конфига.
+func newPublishers(log *zap.Logger, cfg config.OutboxConfig) (publisher.Multi, []io.Closer, error) {
+	var (
+		publishers publisher.Multi
+		closers    []io.Closer
+	)
+
+	if cfg.Log {
+		publishers = append(publishers, publisher.NewLog(log))
 	}
+
+	if cfg.File != "" {
+		file, err := publisher.NewFile(cfg.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		publishers = append(publishers, file)
+		closers = append(closers, file)
+	}
+
+	if cfg.Webhook.URL != "" {
+		publishers = append(publishers, publisher.NewWebhook(
+			cfg.Webhook.URL,
+			cfg.Webhook.Secret,
+			cfg.Webhook.Timeout,
+			cfg.Webhook.MaxRetries,
+		))
+	}
+
+	return publishers, closers, nil
+}
+
+// newMailer собирает отправку писем из конфига или возвращает nil,
+// если она не настроена.
+func newMailer(cfg config.MailConfig) (mailer.Mailer, []io.Closer, error) {
+	switch {
+	case cfg.File != "":
+		file, err := mailer.NewFile(cfg.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		return file, []io.Closer{file}, nil
+	case cfg.SMTP.Addr != "":
+		return mailer.NewSMTP(cfg.SMTP.Addr, cfg.From, cfg.SMTP.Username, cfg.SMTP.Password), nil, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+// NewPasswordHasher собирает хэшер паролей: новые пароли хэшируются
+// выбранным в конфиге алгоритмом, а хэши остальных алгоритмов
+// по-прежнему проверяются и пересчитываются при входе.
+// PBKDF2 только проверяется: такие хэши приходят при импорте из старых систем.
+func NewPasswordHasher(cfg config.PasswordConfig) (*password.Hasher, error) {
+	bcryptScheme := password.NewBcrypt(cfg.Bcrypt.Cost)
+	argonScheme := password.NewArgon2id(cfg.Argon2id.Memory, cfg.Argon2id.Time, cfg.Argon2id.Threads)
+	legacyScheme := password.NewPBKDF2(0)
+
+	switch cfg.Algorithm {
+	case "bcrypt":
+		return password.New(bcryptScheme, argonScheme, legacyScheme), nil
+	case "argon2id", "":
+		return password.New(argonScheme, bcryptScheme, legacyScheme), nil
+	default:
+		return nil, fmt.Errorf("unknown password algorithm: %q", cfg.Algorithm)
+	}
+}
+
+// NewAppSecretKeyring собирает ключи шифрования секретов приложений
+// или возвращает nil, если ключ в конфиге не задан.
+func NewAppSecretKeyring(cfg config.SecretsConfig) (*secretbox.Keyring, error) {
+	if cfg.AppSecretKey == "" {
+		return nil, nil
+	}
+
+	keys := make(map[string][]byte, len(cfg.AppSecretOldKeys)+1)
+	for id, encoded := range cfg.AppSecretOldKeys {
+		key, err := secretbox.ParseKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("old app secret key %q: %w", id, err)
+		}
+		keys[id] = key
+	}
+
+	key, err := secretbox.ParseKey(cfg.AppSecretKey)
+	if err != nil {
+		return nil, err
+	}
+	keys[cfg.AppSecretKeyID] = key
+
+	return secretbox.NewKeyring(cfg.AppSecretKeyID, keys)
 }
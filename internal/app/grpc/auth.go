@@ -6,7 +6,14 @@ import (
 
 	"go.uber.org/zap"
 
+	admingrpc "github.com/Artemiadze/gRPC-Service/internal/grpc/Admin"
+	auditgrpc "github.com/Artemiadze/gRPC-Service/internal/grpc/Audit"
 	authgrpc "github.com/Artemiadze/gRPC-Service/internal/grpc/Auth"
+	profilegrpc "github.com/Artemiadze/gRPC-Service/internal/grpc/Profile"
+	sessionsgrpc "github.com/Artemiadze/gRPC-Service/internal/grpc/Sessions"
+	usereventsgrpc "github.com/Artemiadze/gRPC-Service/internal/grpc/UserEvents"
+	webhooksgrpc "github.com/Artemiadze/gRPC-Service/internal/grpc/Webhooks"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/interceptors"
 
 	"google.golang.org/grpc"
 )
@@ -21,11 +28,33 @@ type App struct {
 func New(
 	log *zap.Logger,
 	authServise authgrpc.Auth,
+	auditService auditgrpc.Audit,
+	sessionsService sessionsgrpc.Sessions,
+	userEvents usereventsgrpc.Watcher,
+	webhooksService webhooksgrpc.Webhooks,
+	adminService admingrpc.Admin,
+	profileService profilegrpc.Profiles,
+	tokenValidator interceptors.TokenValidator,
 	port int,
 ) *App {
-	gRPCServer := grpc.NewServer()
+	gRPCServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptors.ClientInfo(),
+			interceptors.Authenticate(tokenValidator),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptors.StreamClientInfo(),
+			interceptors.StreamAuthenticate(tokenValidator),
+		),
+	)
 
 	authgrpc.Register(gRPCServer, authServise)
+	auditgrpc.Register(gRPCServer, auditService)
+	sessionsgrpc.Register(gRPCServer, sessionsService)
+	usereventsgrpc.Register(gRPCServer, userEvents)
+	webhooksgrpc.Register(gRPCServer, webhooksService)
+	admingrpc.Register(gRPCServer, adminService)
+	profilegrpc.Register(gRPCServer, profileService)
 
 	return &App{
 		log:        log,
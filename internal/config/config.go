@@ -1,49 +1,207 @@
 package config
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
+	"reflect"
 	"time"
 
+	"github.com/Artemiadze/gRPC-Service/internal/lib/secrets"
+
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+// Config - настройки сервиса. Каждое поле можно задать переменной окружения,
+// она важнее значения из файла. Поля с пометкой "reload" перечитываются по SIGHUP.
+// Поля с тегом secret можно задать ссылкой на секрет (file:///run/secrets/x, env:NAME),
+// они не выводятся в --print-config.
 type Config struct {
-	Env            string     `yaml:"env" env-default:"local"`
-	DSN            string     `yaml:"dsn" env-required:"true"`
-	GRPC           GRPCConfig `yaml:"grpc"`
-	MigrationsPath string
-	TokenTTL       time.Duration `yaml:"token_ttl" env-default:"1h"`
+	Env             string             `yaml:"env" env:"ENV" env-default:"local"` // local, dev, prod
+	LogLevel        string             `yaml:"log_level" env:"LOG_LEVEL"`         // reload; пусто - debug для local и dev, info для prod
+	DSN             string             `yaml:"dsn" env:"DSN" secret:"dsn"`
+	GRPC            GRPCConfig         `yaml:"grpc" env-prefix:"GRPC_"`
+	MigrateOnStart  bool               `yaml:"migrate_on_start" env:"MIGRATE_ON_START" env-default:"false"`      // применять встроенные миграции при запуске
+	MigrationsTable string             `yaml:"migrations_table" env:"MIGRATIONS_TABLE" env-default:"migrations"` // таблица версии схемы, как -table у migrator
+	TokenTTL        time.Duration      `yaml:"token_ttl" env:"TOKEN_TTL" env-default:"1h"`                       // reload; у приложения может быть свой
+	TokenIssuer     string             `yaml:"token_issuer" env:"TOKEN_ISSUER" env-default:"sso"`                // reload; claim iss, у приложения может быть свой
+	Password        PasswordConfig     `yaml:"password" env-prefix:"PASSWORD_"`
+	Audit           AuditConfig        `yaml:"audit" env-prefix:"AUDIT_"`
+	Outbox          OutboxConfig       `yaml:"outbox" env-prefix:"OUTBOX_"`
+	Webhooks        WebhooksConfig     `yaml:"webhooks" env-prefix:"WEBHOOKS_"`
+	Secrets         SecretsConfig      `yaml:"secrets" env-prefix:"SECRETS_"`
+	Accounts        AccountsConfig     `yaml:"accounts" env-prefix:"ACCOUNTS_"`
+	Mail            MailConfig         `yaml:"mail" env-prefix:"MAIL_"`
+	Passwordless    PasswordlessConfig `yaml:"passwordless" env-prefix:"PASSWORDLESS_"`
+	Passkeys        PasskeysConfig     `yaml:"passkeys" env-prefix:"PASSKEYS_"`
+	Federation      FederationConfig   `yaml:"federation" env-prefix:"FEDERATION_"`
+
+	path string
 }
 
 type GRPCConfig struct {
-	Port    int           `yaml:"port"`
-	Timeout time.Duration `yaml:"timeout"`
+	Port    int           `yaml:"port" env:"PORT" env-default:"50051"`
+	Timeout time.Duration `yaml:"timeout" env:"TIMEOUT" env-default:"5s"`
+}
+
+// PasswordConfig задаёт алгоритм хэширования паролей и его параметры.
+// Хэши, полученные другим алгоритмом или с другими параметрами,
+// пересчитываются при следующем успешном входе пользователя.
+type PasswordConfig struct {
+	Algorithm string         `yaml:"algorithm" env:"ALGORITHM" env-default:"argon2id"` // bcrypt, argon2id
+	Bcrypt    BcryptConfig   `yaml:"bcrypt" env-prefix:"BCRYPT_"`
+	Argon2id  Argon2idConfig `yaml:"argon2id" env-prefix:"ARGON2ID_"`
+}
+
+type BcryptConfig struct {
+	Cost int `yaml:"cost" env:"COST" env-default:"10"`
+}
+
+type Argon2idConfig struct {
+	Memory  uint32 `yaml:"memory" env:"MEMORY" env-default:"65536"` // KiB
+	Time    uint32 `yaml:"time" env:"TIME" env-default:"3"`
+	Threads uint8  `yaml:"threads" env:"THREADS" env-default:"2"`
+}
+
+// AuditConfig задаёт параметры асинхронной записи журнала аудита.
+type AuditConfig struct {
+	BufferSize    int           `yaml:"buffer_size" env:"BUFFER_SIZE" env-default:"1024"`
+	BatchSize     int           `yaml:"batch_size" env:"BATCH_SIZE" env-default:"100"`
+	FlushInterval time.Duration `yaml:"flush_interval" env:"FLUSH_INTERVAL" env-default:"1s"`
+}
+
+// OutboxConfig задаёт доставку событий жизненного цикла пользователей.
+type OutboxConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" env:"POLL_INTERVAL" env-default:"1s"`
+	BatchSize    int           `yaml:"batch_size" env:"BATCH_SIZE" env-default:"100"`
+	Log          bool          `yaml:"log" env:"LOG" env-default:"false"` // писать события в лог
+	File         string        `yaml:"file" env:"FILE"`                   // путь к JSONL файлу, пусто - не писать
+	Webhook      WebhookConfig `yaml:"webhook" env-prefix:"WEBHOOK_"`
+}
+
+// WebhookConfig - вебхук для событий пользователей. Пустой URL отключает его.
+type WebhookConfig struct {
+	URL        string        `yaml:"url" env:"URL"`
+	Secret     string        `yaml:"secret" env:"SECRET" secret:"true"`
+	Timeout    time.Duration `yaml:"timeout" env:"TIMEOUT" env-default:"5s"`
+	MaxRetries int           `yaml:"max_retries" env:"MAX_RETRIES" env-default:"3"`
+}
+
+// WebhooksConfig задаёт доставку вебхуков приложений.
+type WebhooksConfig struct {
+	BufferSize   int           `yaml:"buffer_size" env:"BUFFER_SIZE" env-default:"1024"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"POLL_INTERVAL" env-default:"1s"`
+	BatchSize    int           `yaml:"batch_size" env:"BATCH_SIZE" env-default:"50"`
+	Timeout      time.Duration `yaml:"timeout" env:"TIMEOUT" env-default:"5s"`
+	MaxAttempts  int           `yaml:"max_attempts" env:"MAX_ATTEMPTS" env-default:"10"` // после - в dead-letter очередь
+}
+
+// SecretsConfig - ключи шифрования секретов приложений в базе (KEK).
+// При смене ключа новый ставится в app_secret_key с новым app_secret_key_id,
+// а прежний переносится в app_secret_old_keys до перешифровки
+// командой ssoctl secrets rotate.
+type SecretsConfig struct {
+	// AppSecretKey - текущий ключ AES-256 в base64. Пусто - секреты хранятся открыто.
+	AppSecretKey     string            `yaml:"app_secret_key" env:"APP_SECRET_KEY" secret:"true"`
+	AppSecretKeyID   string            `yaml:"app_secret_key_id" env:"APP_SECRET_KEY_ID" env-default:"1"`
+	AppSecretOldKeys map[string]string `yaml:"app_secret_old_keys" env:"APP_SECRET_OLD_KEYS" secret:"true"` // id: ключ, в env - "id:ключ,id:ключ"
+}
+
+// AccountsConfig задаёт удаление учётных записей по запросу (EraseUser).
+type AccountsConfig struct {
+	EraseGracePeriod  time.Duration `yaml:"erase_grace_period" env:"ERASE_GRACE_PERIOD" env-default:"720h"` // до удаления можно передумать
+	ErasePollInterval time.Duration `yaml:"erase_poll_interval" env:"ERASE_POLL_INTERVAL" env-default:"1m"`
+	EraseBatchSize    int           `yaml:"erase_batch_size" env:"ERASE_BATCH_SIZE" env-default:"100"`
+}
+
+// MailConfig задаёт отправку писем. Если не задан ни file, ни smtp.addr,
+// письма не отправляются и вход без пароля отключён.
+type MailConfig struct {
+	From string     `yaml:"from" env:"FROM" env-default:"sso@localhost"`
+	File string     `yaml:"file" env:"FILE"` // путь к JSONL файлу вместо отправки, для локальной разработки и тестов
+	SMTP SMTPConfig `yaml:"smtp" env-prefix:"SMTP_"`
+}
+
+type SMTPConfig struct {
+	Addr     string `yaml:"addr" env:"ADDR"` // host:port
+	Username string `yaml:"username" env:"USERNAME"`
+	Password string `yaml:"password" env:"PASSWORD" secret:"true"`
+}
+
+// PasswordlessConfig задаёт вход по одноразовым кодам и ссылкам из письма.
+type PasswordlessConfig struct {
+	CodeTTL     time.Duration `yaml:"code_ttl" env:"CODE_TTL" env-default:"10m"`
+	LinkTTL     time.Duration `yaml:"link_ttl" env:"LINK_TTL" env-default:"15m"`
+	CodeLength  int           `yaml:"code_length" env:"CODE_LENGTH" env-default:"6"`
+	MaxAttempts int           `yaml:"max_attempts" env:"MAX_ATTEMPTS" env-default:"5"`  // попыток ввода одного кода
+	LinkURL     string        `yaml:"link_url" env:"LINK_URL"`                          // страница входа по ссылке, пусто - ссылки отключены
+	MaxSends    int           `yaml:"max_sends" env:"MAX_SENDS" env-default:"5"`        // писем одному пользователю за limit_window
+	MaxFailures int           `yaml:"max_failures" env:"MAX_FAILURES" env-default:"10"` // неверных кодов пользователя за limit_window
+	LimitWindow time.Duration `yaml:"limit_window" env:"LIMIT_WINDOW" env-default:"1h"`
+}
+
+// PasskeysConfig задаёт вход по ключам доступа (WebAuthn). Домен и origin
+// задаются для каждого приложения.
+type PasskeysConfig struct {
+	Timeout time.Duration `yaml:"timeout" env:"TIMEOUT" env-default:"5m"` // сколько действует вызов регистрации или входа
+}
+
+// FederationConfig задаёт вход через внешних OIDC-провайдеров. Сами провайдеры
+// настраиваются для каждого приложения.
+type FederationConfig struct {
+	LoginTTL    time.Duration `yaml:"login_ttl" env:"LOGIN_TTL" env-default:"10m"`       // сколько ждать возврата от провайдера
+	HTTPTimeout time.Duration `yaml:"http_timeout" env:"HTTP_TIMEOUT" env-default:"10s"` // на каждый запрос к провайдеру
 }
 
 // парсинг конфигурации из файла и переменных окружения
 func MustLoad() *Config {
-	configPath := fetchConfigPath()
-	if configPath == "" {
-		panic("config path is empty")
-	}
-
-	return MustLoadPath(configPath)
+	return MustLoadPath(fetchConfigPath())
 }
 
 func MustLoadPath(configPath string) *Config {
-	// check if file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		panic("config file does not exist: " + configPath)
+	cfg, err := Load(configPath)
+	if err != nil {
+		panic("cannot read config: " + err.Error())
 	}
 
+	return cfg
+}
+
+// Load читает конфиг из файла и переменных окружения и проверяет его.
+// Без файла (пустой configPath) настройки берутся только из окружения.
+func Load(configPath string) (*Config, error) {
 	var cfg Config
 
-	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
-		panic("cannot read config: " + err.Error())
+	if configPath == "" {
+		if err := cleanenv.ReadEnv(&cfg); err != nil {
+			return nil, err
+		}
+	} else {
+		// check if file exists
+		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("config file does not exist: %s", configPath)
+		}
+		if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	cfg.path = configPath
+
+	if err := resolveSecrets(context.Background(), reflect.ValueOf(&cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config:\n%w", err)
 	}
 
-	return &cfg
+	return &cfg, nil
+}
+
+// Reload перечитывает конфиг из того же источника.
+func (c *Config) Reload() (*Config, error) {
+	return Load(c.path)
 }
 
 // fetchConfigPath fetches config path from command line flag or environment variable.
@@ -61,3 +219,45 @@ func fetchConfigPath() string {
 
 	return result
 }
+
+// resolveSecrets подставляет значения полей с тегом secret, заданных ссылками.
+func resolveSecrets(ctx context.Context, v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		f, field := v.Type().Field(i), v.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		if field.Kind() == reflect.Struct {
+			if err := resolveSecrets(ctx, field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, ok := f.Tag.Lookup("secret"); !ok {
+			continue
+		}
+
+		switch {
+		case field.Kind() == reflect.String:
+			value, err := secrets.Resolve(ctx, field.String())
+			if err != nil {
+				return err
+			}
+			field.SetString(value)
+
+		case field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.String:
+			iter := field.MapRange()
+			for iter.Next() {
+				value, err := secrets.Resolve(ctx, iter.Value().String())
+				if err != nil {
+					return err
+				}
+				field.SetMapIndex(iter.Key(), reflect.ValueOf(value))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad_EnvOverridesFile(t *testing.T) {
+	path := writeConfig(t, `
+dsn: postgres://sso:secret@db:5432/sso
+grpc:
+  port: 50051
+password:
+  bcrypt:
+    cost: 12
+`)
+	t.Setenv("GRPC_PORT", "6000")
+	t.Setenv("PASSWORD_BCRYPT_COST", "11")
+	t.Setenv("OUTBOX_WEBHOOK_TIMEOUT", "2s")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6000, cfg.GRPC.Port)
+	assert.Equal(t, 11, cfg.Password.Bcrypt.Cost)
+	assert.Equal(t, 2*time.Second, cfg.Outbox.Webhook.Timeout)
+	assert.Equal(t, time.Hour, cfg.TokenTTL, "default")
+}
+
+func TestLoad_EnvOnly(t *testing.T) {
+	t.Setenv("DSN", "postgres://db/sso")
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, 50051, cfg.GRPC.Port)
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	path := writeConfig(t, `
+grpc:
+  port: 70000
+token_ttl: -1m
+log_level: loud
+`)
+
+	_, err := Load(path)
+	require.Error(t, err)
+
+	for _, field := range []string{"dsn", "grpc.port", "token_ttl", "log_level"} {
+		assert.Contains(t, err.Error(), field+":")
+	}
+}
+
+func TestRedactDSN(t *testing.T) {
+	assert.Equal(t, "postgres://sso:REDACTED@db:5432/sso?sslmode=disable",
+		RedactDSN("postgres://sso:secret@db:5432/sso?sslmode=disable"))
+	assert.Equal(t, "postgres://db/sso", RedactDSN("postgres://db/sso"))
+	assert.Equal(t, "host=db user=sso password=REDACTED dbname=sso",
+		RedactDSN("host=db user=sso password='s3 cret' dbname=sso"))
+}
+
+func TestYAML_HidesSecrets(t *testing.T) {
+	cfg := &Config{DSN: "postgres://sso:secret@db/sso"}
+	cfg.Outbox.Webhook.Secret = "hook-secret"
+
+	out, err := cfg.YAML()
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(out, "secret@") || strings.Contains(out, "hook-secret"), out)
+}
+
+func TestRestartRequired(t *testing.T) {
+	cur := &Config{TokenTTL: time.Hour, LogLevel: "info"}
+	cur.GRPC.Port = 50051
+
+	next := *cur
+	next.TokenTTL = time.Minute
+	next.LogLevel = "debug"
+	assert.Empty(t, cur.RestartRequired(&next))
+
+	next.Passwordless.MaxSends = 3
+	next.Passwordless.LimitWindow = time.Minute
+	assert.Empty(t, cur.RestartRequired(&next))
+
+	next.GRPC.Port = 50052
+	next.Passwordless.CodeTTL = time.Minute
+	assert.Equal(t, []string{"grpc", "passwordless.code_ttl"}, cur.RestartRequired(&next))
+}
+
+func TestLoad_ResolvesSecretReferences(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "dsn")
+	require.NoError(t, os.WriteFile(secret, []byte("postgres://sso:from-file@db/sso\n"), 0o600))
+
+	path := writeConfig(t, `
+dsn: file://`+secret+`
+outbox:
+  webhook:
+    url: https://hooks.example.com
+    secret: env:SSO_TEST_WEBHOOK_SECRET
+`)
+	t.Setenv("SSO_TEST_WEBHOOK_SECRET", "hook-secret")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://sso:from-file@db/sso", cfg.DSN)
+	assert.Equal(t, "hook-secret", cfg.Outbox.Webhook.Secret)
+
+	redacted := cfg.Redacted()
+	assert.Equal(t, "postgres://sso:REDACTED@db/sso", redacted.DSN)
+	assert.Equal(t, "REDACTED", redacted.Outbox.Webhook.Secret)
+}
+
+func TestValidate_AppSecretKeys(t *testing.T) {
+	const key = "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="
+
+	path := writeConfig(t, `
+dsn: postgres://db/sso
+secrets:
+  app_secret_key: `+key+`
+  app_secret_key_id: "2"
+  app_secret_old_keys:
+    "1": `+key+`
+`)
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"1": "REDACTED"}, cfg.Redacted().Secrets.AppSecretOldKeys)
+	assert.Equal(t, key, cfg.Secrets.AppSecretOldKeys["1"], "original config is not changed")
+
+	t.Setenv("SECRETS_APP_SECRET_OLD_KEYS", "2:"+key+",3:short")
+	_, err = Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "secrets.app_secret_old_keys.2: has the same id")
+	assert.Contains(t, err.Error(), "secrets.app_secret_old_keys.3: must be 32 bytes")
+}
+
+func TestValidate_MailAndPasswordless(t *testing.T) {
+	path := writeConfig(t, `
+dsn: postgres://db/sso
+mail:
+  file: /tmp/mail.jsonl
+  smtp:
+    addr: smtp.example.com
+passwordless:
+  code_length: 3
+  link_url: /login
+`)
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mail: file and smtp.addr are mutually exclusive")
+	assert.Contains(t, err.Error(), "mail.smtp.addr: must be host:port")
+	assert.Contains(t, err.Error(), "passwordless.code_length: must be between 4 and 12")
+	assert.Contains(t, err.Error(), "passwordless.link_url: must be an http(s) URL")
+}
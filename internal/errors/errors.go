@@ -6,6 +6,48 @@ var (
 	ErrUserExists         = errors.New("user already exists")
 	ErrUserNotFound       = errors.New("user not found")
 	ErrAppNotFound        = errors.New("app not found")
+	ErrAppExists          = errors.New("app already exists")
+	ErrOrgNotFound        = errors.New("organization not found")
+	ErrOrgExists          = errors.New("organization already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrInvalidAppID       = errors.New("invalid app ID")
+	ErrAccountLocked      = errors.New("account locked")
+	ErrAccountDisabled    = errors.New("account disabled")
+	ErrAccountDeleted     = errors.New("account is pending deletion")
+	ErrAppAccessDenied    = errors.New("user is not a member of the app")
+	ErrAppAccessPending   = errors.New("app access request is pending approval")
+	ErrMemberNotFound     = errors.New("app member not found")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrUnauthenticated    = errors.New("authentication required")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSessionRevoked     = errors.New("session revoked")
+	ErrSubscriberLagging  = errors.New("subscriber is lagging behind")
+	ErrWebhookNotFound    = errors.New("webhook not found")
+	ErrDeliveryNotFound   = errors.New("webhook delivery not found")
+	ErrVersionConflict    = errors.New("version conflict")
+	ErrInvalidLoginCode   = errors.New("invalid or expired login code")
+	ErrPasswordlessOff    = errors.New("passwordless login is not configured")
+	ErrInvalidPasskey     = errors.New("invalid passkey")
+	ErrPasskeysOff        = errors.New("passkeys are not enabled for the app")
+	ErrPasskeyExists      = errors.New("passkey already registered")
+	ErrIdPNotFound        = errors.New("identity provider not found")
+	ErrExternalLogin      = errors.New("external login failed")
+	ErrIdentityNotLinked  = errors.New("email belongs to an account the identity is not linked to")
+	ErrIdentityLinked     = errors.New("identity is linked to another user")
+	ErrIdentityNotFound   = errors.New("identity not found")
 )
+
+// ValidationError - ошибка проверки входных данных в сервисном слое.
+// На уровне gRPC превращается в InvalidArgument с errdetails.BadRequest.
+type ValidationError struct {
+	Field       string
+	Description string
+}
+
+func (e *ValidationError) Error() string { return e.Field + ": " + e.Description }
+
+// NewValidationError возвращает ошибку валидации поля field.
+func NewValidationError(field, description string) error {
+	return &ValidationError{Field: field, Description: description}
+}
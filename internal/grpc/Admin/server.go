@@ -0,0 +1,982 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/interceptors"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/pagetoken"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/Artemiadze/gRPC-Service/internal/services/admin"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Admin - сервисный слой администрирования организаций, приложений и пользователей.
+type Admin interface {
+	ListApps(ctx context.Context, callerID int64) ([]models.App, error)
+	CreateApp(ctx context.Context, callerID int64, spec admin.AppSpec) (models.App, error)
+	RotateAppSecret(ctx context.Context, callerID int64, appID int) (string, error)
+	LockUser(ctx context.Context, callerID int64, ref admin.UserRef) (models.User, int64, error)
+	UnlockUser(ctx context.Context, callerID int64, ref admin.UserRef) (int64, error)
+	SetAdmin(ctx context.Context, callerID int64, ref admin.UserRef, isAdmin bool) (int64, error)
+	SetOrgAdmin(ctx context.Context, callerID int64, ref admin.UserRef, orgAdmin bool) (int64, error)
+	CreateOrg(ctx context.Context, callerID int64, spec admin.OrgSpec) (models.Org, error)
+	ListOrgs(ctx context.Context, callerID int64) ([]models.Org, error)
+	SetAppAccessPolicy(ctx context.Context, callerID int64, appID int, policy string) error
+	ListAppMembers(ctx context.Context, callerID int64, appID int, status string) ([]models.AppMember, error)
+	InviteAppMember(ctx context.Context, callerID int64, appID int, ref admin.UserRef) (models.AppMember, error)
+	ApproveAppMember(ctx context.Context, callerID int64, appID int, ref admin.UserRef) (models.AppMember, error)
+	RemoveAppMember(ctx context.Context, callerID int64, appID int, ref admin.UserRef) (int64, error)
+	SetAppTokenSettings(ctx context.Context, callerID int64, appID int, settings models.TokenSettings) error
+	SetAppPasskeySettings(ctx context.Context, callerID int64, appID int, settings models.PasskeySettings) error
+	SetAppIdentityProvider(ctx context.Context, callerID int64, p models.IdentityProvider) (models.IdentityProvider, error)
+	ListAppIdentityProviders(ctx context.Context, callerID int64, appID int) ([]models.IdentityProvider, error)
+	DeleteAppIdentityProvider(ctx context.Context, callerID int64, appID int, name string) error
+	ListUsers(ctx context.Context, callerID int64, org string, filter models.UserFilter) ([]models.User, *models.UserCursor, error)
+	SearchUsers(ctx context.Context, callerID int64, org string, filter models.UserFilter) ([]models.User, *models.UserCursor, error)
+	DisableUser(ctx context.Context, callerID int64, ref admin.UserRef) (models.User, int64, error)
+	EnableUser(ctx context.Context, callerID int64, ref admin.UserRef) (models.User, error)
+	EraseUser(ctx context.Context, callerID int64, ref admin.UserRef) (models.User, int64, error)
+	ExportUserData(ctx context.Context, callerID int64, ref admin.UserRef) (models.UserExport, error)
+	ImportUsers(ctx context.Context, callerID int64, org string, users []models.ImportedUser) (models.ImportResult, error)
+	ExportUsers(ctx context.Context, callerID int64, org string, afterID int64, withHashes bool, send func(models.User) error) error
+}
+
+type serverAPI struct {
+	ssov1.UnimplementedAdminServer
+	admin Admin
+}
+
+const (
+	emptyValue = 0
+)
+
+func Register(gRPCServer *grpc.Server, admin Admin) {
+	ssov1.RegisterAdminServer(gRPCServer, &serverAPI{admin: admin})
+}
+
+func (s *serverAPI) ListApps(
+	ctx context.Context,
+	req *ssov1.ListAppsRequest,
+) (*ssov1.ListAppsResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := s.admin.ListApps(ctx, claims.UserID)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp := &ssov1.ListAppsResponse{Apps: make([]*ssov1.App, 0, len(apps))}
+	for _, app := range apps {
+		resp.Apps = append(resp.Apps, toProto(app))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) CreateApp(
+	ctx context.Context,
+	req *ssov1.CreateAppRequest,
+) (*ssov1.CreateAppResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetName() == "" {
+		return nil, errmap.Validation("name", "name is required")
+	}
+
+	app, err := s.admin.CreateApp(ctx, claims.UserID, admin.AppSpec{
+		Org:          req.GetOrg(),
+		Name:         req.GetName(),
+		Secret:       req.GetSecret(),
+		AccessPolicy: req.GetAccessPolicy(),
+		Token:        tokenFromProto(req.GetToken()),
+		Passkeys:     passkeysFromProto(req.GetPasskeys()),
+	})
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.CreateAppResponse{
+		App:    toProto(app),
+		Secret: app.Secret,
+	}, nil
+}
+
+func (s *serverAPI) RotateAppSecret(
+	ctx context.Context,
+	req *ssov1.RotateAppSecretRequest,
+) (*ssov1.RotateAppSecretResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	secret, err := s.admin.RotateAppSecret(ctx, claims.UserID, int(req.GetAppId()))
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.RotateAppSecretResponse{Secret: secret}, nil
+}
+
+func (s *serverAPI) LockUser(
+	ctx context.Context,
+	req *ssov1.LockUserRequest,
+) (*ssov1.LockUserResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := userRef(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	user, revoked, err := s.admin.LockUser(ctx, claims.UserID, ref)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.LockUserResponse{
+		UserId:          user.ID,
+		LockedAt:        timestamppb.New(user.LockedAt),
+		RevokedSessions: revoked,
+	}, nil
+}
+
+func (s *serverAPI) UnlockUser(
+	ctx context.Context,
+	req *ssov1.UnlockUserRequest,
+) (*ssov1.UnlockUserResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := userRef(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := s.admin.UnlockUser(ctx, claims.UserID, ref)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.UnlockUserResponse{UserId: userID}, nil
+}
+
+func (s *serverAPI) SetAdmin(
+	ctx context.Context,
+	req *ssov1.SetAdminRequest,
+) (*ssov1.SetAdminResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := userRef(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := s.admin.SetAdmin(ctx, claims.UserID, ref, req.GetIsAdmin())
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.SetAdminResponse{UserId: userID}, nil
+}
+
+func (s *serverAPI) SetOrgAdmin(
+	ctx context.Context,
+	req *ssov1.SetOrgAdminRequest,
+) (*ssov1.SetOrgAdminResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := userRef(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := s.admin.SetOrgAdmin(ctx, claims.UserID, ref, req.GetOrgAdmin())
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.SetOrgAdminResponse{UserId: userID}, nil
+}
+
+func (s *serverAPI) CreateOrg(
+	ctx context.Context,
+	req *ssov1.CreateOrgRequest,
+) (*ssov1.CreateOrgResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetSlug() == "" {
+		return nil, errmap.Validation("slug", "slug is required")
+	}
+
+	org, err := s.admin.CreateOrg(ctx, claims.UserID, admin.OrgSpec{Slug: req.GetSlug(), Name: req.GetName()})
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.CreateOrgResponse{Org: orgToProto(org)}, nil
+}
+
+func (s *serverAPI) ListOrgs(
+	ctx context.Context,
+	req *ssov1.ListOrgsRequest,
+) (*ssov1.ListOrgsResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := s.admin.ListOrgs(ctx, claims.UserID)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp := &ssov1.ListOrgsResponse{Orgs: make([]*ssov1.Org, 0, len(orgs))}
+	for _, org := range orgs {
+		resp.Orgs = append(resp.Orgs, orgToProto(org))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) SetAppAccessPolicy(
+	ctx context.Context,
+	req *ssov1.SetAppAccessPolicyRequest,
+) (*ssov1.SetAppAccessPolicyResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	if err := s.admin.SetAppAccessPolicy(ctx, claims.UserID, int(req.GetAppId()), req.GetAccessPolicy()); err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.SetAppAccessPolicyResponse{}, nil
+}
+
+func (s *serverAPI) ListAppMembers(
+	ctx context.Context,
+	req *ssov1.ListAppMembersRequest,
+) (*ssov1.ListAppMembersResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	members, err := s.admin.ListAppMembers(ctx, claims.UserID, int(req.GetAppId()), req.GetStatus())
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp := &ssov1.ListAppMembersResponse{Members: make([]*ssov1.AppMember, 0, len(members))}
+	for _, m := range members {
+		resp.Members = append(resp.Members, memberToProto(m))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) InviteAppMember(
+	ctx context.Context,
+	req *ssov1.InviteAppMemberRequest,
+) (*ssov1.InviteAppMemberResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appID, ref, err := memberRequest(req.GetAppId(), req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.admin.InviteAppMember(ctx, claims.UserID, appID, ref)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.InviteAppMemberResponse{Member: memberToProto(member)}, nil
+}
+
+func (s *serverAPI) ApproveAppMember(
+	ctx context.Context,
+	req *ssov1.ApproveAppMemberRequest,
+) (*ssov1.ApproveAppMemberResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appID, ref, err := memberRequest(req.GetAppId(), req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.admin.ApproveAppMember(ctx, claims.UserID, appID, ref)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.ApproveAppMemberResponse{Member: memberToProto(member)}, nil
+}
+
+func (s *serverAPI) RemoveAppMember(
+	ctx context.Context,
+	req *ssov1.RemoveAppMemberRequest,
+) (*ssov1.RemoveAppMemberResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appID, ref, err := memberRequest(req.GetAppId(), req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.admin.RemoveAppMember(ctx, claims.UserID, appID, ref)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.RemoveAppMemberResponse{RevokedSessions: revoked}, nil
+}
+
+func (s *serverAPI) SetAppTokenSettings(
+	ctx context.Context,
+	req *ssov1.SetAppTokenSettingsRequest,
+) (*ssov1.SetAppTokenSettingsResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	// Пустые настройки возвращают приложение к глобальным
+	settings := models.TokenSettings{}
+	if token := tokenFromProto(req.GetToken()); token != nil {
+		settings = *token
+	}
+
+	if err := s.admin.SetAppTokenSettings(ctx, claims.UserID, int(req.GetAppId()), settings); err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.SetAppTokenSettingsResponse{}, nil
+}
+
+func (s *serverAPI) ListUsers(
+	ctx context.Context,
+	req *ssov1.ListUsersRequest,
+) (*ssov1.ListUsersResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, order, err := userFilter(req.GetFilter(), req.GetOrderBy(), req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	users, next, err := s.admin.ListUsers(ctx, claims.UserID, req.GetOrg(), filter)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.ListUsersResponse{
+		Users:         usersToProto(users),
+		NextPageToken: nextPageToken(order, next),
+	}, nil
+}
+
+func (s *serverAPI) SearchUsers(
+	ctx context.Context,
+	req *ssov1.SearchUsersRequest,
+) (*ssov1.SearchUsersResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetQuery() == "" {
+		return nil, errmap.Validation("query", "query is required")
+	}
+
+	filter, order, err := userFilter(req.GetFilter(), req.GetOrderBy(), req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	filter.Query = req.GetQuery()
+
+	users, next, err := s.admin.SearchUsers(ctx, claims.UserID, req.GetOrg(), filter)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.SearchUsersResponse{
+		Users:         usersToProto(users),
+		NextPageToken: nextPageToken(order, next),
+	}, nil
+}
+
+func (s *serverAPI) DisableUser(
+	ctx context.Context,
+	req *ssov1.DisableUserRequest,
+) (*ssov1.DisableUserResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := userRef(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	user, revoked, err := s.admin.DisableUser(ctx, claims.UserID, ref)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.DisableUserResponse{
+		UserId:          user.ID,
+		DisabledAt:      timestamppb.New(user.DisabledAt),
+		RevokedSessions: revoked,
+	}, nil
+}
+
+func (s *serverAPI) EnableUser(
+	ctx context.Context,
+	req *ssov1.EnableUserRequest,
+) (*ssov1.EnableUserResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := userRef(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.admin.EnableUser(ctx, claims.UserID, ref)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.EnableUserResponse{UserId: user.ID, State: user.State()}, nil
+}
+
+func (s *serverAPI) EraseUser(
+	ctx context.Context,
+	req *ssov1.EraseUserRequest,
+) (*ssov1.EraseUserResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := userRef(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	user, revoked, err := s.admin.EraseUser(ctx, claims.UserID, ref)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.EraseUserResponse{
+		UserId:          user.ID,
+		DeleteAfter:     timestamppb.New(user.DeleteAfter),
+		RevokedSessions: revoked,
+	}, nil
+}
+
+func (s *serverAPI) ExportUserData(
+	ctx context.Context,
+	req *ssov1.ExportUserDataRequest,
+) (*ssov1.ExportUserDataResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := userRef(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := s.admin.ExportUserData(ctx, claims.UserID, ref)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.ExportUserDataResponse{Data: data}, nil
+}
+
+// ImportUsers читает пользователей из потока и передаёт их сервису пачками
+// по admin.MaxImportBatch. Каждая пачка сохраняется отдельно, поэтому при
+// обрыве потока уже сохранённые пачки остаются, а повторный импорт их пропустит.
+func (s *serverAPI) ImportUsers(stream grpc.ClientStreamingServer[ssov1.ImportUsersRequest, ssov1.ImportUsersResponse]) error {
+	ctx := stream.Context()
+
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		org    string
+		first  = true
+		batch  = make([]models.ImportedUser, 0, admin.MaxImportBatch)
+		result models.ImportResult
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		res, err := s.admin.ImportUsers(ctx, claims.UserID, org, batch)
+		if err != nil {
+			return errmap.ToStatus(err)
+		}
+		result.Add(res)
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if first {
+			org, first = req.GetOrg(), false
+		}
+		for _, u := range req.GetUsers() {
+			batch = append(batch, models.ImportedUser{
+				Row:         u.GetRow(),
+				Email:       u.GetEmail(),
+				PassHash:    []byte(u.GetPassHash()),
+				Roles:       u.GetRoles(),
+				DisplayName: u.GetDisplayName(),
+			})
+			if len(batch) == admin.MaxImportBatch {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	resp := &ssov1.ImportUsersResponse{
+		Imported: result.Imported,
+		Skipped:  result.Skipped,
+		Failed:   result.Failed,
+		LastRow:  result.LastRow,
+		Errors:   make([]*ssov1.ImportError, 0, len(result.Errors)),
+	}
+	for _, e := range result.Errors {
+		resp.Errors = append(resp.Errors, &ssov1.ImportError{Row: e.Row, Email: e.Email, Reason: e.Reason})
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+func (s *serverAPI) ExportUsers(
+	req *ssov1.ExportUsersRequest,
+	stream grpc.ServerStreamingServer[ssov1.ExportUsersResponse],
+) error {
+	ctx := stream.Context()
+
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	if req.GetAfterId() < 0 {
+		return errmap.Validation("after_id", "after_id must not be negative")
+	}
+
+	err = s.admin.ExportUsers(ctx, claims.UserID, req.GetOrg(), req.GetAfterId(), req.GetIncludePassHash(), func(u models.User) error {
+		return stream.Send(&ssov1.ExportUsersResponse{User: userToProto(u), PassHash: string(u.PassHash)})
+	})
+	if err != nil {
+		return errmap.ToStatus(err)
+	}
+
+	return nil
+}
+
+// userFilter собирает фильтр списка пользователей из запроса.
+// Возвращает также нормализованный order_by: токен страницы годится
+// только для той же сортировки, с которой получен.
+func userFilter(f *ssov1.UserFilter, orderBy string, pageSize int32, pageToken string) (models.UserFilter, string, error) {
+	if pageSize < 0 {
+		return models.UserFilter{}, "", errmap.Validation("page_size", "page_size must not be negative")
+	}
+
+	filter := models.UserFilter{
+		EmailPrefix: f.GetEmailPrefix(),
+		AppID:       int(f.GetAppId()),
+		Limit:       int(pageSize),
+	}
+	if f != nil {
+		filter.Admin, filter.Locked = f.IsAdmin, f.Locked
+	}
+	if f.GetCreatedAfter() != nil {
+		filter.CreatedAfter = f.GetCreatedAfter().AsTime()
+	}
+	if f.GetCreatedBefore() != nil {
+		filter.CreatedBefore = f.GetCreatedBefore().AsTime()
+	}
+
+	fields := strings.Fields(strings.ToLower(orderBy))
+	switch {
+	case len(fields) == 0:
+		fields = []string{models.UserSortID}
+	case len(fields) == 2 && (fields[1] == "asc" || fields[1] == "desc"):
+		filter.Desc = fields[1] == "desc"
+	case len(fields) != 1:
+		return models.UserFilter{}, "", errmap.Validation("order_by", `order_by must be a field optionally followed by "desc"`)
+	}
+	filter.Sort = fields[0]
+
+	order := filter.Sort
+	if filter.Desc {
+		order += " desc"
+	}
+
+	cursor, err := pagetoken.DecodeKeyset(pageToken)
+	if err != nil {
+		return models.UserFilter{}, "", errmap.Validation("page_token", "page_token is malformed")
+	}
+	if cursor != nil {
+		if cursor.Sort != order {
+			return models.UserFilter{}, "", errmap.Validation("page_token", "page_token was issued for another order_by")
+		}
+		filter.After = &models.UserCursor{Key: cursor.Key, ID: cursor.ID}
+	}
+
+	return filter, order, nil
+}
+
+func nextPageToken(order string, next *models.UserCursor) string {
+	if next == nil {
+		return ""
+	}
+
+	return pagetoken.EncodeKeyset(pagetoken.Keyset{Sort: order, Key: next.Key, ID: next.ID})
+}
+
+func usersToProto(users []models.User) []*ssov1.User {
+	out := make([]*ssov1.User, 0, len(users))
+	for _, u := range users {
+		out = append(out, userToProto(u))
+	}
+
+	return out
+}
+
+func userToProto(u models.User) *ssov1.User {
+	user := &ssov1.User{
+		Id:          u.ID,
+		Email:       u.Email,
+		OrgId:       u.OrgID,
+		DisplayName: u.DisplayName,
+		IsAdmin:     u.IsAdmin,
+		OrgAdmin:    u.OrgAdmin,
+		Roles:       u.Roles,
+		CreatedAt:   timestamppb.New(u.CreatedAt),
+		State:       u.State(),
+	}
+	if !u.LockedAt.IsZero() {
+		user.LockedAt = timestamppb.New(u.LockedAt)
+	}
+	if !u.DeleteAfter.IsZero() {
+		user.DeleteAfter = timestamppb.New(u.DeleteAfter)
+	}
+
+	return user
+}
+
+func (s *serverAPI) SetAppPasskeySettings(
+	ctx context.Context,
+	req *ssov1.SetAppPasskeySettingsRequest,
+) (*ssov1.SetAppPasskeySettingsResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	// Пустые настройки отключают ключи доступа
+	settings := models.PasskeySettings{}
+	if passkeys := passkeysFromProto(req.GetPasskeys()); passkeys != nil {
+		settings = *passkeys
+	}
+
+	if err := s.admin.SetAppPasskeySettings(ctx, claims.UserID, int(req.GetAppId()), settings); err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.SetAppPasskeySettingsResponse{}, nil
+}
+
+func (s *serverAPI) SetAppIdentityProvider(
+	ctx context.Context,
+	req *ssov1.SetAppIdentityProviderRequest,
+) (*ssov1.SetAppIdentityProviderResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+	if req.GetProvider() == nil {
+		return nil, errmap.Validation("provider", "provider is required")
+	}
+
+	p := req.GetProvider()
+	saved, err := s.admin.SetAppIdentityProvider(ctx, claims.UserID, models.IdentityProvider{
+		AppID:        int(req.GetAppId()),
+		Name:         p.GetName(),
+		Issuer:       p.GetIssuer(),
+		ClientID:     p.GetClientId(),
+		ClientSecret: p.GetClientSecret(),
+		RedirectURI:  p.GetRedirectUri(),
+		Scopes:       p.GetScopes(),
+		Claims: models.ClaimMapping{
+			Email:         p.GetClaims().GetEmail(),
+			EmailVerified: p.GetClaims().GetEmailVerified(),
+			Name:          p.GetClaims().GetName(),
+		},
+		TrustEmail:  p.GetTrustEmail(),
+		LinkByEmail: p.GetLinkByEmail(),
+	})
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.SetAppIdentityProviderResponse{Provider: idpToProto(saved)}, nil
+}
+
+func (s *serverAPI) ListAppIdentityProviders(
+	ctx context.Context,
+	req *ssov1.ListAppIdentityProvidersRequest,
+) (*ssov1.ListAppIdentityProvidersResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	providers, err := s.admin.ListAppIdentityProviders(ctx, claims.UserID, int(req.GetAppId()))
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp := &ssov1.ListAppIdentityProvidersResponse{Providers: make([]*ssov1.IdentityProvider, 0, len(providers))}
+	for _, p := range providers {
+		resp.Providers = append(resp.Providers, idpToProto(p))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) DeleteAppIdentityProvider(
+	ctx context.Context,
+	req *ssov1.DeleteAppIdentityProviderRequest,
+) (*ssov1.DeleteAppIdentityProviderResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+	if req.GetName() == "" {
+		return nil, errmap.Validation("name", "name is required")
+	}
+
+	if err := s.admin.DeleteAppIdentityProvider(ctx, claims.UserID, int(req.GetAppId()), req.GetName()); err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.DeleteAppIdentityProviderResponse{}, nil
+}
+
+func memberRequest(appID int64, ref *ssov1.UserRef) (int, admin.UserRef, error) {
+	if appID <= emptyValue {
+		return 0, admin.UserRef{}, errmap.Validation("app_id", "app_id is required")
+	}
+
+	user, err := userRef(ref)
+	if err != nil {
+		return 0, admin.UserRef{}, err
+	}
+
+	return int(appID), user, nil
+}
+
+func userRef(ref *ssov1.UserRef) (admin.UserRef, error) {
+	if ref.GetUserId() <= emptyValue && ref.GetEmail() == "" {
+		return admin.UserRef{}, errmap.Validation("user", "user.user_id or user.email is required")
+	}
+
+	return admin.UserRef{ID: ref.GetUserId(), Email: ref.GetEmail(), Org: ref.GetOrg()}, nil
+}
+
+func toProto(app models.App) *ssov1.App {
+	return &ssov1.App{
+		Id:           int64(app.ID),
+		Name:         app.Name,
+		OrgId:        app.OrgID,
+		AccessPolicy: app.AccessPolicy,
+		Token: &ssov1.TokenSettings{
+			TtlSeconds: int64(app.Token.TTL / time.Second),
+			Issuer:     app.Token.Issuer,
+			Audience:   app.Token.Audience,
+			Claims:     app.Token.Claims,
+		},
+		Passkeys: &ssov1.PasskeySettings{
+			RpId:    app.Passkeys.RPID,
+			RpName:  app.Passkeys.RPName,
+			Origins: app.Passkeys.Origins,
+		},
+	}
+}
+
+// idpToProto не передаёт клиентский секрет.
+func idpToProto(p models.IdentityProvider) *ssov1.IdentityProvider {
+	return &ssov1.IdentityProvider{
+		Name:        p.Name,
+		Issuer:      p.Issuer,
+		ClientId:    p.ClientID,
+		RedirectUri: p.RedirectURI,
+		Scopes:      p.Scopes,
+		Claims: &ssov1.ClaimMapping{
+			Email:         p.Claims.Email,
+			EmailVerified: p.Claims.EmailVerified,
+			Name:          p.Claims.Name,
+		},
+		TrustEmail:  p.TrustEmail,
+		LinkByEmail: p.LinkByEmail,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+	}
+}
+
+// passkeysFromProto возвращает nil, если настройки не переданы.
+func passkeysFromProto(passkeys *ssov1.PasskeySettings) *models.PasskeySettings {
+	if passkeys == nil {
+		return nil
+	}
+
+	return &models.PasskeySettings{
+		RPID:    passkeys.GetRpId(),
+		RPName:  passkeys.GetRpName(),
+		Origins: passkeys.GetOrigins(),
+	}
+}
+
+// tokenFromProto возвращает nil, если настройки не переданы.
+func tokenFromProto(token *ssov1.TokenSettings) *models.TokenSettings {
+	if token == nil {
+		return nil
+	}
+
+	return &models.TokenSettings{
+		TTL:      time.Duration(token.GetTtlSeconds()) * time.Second,
+		Issuer:   token.GetIssuer(),
+		Audience: token.GetAudience(),
+		Claims:   token.GetClaims(),
+	}
+}
+
+func memberToProto(m models.AppMember) *ssov1.AppMember {
+	return &ssov1.AppMember{
+		AppId:     int64(m.AppID),
+		UserId:    m.UserID,
+		Email:     m.Email,
+		Status:    m.Status,
+		AddedBy:   m.AddedBy,
+		CreatedAt: timestamppb.New(m.CreatedAt),
+		UpdatedAt: timestamppb.New(m.UpdatedAt),
+	}
+}
+
+func orgToProto(org models.Org) *ssov1.Org {
+	return &ssov1.Org{
+		Id:        org.ID,
+		Slug:      org.Slug,
+		Name:      org.Name,
+		CreatedAt: timestamppb.New(org.CreatedAt),
+	}
+}
@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"context"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/interceptors"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/pagetoken"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Audit - сервисный слой журнала аудита.
+type Audit interface {
+	ListEvents(
+		ctx context.Context,
+		callerID int64,
+		filter models.AuditFilter,
+	) (events []models.AuditEvent, next int64, err error)
+}
+
+type serverAPI struct {
+	ssov1.UnimplementedAuditServer
+	audit Audit
+}
+
+func Register(gRPCServer *grpc.Server, audit Audit) {
+	ssov1.RegisterAuditServer(gRPCServer, &serverAPI{audit: audit})
+}
+
+func (s *serverAPI) ListAuditEvents(
+	ctx context.Context,
+	req *ssov1.ListAuditEventsRequest,
+) (*ssov1.ListAuditEventsResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetPageSize() < 0 {
+		return nil, errmap.Validation("page_size", "page_size must not be negative")
+	}
+
+	cursor, err := pagetoken.Decode(req.GetPageToken())
+	if err != nil {
+		return nil, errmap.Validation("page_token", "page_token is malformed")
+	}
+
+	filter := models.AuditFilter{
+		UserID: req.GetUserId(),
+		AppID:  int(req.GetAppId()),
+		Types:  req.GetTypes(),
+		Cursor: cursor,
+		Limit:  int(req.GetPageSize()),
+	}
+	if req.GetSince() != nil {
+		filter.Since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		filter.Until = req.GetUntil().AsTime()
+	}
+
+	events, next, err := s.audit.ListEvents(ctx, claims.UserID, filter)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp := &ssov1.ListAuditEventsResponse{
+		Events: make([]*ssov1.AuditEvent, 0, len(events)),
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, &ssov1.AuditEvent{
+			Id:        e.ID,
+			Type:      e.Type,
+			UserId:    e.UserID,
+			AppId:     int64(e.AppID),
+			Email:     e.Email,
+			Ip:        e.IP,
+			UserAgent: e.UserAgent,
+			Metadata:  e.Metadata,
+			CreatedAt: timestamppb.New(e.CreatedAt),
+		})
+	}
+	resp.NextPageToken = pagetoken.Encode(next)
+
+	return resp, nil
+}
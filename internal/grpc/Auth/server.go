@@ -2,25 +2,32 @@ package auth
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"time"
 
 	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
 	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/interceptors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Интерфейс, который мы передавали в grpcApp
 type Auth interface {
 	Login(
 		ctx context.Context,
+		org string,
 		email string,
 		password string,
 		appID int,
 	) (token string, err error)
 	RegisterNewUser(
 		ctx context.Context,
+		org string,
 		email string,
 		password string,
 	) (userID int64, err error)
@@ -28,6 +35,65 @@ type Auth interface {
 		ctx context.Context,
 		userID int64,
 	) (isAdmin bool, err error)
+	Logout(
+		ctx context.Context,
+		token string,
+	) error
+	ValidateToken(
+		ctx context.Context,
+		token string,
+	) (models.TokenClaims, error)
+	StartPasswordlessLogin(
+		ctx context.Context,
+		org string,
+		email string,
+		appID int,
+		method string,
+	) (loginID string, expiresAt time.Time, err error)
+	CompletePasswordlessLogin(
+		ctx context.Context,
+		loginID string,
+		codeOrToken string,
+	) (token string, err error)
+	BeginPasskeyRegistration(
+		ctx context.Context,
+		userID int64,
+		appID int,
+	) (ceremonyID string, options []byte, err error)
+	FinishPasskeyRegistration(
+		ctx context.Context,
+		userID int64,
+		ceremonyID string,
+		response []byte,
+		name string,
+	) (credentialID []byte, err error)
+	BeginPasskeyLogin(
+		ctx context.Context,
+		org string,
+		email string,
+		appID int,
+	) (ceremonyID string, options []byte, err error)
+	FinishPasskeyLogin(
+		ctx context.Context,
+		ceremonyID string,
+		response []byte,
+	) (token string, err error)
+	StartExternalLogin(
+		ctx context.Context,
+		appID int,
+		provider string,
+	) (models.ExternalLoginStart, error)
+	StartExternalLink(
+		ctx context.Context,
+		userID int64,
+		appID int,
+		provider string,
+	) (models.ExternalLoginStart, error)
+	FinishExternalLogin(
+		ctx context.Context,
+		state string,
+		code string,
+	) (models.ExternalLoginResult, error)
 }
 
 type serverAPI struct {
@@ -49,22 +115,13 @@ func (s *serverAPI) Login(
 ) (*ssov1.LoginResponse, error) {
 
 	// Валидация входящих данных
-	if req.GetEmail() == "" || req.GetPassword() == "" {
-		return nil, status.Error(
-			codes.InvalidArgument, "Email and password must be provided")
-	}
-
-	if req.GetAppId() <= emptyValue {
-		return nil, status.Error(
-			codes.InvalidArgument, "App ID must be a positive integer")
+	if err := validateLogin(req); err != nil {
+		return nil, err
 	}
 
-	token, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword(), int(req.GetAppId()))
+	token, err := s.auth.Login(ctx, req.GetOrg(), req.GetEmail(), req.GetPassword(), int(req.GetAppId()))
 	if err != nil {
-		if errors.Is(err, _error.ErrInvalidCredentials) {
-			return nil, status.Error(codes.InvalidArgument, "Invalid email or password")
-		}
-		return nil, status.Error(codes.Internal, "Failed to login: "+err.Error())
+		return nil, errmap.ToStatus(err)
 	}
 
 	return &ssov1.LoginResponse{
@@ -76,17 +133,13 @@ func (s *serverAPI) Register(
 	ctx context.Context,
 	req *ssov1.RegisterRequest,
 ) (*ssov1.RegisterResponse, error) {
-	if req.GetEmail() == "" || req.GetPassword() == "" {
-		return nil, status.Error(
-			codes.InvalidArgument, "Email and password must be provided")
+	if err := validateRegister(req); err != nil {
+		return nil, err
 	}
 
-	uid, err := s.auth.RegisterNewUser(ctx, req.GetEmail(), req.GetPassword())
+	uid, err := s.auth.RegisterNewUser(ctx, req.GetOrg(), req.GetEmail(), req.GetPassword())
 	if err != nil {
-		if errors.Is(err, _error.ErrUserExists) {
-			return nil, status.Error(codes.AlreadyExists, "User already exists")
-		}
-		return nil, status.Error(codes.Internal, "failed to register user")
+		return nil, errmap.ToStatus(err)
 	}
 
 	return &ssov1.RegisterResponse{UserId: uid}, nil
@@ -97,18 +150,290 @@ func (s *serverAPI) IsAdmin(
 	ctx context.Context,
 	req *ssov1.IsAdminRequest,
 ) (*ssov1.IsAdminResponse, error) {
-	if req.UserId == 0 {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	if req.GetUserId() == emptyValue {
+		return nil, errmap.Validation("user_id", "user_id is required")
 	}
 
 	isAdmin, err := s.auth.IsAdmin(ctx, req.GetUserId())
 	if err != nil {
-		if errors.Is(err, _error.ErrUserNotFound) {
-			return nil, status.Error(codes.NotFound, "user not found")
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.IsAdminResponse{IsAdmin: isAdmin}, nil
+}
+
+func (s *serverAPI) Logout(
+	ctx context.Context,
+	req *ssov1.LogoutRequest,
+) (*ssov1.LogoutResponse, error) {
+	if req.GetToken() == "" {
+		return nil, errmap.Validation("token", "token is required")
+	}
+
+	if err := s.auth.Logout(ctx, req.GetToken()); err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.LogoutResponse{Success: true}, nil
+}
+
+// Introspect нужен сервисам, у которых нет секрета приложения для проверки токена.
+// Недействительный токен - обычный ответ с active = false, а не ошибка.
+func (s *serverAPI) Introspect(
+	ctx context.Context,
+	req *ssov1.IntrospectRequest,
+) (*ssov1.IntrospectResponse, error) {
+	if req.GetToken() == "" {
+		return nil, errmap.Validation("token", "token is required")
+	}
+
+	claims, err := s.auth.ValidateToken(ctx, req.GetToken())
+	if err != nil {
+		if errors.Is(err, _error.ErrInvalidToken) || errors.Is(err, _error.ErrSessionRevoked) {
+			return &ssov1.IntrospectResponse{Active: false}, nil
 		}
+		return nil, errmap.ToStatus(err)
+	}
 
-		return nil, status.Error(codes.Internal, "failed to check admin status")
+	custom, err := structpb.NewStruct(claims.Custom)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
 	}
 
-	return &ssov1.IsAdminResponse{IsAdmin: isAdmin}, nil
+	return &ssov1.IntrospectResponse{
+		Active:       true,
+		UserId:       claims.UserID,
+		Email:        claims.Email,
+		AppId:        int64(claims.AppID),
+		SessionId:    claims.SessionID,
+		ExpiresAt:    timestamppb.New(claims.ExpiresAt),
+		IsAdmin:      claims.IsAdmin,
+		Roles:        claims.Roles,
+		OrgId:        claims.OrgID,
+		OrgAdmin:     claims.OrgAdmin,
+		Issuer:       claims.Issuer,
+		Audience:     claims.Audience,
+		CustomClaims: custom,
+	}, nil
+}
+
+func (s *serverAPI) StartPasswordlessLogin(
+	ctx context.Context,
+	req *ssov1.StartPasswordlessLoginRequest,
+) (*ssov1.StartPasswordlessLoginResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, errmap.Validation("email", "email is required")
+	}
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	method := req.GetMethod()
+	if method == "" {
+		method = models.LoginMethodCode
+	}
+
+	loginID, expiresAt, err := s.auth.StartPasswordlessLogin(ctx, req.GetOrg(), req.GetEmail(), int(req.GetAppId()), method)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.StartPasswordlessLoginResponse{
+		LoginId:   loginID,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+func (s *serverAPI) CompletePasswordlessLogin(
+	ctx context.Context,
+	req *ssov1.CompletePasswordlessLoginRequest,
+) (*ssov1.CompletePasswordlessLoginResponse, error) {
+	if req.GetCodeOrToken() == "" {
+		return nil, errmap.Validation("code_or_token", "code_or_token is required")
+	}
+
+	token, err := s.auth.CompletePasswordlessLogin(ctx, req.GetLoginId(), req.GetCodeOrToken())
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.CompletePasswordlessLoginResponse{Token: token}, nil
+}
+
+// BeginPasskeyRegistration регистрирует ключ для пользователя и приложения из токена.
+func (s *serverAPI) BeginPasskeyRegistration(
+	ctx context.Context,
+	_ *ssov1.BeginPasskeyRegistrationRequest,
+) (*ssov1.BeginPasskeyRegistrationResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ceremonyID, options, err := s.auth.BeginPasskeyRegistration(ctx, claims.UserID, claims.AppID)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.BeginPasskeyRegistrationResponse{CeremonyId: ceremonyID, OptionsJson: string(options)}, nil
+}
+
+func (s *serverAPI) FinishPasskeyRegistration(
+	ctx context.Context,
+	req *ssov1.FinishPasskeyRegistrationRequest,
+) (*ssov1.FinishPasskeyRegistrationResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetCeremonyId() == "" {
+		return nil, errmap.Validation("ceremony_id", "ceremony_id is required")
+	}
+	if req.GetCredentialJson() == "" {
+		return nil, errmap.Validation("credential_json", "credential_json is required")
+	}
+
+	id, err := s.auth.FinishPasskeyRegistration(ctx, claims.UserID, req.GetCeremonyId(), []byte(req.GetCredentialJson()), req.GetName())
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.FinishPasskeyRegistrationResponse{CredentialId: base64.RawURLEncoding.EncodeToString(id)}, nil
+}
+
+func (s *serverAPI) BeginPasskeyLogin(
+	ctx context.Context,
+	req *ssov1.BeginPasskeyLoginRequest,
+) (*ssov1.BeginPasskeyLoginResponse, error) {
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	ceremonyID, options, err := s.auth.BeginPasskeyLogin(ctx, req.GetOrg(), req.GetEmail(), int(req.GetAppId()))
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.BeginPasskeyLoginResponse{CeremonyId: ceremonyID, OptionsJson: string(options)}, nil
+}
+
+func (s *serverAPI) FinishPasskeyLogin(
+	ctx context.Context,
+	req *ssov1.FinishPasskeyLoginRequest,
+) (*ssov1.FinishPasskeyLoginResponse, error) {
+	if req.GetCeremonyId() == "" {
+		return nil, errmap.Validation("ceremony_id", "ceremony_id is required")
+	}
+	if req.GetCredentialJson() == "" {
+		return nil, errmap.Validation("credential_json", "credential_json is required")
+	}
+
+	token, err := s.auth.FinishPasskeyLogin(ctx, req.GetCeremonyId(), []byte(req.GetCredentialJson()))
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.FinishPasskeyLoginResponse{Token: token}, nil
+}
+
+func (s *serverAPI) StartExternalLogin(
+	ctx context.Context,
+	req *ssov1.StartExternalLoginRequest,
+) (*ssov1.StartExternalLoginResponse, error) {
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+	if req.GetProvider() == "" {
+		return nil, errmap.Validation("provider", "provider is required")
+	}
+
+	start, err := s.auth.StartExternalLogin(ctx, int(req.GetAppId()), req.GetProvider())
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.StartExternalLoginResponse{
+		AuthorizationUrl: start.AuthURL,
+		State:            start.State,
+		ExpiresAt:        timestamppb.New(start.ExpiresAt),
+	}, nil
+}
+
+// StartExternalLink привязывает провайдер к пользователю и приложению из токена.
+func (s *serverAPI) StartExternalLink(
+	ctx context.Context,
+	req *ssov1.StartExternalLinkRequest,
+) (*ssov1.StartExternalLinkResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetProvider() == "" {
+		return nil, errmap.Validation("provider", "provider is required")
+	}
+
+	start, err := s.auth.StartExternalLink(ctx, claims.UserID, claims.AppID, req.GetProvider())
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.StartExternalLinkResponse{
+		AuthorizationUrl: start.AuthURL,
+		State:            start.State,
+		ExpiresAt:        timestamppb.New(start.ExpiresAt),
+	}, nil
+}
+
+func (s *serverAPI) FinishExternalLogin(
+	ctx context.Context,
+	req *ssov1.FinishExternalLoginRequest,
+) (*ssov1.FinishExternalLoginResponse, error) {
+	if req.GetState() == "" {
+		return nil, errmap.Validation("state", "state is required")
+	}
+	if req.GetCode() == "" {
+		return nil, errmap.Validation("code", "code is required")
+	}
+
+	res, err := s.auth.FinishExternalLogin(ctx, req.GetState(), req.GetCode())
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.FinishExternalLoginResponse{
+		Token:   res.Token,
+		UserId:  res.UserID,
+		Created: res.Created,
+		Linked:  res.Linked,
+	}, nil
+}
+
+func validateLogin(req *ssov1.LoginRequest) error {
+	if req.GetEmail() == "" {
+		return errmap.Validation("email", "email is required")
+	}
+
+	if req.GetPassword() == "" {
+		return errmap.Validation("password", "password is required")
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return errmap.Validation("app_id", "app_id is required")
+	}
+
+	return nil
+}
+
+func validateRegister(req *ssov1.RegisterRequest) error {
+	if req.GetEmail() == "" {
+		return errmap.Validation("email", "email is required")
+	}
+
+	if req.GetPassword() == "" {
+		return errmap.Validation("password", "password is required")
+	}
+
+	return nil
 }
@@ -0,0 +1,119 @@
+package profile
+
+import (
+	"context"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/interceptors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/Artemiadze/gRPC-Service/internal/services/profile"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Profiles - сервисный слой профилей пользователей.
+type Profiles interface {
+	Get(ctx context.Context, caller models.TokenClaims, userID int64) (models.Profile, error)
+	Update(ctx context.Context, caller models.TokenClaims, userID int64, upd profile.Update) (models.Profile, error)
+}
+
+type serverAPI struct {
+	ssov1.UnimplementedProfileServer
+	profiles Profiles
+}
+
+const (
+	emptyValue = 0
+)
+
+func Register(gRPCServer *grpc.Server, profiles Profiles) {
+	ssov1.RegisterProfileServer(gRPCServer, &serverAPI{profiles: profiles})
+}
+
+func (s *serverAPI) GetProfile(
+	ctx context.Context,
+	req *ssov1.GetProfileRequest,
+) (*ssov1.GetProfileResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.profiles.Get(ctx, claims, userIDOrCaller(req.GetUserId(), claims))
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp, err := toProto(p)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.GetProfileResponse{Profile: resp}, nil
+}
+
+func (s *serverAPI) UpdateProfile(
+	ctx context.Context,
+	req *ssov1.UpdateProfileRequest,
+) (*ssov1.UpdateProfileResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		return nil, errmap.Validation("update_mask", "update_mask is required")
+	}
+
+	in := req.GetProfile()
+	p, err := s.profiles.Update(ctx, claims, userIDOrCaller(req.GetUserId(), claims), profile.Update{
+		DisplayName: in.GetDisplayName(),
+		Locale:      in.GetLocale(),
+		Timezone:    in.GetTimezone(),
+		AvatarURL:   in.GetAvatarUrl(),
+		Metadata:    in.GetMetadata().AsMap(),
+		Fields:      req.GetUpdateMask().GetPaths(),
+		Version:     req.GetVersion(),
+	})
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp, err := toProto(p)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.UpdateProfileResponse{Profile: resp}, nil
+}
+
+func toProto(p models.Profile) (*ssov1.UserProfile, error) {
+	metadata, err := structpb.NewStruct(p.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssov1.UserProfile{
+		UserId:      p.User.ID,
+		Email:       p.User.Email,
+		OrgId:       p.User.OrgID,
+		DisplayName: p.User.DisplayName,
+		Locale:      p.User.Locale,
+		Timezone:    p.User.Timezone,
+		AvatarUrl:   p.User.AvatarURL,
+		Metadata:    metadata,
+		CreatedAt:   timestamppb.New(p.User.CreatedAt),
+		UpdatedAt:   timestamppb.New(p.User.UpdatedAt),
+		Version:     p.User.Version,
+	}, nil
+}
+
+func userIDOrCaller(userID int64, caller models.TokenClaims) int64 {
+	if userID == emptyValue {
+		return caller.UserID
+	}
+
+	return userID
+}
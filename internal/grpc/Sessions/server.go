@@ -0,0 +1,111 @@
+package sessions
+
+import (
+	"context"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/interceptors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Sessions - сервисный слой управления сессиями.
+type Sessions interface {
+	List(ctx context.Context, caller models.TokenClaims, userID int64) ([]models.Session, error)
+	Revoke(ctx context.Context, caller models.TokenClaims, sessionID string) error
+	RevokeAll(ctx context.Context, caller models.TokenClaims, userID int64, keepCurrent bool) (int64, error)
+}
+
+type serverAPI struct {
+	ssov1.UnimplementedSessionsServer
+	sessions Sessions
+}
+
+const (
+	emptyValue = 0
+)
+
+func Register(gRPCServer *grpc.Server, sessions Sessions) {
+	ssov1.RegisterSessionsServer(gRPCServer, &serverAPI{sessions: sessions})
+}
+
+func (s *serverAPI) ListSessions(
+	ctx context.Context,
+	req *ssov1.ListSessionsRequest,
+) (*ssov1.ListSessionsResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessions.List(ctx, claims, userIDOrCaller(req.GetUserId(), claims))
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp := &ssov1.ListSessionsResponse{
+		Sessions: make([]*ssov1.Session, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &ssov1.Session{
+			Id:         session.ID,
+			UserId:     session.UserID,
+			AppId:      int64(session.AppID),
+			Ip:         session.IP,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastSeenAt: timestamppb.New(session.LastSeenAt),
+			ExpiresAt:  timestamppb.New(session.ExpiresAt),
+			Current:    session.ID == claims.SessionID,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) RevokeSession(
+	ctx context.Context,
+	req *ssov1.RevokeSessionRequest,
+) (*ssov1.RevokeSessionResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetSessionId() == "" {
+		return nil, errmap.Validation("session_id", "session_id is required")
+	}
+
+	if err := s.sessions.Revoke(ctx, claims, req.GetSessionId()); err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.RevokeSessionResponse{}, nil
+}
+
+func (s *serverAPI) RevokeAllSessions(
+	ctx context.Context,
+	req *ssov1.RevokeAllSessionsRequest,
+) (*ssov1.RevokeAllSessionsResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.sessions.RevokeAll(ctx, claims, userIDOrCaller(req.GetUserId(), claims), req.GetKeepCurrent())
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.RevokeAllSessionsResponse{Revoked: revoked}, nil
+}
+
+func userIDOrCaller(userID int64, caller models.TokenClaims) int64 {
+	if userID == emptyValue {
+		return caller.UserID
+	}
+
+	return userID
+}
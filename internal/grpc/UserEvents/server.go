@@ -0,0 +1,64 @@
+package userevents
+
+import (
+	"context"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/interceptors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Watcher - сервисный слой потока событий пользователей.
+type Watcher interface {
+	Watch(
+		ctx context.Context,
+		callerID int64,
+		afterID int64,
+		types []string,
+		send func(models.UserEvent) error,
+	) error
+}
+
+type serverAPI struct {
+	ssov1.UnimplementedUserEventsServer
+	watcher Watcher
+}
+
+func Register(gRPCServer *grpc.Server, watcher Watcher) {
+	ssov1.RegisterUserEventsServer(gRPCServer, &serverAPI{watcher: watcher})
+}
+
+func (s *serverAPI) WatchUserEvents(
+	req *ssov1.WatchUserEventsRequest,
+	stream grpc.ServerStreamingServer[ssov1.UserEvent],
+) error {
+	ctx := stream.Context()
+
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	if req.GetAfterId() < 0 {
+		return errmap.Validation("after_id", "after_id must not be negative")
+	}
+
+	err = s.watcher.Watch(ctx, claims.UserID, req.GetAfterId(), req.GetTypes(), func(e models.UserEvent) error {
+		return stream.Send(&ssov1.UserEvent{
+			Id:         e.ID,
+			Type:       e.Type,
+			UserId:     e.UserID,
+			Email:      e.Email,
+			Data:       e.Data,
+			OccurredAt: timestamppb.New(e.OccurredAt),
+		})
+	})
+	if err != nil {
+		return errmap.ToStatus(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package webhooks
+
+import (
+	"context"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/interceptors"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/pagetoken"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Webhooks - сервисный слой управления вебхуками.
+type Webhooks interface {
+	Create(ctx context.Context, callerID int64, webhook models.Webhook) (models.Webhook, error)
+	List(ctx context.Context, callerID int64, appID int) ([]models.Webhook, error)
+	Delete(ctx context.Context, callerID int64, webhookID int64) error
+	Deliveries(
+		ctx context.Context,
+		callerID int64,
+		webhookID int64,
+		status string,
+		cursor int64,
+		limit int,
+	) (deliveries []models.WebhookDelivery, next int64, err error)
+	Redeliver(ctx context.Context, callerID int64, webhookID int64, deliveryID int64) error
+}
+
+type serverAPI struct {
+	ssov1.UnimplementedWebhooksServer
+	webhooks Webhooks
+}
+
+const (
+	emptyValue = 0
+)
+
+func Register(gRPCServer *grpc.Server, webhooks Webhooks) {
+	ssov1.RegisterWebhooksServer(gRPCServer, &serverAPI{webhooks: webhooks})
+}
+
+func (s *serverAPI) CreateWebhook(
+	ctx context.Context,
+	req *ssov1.CreateWebhookRequest,
+) (*ssov1.CreateWebhookResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	webhook, err := s.webhooks.Create(ctx, claims.UserID, models.Webhook{
+		AppID:      int(req.GetAppId()),
+		URL:        req.GetUrl(),
+		EventTypes: req.GetEventTypes(),
+		Secret:     req.GetSecret(),
+	})
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.CreateWebhookResponse{
+		Webhook: toProto(webhook),
+		Secret:  webhook.Secret,
+	}, nil
+}
+
+func (s *serverAPI) ListWebhooks(
+	ctx context.Context,
+	req *ssov1.ListWebhooksRequest,
+) (*ssov1.ListWebhooksResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAppId() <= emptyValue {
+		return nil, errmap.Validation("app_id", "app_id is required")
+	}
+
+	webhooks, err := s.webhooks.List(ctx, claims.UserID, int(req.GetAppId()))
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp := &ssov1.ListWebhooksResponse{
+		Webhooks: make([]*ssov1.Webhook, 0, len(webhooks)),
+	}
+	for _, w := range webhooks {
+		resp.Webhooks = append(resp.Webhooks, toProto(w))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) DeleteWebhook(
+	ctx context.Context,
+	req *ssov1.DeleteWebhookRequest,
+) (*ssov1.DeleteWebhookResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetWebhookId() <= emptyValue {
+		return nil, errmap.Validation("webhook_id", "webhook_id is required")
+	}
+
+	if err := s.webhooks.Delete(ctx, claims.UserID, req.GetWebhookId()); err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.DeleteWebhookResponse{}, nil
+}
+
+func (s *serverAPI) ListWebhookDeliveries(
+	ctx context.Context,
+	req *ssov1.ListWebhookDeliveriesRequest,
+) (*ssov1.ListWebhookDeliveriesResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetWebhookId() <= emptyValue {
+		return nil, errmap.Validation("webhook_id", "webhook_id is required")
+	}
+
+	cursor, err := pagetoken.Decode(req.GetPageToken())
+	if err != nil {
+		return nil, errmap.Validation("page_token", "page_token is malformed")
+	}
+
+	deliveries, next, err := s.webhooks.Deliveries(
+		ctx,
+		claims.UserID,
+		req.GetWebhookId(),
+		req.GetStatus(),
+		cursor,
+		int(req.GetPageSize()),
+	)
+	if err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	resp := &ssov1.ListWebhookDeliveriesResponse{
+		Deliveries:    make([]*ssov1.WebhookDelivery, 0, len(deliveries)),
+		NextPageToken: pagetoken.Encode(next),
+	}
+	for _, d := range deliveries {
+		delivery := &ssov1.WebhookDelivery{
+			Id:           d.ID,
+			WebhookId:    d.WebhookID,
+			EventType:    d.EventType,
+			Payload:      string(d.Payload),
+			Status:       d.Status,
+			Attempts:     int32(d.Attempts),
+			ResponseCode: int32(d.ResponseCode),
+			LastError:    d.LastError,
+			CreatedAt:    timestamppb.New(d.CreatedAt),
+		}
+		if !d.DeliveredAt.IsZero() {
+			delivery.DeliveredAt = timestamppb.New(d.DeliveredAt)
+		}
+		resp.Deliveries = append(resp.Deliveries, delivery)
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) RedeliverWebhook(
+	ctx context.Context,
+	req *ssov1.RedeliverWebhookRequest,
+) (*ssov1.RedeliverWebhookResponse, error) {
+	claims, err := interceptors.RequireClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetDeliveryId() <= emptyValue {
+		return nil, errmap.Validation("delivery_id", "delivery_id is required")
+	}
+
+	if err := s.webhooks.Redeliver(ctx, claims.UserID, req.GetWebhookId(), req.GetDeliveryId()); err != nil {
+		return nil, errmap.ToStatus(err)
+	}
+
+	return &ssov1.RedeliverWebhookResponse{}, nil
+}
+
+func toProto(w models.Webhook) *ssov1.Webhook {
+	return &ssov1.Webhook{
+		Id:         w.ID,
+		AppId:      int64(w.AppID),
+		Url:        w.URL,
+		EventTypes: w.EventTypes,
+		CreatedAt:  timestamppb.New(w.CreatedAt),
+	}
+}
@@ -0,0 +1,181 @@
+// Package errmap переводит ошибки сервисного слоя в gRPC статусы.
+//
+// Каждая ошибка из internal/errors получает стабильный gRPC код и
+// стабильную причину (reason) в errdetails.ErrorInfo, поэтому клиенты
+// могут ветвиться по причине, а не по тексту сообщения.
+// Неизвестные ошибки превращаются в codes.Internal без внутренних подробностей.
+package errmap
+
+import (
+	"context"
+	"errors"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain - значение ErrorInfo.Domain для всех ошибок сервиса.
+const Domain = "sso"
+
+// Стабильные причины ошибок. Менять существующие значения нельзя:
+// на них завязаны клиенты.
+const (
+	ReasonInvalidCredentials = "INVALID_CREDENTIALS"
+	ReasonAccountLocked      = "ACCOUNT_LOCKED"
+	ReasonAccountDisabled    = "ACCOUNT_DISABLED"
+	ReasonAccountDeleted     = "ACCOUNT_PENDING_DELETION"
+	ReasonAppAccessDenied    = "APP_ACCESS_DENIED"
+	ReasonAppAccessPending   = "APP_ACCESS_PENDING"
+	ReasonMemberNotFound     = "MEMBER_NOT_FOUND"
+	ReasonUserExists         = "USER_ALREADY_EXISTS"
+	ReasonUserNotFound       = "USER_NOT_FOUND"
+	ReasonAppNotFound        = "APP_NOT_FOUND"
+	ReasonAppExists          = "APP_ALREADY_EXISTS"
+	ReasonOrgNotFound        = "ORG_NOT_FOUND"
+	ReasonOrgExists          = "ORG_ALREADY_EXISTS"
+	ReasonInvalidAppID       = "INVALID_APP_ID"
+	ReasonInvalidToken       = "INVALID_TOKEN"
+	ReasonUnauthenticated    = "UNAUTHENTICATED"
+	ReasonPermissionDenied   = "PERMISSION_DENIED"
+	ReasonSessionNotFound    = "SESSION_NOT_FOUND"
+	ReasonSessionRevoked     = "SESSION_REVOKED"
+	ReasonSubscriberLagging  = "SUBSCRIBER_LAGGING"
+	ReasonWebhookNotFound    = "WEBHOOK_NOT_FOUND"
+	ReasonDeliveryNotFound   = "DELIVERY_NOT_FOUND"
+	ReasonVersionConflict    = "VERSION_CONFLICT"
+	ReasonInvalidLoginCode   = "INVALID_LOGIN_CODE"
+	ReasonPasswordlessOff    = "PASSWORDLESS_DISABLED"
+	ReasonInvalidPasskey     = "INVALID_PASSKEY"
+	ReasonPasskeysOff        = "PASSKEYS_DISABLED"
+	ReasonPasskeyExists      = "PASSKEY_EXISTS"
+	ReasonIdPNotFound        = "IDENTITY_PROVIDER_NOT_FOUND"
+	ReasonExternalLogin      = "EXTERNAL_LOGIN_FAILED"
+	ReasonIdentityNotLinked  = "IDENTITY_NOT_LINKED"
+	ReasonIdentityLinked     = "IDENTITY_ALREADY_LINKED"
+	ReasonIdentityNotFound   = "IDENTITY_NOT_FOUND"
+	ReasonValidationFailed   = "VALIDATION_FAILED"
+	ReasonCanceled           = "CANCELED"
+	ReasonDeadlineExceeded   = "DEADLINE_EXCEEDED"
+	ReasonInternal           = "INTERNAL"
+)
+
+type mapping struct {
+	err     error
+	code    codes.Code
+	reason  string
+	message string
+}
+
+// mappings - таблица соответствия ошибок сервисного слоя gRPC статусам.
+// Порядок важен: берётся первое совпадение по errors.Is.
+var mappings = []mapping{
+	{_error.ErrInvalidCredentials, codes.Unauthenticated, ReasonInvalidCredentials, "invalid email or password"},
+	{_error.ErrInvalidLoginCode, codes.Unauthenticated, ReasonInvalidLoginCode, "invalid or expired login code"},
+	{_error.ErrPasswordlessOff, codes.FailedPrecondition, ReasonPasswordlessOff, "passwordless login is not enabled"},
+	{_error.ErrInvalidPasskey, codes.Unauthenticated, ReasonInvalidPasskey, "passkey verification failed"},
+	{_error.ErrPasskeysOff, codes.FailedPrecondition, ReasonPasskeysOff, "passkeys are not enabled for the app"},
+	{_error.ErrPasskeyExists, codes.AlreadyExists, ReasonPasskeyExists, "passkey already registered"},
+	{_error.ErrIdPNotFound, codes.NotFound, ReasonIdPNotFound, "identity provider not found"},
+	{_error.ErrExternalLogin, codes.Unauthenticated, ReasonExternalLogin, "external login failed"},
+	{_error.ErrIdentityNotLinked, codes.FailedPrecondition, ReasonIdentityNotLinked, "an account with this email exists, log in and link the identity first"},
+	{_error.ErrIdentityLinked, codes.AlreadyExists, ReasonIdentityLinked, "identity is linked to another user"},
+	{_error.ErrIdentityNotFound, codes.NotFound, ReasonIdentityNotFound, "identity not found"},
+	{_error.ErrAccountLocked, codes.PermissionDenied, ReasonAccountLocked, "account is locked"},
+	{_error.ErrAccountDisabled, codes.PermissionDenied, ReasonAccountDisabled, "account is disabled"},
+	{_error.ErrAccountDeleted, codes.PermissionDenied, ReasonAccountDeleted, "account is scheduled for deletion"},
+	{_error.ErrAppAccessDenied, codes.PermissionDenied, ReasonAppAccessDenied, "you are not a member of this app"},
+	{_error.ErrAppAccessPending, codes.PermissionDenied, ReasonAppAccessPending, "your access request is waiting for approval"},
+	{_error.ErrMemberNotFound, codes.NotFound, ReasonMemberNotFound, "app member not found"},
+	{_error.ErrUserExists, codes.AlreadyExists, ReasonUserExists, "user already exists"},
+	{_error.ErrUserNotFound, codes.NotFound, ReasonUserNotFound, "user not found"},
+	{_error.ErrAppNotFound, codes.NotFound, ReasonAppNotFound, "app not found"},
+	{_error.ErrAppExists, codes.AlreadyExists, ReasonAppExists, "app already exists"},
+	{_error.ErrOrgNotFound, codes.NotFound, ReasonOrgNotFound, "organization not found"},
+	{_error.ErrOrgExists, codes.AlreadyExists, ReasonOrgExists, "organization already exists"},
+	{_error.ErrInvalidAppID, codes.InvalidArgument, ReasonInvalidAppID, "invalid app_id"},
+	{_error.ErrInvalidToken, codes.Unauthenticated, ReasonInvalidToken, "invalid token"},
+	{_error.ErrUnauthenticated, codes.Unauthenticated, ReasonUnauthenticated, "authentication required"},
+	{_error.ErrPermissionDenied, codes.PermissionDenied, ReasonPermissionDenied, "permission denied"},
+	{_error.ErrSessionNotFound, codes.NotFound, ReasonSessionNotFound, "session not found"},
+	{_error.ErrSessionRevoked, codes.Unauthenticated, ReasonSessionRevoked, "session has been revoked"},
+	{_error.ErrVersionConflict, codes.Aborted, ReasonVersionConflict, "the resource was changed by another request, reload it and retry"},
+	{_error.ErrSubscriberLagging, codes.ResourceExhausted, ReasonSubscriberLagging, "subscriber is too slow, resume from the last received id"},
+	{_error.ErrWebhookNotFound, codes.NotFound, ReasonWebhookNotFound, "webhook not found"},
+	{_error.ErrDeliveryNotFound, codes.NotFound, ReasonDeliveryNotFound, "webhook delivery not found"},
+	{context.Canceled, codes.Canceled, ReasonCanceled, "request canceled"},
+	{context.DeadlineExceeded, codes.DeadlineExceeded, ReasonDeadlineExceeded, "deadline exceeded"},
+}
+
+// ToStatus переводит ошибку сервисного слоя в gRPC статус с ErrorInfo.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// Ошибка уже является gRPC статусом - отдаём как есть.
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var validation *_error.ValidationError
+	if errors.As(err, &validation) {
+		return Validation(validation.Field, validation.Description)
+	}
+
+	code, reason, message := codes.Internal, ReasonInternal, "internal error"
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			code, reason, message = m.code, m.reason, m.message
+			break
+		}
+	}
+
+	return withDetails(status.New(code, message), &errdetails.ErrorInfo{Reason: reason, Domain: Domain})
+}
+
+// Validation возвращает InvalidArgument с причиной VALIDATION_FAILED
+// и описанием поля в errdetails.BadRequest.
+func Validation(field, description string) error {
+	return withDetails(
+		status.New(codes.InvalidArgument, description),
+		&errdetails.ErrorInfo{
+			Reason:   ReasonValidationFailed,
+			Domain:   Domain,
+			Metadata: map[string]string{"field": field},
+		},
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: field, Description: description},
+			},
+		},
+	)
+}
+
+// Reason достаёт причину из ErrorInfo gRPC статуса.
+// Возвращает пустую строку, если причины нет.
+func Reason(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+
+	return ""
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
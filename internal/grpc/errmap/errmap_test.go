@@ -0,0 +1,48 @@
+package errmap
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		reason  string
+		message string
+	}{
+		{"InvalidCredentials", fmt.Errorf("op: %w", _error.ErrInvalidCredentials), codes.Unauthenticated, ReasonInvalidCredentials, "invalid email or password"},
+		{"UserExists", fmt.Errorf("op: %w", _error.ErrUserExists), codes.AlreadyExists, ReasonUserExists, "user already exists"},
+		{"AppNotFound", fmt.Errorf("op: %w", _error.ErrAppNotFound), codes.NotFound, ReasonAppNotFound, "app not found"},
+		{"Unknown", errors.New("pq: connection refused"), codes.Internal, ReasonInternal, "internal error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(ToStatus(tt.err))
+			require.True(t, ok)
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.message, st.Message())
+			assert.Equal(t, tt.reason, Reason(st.Err()))
+		})
+	}
+}
+
+func TestValidation(t *testing.T) {
+	err := Validation("email", "email is required")
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, ReasonValidationFailed, Reason(err))
+	assert.Equal(t, err, ToStatus(err))
+}
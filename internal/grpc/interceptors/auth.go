@@ -0,0 +1,111 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TokenValidator проверяет токен доступа.
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (models.TokenClaims, error)
+}
+
+type (
+	claimsKey   struct{}
+	tokenErrKey struct{}
+)
+
+// Authenticate проверяет bearer токен из заголовка authorization и
+// кладёт его данные в контекст. Запросы без токена или с недействительным
+// токеном пропускаются: публичные RPC (Login, Register и др.) работают
+// со старым токеном в заголовке, а обработчики, которым нужен пользователь,
+// вызывают RequireClaims и получают ошибку проверки токена.
+func Authenticate(v TokenValidator) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		token := bearerToken(ctx)
+		if token == "" {
+			return handler(ctx, req)
+		}
+
+		return handler(authenticate(ctx, v, token), req)
+	}
+}
+
+// StreamAuthenticate - Authenticate для потоковых RPC.
+func StreamAuthenticate(v TokenValidator) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := ss.Context()
+
+		token := bearerToken(ctx)
+		if token == "" {
+			return handler(srv, ss)
+		}
+
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: authenticate(ctx, v, token)})
+	}
+}
+
+// authenticate кладёт в контекст данные токена или ошибку его проверки.
+func authenticate(ctx context.Context, v TokenValidator, token string) context.Context {
+	claims, err := v.ValidateToken(ctx, token)
+	if err != nil {
+		return context.WithValue(ctx, tokenErrKey{}, err)
+	}
+
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext возвращает данные токена текущего запроса.
+func ClaimsFromContext(ctx context.Context) (models.TokenClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(models.TokenClaims)
+	return claims, ok
+}
+
+// RequireClaims возвращает данные токена, ошибку его проверки
+// или Unauthenticated, если запрос пришёл без токена.
+func RequireClaims(ctx context.Context) (models.TokenClaims, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		if err, ok := ctx.Value(tokenErrKey{}).(error); ok {
+			return models.TokenClaims{}, errmap.ToStatus(err)
+		}
+		return models.TokenClaims{}, errmap.ToStatus(_error.ErrUnauthenticated)
+	}
+
+	return claims, nil
+}
+
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+
+	const prefix = "bearer "
+	if len(values[0]) < len(prefix) || !strings.EqualFold(values[0][:len(prefix)], prefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(values[0][len(prefix):])
+}
@@ -0,0 +1,65 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/clientinfo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientInfo кладёт в контекст IP клиента и его user agent.
+func ClientInfo() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		return handler(clientinfo.NewContext(ctx, clientInfoFromContext(ctx)), req)
+	}
+}
+
+func clientInfoFromContext(ctx context.Context) clientinfo.Info {
+	var info clientinfo.Info
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		info.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(info.IP); err == nil {
+			info.IP = host
+		}
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ua := md.Get("user-agent"); len(ua) > 0 {
+			info.UserAgent = ua[0]
+		}
+	}
+
+	return info
+}
+
+// StreamClientInfo - ClientInfo для потоковых RPC.
+func StreamClientInfo() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := clientinfo.NewContext(ss.Context(), clientInfoFromContext(ss.Context()))
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// wrappedStream подменяет контекст потока.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,70 @@
+// Package pagetoken кодирует курсоры пагинации в непрозрачные токены страниц.
+package pagetoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// Encode кодирует курсор (ID последней записи страницы).
+// Клиент не должен разбирать токен. 0 означает, что страниц больше нет.
+func Encode(cursor int64) string {
+	if cursor == 0 {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(cursor, 10)))
+}
+
+// Decode возвращает курсор из токена. Пустой токен - первая страница.
+func Decode(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(string(raw), 10, 64)
+}
+
+// Keyset - курсор для сортировки по произвольному ключу: сортировка,
+// значение ключа и ID последней записи страницы.
+type Keyset struct {
+	Sort string `json:"s"`
+	Key  string `json:"k,omitempty"`
+	ID   int64  `json:"i"`
+}
+
+// EncodeKeyset кодирует курсор в токен страницы.
+func EncodeKeyset(k Keyset) string {
+	raw, _ := json.Marshal(k)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeKeyset возвращает курсор из токена. Пустой токен - первая страница, nil.
+func DecodeKeyset(token string) (*Keyset, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var k Keyset
+	if err := json.Unmarshal(raw, &k); err != nil {
+		return nil, err
+	}
+	if k.ID <= 0 {
+		return nil, errors.New("pagetoken: cursor id is missing")
+	}
+
+	return &k, nil
+}
@@ -0,0 +1,23 @@
+// Package clientinfo хранит в контексте сведения о клиенте запроса:
+// IP адрес и user agent. Заполняется gRPC интерсептором.
+package clientinfo
+
+import "context"
+
+type Info struct {
+	IP        string
+	UserAgent string
+}
+
+type ctxKey struct{}
+
+// NewContext возвращает копию ctx с информацией о клиенте.
+func NewContext(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, ctxKey{}, info)
+}
+
+// FromContext возвращает информацию о клиенте. Если её нет - пустую структуру.
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(ctxKey{}).(Info)
+	return info
+}
@@ -1,19 +1,77 @@
 package jwt
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/Artemiadze/gRPC-Service/internal/lib/random"
 	"github.com/Artemiadze/gRPC-Service/internal/models"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func GenerateToken(user models.User, app models.App, tokenTTL time.Duration) (string, error) {
+// ErrInvalidToken возвращается, если токен не прошёл проверку.
+var ErrInvalidToken = errors.New("invalid token")
+
+// SecretFunc возвращает секрет приложения, которым подписан токен.
+type SecretFunc func(appID int) (string, error)
+
+// KeyFunc возвращает ключ для проверки подписи. Получает заголовок
+// и ещё не проверенные claims токена, например чтобы выбрать ключ по kid или app_id.
+type KeyFunc func(header map[string]any, claims map[string]any) (any, error)
+
+// reserved - claims, которые выдаёт сам SSO. Дополнительные claims приложения
+// не могут их переопределить.
+var reserved = map[string]bool{
+	"uid": true, "email": true, "app_id": true, "org_id": true, "sid": true,
+	"admin": true, "org_admin": true, "roles": true,
+	"iss": true, "sub": true, "aud": true, "exp": true, "nbf": true, "iat": true, "jti": true,
+}
+
+// Reserved сообщает, что claim name выдаёт сам SSO.
+func Reserved(name string) bool {
+	return reserved[name]
+}
+
+// GenerateToken выдаёт токен пользователю, подписанный секретом приложения.
+// Издатель, аудитория и дополнительные claims берутся из app.Token:
+// пустые значения в токен не попадают.
+func GenerateToken(user models.User, app models.App, sessionID string, tokenTTL time.Duration) (string, error) {
+	now := time.Now()
+
 	token := jwt.New(jwt.SigningMethodHS256)
 	claims := token.Claims.(jwt.MapClaims)
 	claims["uid"] = user.ID
 	claims["email"] = user.Email
 	claims["app_id"] = app.ID
-	claims["exp"] = time.Now().Add(tokenTTL).Unix()
+	claims["org_id"] = orgOrDefault(user.OrgID)
+	claims["sid"] = sessionID
+	claims["sub"] = strconv.FormatInt(user.ID, 10)
+	claims["iat"] = now.Unix()
+	claims["nbf"] = now.Unix()
+	claims["exp"] = now.Add(tokenTTL).Unix()
+	claims["jti"] = random.Hex(16)
+	if app.Token.Issuer != "" {
+		claims["iss"] = app.Token.Issuer
+	}
+	if len(app.Token.Audience) > 0 {
+		claims["aud"] = app.Token.Audience
+	}
+	if user.IsAdmin {
+		claims["admin"] = true
+	}
+	if user.OrgAdmin {
+		claims["org_admin"] = true
+	}
+	if len(user.Roles) > 0 {
+		claims["roles"] = user.Roles
+	}
+	for name, source := range app.Token.Claims {
+		if value, ok := user.ClaimValue(source); ok && !reserved[name] {
+			claims[name] = value
+		}
+	}
 
 	tokenString, err := token.SignedString([]byte(app.Secret))
 	if err != nil {
@@ -22,3 +80,96 @@ func GenerateToken(user models.User, app models.App, tokenTTL time.Duration) (st
 
 	return tokenString, nil
 }
+
+// ParseToken проверяет подпись и срок действия токена и возвращает его данные.
+// Секрет для проверки выбирается по claim app_id.
+func ParseToken(tokenString string, secret SecretFunc) (models.TokenClaims, error) {
+	return VerifyToken(tokenString, func(_ map[string]any, claims map[string]any) (any, error) {
+		appID, ok := claims["app_id"].(float64)
+		if !ok {
+			return nil, errors.New("app_id claim is missing")
+		}
+
+		s, err := secret(int(appID))
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(s), nil
+	}, jwt.SigningMethodHS256.Alg())
+}
+
+// VerifyToken проверяет токен ключом из key. Допускаются только алгоритмы methods.
+func VerifyToken(tokenString string, key KeyFunc, methods ...string) (models.TokenClaims, error) {
+	const op = "jwt.VerifyToken"
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return key(token.Header, claims)
+	},
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return models.TokenClaims{}, fmt.Errorf("%s: %w: %w", op, ErrInvalidToken, err)
+	}
+
+	uid, _ := claims["uid"].(float64)
+	appID, _ := claims["app_id"].(float64)
+	email, _ := claims["email"].(string)
+	sessionID, _ := claims["sid"].(string)
+	isAdmin, _ := claims["admin"].(bool)
+	orgAdmin, _ := claims["org_admin"].(bool)
+	exp, err := claims.GetExpirationTime()
+	if err != nil || uid == 0 {
+		return models.TokenClaims{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	// Токены без org_id выданы до появления организаций
+	orgID, _ := claims["org_id"].(float64)
+	issuer, _ := claims.GetIssuer()
+	audience, _ := claims.GetAudience()
+
+	var custom map[string]any
+	for name, value := range claims {
+		if reserved[name] {
+			continue
+		}
+		if custom == nil {
+			custom = make(map[string]any)
+		}
+		custom[name] = value
+	}
+
+	var roles []string
+	if list, ok := claims["roles"].([]any); ok {
+		for _, r := range list {
+			if role, ok := r.(string); ok {
+				roles = append(roles, role)
+			}
+		}
+	}
+
+	return models.TokenClaims{
+		UserID:    int64(uid),
+		Email:     email,
+		AppID:     int(appID),
+		SessionID: sessionID,
+		ExpiresAt: exp.Time,
+		IsAdmin:   isAdmin,
+		Roles:     roles,
+		OrgID:     orgOrDefault(int64(orgID)),
+		OrgAdmin:  orgAdmin,
+		Issuer:    issuer,
+		Audience:  audience,
+		Custom:    custom,
+	}, nil
+}
+
+func orgOrDefault(orgID int64) int64 {
+	if orgID == 0 {
+		return models.DefaultOrgID
+	}
+
+	return orgID
+}
@@ -0,0 +1,48 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateToken_AppSettings(t *testing.T) {
+	user := models.User{ID: 7, Email: "user@example.com", OrgID: 2, Roles: []string{"editor"}}
+	app := models.App{ID: 3, Secret: "secret", Token: models.TokenSettings{
+		Issuer:   "https://sso.example.com",
+		Audience: []string{"crm", "billing"},
+		Claims: map[string]string{
+			"mail":   models.ClaimSourceEmail,
+			"groups": models.ClaimSourceRoles,
+			"exp":    models.ClaimSourceUserID, // зарезервированный claim не переопределяется
+		},
+	}}
+
+	token, err := GenerateToken(user, app, "session", time.Hour)
+	require.NoError(t, err)
+
+	claims, err := ParseToken(token, func(int) (string, error) { return "secret", nil })
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), claims.UserID)
+	assert.Equal(t, "https://sso.example.com", claims.Issuer)
+	assert.Equal(t, []string{"crm", "billing"}, claims.Audience)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt, time.Minute)
+	assert.Equal(t, map[string]any{"mail": "user@example.com", "groups": []any{"editor"}}, claims.Custom)
+}
+
+func TestGenerateToken_Defaults(t *testing.T) {
+	token, err := GenerateToken(models.User{ID: 7}, models.App{ID: 1, Secret: "secret"}, "", time.Minute)
+	require.NoError(t, err)
+
+	claims, err := ParseToken(token, func(int) (string, error) { return "secret", nil })
+	require.NoError(t, err)
+
+	assert.Empty(t, claims.Issuer)
+	assert.Empty(t, claims.Audience)
+	assert.Nil(t, claims.Custom)
+	assert.Equal(t, models.DefaultOrgID, claims.OrgID)
+}
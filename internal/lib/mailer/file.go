@@ -0,0 +1,62 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// File вместо отправки дописывает письма в файл в формате JSON Lines.
+// Нужен для локального запуска и тестов.
+type File struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// fileMessage - строка файла: письмо и время отправки.
+type fileMessage struct {
+	Message
+	SentAt time.Time `json:"sent_at"`
+}
+
+// NewFile opens (or creates) path for appending, creating missing directories.
+func NewFile(path string) (*File, error) {
+	const op = "mailer.NewFile"
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &File{file: f}, nil
+}
+
+func (f *File) Send(_ context.Context, msg Message) error {
+	const op = "mailer.File.Send"
+
+	line, err := json.Marshal(fileMessage{Message: msg, SentAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (f *File) Close() error {
+	return f.file.Close()
+}
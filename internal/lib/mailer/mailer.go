@@ -0,0 +1,16 @@
+// Package mailer отправляет письма пользователям: коды и ссылки входа без пароля.
+package mailer
+
+import "context"
+
+// Message - письмо с текстовым телом.
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer отправляет письмо. Ошибка означает, что письмо не ушло.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTP отправляет письма через SMTP сервер. Если сервер поддерживает
+// STARTTLS, соединение шифруется; учётные данные передаются только
+// по зашифрованному соединению (так устроен smtp.PlainAuth).
+type SMTP struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTP creates an SMTP mailer. Empty username disables authentication.
+func NewSMTP(addr, from, username, password string) *SMTP {
+	s := &SMTP{addr: addr, from: from}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	const op = "mailer.SMTP.Send"
+
+	if strings.ContainsAny(msg.To, "\r\n") {
+		return fmt.Errorf("%s: invalid recipient", op)
+	}
+
+	// smtp.SendMail не принимает контекст, поэтому ждём его в отдельной горутине
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.addr, s.auth, s.from, []string{msg.To}, s.format(msg))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s: %w", op, ctx.Err())
+	}
+}
+
+func (s *SMTP) format(msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
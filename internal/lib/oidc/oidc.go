@@ -0,0 +1,362 @@
+// Package oidc - клиент OpenID Connect для входа через внешних провайдеров:
+// authorization code flow с PKCE (S256) и проверкой ID-токена ключами
+// из discovery-документа провайдера.
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/random"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrExchange - провайдер не обменял код на токены, например код истёк.
+	ErrExchange = errors.New("oidc: code exchange failed")
+	// ErrInvalidToken - ID-токен не прошёл проверку.
+	ErrInvalidToken = errors.New("oidc: invalid id token")
+)
+
+// Config - регистрация клиента у провайдера.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string // пусто - публичный клиент, защищённый только PKCE
+	RedirectURI  string
+	Scopes       []string // запрашиваются вместе с openid
+}
+
+// Claims - claims проверенного ID-токена.
+type Claims map[string]any
+
+// Subject - постоянный идентификатор пользователя у провайдера.
+func (c Claims) Subject() string {
+	return c.String("sub")
+}
+
+// String возвращает строковый claim или пустую строку.
+func (c Claims) String(name string) string {
+	s, _ := c[name].(string)
+	return s
+}
+
+// Bool возвращает логический claim. Некоторые провайдеры передают
+// email_verified строкой, поэтому "true" тоже считается истиной.
+func (c Claims) Bool(name string) bool {
+	switch v := c[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+
+	return false
+}
+
+// Client ходит к провайдерам и кэширует их discovery-документы и ключи.
+// Безопасен для одновременного использования.
+type Client struct {
+	http *http.Client
+
+	mu        sync.Mutex
+	providers map[string]*provider // по issuer
+}
+
+// NewClient создаёт клиент. httpClient == nil - http.DefaultClient.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{http: httpClient, providers: make(map[string]*provider)}
+}
+
+// NewVerifier возвращает случайный code_verifier для PKCE.
+func NewVerifier() string {
+	return random.Token(32)
+}
+
+// AuthCodeURL возвращает адрес страницы входа провайдера. После входа провайдер
+// вернёт пользователя на cfg.RedirectURI с параметрами code и state.
+func (c *Client) AuthCodeURL(ctx context.Context, cfg Config, state, nonce, verifier string) (string, error) {
+	const op = "oidc.Client.AuthCodeURL"
+
+	p, err := c.provider(ctx, cfg.Issuer)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	u, err := url.Parse(p.meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", cfg.ClientID)
+	q.Set("redirect_uri", cfg.RedirectURI)
+	q.Set("scope", strings.Join(append([]string{"openid"}, cfg.Scopes...), " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Exchange обменивает код на токены и возвращает claims ID-токена,
+// проверив подпись, издателя, аудиторию, срок и nonce.
+func (c *Client) Exchange(ctx context.Context, cfg Config, code, verifier, nonce string) (Claims, error) {
+	const op = "oidc.Client.Exchange"
+
+	p, err := c.provider(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rawIDToken, err := c.exchange(ctx, p, cfg, code, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	claims, err := c.verify(ctx, p, cfg, rawIDToken, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidToken, err)
+	}
+
+	return claims, nil
+}
+
+func (c *Client) exchange(ctx context.Context, p *provider, cfg Config, code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {cfg.RedirectURI},
+		"code_verifier": {verifier},
+	}
+
+	// client_secret_basic - метод по умолчанию, client_secret_post - если провайдер умеет только его
+	basic := cfg.ClientSecret != "" && (len(p.meta.TokenAuthMethods) == 0 ||
+		slices.Contains(p.meta.TokenAuthMethods, "client_secret_basic") ||
+		!slices.Contains(p.meta.TokenAuthMethods, "client_secret_post"))
+	if !basic {
+		form.Set("client_id", cfg.ClientID)
+		if cfg.ClientSecret != "" {
+			form.Set("client_secret", cfg.ClientSecret)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if basic {
+		req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrExchange, resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s: %s %s", ErrExchange, resp.Status, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("%w: no id_token in response", ErrExchange)
+	}
+
+	return body.IDToken, nil
+}
+
+// Допустимое расхождение часов с провайдером.
+const leeway = time.Minute
+
+var supportedAlgs = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+func (c *Client) verify(ctx context.Context, p *provider, cfg Config, rawIDToken, nonce string) (Claims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return c.key(ctx, p, kid)
+	},
+		jwt.WithValidMethods(supportedAlgs),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(leeway),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return nil, errors.New("nonce mismatch")
+	}
+	// При нескольких аудиториях токен должен быть выдан именно нам
+	if aud, _ := claims.GetAudience(); len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != cfg.ClientID {
+			return nil, errors.New("azp does not match client_id")
+		}
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, errors.New("sub claim is missing")
+	}
+
+	return Claims(claims), nil
+}
+
+// maxResponseSize ограничивает ответы провайдера.
+const maxResponseSize = 1 << 20
+
+// Сколько хранить discovery-документ и как часто можно перечитывать ключи.
+const (
+	discoveryTTL   = time.Hour
+	keysTTL        = time.Hour
+	minKeysRefresh = time.Minute
+)
+
+type metadata struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	TokenAuthMethods      []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+type provider struct {
+	meta      metadata
+	fetchedAt time.Time
+
+	keys          map[string]any // по kid
+	keysFetchedAt time.Time
+	keysTriedAt   time.Time
+}
+
+// provider возвращает метаданные провайдера issuer, при необходимости загружая
+// их заново. Если загрузить не удалось, используются прежние.
+func (c *Client) provider(ctx context.Context, issuer string) (*provider, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.providers[issuer]
+	if p != nil && time.Since(p.fetchedAt) < discoveryTTL {
+		return p, nil
+	}
+
+	var meta metadata
+	err := c.getJSON(ctx, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", &meta)
+	switch {
+	case err == nil && meta.Issuer != issuer:
+		err = fmt.Errorf("discovery document is for issuer %q", meta.Issuer)
+	case err == nil && (meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == ""):
+		err = errors.New("discovery document lacks required endpoints")
+	}
+	if err != nil {
+		if p != nil {
+			return p, nil
+		}
+		return nil, fmt.Errorf("discovery of %s: %w", issuer, err)
+	}
+
+	if p == nil || p.meta.JWKSURI != meta.JWKSURI {
+		p = &provider{}
+		c.providers[issuer] = p
+	}
+	p.meta, p.fetchedAt = meta, time.Now()
+
+	return p, nil
+}
+
+// key возвращает ключ провайдера kid. Незнакомый kid - повод перечитать
+// набор ключей, но не чаще раза в minKeysRefresh.
+func (c *Client) key(ctx context.Context, p *provider, kid string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, ok := lookupKey(p.keys, kid)
+	stale := time.Since(p.keysFetchedAt) >= keysTTL
+	if ok && !stale {
+		return key, nil
+	}
+	if p.keys != nil && time.Since(p.keysTriedAt) < minKeysRefresh {
+		if ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+
+	p.keysTriedAt = time.Now()
+	keys, err := c.fetchKeys(ctx, p.meta.JWKSURI)
+	if err != nil {
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+	p.keys, p.keysFetchedAt = keys, time.Now()
+
+	if key, ok = lookupKey(keys, kid); !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+
+	return key, nil
+}
+
+// lookupKey ищет ключ по kid. Токен без kid подходит, только если ключ один.
+func lookupKey(keys map[string]any, kid string) (any, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+
+	key, ok := keys[kid]
+	return key, ok
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+}
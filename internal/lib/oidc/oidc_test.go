@@ -0,0 +1,98 @@
+package oidc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/oidc"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProvider(t *testing.T) (*oidctest.Provider, oidc.Config) {
+	t.Helper()
+
+	p, err := oidctest.New("sso", "client-secret")
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+
+	return p, oidc.Config{
+		Issuer:       p.Issuer(),
+		ClientID:     "sso",
+		ClientSecret: "client-secret",
+		RedirectURI:  "https://app.example.com/callback",
+		Scopes:       []string{"email", "profile"},
+	}
+}
+
+// login проходит вход у провайдера и возвращает код из адреса возврата.
+func login(t *testing.T, client *oidc.Client, p *oidctest.Provider, cfg oidc.Config, nonce, verifier string) string {
+	t.Helper()
+
+	authURL, err := client.AuthCodeURL(context.Background(), cfg, "state-1", nonce, verifier)
+	require.NoError(t, err)
+
+	redirect, err := p.Authorize(authURL, map[string]any{"sub": "u-42", "email": "alice@corp.example", "email_verified": true})
+	require.NoError(t, err)
+	assert.Equal(t, "app.example.com", redirect.Host)
+	assert.Equal(t, "state-1", redirect.Query().Get("state"))
+
+	return redirect.Query().Get("code")
+}
+
+func TestExchange(t *testing.T) {
+	for _, postAuth := range []bool{false, true} {
+		p, cfg := newProvider(t)
+		p.PostAuth = postAuth
+		client := oidc.NewClient(nil)
+		verifier := oidc.NewVerifier()
+
+		code := login(t, client, p, cfg, "nonce-1", verifier)
+		claims, err := client.Exchange(context.Background(), cfg, code, verifier, "nonce-1")
+		require.NoError(t, err, "post auth: %v", postAuth)
+		assert.Equal(t, "u-42", claims.Subject())
+		assert.Equal(t, "alice@corp.example", claims.String("email"))
+		assert.True(t, claims.Bool("email_verified"))
+
+		// Код одноразовый
+		_, err = client.Exchange(context.Background(), cfg, code, verifier, "nonce-1")
+		assert.ErrorIs(t, err, oidc.ErrExchange)
+	}
+}
+
+func TestExchange_Rejected(t *testing.T) {
+	p, cfg := newProvider(t)
+	client := oidc.NewClient(nil)
+	ctx := context.Background()
+
+	verifier := oidc.NewVerifier()
+	code := login(t, client, p, cfg, "nonce-1", verifier)
+	_, err := client.Exchange(ctx, cfg, code, oidc.NewVerifier(), "nonce-1")
+	assert.ErrorIs(t, err, oidc.ErrExchange, "wrong PKCE verifier")
+
+	code = login(t, client, p, cfg, "nonce-1", verifier)
+	_, err = client.Exchange(ctx, cfg, code, verifier, "nonce-2")
+	assert.ErrorIs(t, err, oidc.ErrInvalidToken, "wrong nonce")
+
+	code = login(t, client, p, cfg, "nonce-1", verifier)
+	wrongSecret := cfg
+	wrongSecret.ClientSecret = "other"
+	_, err = client.Exchange(ctx, wrongSecret, code, verifier, "nonce-1")
+	assert.ErrorIs(t, err, oidc.ErrExchange, "wrong client secret")
+
+	// Код, выданный другим провайдером, не подходит
+	other, otherCfg := newProvider(t)
+	code = login(t, client, other, otherCfg, "nonce-1", verifier)
+	otherCfg.Issuer = p.Issuer()
+	_, err = client.Exchange(ctx, otherCfg, code, verifier, "nonce-1")
+	assert.Error(t, err)
+}
+
+func TestAuthCodeURL_UnknownIssuer(t *testing.T) {
+	_, cfg := newProvider(t)
+	cfg.Issuer += "/other"
+
+	_, err := oidc.NewClient(nil).AuthCodeURL(context.Background(), cfg, "s", "n", oidc.NewVerifier())
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package password
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idPrefix = "$argon2id$"
+
+	defaultArgon2Memory  = 64 * 1024 // KiB
+	defaultArgon2Time    = 3
+	defaultArgon2Threads = 2
+	argon2SaltLen        = 16
+	argon2KeyLen         = 32
+
+	// Пределы параметров чужих хэшей, например импортированных: без них
+	// один хэш может занять всю память или процессор при входе.
+	maxArgon2Memory  = 1024 * 1024 // KiB
+	maxArgon2Time    = 32
+	maxArgon2Threads = 64
+	maxArgon2KeyLen  = 128
+)
+
+var errMalformedHash = errors.New("malformed argon2id hash")
+
+// Argon2id хэширует пароли алгоритмом argon2id.
+// Формат хэша: $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>.
+type Argon2id struct {
+	Memory  uint32 // объём памяти в KiB
+	Time    uint32 // число итераций
+	Threads uint8  // степень параллелизма
+}
+
+// NewArgon2id creates an argon2id scheme. Zero values fall back to defaults.
+func NewArgon2id(memory uint32, time uint32, threads uint8) *Argon2id {
+	if memory == 0 {
+		memory = defaultArgon2Memory
+	}
+	if time == 0 {
+		time = defaultArgon2Time
+	}
+	if threads == 0 {
+		threads = defaultArgon2Threads
+	}
+
+	return &Argon2id{Memory: memory, Time: time, Threads: threads}
+}
+
+func (a *Argon2id) Hash(password string) ([]byte, error) {
+	const op = "password.Argon2id.Hash"
+
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.Time, a.Memory, a.Threads, argon2KeyLen)
+
+	encoded := fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		a.Memory, a.Time, a.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+
+	return []byte(encoded), nil
+}
+
+func (a *Argon2id) Compare(hash []byte, password string) error {
+	const op = "password.Argon2id.Compare"
+
+	p, err := parseArgon2id(hash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+	if subtle.ConstantTimeCompare(key, p.key) != 1 {
+		return fmt.Errorf("%s: %w", op, ErrMismatch)
+	}
+
+	return nil
+}
+
+func (a *Argon2id) NeedsRehash(hash []byte) bool {
+	p, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+
+	return p.version != argon2.Version ||
+		p.memory != a.Memory ||
+		p.time != a.Time ||
+		p.threads != a.Threads
+}
+
+func (a *Argon2id) Validate(hash []byte) error {
+	_, err := parseArgon2id(hash)
+	return err
+}
+
+func (a *Argon2id) Identify(hash []byte) bool {
+	return bytes.HasPrefix(hash, []byte(argon2idPrefix))
+}
+
+type argon2Params struct {
+	version int
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func parseArgon2id(hash []byte) (argon2Params, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(string(hash), "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return argon2Params{}, errMalformedHash
+	}
+
+	var p argon2Params
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &p.version); err != nil {
+		return argon2Params{}, errMalformedHash
+	}
+	if p.version != argon2.Version {
+		return argon2Params{}, fmt.Errorf("%w: unsupported version %d", errMalformedHash, p.version)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return argon2Params{}, errMalformedHash
+	}
+	switch {
+	case p.memory < 1 || p.memory > maxArgon2Memory:
+		return argon2Params{}, fmt.Errorf("%w: memory must be 1-%d KiB", errMalformedHash, maxArgon2Memory)
+	case p.time < 1 || p.time > maxArgon2Time:
+		return argon2Params{}, fmt.Errorf("%w: time must be 1-%d", errMalformedHash, maxArgon2Time)
+	case p.threads < 1 || p.threads > maxArgon2Threads:
+		return argon2Params{}, fmt.Errorf("%w: threads must be 1-%d", errMalformedHash, maxArgon2Threads)
+	}
+
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return argon2Params{}, errMalformedHash
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 || len(p.key) > maxArgon2KeyLen {
+		return argon2Params{}, errMalformedHash
+	}
+
+	return p, nil
+}
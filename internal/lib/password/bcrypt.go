@@ -0,0 +1,94 @@
+package password
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	bcryptHashLen = 60
+	// maxBcryptCost ограничивает стоимость чужих хэшей: bcrypt допускает
+	// до 31, а это часы работы процессора на одну проверку.
+	maxBcryptCost = 16
+)
+
+var errMalformedBcrypt = errors.New("malformed bcrypt hash")
+
+// Bcrypt хэширует пароли алгоритмом bcrypt.
+// bcrypt использует собственную строку формата $2a$<cost>$<salt+hash>,
+// которую спецификация PHC допускает как совместимую, поэтому уже
+// сохранённые в базе хэши продолжают проверяться без миграции.
+type Bcrypt struct {
+	Cost int
+}
+
+// NewBcrypt creates a bcrypt scheme. Zero cost means bcrypt.DefaultCost.
+func NewBcrypt(cost int) *Bcrypt {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+
+	return &Bcrypt{Cost: cost}
+}
+
+func (b *Bcrypt) Hash(password string) ([]byte, error) {
+	const op = "password.Bcrypt.Hash"
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hash, nil
+}
+
+func (b *Bcrypt) Compare(hash []byte, password string) error {
+	const op = "password.Bcrypt.Compare"
+
+	if err := b.Validate(hash); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := bcrypt.CompareHashAndPassword(hash, []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return fmt.Errorf("%s: %w", op, ErrMismatch)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (b *Bcrypt) NeedsRehash(hash []byte) bool {
+	cost, err := bcrypt.Cost(hash)
+	if err != nil {
+		return true
+	}
+
+	return cost != b.Cost
+}
+
+func (b *Bcrypt) Validate(hash []byte) error {
+	if len(hash) != bcryptHashLen {
+		return errMalformedBcrypt
+	}
+	cost, err := bcrypt.Cost(hash)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errMalformedBcrypt, err)
+	}
+	if cost > maxBcryptCost {
+		return fmt.Errorf("%w: cost must be at most %d", errMalformedBcrypt, maxBcryptCost)
+	}
+
+	return nil
+}
+
+func (b *Bcrypt) Identify(hash []byte) bool {
+	return bytes.HasPrefix(hash, []byte("$2a$")) ||
+		bytes.HasPrefix(hash, []byte("$2b$")) ||
+		bytes.HasPrefix(hash, []byte("$2y$"))
+}
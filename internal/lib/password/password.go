@@ -0,0 +1,105 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMismatch возвращается, если пароль не совпадает с хэшем.
+	ErrMismatch = errors.New("password does not match hash")
+	// ErrUnknownAlgorithm возвращается, если формат хэша не распознан.
+	ErrUnknownAlgorithm = errors.New("unknown password hash algorithm")
+)
+
+// PasswordHasher хэширует и проверяет пароли.
+// Хэши хранятся в самоописывающем формате PHC ($<id>$<params>$<salt>$<hash>),
+// поэтому по самой строке можно понять, каким алгоритмом и с какими параметрами
+// она получена.
+type PasswordHasher interface {
+	// Hash возвращает хэш пароля в формате PHC.
+	Hash(password string) ([]byte, error)
+	// Compare сравнивает пароль с хэшем. Возвращает ErrMismatch, если пароль неверный.
+	Compare(hash []byte, password string) error
+	// NeedsRehash сообщает, что хэш получен устаревшим алгоритмом или параметрами
+	// и его стоит пересчитать при следующем успешном входе.
+	NeedsRehash(hash []byte) bool
+}
+
+// Scheme - конкретный алгоритм хэширования.
+type Scheme interface {
+	PasswordHasher
+	// Identify сообщает, получен ли хэш этим алгоритмом.
+	Identify(hash []byte) bool
+	// Validate полностью разбирает хэш этого алгоритма и проверяет,
+	// что его параметры в допустимых пределах.
+	Validate(hash []byte) error
+}
+
+// Hasher хэширует пароли текущим алгоритмом и умеет проверять хэши,
+// полученные любым из известных алгоритмов.
+type Hasher struct {
+	current Scheme
+	schemes []Scheme
+}
+
+// New creates a Hasher that hashes new passwords with current and verifies
+// hashes produced by current or any of legacy.
+func New(current Scheme, legacy ...Scheme) *Hasher {
+	return &Hasher{
+		current: current,
+		schemes: append([]Scheme{current}, legacy...),
+	}
+}
+
+// Hash хэширует пароль текущим алгоритмом.
+func (h *Hasher) Hash(password string) ([]byte, error) {
+	return h.current.Hash(password)
+}
+
+// Compare находит алгоритм по формату хэша и проверяет пароль.
+func (h *Hasher) Compare(hash []byte, password string) error {
+	scheme, err := h.identify(hash)
+	if err != nil {
+		return err
+	}
+
+	return scheme.Compare(hash, password)
+}
+
+// NeedsRehash возвращает true, если хэш получен не текущим алгоритмом
+// или текущим алгоритмом, но с другими параметрами.
+func (h *Hasher) NeedsRehash(hash []byte) bool {
+	if !h.current.Identify(hash) {
+		return true
+	}
+
+	return h.current.NeedsRehash(hash)
+}
+
+// Identify сообщает, распознан ли формат хэша одним из известных алгоритмов.
+func (h *Hasher) Identify(hash []byte) bool {
+	_, err := h.identify(hash)
+	return err == nil
+}
+
+// Validate проверяет, что хэш получен известным алгоритмом, разбирается
+// и его можно проверить за разумное время. Нужен для хэшей извне, например при импорте.
+func (h *Hasher) Validate(hash []byte) error {
+	scheme, err := h.identify(hash)
+	if err != nil {
+		return err
+	}
+
+	return scheme.Validate(hash)
+}
+
+func (h *Hasher) identify(hash []byte) (Scheme, error) {
+	for _, s := range h.schemes {
+		if s.Identify(hash) {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("password.identify: %w", ErrUnknownAlgorithm)
+}
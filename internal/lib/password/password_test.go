@@ -0,0 +1,107 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHasher_HashAndCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme Scheme
+		prefix string
+	}{
+		{"Bcrypt", NewBcrypt(bcrypt.MinCost), "$2a$"},
+		{"Argon2id", NewArgon2id(1024, 1, 1), "$argon2id$v=19$m=1024,t=1,p=1$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(tt.scheme)
+
+			hash, err := h.Hash("secret")
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(hash), tt.prefix))
+
+			require.NoError(t, h.Compare(hash, "secret"))
+			require.ErrorIs(t, h.Compare(hash, "wrong"), ErrMismatch)
+			assert.False(t, h.NeedsRehash(hash))
+		})
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	oldBcrypt := NewBcrypt(bcrypt.MinCost)
+	oldArgon := NewArgon2id(1024, 1, 1)
+
+	bcryptHash, err := oldBcrypt.Hash("secret")
+	require.NoError(t, err)
+	argonHash, err := oldArgon.Hash("secret")
+	require.NoError(t, err)
+
+	// Алгоритм сменился: старые bcrypt-хэши проверяются, но требуют пересчёта.
+	h := New(NewArgon2id(2048, 1, 1), oldBcrypt)
+	require.NoError(t, h.Compare(bcryptHash, "secret"))
+	assert.True(t, h.NeedsRehash(bcryptHash))
+
+	// Те же алгоритм, но другие параметры.
+	assert.True(t, h.NeedsRehash(argonHash))
+	require.NoError(t, h.Compare(argonHash, "secret"))
+
+	assert.True(t, New(NewBcrypt(bcrypt.MinCost+1)).NeedsRehash(bcryptHash))
+}
+
+func TestHasher_UnknownAlgorithm(t *testing.T) {
+	h := New(NewBcrypt(bcrypt.MinCost))
+
+	require.ErrorIs(t, h.Compare([]byte("$scrypt$ln=15$abc$def"), "secret"), ErrUnknownAlgorithm)
+	require.Error(t, NewArgon2id(0, 0, 0).Compare([]byte("$argon2id$broken"), "secret"))
+}
+
+func TestPBKDF2_Legacy(t *testing.T) {
+	// Хэши из passlib
+	const (
+		sha256Hash = "$pbkdf2-sha256$6400$0ZrzXitFSGltTQnBWOsdAw$Y11AchqV4b0sUisdZd0Xr97KWoymNE0LNNrnEgY4H9M"
+		sha1Hash   = "$pbkdf2$1000$c2FsdHNhbHRzYWx0c2FsdA$NVAcjfHDmGrmDwP00qatGi6LKfY"
+	)
+
+	h := New(NewArgon2id(1024, 1, 1), NewBcrypt(bcrypt.MinCost), NewPBKDF2(0))
+
+	require.NoError(t, h.Compare([]byte(sha256Hash), "password"))
+	require.ErrorIs(t, h.Compare([]byte(sha256Hash), "wrong"), ErrMismatch)
+	require.NoError(t, h.Compare([]byte(sha1Hash), "legacy"))
+	assert.True(t, h.NeedsRehash([]byte(sha256Hash)))
+	assert.True(t, h.Identify([]byte(sha1Hash)))
+
+	require.Error(t, h.Compare([]byte("$pbkdf2-sha256$0$abc$def"), "password"))
+}
+
+func TestHasher_Validate(t *testing.T) {
+	h := New(NewArgon2id(1024, 1, 1), NewBcrypt(bcrypt.MinCost), NewPBKDF2(0))
+
+	argon, err := NewArgon2id(1024, 1, 1).Hash("secret")
+	require.NoError(t, err)
+	require.NoError(t, h.Validate(argon))
+
+	const salt = "c2FsdHNhbHRzYWx0c2FsdA$NVAcjfHDmGrmDwP00qatGi6LKfY"
+	for name, hash := range map[string]string{
+		"argon2id zero time":    "$argon2id$v=19$m=65536,t=0,p=1$" + salt,
+		"argon2id zero threads": "$argon2id$v=19$m=65536,t=1,p=0$" + salt,
+		"argon2id huge memory":  "$argon2id$v=19$m=4294967295,t=1,p=1$" + salt,
+		"argon2id huge time":    "$argon2id$v=19$m=65536,t=100000,p=1$" + salt,
+		"argon2id old version":  "$argon2id$v=16$m=65536,t=1,p=1$" + salt,
+		"pbkdf2 huge rounds":    "$pbkdf2$1000000000$" + salt,
+		"bcrypt huge cost":      "$2a$31$" + strings.Repeat("a", 53),
+		"bcrypt truncated":      "$2a$10$abc",
+		"unknown":               "$scrypt$ln=15$abc$def",
+	} {
+		assert.Error(t, h.Validate([]byte(hash)), name)
+	}
+
+	// Проверка пароля по такому хэшу - ошибка, а не паника в argon2
+	require.Error(t, h.Compare([]byte("$argon2id$v=19$m=65536,t=0,p=1$"+salt), "secret"))
+}
@@ -0,0 +1,147 @@
+package password
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	defaultPBKDF2Rounds = 29000
+	pbkdf2SaltLen       = 16
+	// maxPBKDF2Rounds ограничивает число итераций чужих хэшей
+	maxPBKDF2Rounds = 2_000_000
+)
+
+var errMalformedPBKDF2 = errors.New("malformed pbkdf2 hash")
+
+// pbkdf2Digests - поддерживаемые варианты PBKDF2 и длина ключа каждого.
+var pbkdf2Digests = map[string]struct {
+	hash   func() hash.Hash
+	keyLen int
+}{
+	"pbkdf2":        {sha1.New, sha1.Size},
+	"pbkdf2-sha256": {sha256.New, sha256.Size},
+	"pbkdf2-sha512": {sha512.New, sha512.Size},
+}
+
+// ab64 - base64 из passlib: "." вместо "+" и без выравнивания.
+var ab64 = base64.NewEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789./").WithPadding(base64.NoPadding)
+
+// PBKDF2 проверяет хэши PBKDF2 в формате passlib, которые встречаются
+// в импортированных из старых систем учётных записях:
+// $pbkdf2-sha256$<rounds>$<salt>$<hash> (также $pbkdf2$ с SHA-1 и $pbkdf2-sha512$).
+// Новые пароли им хэшировать не стоит: схема нужна только как устаревшая,
+// хэши пересчитываются текущим алгоритмом при первом входе.
+type PBKDF2 struct {
+	Rounds int
+}
+
+// NewPBKDF2 creates a PBKDF2-SHA256 scheme. Zero rounds fall back to the default.
+func NewPBKDF2(rounds int) *PBKDF2 {
+	if rounds == 0 {
+		rounds = defaultPBKDF2Rounds
+	}
+
+	return &PBKDF2{Rounds: rounds}
+}
+
+func (p *PBKDF2) Hash(password string) ([]byte, error) {
+	const op = "password.PBKDF2.Hash"
+
+	salt := make([]byte, pbkdf2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := pbkdf2.Key([]byte(password), salt, p.Rounds, sha256.Size, sha256.New)
+
+	return []byte(fmt.Sprintf("$pbkdf2-sha256$%d$%s$%s", p.Rounds, ab64.EncodeToString(salt), ab64.EncodeToString(key))), nil
+}
+
+func (p *PBKDF2) Compare(hash []byte, password string) error {
+	const op = "password.PBKDF2.Compare"
+
+	parsed, err := parsePBKDF2(hash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := pbkdf2.Key([]byte(password), parsed.salt, parsed.rounds, len(parsed.key), parsed.digest)
+	if subtle.ConstantTimeCompare(key, parsed.key) != 1 {
+		return fmt.Errorf("%s: %w", op, ErrMismatch)
+	}
+
+	return nil
+}
+
+func (p *PBKDF2) NeedsRehash(hash []byte) bool {
+	parsed, err := parsePBKDF2(hash)
+	if err != nil {
+		return true
+	}
+
+	return parsed.variant != "pbkdf2-sha256" || parsed.rounds != p.Rounds
+}
+
+func (p *PBKDF2) Validate(hash []byte) error {
+	_, err := parsePBKDF2(hash)
+	return err
+}
+
+func (p *PBKDF2) Identify(hash []byte) bool {
+	for variant := range pbkdf2Digests {
+		if bytes.HasPrefix(hash, []byte("$"+variant+"$")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type pbkdf2Params struct {
+	variant string
+	digest  func() hash.Hash
+	rounds  int
+	salt    []byte
+	key     []byte
+}
+
+func parsePBKDF2(hash []byte) (pbkdf2Params, error) {
+	// "", "pbkdf2-sha256", rounds, salt, hash
+	parts := strings.Split(string(hash), "$")
+	if len(parts) != 5 {
+		return pbkdf2Params{}, errMalformedPBKDF2
+	}
+
+	d, ok := pbkdf2Digests[parts[1]]
+	if !ok {
+		return pbkdf2Params{}, errMalformedPBKDF2
+	}
+
+	p := pbkdf2Params{variant: parts[1], digest: d.hash}
+
+	var err error
+	if p.rounds, err = strconv.Atoi(parts[2]); err != nil || p.rounds <= 0 || p.rounds > maxPBKDF2Rounds {
+		return pbkdf2Params{}, fmt.Errorf("%w: rounds must be 1-%d", errMalformedPBKDF2, maxPBKDF2Rounds)
+	}
+	if p.salt, err = ab64.DecodeString(parts[3]); err != nil {
+		return pbkdf2Params{}, errMalformedPBKDF2
+	}
+	if p.key, err = ab64.DecodeString(parts[4]); err != nil || len(p.key) != d.keyLen {
+		return pbkdf2Params{}, errMalformedPBKDF2
+	}
+
+	return p, nil
+}
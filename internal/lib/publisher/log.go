@@ -0,0 +1,71 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// Log пишет события в лог сервиса.
+type Log struct {
+	log *zap.Logger
+}
+
+func NewLog(log *zap.Logger) *Log {
+	return &Log{log: log}
+}
+
+func (l *Log) Publish(_ context.Context, event models.UserEvent) error {
+	l.log.Info("user event",
+		zap.Int64("id", event.ID),
+		zap.String("type", event.Type),
+		zap.Int64("uid", event.UserID),
+	)
+
+	return nil
+}
+
+// File дописывает события в файл в формате JSON Lines.
+type File struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFile opens (or creates) path for appending.
+func NewFile(path string) (*File, error) {
+	const op = "publisher.NewFile"
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &File{file: f}, nil
+}
+
+func (f *File) Publish(_ context.Context, event models.UserEvent) error {
+	const op = "publisher.File.Publish"
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (f *File) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,33 @@
+// Package publisher доставляет события жизненного цикла пользователя
+// внешним подписчикам.
+package publisher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+)
+
+// Publisher публикует событие. Возвращённая ошибка означает,
+// что событие нужно опубликовать повторно.
+// Публикация идемпотентна по event.ID: подписчики могут получить
+// одно событие несколько раз и должны дедуплицировать по ID.
+type Publisher interface {
+	Publish(ctx context.Context, event models.UserEvent) error
+}
+
+// Multi публикует событие во все publishers.
+// Ошибки всех publishers объединяются в одну.
+type Multi []Publisher
+
+func (m Multi) Publish(ctx context.Context, event models.UserEvent) error {
+	var errs []error
+	for _, p := range m {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
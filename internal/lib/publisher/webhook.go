@@ -0,0 +1,117 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+)
+
+// Заголовки запроса вебхука.
+const (
+	HeaderSignature = "X-SSO-Signature"
+	HeaderTimestamp = "X-SSO-Timestamp"
+	HeaderEventID   = "X-SSO-Event-ID"
+	HeaderEventType = "X-SSO-Event-Type"
+)
+
+// Webhook отправляет события POST запросом на URL.
+// Тело подписывается HMAC-SHA256: подпись считается от "<timestamp>.<body>"
+// и передаётся в заголовке X-SSO-Signature как "sha256=<hex>".
+// Неудачные запросы (сетевые ошибки и ответы 5xx/429) повторяются
+// с экспоненциальной задержкой.
+type Webhook struct {
+	url        string
+	secret     []byte
+	client     *http.Client
+	maxRetries int
+	backoff    time.Duration
+}
+
+// NewWebhook creates a webhook publisher.
+func NewWebhook(url string, secret string, timeout time.Duration, maxRetries int) *Webhook {
+	return &Webhook{
+		url:        url,
+		secret:     []byte(secret),
+		client:     &http.Client{Timeout: timeout},
+		maxRetries: maxRetries,
+		backoff:    200 * time.Millisecond,
+	}
+}
+
+func (w *Webhook) Publish(ctx context.Context, event models.UserEvent) error {
+	const op = "publisher.Webhook.Publish"
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	delay := w.backoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := w.send(ctx, event, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= w.maxRetries {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (w *Webhook) send(ctx context.Context, event models.UserEvent, body []byte) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderEventID, strconv.FormatInt(event.ID, 10))
+	req.Header.Set(HeaderEventType, event.Type)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(w.secret, ts, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("webhook responded with %s", resp.Status)
+	default:
+		return false, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+}
+
+// Sign возвращает hex HMAC-SHA256 подпись "<timestamp>.<body>".
+// Получатель вебхука проверяет подпись той же функцией.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
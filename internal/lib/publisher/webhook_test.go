@@ -0,0 +1,65 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_SignsAndRetries(t *testing.T) {
+	const secret = "webhook-secret"
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		want := "sha256=" + Sign([]byte(secret), r.Header.Get(HeaderTimestamp), body)
+		assert.Equal(t, want, r.Header.Get(HeaderSignature))
+		assert.Equal(t, "42", r.Header.Get(HeaderEventID))
+
+		var event models.UserEvent
+		require.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, models.UserRegistered, event.Type)
+
+		// Первая попытка падает, вторая успешна.
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	wh := NewWebhook(srv.URL, secret, time.Second, 2)
+	wh.backoff = time.Millisecond
+
+	err := wh.Publish(context.Background(), models.UserEvent{ID: 42, Type: models.UserRegistered, UserID: 7})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestWebhook_ClientErrorIsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	wh := NewWebhook(srv.URL, "secret", time.Second, 3)
+	wh.backoff = time.Millisecond
+
+	require.Error(t, wh.Publish(context.Background(), models.UserEvent{ID: 1, Type: models.UserDeleted}))
+	assert.EqualValues(t, 1, calls.Load())
+}
@@ -0,0 +1,49 @@
+// Package random генерирует криптографически стойкие случайные строки.
+package random
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+)
+
+// Hex возвращает n случайных байт в виде hex-строки.
+func Hex(n int) string {
+	return hex.EncodeToString(bytes(n))
+}
+
+// Token возвращает n случайных байт в base64url без паддинга.
+func Token(n int) string {
+	return base64.RawURLEncoding.EncodeToString(bytes(n))
+}
+
+// Digits возвращает n случайных десятичных цифр, например код подтверждения.
+func Digits(n int) string {
+	digits := make([]byte, n)
+	for i := range digits {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			panic("random: " + err.Error())
+		}
+		digits[i] = byte('0' + d.Int64())
+	}
+
+	return string(digits)
+}
+
+// Bytes возвращает n случайных байт.
+func Bytes(n int) []byte {
+	return bytes(n)
+}
+
+func bytes(n int) []byte {
+	b := make([]byte, n)
+	// crypto/rand.Read не возвращает ошибок начиная с Go 1.24 и
+	// на поддерживаемых платформах на практике не падает.
+	if _, err := rand.Read(b); err != nil {
+		panic("random: " + err.Error())
+	}
+
+	return b
+}
@@ -0,0 +1,252 @@
+// Package secretbox шифрует короткие секреты для хранения в базе.
+//
+// Используется конвертное шифрование: каждый секрет шифруется своим
+// случайным ключом данных (AES-256-GCM), а ключ данных - ключом шифрования
+// ключей (KEK) из конфига. KEK имеют идентификаторы, поэтому после смены
+// KEK старые значения читаются, пока не будут перешифрованы (Rewrap).
+//
+// Формат значения: enc:v2:<kid>:<зашифрованный ключ данных>:<зашифрованный секрет>.
+// Значения формата enc:v1 (секрет, зашифрованный прямо KEK, без kid)
+// по-прежнему читаются.
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	prefixV1 = "enc:v1:"
+	prefixV2 = "enc:v2:"
+)
+
+// KeySize - длина ключа в байтах.
+const KeySize = 32
+
+var (
+	ErrNotSealed  = errors.New("secretbox: value is not encrypted")
+	ErrUnknownKey = errors.New("secretbox: value is encrypted with an unknown key")
+)
+
+// Keyring хранит KEK по идентификаторам. Новые значения шифруются текущим KEK.
+type Keyring struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// NewKeyring создаёт Keyring. keys должен содержать ключ current.
+func NewKeyring(current string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("secretbox: current key %q is missing", current)
+	}
+
+	k := &Keyring{current: current, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("secretbox: invalid key id %q", id)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("secretbox: key %q: %w", id, err)
+		}
+		k.keys[id] = aead
+	}
+
+	return k, nil
+}
+
+// ParseKey разбирает ключ в base64, как он хранится в конфиге.
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("secretbox: key is not base64: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("secretbox: key must be %d bytes, got %d", KeySize, len(key))
+	}
+
+	return key, nil
+}
+
+// Sealed сообщает, зашифровано ли значение.
+func Sealed(value string) bool {
+	return strings.HasPrefix(value, prefixV1) || strings.HasPrefix(value, prefixV2)
+}
+
+// Seal шифрует секрет новым ключом данных под текущим KEK.
+func (k *Keyring) Seal(plaintext string) (string, error) {
+	dek := make([]byte, KeySize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", err
+	}
+
+	data, err := newAEAD(dek)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(data, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return k.wrap(dek, ciphertext)
+}
+
+// Open расшифровывает значение любым известным KEK.
+func (k *Keyring) Open(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, prefixV2):
+		_, dek, ciphertext, err := k.unwrap(value)
+		if err != nil {
+			return "", err
+		}
+		data, err := newAEAD(dek)
+		if err != nil {
+			return "", err
+		}
+		plaintext, err := open(data, ciphertext, nil)
+		if err != nil {
+			return "", err
+		}
+		return string(plaintext), nil
+
+	case strings.HasPrefix(value, prefixV1):
+		raw, err := decode(strings.TrimPrefix(value, prefixV1))
+		if err != nil {
+			return "", err
+		}
+		// В v1 нет идентификатора ключа: подходит тот, что проходит проверку GCM
+		for _, kek := range k.keys {
+			if plaintext, err := open(kek, raw, nil); err == nil {
+				return string(plaintext), nil
+			}
+		}
+		return "", ErrUnknownKey
+
+	default:
+		return "", ErrNotSealed
+	}
+}
+
+// NeedsRewrap сообщает, что значение не зашифровано текущим KEK в формате v2.
+func (k *Keyring) NeedsRewrap(value string) bool {
+	if !strings.HasPrefix(value, prefixV2) {
+		return true
+	}
+	kid, _, _ := strings.Cut(strings.TrimPrefix(value, prefixV2), ":")
+
+	return kid != k.current
+}
+
+// Rewrap переводит значение под текущий KEK. Для v2 перешифровывается только
+// ключ данных, открытые значения и v1 шифруются заново.
+func (k *Keyring) Rewrap(value string) (string, error) {
+	if !Sealed(value) {
+		return k.Seal(value)
+	}
+	if !strings.HasPrefix(value, prefixV2) {
+		plaintext, err := k.Open(value)
+		if err != nil {
+			return "", err
+		}
+		return k.Seal(plaintext)
+	}
+
+	_, dek, ciphertext, err := k.unwrap(value)
+	if err != nil {
+		return "", err
+	}
+
+	return k.wrap(dek, ciphertext)
+}
+
+// wrap шифрует ключ данных текущим KEK. Идентификатор KEK входит
+// в дополнительные данные GCM, чтобы его нельзя было подменить.
+func (k *Keyring) wrap(dek, ciphertext []byte) (string, error) {
+	wrapped, err := seal(k.keys[k.current], dek, []byte(k.current))
+	if err != nil {
+		return "", err
+	}
+
+	return prefixV2 + k.current + ":" + encode(wrapped) + ":" + encode(ciphertext), nil
+}
+
+func (k *Keyring) unwrap(value string) (kid string, dek, ciphertext []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, prefixV2), ":")
+	if len(parts) != 3 {
+		return "", nil, nil, errors.New("secretbox: malformed value")
+	}
+
+	kek, ok := k.keys[parts[0]]
+	if !ok {
+		return "", nil, nil, fmt.Errorf("%w %q", ErrUnknownKey, parts[0])
+	}
+
+	wrapped, err := decode(parts[1])
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if ciphertext, err = decode(parts[2]); err != nil {
+		return "", nil, nil, err
+	}
+	if dek, err = open(kek, wrapped, []byte(parts[0])); err != nil {
+		return "", nil, nil, err
+	}
+
+	return parts[0], dek, ciphertext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal возвращает nonce и шифротекст одним срезом.
+func seal(aead cipher.AEAD, plaintext, ad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, ad), nil
+}
+
+func open(aead cipher.AEAD, sealed, ad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("secretbox: malformed value")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, ad)
+	if err != nil {
+		return nil, errors.New("secretbox: wrong key or corrupted value")
+	}
+
+	return plaintext, nil
+}
+
+func encode(b []byte) string {
+	return base64.RawStdEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	b, err := base64.RawStdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("secretbox: malformed value")
+	}
+
+	return b, nil
+}
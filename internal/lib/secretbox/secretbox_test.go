@@ -0,0 +1,108 @@
+package secretbox
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func key(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func TestSealOpen(t *testing.T) {
+	ring, err := NewKeyring("1", map[string][]byte{"1": key(1)})
+	require.NoError(t, err)
+
+	sealed, err := ring.Seal("app-secret")
+	require.NoError(t, err)
+	assert.True(t, Sealed(sealed))
+	assert.True(t, strings.HasPrefix(sealed, "enc:v2:1:"))
+	assert.NotContains(t, sealed, "app-secret")
+	assert.False(t, ring.NeedsRewrap(sealed))
+
+	again, _ := ring.Seal("app-secret")
+	assert.NotEqual(t, sealed, again, "data key and nonce are random")
+
+	opened, err := ring.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "app-secret", opened)
+
+	other, _ := NewKeyring("1", map[string][]byte{"1": key(2)})
+	_, err = other.Open(sealed)
+	assert.Error(t, err, "wrong key")
+
+	_, err = ring.Open("app-secret")
+	assert.ErrorIs(t, err, ErrNotSealed)
+}
+
+func TestRotation(t *testing.T) {
+	old, _ := NewKeyring("1", map[string][]byte{"1": key(1)})
+	sealed, _ := old.Seal("app-secret")
+
+	ring, err := NewKeyring("2", map[string][]byte{"1": key(1), "2": key(2)})
+	require.NoError(t, err)
+
+	// Старое значение читается, пока не перешифровано
+	opened, err := ring.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "app-secret", opened)
+	assert.True(t, ring.NeedsRewrap(sealed))
+
+	rewrapped, err := ring.Rewrap(sealed)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rewrapped, "enc:v2:2:"))
+	assert.False(t, ring.NeedsRewrap(rewrapped))
+
+	// После перешифровки старый KEK не нужен
+	onlyNew, _ := NewKeyring("2", map[string][]byte{"2": key(2)})
+	opened, err = onlyNew.Open(rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "app-secret", opened)
+
+	_, err = onlyNew.Open(sealed)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	plain, err := ring.Rewrap("plain-secret")
+	require.NoError(t, err)
+	opened, _ = ring.Open(plain)
+	assert.Equal(t, "plain-secret", opened)
+}
+
+func TestKeyIDIsAuthenticated(t *testing.T) {
+	ring, _ := NewKeyring("1", map[string][]byte{"1": key(1), "2": key(1)})
+	sealed, _ := ring.Seal("app-secret")
+
+	tampered := strings.Replace(sealed, "enc:v2:1:", "enc:v2:2:", 1)
+	_, err := ring.Open(tampered)
+	assert.Error(t, err)
+}
+
+func TestOpenV1(t *testing.T) {
+	// Формат v1: секрет зашифрован прямо KEK, без идентификатора ключа
+	block, _ := aes.NewCipher(key(1))
+	aead, _ := cipher.NewGCM(block)
+	nonce := make([]byte, aead.NonceSize())
+	v1 := "enc:v1:" + base64.RawStdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte("legacy"), nil))
+
+	ring, _ := NewKeyring("2", map[string][]byte{"1": key(1), "2": key(2)})
+	opened, err := ring.Open(v1)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", opened)
+	assert.True(t, ring.NeedsRewrap(v1))
+}
+
+func TestParseKey(t *testing.T) {
+	_, err := ParseKey("c2hvcnQ=")
+	assert.Error(t, err)
+
+	parsed, err := ParseKey("AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=")
+	require.NoError(t, err)
+	assert.Len(t, parsed, KeySize)
+}
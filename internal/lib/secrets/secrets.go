@@ -0,0 +1,106 @@
+// Package secrets подставляет значения секретов по ссылкам вида
+// file:///run/secrets/db_password или env:DB_PASSWORD. Провайдеры
+// подключаются по схеме ссылки, например так можно добавить Vault:
+//
+//	secrets.Register("vault", vaultProvider)
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Provider возвращает значение секрета. ref - ссылка без схемы:
+// для file:///run/secrets/x это /run/secrets/x, для env:NAME - NAME.
+type Provider interface {
+	Secret(ctx context.Context, ref string) (string, error)
+}
+
+// ProviderFunc позволяет использовать функцию как Provider.
+type ProviderFunc func(ctx context.Context, ref string) (string, error)
+
+func (f ProviderFunc) Secret(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// Resolver выбирает провайдера по схеме ссылки.
+type Resolver struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+}
+
+// NewResolver создаёт Resolver с провайдерами file и env.
+func NewResolver() *Resolver {
+	return &Resolver{providers: map[string]Provider{
+		"file": ProviderFunc(fromFile),
+		"env":  ProviderFunc(fromEnv),
+	}}
+}
+
+// Register добавляет или заменяет провайдера для схемы.
+func (r *Resolver) Register(scheme string, p Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.providers[scheme] = p
+}
+
+// Resolve возвращает значение секрета, если value - ссылка на него,
+// и value без изменений, если схема не зарегистрирована
+// (так postgres://... остаётся обычной строкой).
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	scheme, ref, ok := strings.Cut(value, ":")
+	if !ok {
+		return value, nil
+	}
+
+	r.mu.RLock()
+	p, ok := r.providers[scheme]
+	r.mu.RUnlock()
+	if !ok {
+		return value, nil
+	}
+
+	secret, err := p.Secret(ctx, strings.TrimPrefix(ref, "//"))
+	if err != nil {
+		// Саму ссылку показывать можно, значение секрета в ошибку не попадает
+		return "", fmt.Errorf("secrets: failed to resolve %s: %w", value, err)
+	}
+
+	return secret, nil
+}
+
+var defaultResolver = NewResolver()
+
+// Register добавляет провайдера в Resolver по умолчанию, которым пользуется конфиг.
+func Register(scheme string, p Provider) {
+	defaultResolver.Register(scheme, p)
+}
+
+// Resolve - Resolver.Resolve для Resolver по умолчанию.
+func Resolve(ctx context.Context, value string) (string, error) {
+	return defaultResolver.Resolve(ctx, value)
+}
+
+// fromFile читает секрет из файла, например Docker или Kubernetes secret.
+// Перевод строки в конце файла отбрасывается.
+func fromFile(_ context.Context, path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(raw), "\r\n"), nil
+}
+
+func fromEnv(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	return value, nil
+}
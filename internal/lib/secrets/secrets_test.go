@@ -0,0 +1,46 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db_password")
+	require.NoError(t, os.WriteFile(path, []byte("s3cret\n"), 0o600))
+	t.Setenv("SSO_TEST_SECRET", "from-env")
+
+	r := NewResolver()
+	r.Register("vault", ProviderFunc(func(_ context.Context, ref string) (string, error) {
+		return "vault:" + ref, nil
+	}))
+
+	tests := map[string]string{
+		"file://" + path:        "s3cret",
+		"env:SSO_TEST_SECRET":   "from-env",
+		"vault://kv/sso#dsn":    "vault:kv/sso#dsn",
+		"postgres://u:p@db/sso": "postgres://u:p@db/sso",
+		"plain value":           "plain value",
+		"":                      "",
+	}
+	for ref, want := range tests {
+		got, err := r.Resolve(context.Background(), ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, want, got, ref)
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	r := NewResolver()
+
+	_, err := r.Resolve(context.Background(), "env:SSO_TEST_MISSING")
+	assert.ErrorContains(t, err, "env:SSO_TEST_MISSING")
+
+	_, err = r.Resolve(context.Background(), "file:///nonexistent/secret")
+	assert.Error(t, err)
+}
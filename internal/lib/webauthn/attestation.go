@@ -0,0 +1,71 @@
+package webauthn
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+// verifyAttestation проверяет заявление об аттестации форматов none и packed.
+// Цепочка сертификатов packed не сверяется с корнями производителей:
+// сервис запрашивает attestation "none" и не ограничивает модели
+// аутентификаторов, проверяется только подпись.
+func verifyAttestation(format string, stmt map[any]any, authData []byte, clientDataHash []byte, credKey publicKey) error {
+	switch format {
+	case "none":
+		if len(stmt) != 0 {
+			return fmt.Errorf("%w: none attestation with a statement", ErrVerification)
+		}
+		return nil
+
+	case "packed":
+		alg, _ := stmt["alg"].(int64)
+		sig, _ := stmt["sig"].([]byte)
+		if len(sig) == 0 {
+			return fmt.Errorf("%w: packed attestation without sig", ErrVerification)
+		}
+		signed := append(append([]byte(nil), authData...), clientDataHash...)
+
+		chain, hasChain := stmt["x5c"].([]any)
+		if !hasChain {
+			// Самоаттестация: подписано ключом самих учётных данных
+			if alg != credKey.alg {
+				return fmt.Errorf("%w: packed self attestation alg %d does not match key alg %d", ErrVerification, alg, credKey.alg)
+			}
+			if !credKey.verify(signed, sig) {
+				return fmt.Errorf("%w: bad packed self attestation signature", ErrVerification)
+			}
+			return nil
+		}
+
+		if len(chain) == 0 {
+			return fmt.Errorf("%w: empty x5c", ErrVerification)
+		}
+		leaf, _ := chain[0].([]byte)
+		cert, err := x509.ParseCertificate(leaf)
+		if err != nil {
+			return fmt.Errorf("%w: attestation certificate: %w", ErrVerification, err)
+		}
+		if cert.IsCA {
+			return fmt.Errorf("%w: attestation certificate is a CA", ErrVerification)
+		}
+
+		var sigAlg x509.SignatureAlgorithm
+		switch alg {
+		case AlgES256:
+			sigAlg = x509.ECDSAWithSHA256
+		case AlgRS256:
+			sigAlg = x509.SHA256WithRSA
+		case AlgEdDSA:
+			sigAlg = x509.PureEd25519
+		default:
+			return fmt.Errorf("%w: unsupported attestation alg %d", ErrVerification, alg)
+		}
+		if err := cert.CheckSignature(sigAlg, signed, sig); err != nil {
+			return fmt.Errorf("%w: bad packed attestation signature: %w", ErrVerification, err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: unsupported attestation format %q", ErrVerification, format)
+	}
+}
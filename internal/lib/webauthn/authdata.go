@@ -0,0 +1,77 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Флаги authenticatorData
+const (
+	flagUserPresent      = 0x01
+	flagUserVerified     = 0x04
+	flagAttestedData     = 0x40
+	flagExtensionData    = 0x80
+	maxCredentialIDBytes = 1023
+)
+
+var errMalformedAuthData = errors.New("malformed authenticator data")
+
+// authenticatorData - данные аутентификатора, которые он подписывает.
+type authenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+
+	// Есть только при регистрации (флаг AT)
+	aaguid        []byte
+	credentialID  []byte
+	credentialKey []byte // COSE_Key как есть
+}
+
+func parseAuthenticatorData(data []byte) (authenticatorData, error) {
+	if len(data) < 37 {
+		return authenticatorData{}, fmt.Errorf("%w: too short", errMalformedAuthData)
+	}
+
+	ad := authenticatorData{
+		rpIDHash:  data[:32],
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[37:]
+
+	if ad.flags&flagAttestedData != 0 {
+		if len(rest) < 18 {
+			return authenticatorData{}, fmt.Errorf("%w: truncated credential data", errMalformedAuthData)
+		}
+		ad.aaguid = rest[:16]
+		idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLen == 0 || idLen > maxCredentialIDBytes || len(rest) < idLen {
+			return authenticatorData{}, fmt.Errorf("%w: bad credential ID length", errMalformedAuthData)
+		}
+		ad.credentialID, rest = rest[:idLen], rest[idLen:]
+
+		// Длину ключа знает только CBOR
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return authenticatorData{}, fmt.Errorf("%w: credential key: %w", errMalformedAuthData, err)
+		}
+		ad.credentialKey, rest = rest[:len(rest)-len(after)], after
+	}
+
+	if ad.flags&flagExtensionData != 0 {
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return authenticatorData{}, fmt.Errorf("%w: extensions: %w", errMalformedAuthData, err)
+		}
+		rest = after
+	}
+
+	if len(rest) != 0 {
+		return authenticatorData{}, fmt.Errorf("%w: trailing data", errMalformedAuthData)
+	}
+
+	return ad, nil
+}
@@ -0,0 +1,155 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errMalformedCBOR = errors.New("malformed CBOR")
+
+// maxCBORDepth ограничивает вложенность, чтобы чужие данные не исчерпали стек.
+const maxCBORDepth = 16
+
+// decodeCBOR разбирает одно значение CBOR (RFC 8949) в начале data и
+// возвращает его вместе с остатком. Поддерживается только то, что
+// встречается в WebAuthn: целые, байты, строки, массивы, карты, true/false/null.
+// Целые возвращаются как int64, карты - как map[any]any с ключами int64 или string.
+func decodeCBOR(data []byte) (value any, rest []byte, err error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (any, []byte, error) {
+	if depth > maxCBORDepth {
+		return nil, nil, fmt.Errorf("%w: nesting is too deep", errMalformedCBOR)
+	}
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("%w: unexpected end of data", errMalformedCBOR)
+	}
+
+	major, info := data[0]>>5, data[0]&0x1f
+	data = data[1:]
+
+	// Простые значения не несут длины
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, data, nil
+		case 21:
+			return true, data, nil
+		case 22, 23:
+			return nil, data, nil
+		default:
+			return nil, nil, fmt.Errorf("%w: unsupported simple value %d", errMalformedCBOR, info)
+		}
+	}
+
+	arg, data, err := cborArgument(info, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, nil, fmt.Errorf("%w: integer overflow", errMalformedCBOR)
+		}
+		return int64(arg), data, nil
+
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, nil, fmt.Errorf("%w: integer overflow", errMalformedCBOR)
+		}
+		return -1 - int64(arg), data, nil
+
+	case 2, 3:
+		if arg > uint64(len(data)) {
+			return nil, nil, fmt.Errorf("%w: unexpected end of data", errMalformedCBOR)
+		}
+		if major == 2 {
+			return append([]byte(nil), data[:arg]...), data[arg:], nil
+		}
+		return string(data[:arg]), data[arg:], nil
+
+	case 4:
+		// Каждый элемент занимает хотя бы байт, иначе длина ложная
+		if arg > uint64(len(data)) {
+			return nil, nil, fmt.Errorf("%w: unexpected end of data", errMalformedCBOR)
+		}
+		items := make([]any, 0, arg)
+		for range arg {
+			var item any
+			if item, data, err = decodeCBORItem(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, data, nil
+
+	case 5:
+		if arg > uint64(len(data))/2 {
+			return nil, nil, fmt.Errorf("%w: unexpected end of data", errMalformedCBOR)
+		}
+		m := make(map[any]any, arg)
+		for range arg {
+			var key, value any
+			if key, data, err = decodeCBORItem(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, fmt.Errorf("%w: unsupported map key %T", errMalformedCBOR, key)
+			}
+			if value, data, err = decodeCBORItem(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+			if _, dup := m[key]; dup {
+				return nil, nil, fmt.Errorf("%w: duplicate map key %v", errMalformedCBOR, key)
+			}
+			m[key] = value
+		}
+		return m, data, nil
+
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported major type %d", errMalformedCBOR, major)
+	}
+}
+
+// cborArgument читает аргумент заголовка: значение, длину или число элементов.
+// Неопределённая длина (info 31) в WebAuthn не используется и не поддерживается.
+func cborArgument(info byte, data []byte) (uint64, []byte, error) {
+	var size int
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, nil, fmt.Errorf("%w: unsupported length encoding %d", errMalformedCBOR, info)
+	}
+	if len(data) < size {
+		return 0, nil, fmt.Errorf("%w: unexpected end of data", errMalformedCBOR)
+	}
+
+	var arg uint64
+	switch size {
+	case 1:
+		arg = uint64(data[0])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(data))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(data))
+	case 8:
+		arg = binary.BigEndian.Uint64(data)
+	}
+
+	return arg, data[size:], nil
+}
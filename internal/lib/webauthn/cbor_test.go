@@ -0,0 +1,39 @@
+package webauthn
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCBOR(t *testing.T) {
+	// {1: 2, 3: -7, "a": h'0102', "b": [true, null]}, затем лишний байт
+	data := []byte{0xa4, 0x01, 0x02, 0x03, 0x26, 0x61, 'a', 0x42, 0x01, 0x02, 0x61, 'b', 0x82, 0xf5, 0xf6, 0xff}
+
+	value, rest, err := decodeCBOR(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[any]any{
+		int64(1): int64(2),
+		int64(3): int64(-7),
+		"a":      []byte{1, 2},
+		"b":      []any{true, nil},
+	}, value)
+	assert.Equal(t, []byte{0xff}, rest)
+
+	for name, bad := range map[string][]byte{
+		"empty":         {},
+		"truncated":     {0x42, 0x01},
+		"long length":   {0x5b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"indefinite":    {0x5f},
+		"huge array":    {0x9a, 0xff, 0xff, 0xff, 0xff},
+		"duplicate key": {0xa2, 0x01, 0x01, 0x01, 0x02},
+		"bytes map key": {0xa1, 0x41, 0x00, 0x01},
+		"float":         {0xf9, 0x3c, 0x00},
+		"too deep":      append(bytes.Repeat([]byte{0x81}, maxCBORDepth+2), 0x00),
+	} {
+		_, _, err := decodeCBOR(bad)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,117 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Алгоритмы COSE (RFC 9053), которые принимает сервис, в порядке предпочтения.
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// SupportedAlgorithms передаются браузеру в pubKeyCredParams.
+var SupportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// Параметры COSE_Key
+const (
+	coseKty = 1
+	coseAlg = 3
+	coseCrv = -1
+	coseX   = -2 // у RSA - модуль n
+	coseY   = -3 // у RSA - экспонента e
+
+	ktyOKP = 1
+	ktyEC2 = 2
+	ktyRSA = 3
+
+	crvP256    = 1
+	crvEd25519 = 6
+)
+
+var errUnsupportedKey = errors.New("unsupported public key")
+
+// publicKey - разобранный открытый ключ COSE и его алгоритм.
+type publicKey struct {
+	alg int64
+	key crypto.PublicKey
+}
+
+// parseCOSEKey разбирает открытый ключ учётных данных в формате COSE_Key.
+func parseCOSEKey(raw []byte) (publicKey, error) {
+	value, rest, err := decodeCBOR(raw)
+	if err != nil {
+		return publicKey{}, err
+	}
+	if len(rest) != 0 {
+		return publicKey{}, fmt.Errorf("%w: trailing data", errMalformedCBOR)
+	}
+
+	return coseKeyFromMap(value)
+}
+
+func coseKeyFromMap(value any) (publicKey, error) {
+	m, ok := value.(map[any]any)
+	if !ok {
+		return publicKey{}, fmt.Errorf("%w: key is not a map", errUnsupportedKey)
+	}
+
+	kty, _ := m[int64(coseKty)].(int64)
+	alg, _ := m[int64(coseAlg)].(int64)
+	crv, _ := m[int64(coseCrv)].(int64)
+	x, _ := m[int64(coseX)].([]byte)
+	y, _ := m[int64(coseY)].([]byte)
+
+	switch {
+	case kty == ktyEC2 && alg == AlgES256 && crv == crvP256:
+		if len(x) != 32 || len(y) != 32 {
+			return publicKey{}, fmt.Errorf("%w: bad P-256 coordinates", errUnsupportedKey)
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return publicKey{}, fmt.Errorf("%w: point is not on P-256", errUnsupportedKey)
+		}
+		return publicKey{alg: alg, key: key}, nil
+
+	case kty == ktyOKP && alg == AlgEdDSA && crv == crvEd25519:
+		if len(x) != ed25519.PublicKeySize {
+			return publicKey{}, fmt.Errorf("%w: bad Ed25519 key", errUnsupportedKey)
+		}
+		return publicKey{alg: alg, key: ed25519.PublicKey(x)}, nil
+
+	case kty == ktyRSA && alg == AlgRS256:
+		n, e := new(big.Int).SetBytes(x), new(big.Int).SetBytes(y)
+		if n.BitLen() < 2048 || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return publicKey{}, fmt.Errorf("%w: bad RSA key", errUnsupportedKey)
+		}
+		return publicKey{alg: alg, key: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+
+	default:
+		return publicKey{}, fmt.Errorf("%w: kty %d, alg %d, crv %d", errUnsupportedKey, kty, alg, crv)
+	}
+}
+
+// verify проверяет подпись data. Подписи ES256 в WebAuthn - в DER.
+func (k publicKey) verify(data, sig []byte) bool {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, data, sig)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	default:
+		return false
+	}
+}
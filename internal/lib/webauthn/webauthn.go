@@ -0,0 +1,398 @@
+// Package webauthn проверяет регистрацию и вход по ключам доступа (passkeys)
+// по спецификации WebAuthn Level 2. Сервер выдаёт параметры для
+// navigator.credentials.create/get в JSON (PublicKeyCredential*OptionsJSON)
+// и принимает ответ браузера в том же виде (PublicKeyCredential.toJSON).
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrVerification - ответ аутентификатора не прошёл проверку.
+	ErrVerification = errors.New("webauthn verification failed")
+	// ErrClonedAuthenticator - счётчик подписей не вырос: похоже, ключ скопирован.
+	ErrClonedAuthenticator = errors.New("authenticator sign counter did not increase")
+)
+
+const challengeBytes = 32
+
+// RelyingParty - проверяющая сторона: домен (RP ID), на котором работают
+// ключи, и точные origin страниц, с которых разрешены регистрация и вход.
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// User - владелец регистрируемого ключа. ID - непрозрачный идентификатор
+// (user handle), аутентификатор возвращает его при входе.
+type User struct {
+	ID          []byte
+	Name        string
+	DisplayName string
+}
+
+// Credential - зарегистрированный ключ доступа.
+type Credential struct {
+	ID         []byte
+	PublicKey  []byte // COSE_Key
+	Algorithm  int64
+	SignCount  uint32
+	Transports []string
+	AAGUID     []byte
+}
+
+// Assertion - разобранный ответ на navigator.credentials.get.
+type Assertion struct {
+	CredentialID []byte
+	UserHandle   []byte // пусто, если аутентификатор его не вернул
+
+	clientDataJSON []byte
+	authData       []byte
+	signature      []byte
+}
+
+// NewChallenge возвращает случайный вызов для одной церемонии.
+func NewChallenge() []byte {
+	b := make([]byte, challengeBytes)
+	if _, err := rand.Read(b); err != nil {
+		panic("webauthn: " + err.Error())
+	}
+
+	return b
+}
+
+// Base64 - base64url без выравнивания, как в JSON-форме WebAuthn.
+// При разборе выравнивание допускается.
+type Base64 []byte
+
+func (b Base64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *Base64) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return err
+	}
+	*b = raw
+
+	return nil
+}
+
+type credentialParam struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type credentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         Base64   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// CreationOptions - PublicKeyCredentialCreationOptionsJSON.
+type CreationOptions struct {
+	RP struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"rp"`
+	User struct {
+		ID          Base64 `json:"id"`
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+	} `json:"user"`
+	Challenge              Base64                 `json:"challenge"`
+	PubKeyCredParams       []credentialParam      `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []credentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection struct {
+		ResidentKey        string `json:"residentKey"`
+		RequireResidentKey bool   `json:"requireResidentKey"`
+		UserVerification   string `json:"userVerification"`
+	} `json:"authenticatorSelection"`
+	Attestation string `json:"attestation"`
+}
+
+// RequestOptions - PublicKeyCredentialRequestOptionsJSON.
+type RequestOptions struct {
+	Challenge        Base64                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []credentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// CreationOptions возвращает параметры регистрации нового ключа. Ключи
+// из exclude аутентификатор не даст зарегистрировать повторно.
+// Создаётся ключ с проверкой пользователя (PIN, биометрия), который
+// обязательно хранится на аутентификаторе: вход идёт без списка ключей.
+func (rp RelyingParty) CreationOptions(challenge []byte, user User, exclude []Credential, timeout time.Duration) CreationOptions {
+	var o CreationOptions
+	o.RP.ID, o.RP.Name = rp.ID, rp.Name
+	o.User.ID, o.User.Name, o.User.DisplayName = user.ID, user.Name, user.DisplayName
+	if o.User.DisplayName == "" {
+		o.User.DisplayName = user.Name
+	}
+	o.Challenge = challenge
+	for _, alg := range SupportedAlgorithms {
+		o.PubKeyCredParams = append(o.PubKeyCredParams, credentialParam{Type: "public-key", Alg: alg})
+	}
+	o.Timeout = timeout.Milliseconds()
+	o.ExcludeCredentials = descriptors(exclude)
+	o.AuthenticatorSelection.ResidentKey = "required"
+	o.AuthenticatorSelection.RequireResidentKey = true
+	o.AuthenticatorSelection.UserVerification = "required"
+	o.Attestation = "none"
+
+	return o
+}
+
+// RequestOptions возвращает параметры входа. Пустой allow - вход
+// ключом, который хранится на аутентификаторе (discoverable credential).
+func (rp RelyingParty) RequestOptions(challenge []byte, allow []Credential, timeout time.Duration) RequestOptions {
+	return RequestOptions{
+		Challenge:        challenge,
+		Timeout:          timeout.Milliseconds(),
+		RPID:             rp.ID,
+		AllowCredentials: descriptors(allow),
+		UserVerification: "required",
+	}
+}
+
+func descriptors(creds []Credential) []credentialDescriptor {
+	list := make([]credentialDescriptor, 0, len(creds))
+	for _, c := range creds {
+		list = append(list, credentialDescriptor{Type: "public-key", ID: c.ID, Transports: c.Transports})
+	}
+
+	return list
+}
+
+// registrationResponse - RegistrationResponseJSON.
+type registrationResponse struct {
+	ID       string `json:"id"`
+	RawID    Base64 `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    Base64   `json:"clientDataJSON"`
+		AttestationObject Base64   `json:"attestationObject"`
+		Transports        []string `json:"transports"`
+	} `json:"response"`
+}
+
+// authenticationResponse - AuthenticationResponseJSON.
+type authenticationResponse struct {
+	ID       string `json:"id"`
+	RawID    Base64 `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    Base64 `json:"clientDataJSON"`
+		AuthenticatorData Base64 `json:"authenticatorData"`
+		Signature         Base64 `json:"signature"`
+		UserHandle        Base64 `json:"userHandle"`
+	} `json:"response"`
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   Base64 `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// VerifyRegistration проверяет ответ navigator.credentials.create
+// на вызов challenge и возвращает новый ключ.
+func (rp RelyingParty) VerifyRegistration(challenge []byte, response []byte) (Credential, error) {
+	var resp registrationResponse
+	if err := json.Unmarshal(response, &resp); err != nil {
+		return Credential{}, fmt.Errorf("%w: bad response: %w", ErrVerification, err)
+	}
+	if resp.Type != "public-key" {
+		return Credential{}, fmt.Errorf("%w: credential type %q", ErrVerification, resp.Type)
+	}
+
+	if err := rp.checkClientData(resp.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return Credential{}, err
+	}
+
+	value, rest, err := decodeCBOR(resp.Response.AttestationObject)
+	if err != nil || len(rest) != 0 {
+		return Credential{}, fmt.Errorf("%w: bad attestation object", ErrVerification)
+	}
+	obj, _ := value.(map[any]any)
+	format, _ := obj["fmt"].(string)
+	stmt, _ := obj["attStmt"].(map[any]any)
+	rawAuthData, _ := obj["authData"].([]byte)
+
+	ad, err := rp.checkAuthData(rawAuthData)
+	if err != nil {
+		return Credential{}, err
+	}
+	if ad.flags&flagAttestedData == 0 {
+		return Credential{}, fmt.Errorf("%w: no attested credential data", ErrVerification)
+	}
+	if len(resp.RawID) != 0 && !bytes.Equal(resp.RawID, ad.credentialID) {
+		return Credential{}, fmt.Errorf("%w: rawId does not match authenticator data", ErrVerification)
+	}
+
+	key, err := parseCOSEKey(ad.credentialKey)
+	if err != nil {
+		return Credential{}, fmt.Errorf("%w: %w", ErrVerification, err)
+	}
+
+	clientDataHash := sha256.Sum256(resp.Response.ClientDataJSON)
+	if err := verifyAttestation(format, stmt, rawAuthData, clientDataHash[:], key); err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{
+		ID:         ad.credentialID,
+		PublicKey:  ad.credentialKey,
+		Algorithm:  key.alg,
+		SignCount:  ad.signCount,
+		Transports: resp.Response.Transports,
+		AAGUID:     ad.aaguid,
+	}, nil
+}
+
+// ParseAssertion разбирает ответ navigator.credentials.get, чтобы по ID
+// найти ключ. Подпись проверяет VerifyAssertion.
+func ParseAssertion(response []byte) (Assertion, error) {
+	var resp authenticationResponse
+	if err := json.Unmarshal(response, &resp); err != nil {
+		return Assertion{}, fmt.Errorf("%w: bad response: %w", ErrVerification, err)
+	}
+	if resp.Type != "public-key" {
+		return Assertion{}, fmt.Errorf("%w: credential type %q", ErrVerification, resp.Type)
+	}
+	if len(resp.RawID) == 0 {
+		return Assertion{}, fmt.Errorf("%w: no credential ID", ErrVerification)
+	}
+
+	return Assertion{
+		CredentialID:   resp.RawID,
+		UserHandle:     resp.Response.UserHandle,
+		clientDataJSON: resp.Response.ClientDataJSON,
+		authData:       resp.Response.AuthenticatorData,
+		signature:      resp.Response.Signature,
+	}, nil
+}
+
+// VerifyAssertion проверяет подпись входа ключом cred на вызов challenge
+// и возвращает новое значение счётчика подписей.
+func (rp RelyingParty) VerifyAssertion(challenge []byte, a Assertion, cred Credential) (uint32, error) {
+	if !bytes.Equal(a.CredentialID, cred.ID) {
+		return 0, fmt.Errorf("%w: credential ID mismatch", ErrVerification)
+	}
+
+	if err := rp.checkClientData(a.clientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+
+	ad, err := rp.checkAuthData(a.authData)
+	if err != nil {
+		return 0, err
+	}
+
+	key, err := parseCOSEKey(cred.PublicKey)
+	if err != nil {
+		return 0, fmt.Errorf("%w: stored key: %w", ErrVerification, err)
+	}
+	clientDataHash := sha256.Sum256(a.clientDataJSON)
+	signed := append(append([]byte(nil), a.authData...), clientDataHash[:]...)
+	if !key.verify(signed, a.signature) {
+		return 0, fmt.Errorf("%w: bad signature", ErrVerification)
+	}
+
+	// Синхронизируемые ключи всегда присылают 0, у остальных счётчик растёт
+	if (ad.signCount != 0 || cred.SignCount != 0) && ad.signCount <= cred.SignCount {
+		return 0, ErrClonedAuthenticator
+	}
+
+	return ad.signCount, nil
+}
+
+func (rp RelyingParty) checkClientData(raw []byte, typ string, challenge []byte) error {
+	var cd clientData
+	if err := json.Unmarshal(raw, &cd); err != nil {
+		return fmt.Errorf("%w: bad client data: %w", ErrVerification, err)
+	}
+	if cd.Type != typ {
+		return fmt.Errorf("%w: client data type %q, want %q", ErrVerification, cd.Type, typ)
+	}
+	if len(challenge) == 0 || !bytes.Equal(cd.Challenge, challenge) {
+		return fmt.Errorf("%w: challenge mismatch", ErrVerification)
+	}
+	if !slices.Contains(rp.Origins, cd.Origin) {
+		return fmt.Errorf("%w: origin %q is not allowed", ErrVerification, cd.Origin)
+	}
+	if cd.CrossOrigin {
+		return fmt.Errorf("%w: cross-origin request", ErrVerification)
+	}
+
+	return nil
+}
+
+func (rp RelyingParty) checkAuthData(raw []byte) (authenticatorData, error) {
+	ad, err := parseAuthenticatorData(raw)
+	if err != nil {
+		return authenticatorData{}, fmt.Errorf("%w: %w", ErrVerification, err)
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(ad.rpIDHash, rpIDHash[:]) {
+		return authenticatorData{}, fmt.Errorf("%w: RP ID mismatch", ErrVerification)
+	}
+	if ad.flags&flagUserPresent == 0 {
+		return authenticatorData{}, fmt.Errorf("%w: user not present", ErrVerification)
+	}
+	if ad.flags&flagUserVerified == 0 {
+		return authenticatorData{}, fmt.Errorf("%w: user not verified", ErrVerification)
+	}
+
+	return ad, nil
+}
+
+// Validate проверяет настройки: RP ID - домен без схемы и порта, каждый
+// origin - https (http только для localhost) на этом домене или его поддомене.
+func (rp RelyingParty) Validate() error {
+	if rp.ID == "" || strings.ContainsAny(rp.ID, ":/ ") || rp.ID != strings.ToLower(rp.ID) {
+		return fmt.Errorf("rp_id must be a lowercase domain, got %q", rp.ID)
+	}
+	if len(rp.Origins) == 0 {
+		return errors.New("at least one origin is required")
+	}
+
+	for _, origin := range rp.Origins {
+		u, err := url.Parse(origin)
+		if err != nil || u.Host == "" || u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return fmt.Errorf("origin %q must look like https://example.com[:port]", origin)
+		}
+		host := u.Hostname()
+		if u.Scheme != "https" && !(u.Scheme == "http" && host == "localhost") {
+			return fmt.Errorf("origin %q must use https (http is allowed only for localhost)", origin)
+		}
+		if host != rp.ID && !strings.HasSuffix(host, "."+rp.ID) {
+			return fmt.Errorf("origin %q is not on rp_id %q or its subdomain", origin, rp.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package webauthn_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/webauthn"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/webauthn/webauthntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rp = webauthn.RelyingParty{ID: "example.com", Name: "Example", Origins: []string{"https://login.example.com"}}
+
+func register(t *testing.T, auth *webauthntest.Authenticator) webauthn.Credential {
+	t.Helper()
+
+	challenge := webauthn.NewChallenge()
+	opts, err := json.Marshal(rp.CreationOptions(challenge, webauthn.User{ID: []byte("42"), Name: "alice@example.com"}, nil, time.Minute))
+	require.NoError(t, err)
+
+	resp, err := auth.Create(opts)
+	require.NoError(t, err)
+
+	cred, err := rp.VerifyRegistration(challenge, resp)
+	require.NoError(t, err)
+
+	return cred
+}
+
+func login(t *testing.T, auth *webauthntest.Authenticator, allow []webauthn.Credential) ([]byte, webauthn.Assertion) {
+	t.Helper()
+
+	challenge := webauthn.NewChallenge()
+	opts, err := json.Marshal(rp.RequestOptions(challenge, allow, time.Minute))
+	require.NoError(t, err)
+
+	resp, err := auth.Get(opts)
+	require.NoError(t, err)
+
+	assertion, err := webauthn.ParseAssertion(resp)
+	require.NoError(t, err)
+
+	return challenge, assertion
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	for _, packed := range []bool{false, true} {
+		auth := webauthntest.New("https://login.example.com")
+		auth.Packed = packed
+
+		cred := register(t, auth)
+		assert.Equal(t, webauthn.AlgES256, cred.Algorithm)
+		assert.Equal(t, []string{"internal", "hybrid"}, cred.Transports)
+
+		// Вход без allowCredentials - ключ с аутентификатора
+		challenge, assertion := login(t, auth, nil)
+		assert.Equal(t, cred.ID, assertion.CredentialID)
+		assert.Equal(t, []byte("42"), assertion.UserHandle)
+
+		count, err := rp.VerifyAssertion(challenge, assertion, cred)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), count)
+
+		// Ответ привязан к своему вызову
+		_, err = rp.VerifyAssertion(webauthn.NewChallenge(), assertion, cred)
+		assert.ErrorIs(t, err, webauthn.ErrVerification)
+	}
+}
+
+func TestVerifyRegistration_WrongOrigin(t *testing.T) {
+	auth := webauthntest.New("https://evil.example.net")
+
+	challenge := webauthn.NewChallenge()
+	opts, err := json.Marshal(rp.CreationOptions(challenge, webauthn.User{ID: []byte("1"), Name: "bob"}, nil, time.Minute))
+	require.NoError(t, err)
+	resp, err := auth.Create(opts)
+	require.NoError(t, err)
+
+	_, err = rp.VerifyRegistration(challenge, resp)
+	assert.ErrorIs(t, err, webauthn.ErrVerification)
+	assert.ErrorContains(t, err, "origin")
+}
+
+func TestVerifyAssertion_Counter(t *testing.T) {
+	auth := webauthntest.New("https://login.example.com")
+	cred := register(t, auth)
+
+	challenge, assertion := login(t, auth, []webauthn.Credential{cred})
+	count, err := rp.VerifyAssertion(challenge, assertion, cred)
+	require.NoError(t, err)
+
+	// Счётчик не вырос по сравнению с сохранённым - копия ключа
+	cred.SignCount = count + 5
+	challenge, assertion = login(t, auth, []webauthn.Credential{cred})
+	_, err = rp.VerifyAssertion(challenge, assertion, cred)
+	assert.ErrorIs(t, err, webauthn.ErrClonedAuthenticator)
+
+	// Синхронизируемые ключи всегда присылают 0
+	synced := webauthntest.New("https://login.example.com")
+	synced.StaticCounter = true
+	cred = register(t, synced)
+	for range 2 {
+		challenge, assertion = login(t, synced, nil)
+		_, err = rp.VerifyAssertion(challenge, assertion, cred)
+		require.NoError(t, err)
+	}
+}
+
+func TestVerifyAssertion_OtherKey(t *testing.T) {
+	auth := webauthntest.New("https://login.example.com")
+	cred := register(t, auth)
+	other := register(t, webauthntest.New("https://login.example.com"))
+
+	challenge, assertion := login(t, auth, nil)
+	other.ID = cred.ID
+	_, err := rp.VerifyAssertion(challenge, assertion, other)
+	assert.ErrorIs(t, err, webauthn.ErrVerification)
+}
+
+func TestRelyingParty_Validate(t *testing.T) {
+	assert.NoError(t, rp.Validate())
+	assert.NoError(t, webauthn.RelyingParty{ID: "localhost", Origins: []string{"http://localhost:3000"}}.Validate())
+
+	for _, bad := range []webauthn.RelyingParty{
+		{ID: "", Origins: []string{"https://example.com"}},
+		{ID: "https://example.com", Origins: []string{"https://example.com"}},
+		{ID: "example.com"},
+		{ID: "example.com", Origins: []string{"http://example.com"}},
+		{ID: "example.com", Origins: []string{"https://example.org"}},
+		{ID: "example.com", Origins: []string{"https://notexample.com"}},
+		{ID: "example.com", Origins: []string{"https://example.com/login"}},
+	} {
+		assert.Error(t, bad.Validate(), "%+v", bad)
+	}
+}
@@ -0,0 +1,263 @@
+// Package webauthntest - программный аутентификатор для тестов регистрации
+// и входа по ключам доступа без настоящего устройства и браузера.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/webauthn"
+)
+
+// Authenticator ведёт себя как платформенный аутентификатор в браузере
+// на странице Origin: создаёт ключи P-256 и подписывает входы.
+// Проверка пользователя всегда считается пройденной.
+type Authenticator struct {
+	Origin string
+	// Packed включает самоаттестацию packed вместо none.
+	Packed bool
+	// StaticCounter не увеличивает счётчик подписей, как синхронизируемые ключи.
+	StaticCounter bool
+
+	creds []*credential
+}
+
+type credential struct {
+	id         []byte
+	key        *ecdsa.PrivateKey
+	rpID       string
+	userHandle []byte
+	signCount  uint32
+}
+
+// New создаёт аутентификатор для страниц origin.
+func New(origin string) *Authenticator {
+	return &Authenticator{Origin: origin}
+}
+
+// Create отвечает на параметры navigator.credentials.create в JSON
+// так же, как PublicKeyCredential.toJSON в браузере.
+func (a *Authenticator) Create(options []byte) ([]byte, error) {
+	var opts webauthn.CreationOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return nil, err
+	}
+	for _, excluded := range opts.ExcludeCredentials {
+		if a.find(opts.RP.ID, excluded.ID) != nil {
+			return nil, errors.New("webauthntest: credential is already registered")
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	cred := &credential{id: randomBytes(16), key: key, rpID: opts.RP.ID, userHandle: opts.User.ID}
+
+	clientData := a.clientData("webauthn.create", opts.Challenge)
+
+	var authData []byte
+	authData = append(authData, rpIDHash(opts.RP.ID)...)
+	authData = append(authData, 0x01|0x04|0x40) // UP, UV, AT
+	authData = binary.BigEndian.AppendUint32(authData, 0)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(cred.id)))
+	authData = append(authData, cred.id...)
+	authData = append(authData, coseKey(&key.PublicKey)...)
+
+	format, stmt := "none", cborMap{}
+	if a.Packed {
+		sig, err := sign(key, authData, clientData)
+		if err != nil {
+			return nil, err
+		}
+		format, stmt = "packed", cborMap{{"alg", webauthn.AlgES256}, {"sig", sig}}
+	}
+	attestation := encodeCBOR(cborMap{{"fmt", format}, {"attStmt", stmt}, {"authData", authData}})
+
+	a.creds = append(a.creds, cred)
+
+	return json.Marshal(map[string]any{
+		"id":    b64(cred.id),
+		"rawId": b64(cred.id),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    b64(clientData),
+			"attestationObject": b64(attestation),
+			"transports":        []string{"internal", "hybrid"},
+		},
+		"authenticatorAttachment": "platform",
+		"clientExtensionResults":  map[string]any{},
+	})
+}
+
+// Get отвечает на параметры navigator.credentials.get в JSON. Без
+// allowCredentials используется первый ключ этого RP ID.
+func (a *Authenticator) Get(options []byte) ([]byte, error) {
+	var opts webauthn.RequestOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return nil, err
+	}
+
+	var cred *credential
+	for _, allowed := range opts.AllowCredentials {
+		if cred = a.find(opts.RPID, allowed.ID); cred != nil {
+			break
+		}
+	}
+	if len(opts.AllowCredentials) == 0 {
+		for _, c := range a.creds {
+			if c.rpID == opts.RPID {
+				cred = c
+				break
+			}
+		}
+	}
+	if cred == nil {
+		return nil, errors.New("webauthntest: no credential for the request")
+	}
+
+	if !a.StaticCounter {
+		cred.signCount++
+	}
+	clientData := a.clientData("webauthn.get", opts.Challenge)
+
+	var authData []byte
+	authData = append(authData, rpIDHash(opts.RPID)...)
+	authData = append(authData, 0x01|0x04) // UP, UV
+	authData = binary.BigEndian.AppendUint32(authData, cred.signCount)
+
+	sig, err := sign(cred.key, authData, clientData)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]any{
+		"id":    b64(cred.id),
+		"rawId": b64(cred.id),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    b64(clientData),
+			"authenticatorData": b64(authData),
+			"signature":         b64(sig),
+			"userHandle":        b64(cred.userHandle),
+		},
+		"authenticatorAttachment": "platform",
+		"clientExtensionResults":  map[string]any{},
+	})
+}
+
+func (a *Authenticator) find(rpID string, id []byte) *credential {
+	for _, c := range a.creds {
+		if c.rpID == rpID && string(c.id) == string(id) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (a *Authenticator) clientData(typ string, challenge []byte) []byte {
+	raw, _ := json.Marshal(map[string]any{
+		"type":        typ,
+		"challenge":   b64(challenge),
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+
+	return raw
+}
+
+func sign(key *ecdsa.PrivateKey, authData, clientData []byte) ([]byte, error) {
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+
+	return ecdsa.SignASN1(rand.Reader, key, digest[:])
+}
+
+// coseKey кодирует открытый ключ P-256 в COSE_Key (ES256).
+func coseKey(key *ecdsa.PublicKey) []byte {
+	return encodeCBOR(cborMap{
+		{int64(1), int64(2)},  // kty: EC2
+		{int64(3), int64(-7)}, // alg: ES256
+		{int64(-1), int64(1)}, // crv: P-256
+		{int64(-2), pad32(key.X)},
+		{int64(-3), pad32(key.Y)},
+	})
+}
+
+func pad32(n *big.Int) []byte {
+	return n.FillBytes(make([]byte, 32))
+}
+
+func rpIDHash(rpID string) []byte {
+	sum := sha256.Sum256([]byte(rpID))
+	return sum[:]
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// cborMap - карта CBOR с заданным порядком ключей.
+type cborMap []struct {
+	key   any
+	value any
+}
+
+// encodeCBOR кодирует то немногое, что нужно аутентификатору:
+// int64, []byte, string и cborMap.
+func encodeCBOR(v any) []byte {
+	switch v := v.(type) {
+	case int64:
+		if v < 0 {
+			return cborHead(1, uint64(-1-v))
+		}
+		return cborHead(0, uint64(v))
+	case []byte:
+		return append(cborHead(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHead(3, uint64(len(v))), v...)
+	case cborMap:
+		out := cborHead(5, uint64(len(v)))
+		for _, kv := range v {
+			out = append(out, encodeCBOR(kv.key)...)
+			out = append(out, encodeCBOR(kv.value)...)
+		}
+		return out
+	default:
+		panic(fmt.Sprintf("webauthntest: cannot encode %T", v))
+	}
+}
+
+func cborHead(major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return []byte{major<<5 | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major<<5 | 24, byte(arg)}
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major<<5 | 27}, arg)
+	}
+}
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id         BIGSERIAL PRIMARY KEY,
+    event_type TEXT        NOT NULL,
+    user_id    BIGINT,
+    app_id     INT,
+    email      TEXT,
+    ip         TEXT,
+    user_agent TEXT,
+    metadata   JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_user_id ON audit_events (user_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_app_id ON audit_events (app_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
+
+-- Журнал только дополняется: изменение и удаление записей запрещено.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_events_append_only ON audit_events;
+CREATE TRIGGER trg_audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1 @@
+ALTER TABLE apps ADD CONSTRAINT apps_secret_key UNIQUE (secret);
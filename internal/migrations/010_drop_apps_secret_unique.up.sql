@@ -0,0 +1,2 @@
+-- Зашифрованные значения всегда различаются, поэтому уникальность секрета больше ничего не проверяет.
+ALTER TABLE apps DROP CONSTRAINT IF EXISTS apps_secret_key;
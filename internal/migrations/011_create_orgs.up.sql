@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS orgs
+(
+    id         SERIAL PRIMARY KEY,
+    slug       TEXT        NOT NULL UNIQUE,
+    name       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Организация по умолчанию: ей принадлежат все пользователи и приложения,
+-- созданные до появления организаций.
+INSERT INTO orgs (id, slug, name)
+VALUES (1, 'default', 'Default')
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('orgs', 'id'), (SELECT MAX(id) FROM orgs));
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_id INT NOT NULL DEFAULT 1 REFERENCES orgs (id);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_admin BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS org_id INT NOT NULL DEFAULT 1 REFERENCES orgs (id);
+
+-- Email и имя приложения уникальны в пределах организации.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_org_id_email_key UNIQUE (org_id, email);
+ALTER TABLE apps DROP CONSTRAINT IF EXISTS apps_name_key;
+ALTER TABLE apps ADD CONSTRAINT apps_org_id_name_key UNIQUE (org_id, name);
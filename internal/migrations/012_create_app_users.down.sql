@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS app_users;
+ALTER TABLE apps DROP COLUMN IF EXISTS access_policy;
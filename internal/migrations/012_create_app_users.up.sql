@@ -0,0 +1,17 @@
+-- Политика доступа к приложению: open - любой пользователь организации,
+-- invite_only - только приглашённые, approval_required - по заявке, одобренной администратором.
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS access_policy TEXT NOT NULL DEFAULT 'open'
+    CHECK (access_policy IN ('open', 'invite_only', 'approval_required'));
+
+CREATE TABLE IF NOT EXISTS app_users
+(
+    app_id     INT         NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status     TEXT        NOT NULL CHECK (status IN ('active', 'pending')),
+    added_by   BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (app_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_app_users_user_id ON app_users (user_id);
@@ -0,0 +1,4 @@
+ALTER TABLE apps DROP COLUMN IF EXISTS token_claims;
+ALTER TABLE apps DROP COLUMN IF EXISTS token_audience;
+ALTER TABLE apps DROP COLUMN IF EXISTS token_issuer;
+ALTER TABLE apps DROP COLUMN IF EXISTS token_ttl_seconds;
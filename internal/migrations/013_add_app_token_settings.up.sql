@@ -0,0 +1,6 @@
+-- Параметры токенов приложения. Нулевые значения - глобальные настройки сервиса.
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS token_ttl_seconds INT NOT NULL DEFAULT 0 CHECK (token_ttl_seconds >= 0);
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS token_issuer TEXT NOT NULL DEFAULT '';
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS token_audience TEXT[] NOT NULL DEFAULT '{}';
+-- Дополнительные claims: имя claim -> атрибут пользователя
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS token_claims JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,8 @@
+ALTER TABLE users DROP COLUMN IF EXISTS metadata;
+ALTER TABLE users DROP COLUMN IF EXISTS version;
+ALTER TABLE users DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE users DROP COLUMN IF EXISTS created_at;
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_url;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
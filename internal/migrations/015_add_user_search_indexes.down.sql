@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_users_display_name_trgm;
+DROP INDEX IF EXISTS idx_users_email_trgm;
+DROP INDEX IF EXISTS idx_users_locked;
+DROP INDEX IF EXISTS idx_users_admins;
+DROP INDEX IF EXISTS idx_users_lower_email_prefix;
+DROP INDEX IF EXISTS idx_users_org_id_created_at_id;
+DROP INDEX IF EXISTS idx_users_org_id_id;
+DROP INDEX IF EXISTS idx_users_created_at_id;
+DROP INDEX IF EXISTS idx_users_email_id;
+-- pg_trgm не удаляется: расширением могут пользоваться другие объекты
@@ -0,0 +1,17 @@
+-- Индексы для списка и поиска пользователей администраторами.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Сортировка и пагинация курсором (ключ, id)
+CREATE INDEX IF NOT EXISTS idx_users_email_id ON users (email, id);
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_users_org_id_id ON users (org_id, id);
+CREATE INDEX IF NOT EXISTS idx_users_org_id_created_at_id ON users (org_id, created_at, id);
+
+-- Фильтры: префикс email без учёта регистра, администраторы, заблокированные
+CREATE INDEX IF NOT EXISTS idx_users_lower_email_prefix ON users (lower(email) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_users_admins ON users (id) WHERE is_admin;
+CREATE INDEX IF NOT EXISTS idx_users_locked ON users (id) WHERE locked_at IS NOT NULL;
+
+-- Поиск подстроки в email и имени
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING gin (lower(email) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_display_name_trgm ON users USING gin (lower(display_name) gin_trgm_ops);
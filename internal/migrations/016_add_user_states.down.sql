@@ -0,0 +1,11 @@
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_user_id;
+DROP INDEX IF EXISTS idx_outbox_user_id;
+DROP INDEX IF EXISTS idx_users_delete_after;
+ALTER TABLE users DROP COLUMN IF EXISTS delete_after;
+ALTER TABLE users DROP COLUMN IF EXISTS disabled_at;
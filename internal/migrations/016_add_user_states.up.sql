@@ -0,0 +1,29 @@
+-- Состояния учётной записи: отключена администратором и ожидает удаления.
+-- Блокировка (locked_at) остаётся отдельным признаком.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS delete_after TIMESTAMPTZ;
+
+-- Фоновое удаление выбирает пользователей, у которых истёк срок ожидания
+CREATE INDEX IF NOT EXISTS idx_users_delete_after ON users (delete_after) WHERE delete_after IS NOT NULL;
+
+-- При удалении пользователя из событий outbox и доставок вебхуков стираются персональные данные
+CREATE INDEX IF NOT EXISTS idx_outbox_user_id ON outbox (user_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_user_id ON webhook_deliveries (((payload ->> 'user_id')::bigint));
+
+-- Журнал по-прежнему только дополняется. Единственное разрешённое изменение -
+-- обезличивание записи удалённого пользователя: стираются email, IP и User-Agent.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND NEW.id = OLD.id
+        AND NEW.event_type = OLD.event_type
+        AND NEW.user_id IS NOT DISTINCT FROM OLD.user_id
+        AND NEW.app_id IS NOT DISTINCT FROM OLD.app_id
+        AND NEW.metadata = OLD.metadata
+        AND NEW.created_at = OLD.created_at
+        AND NEW.email IS NULL AND NEW.ip IS NULL AND NEW.user_agent IS NULL THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,15 @@
+-- Одноразовые коды и ссылки входа без пароля. Хранится только хэш кода.
+CREATE TABLE IF NOT EXISTS login_codes
+(
+    id         TEXT PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    app_id     INT         NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+    method     TEXT        NOT NULL,
+    code_hash  BYTEA       NOT NULL,
+    attempts   INT         NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_codes_user_id ON login_codes (user_id, app_id);
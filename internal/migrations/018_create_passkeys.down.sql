@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS passkey_challenges;
+DROP TABLE IF EXISTS passkeys;
+ALTER TABLE apps DROP COLUMN IF EXISTS passkey_origins;
+ALTER TABLE apps DROP COLUMN IF EXISTS passkey_rp_name;
+ALTER TABLE apps DROP COLUMN IF EXISTS passkey_rp_id;
@@ -0,0 +1,35 @@
+-- Проверяющая сторона WebAuthn приложения. Пустой passkey_rp_id - ключи доступа отключены.
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS passkey_rp_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS passkey_rp_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS passkey_origins TEXT[] NOT NULL DEFAULT '{}';
+
+-- Ключи доступа пользователей: открытый ключ, счётчик подписей и способы связи с аутентификатором.
+CREATE TABLE IF NOT EXISTS passkeys
+(
+    id           BYTEA PRIMARY KEY,
+    user_id      BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    rp_id        TEXT        NOT NULL,
+    name         TEXT        NOT NULL DEFAULT '',
+    public_key   BYTEA       NOT NULL,
+    algorithm    INT         NOT NULL,
+    sign_count   BIGINT      NOT NULL DEFAULT 0,
+    transports   TEXT[]      NOT NULL DEFAULT '{}',
+    aaguid       BYTEA,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_passkeys_user_id ON passkeys (user_id, rp_id);
+
+-- Вызовы незавершённых регистраций и входов, каждый используется один раз.
+CREATE TABLE IF NOT EXISTS passkey_challenges
+(
+    id         TEXT PRIMARY KEY,
+    kind       TEXT        NOT NULL,
+    user_id    BIGINT REFERENCES users (id) ON DELETE CASCADE,
+    app_id     INT         NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+    challenge  BYTEA       NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_passkey_challenges_expires_at ON passkey_challenges (expires_at);
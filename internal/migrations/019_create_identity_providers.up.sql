@@ -0,0 +1,49 @@
+-- Внешние OIDC-провайдеры приложений.
+CREATE TABLE IF NOT EXISTS identity_providers
+(
+    id             BIGSERIAL PRIMARY KEY,
+    app_id         INT         NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+    name           TEXT        NOT NULL,
+    issuer         TEXT        NOT NULL,
+    client_id      TEXT        NOT NULL,
+    client_secret  TEXT        NOT NULL DEFAULT '',
+    redirect_uri   TEXT        NOT NULL,
+    scopes         TEXT[]      NOT NULL DEFAULT '{}',
+    email_claim    TEXT        NOT NULL DEFAULT '',
+    verified_claim TEXT        NOT NULL DEFAULT '',
+    name_claim     TEXT        NOT NULL DEFAULT '',
+    trust_email    BOOLEAN     NOT NULL DEFAULT false,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (app_id, name)
+);
+
+-- Учётные записи пользователей у внешних провайдеров. Связь идёт по издателю,
+-- а не по провайдеру приложения, поэтому она общая для приложений организации.
+CREATE TABLE IF NOT EXISTS user_identities
+(
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    org_id        INT         NOT NULL REFERENCES orgs (id) ON DELETE CASCADE,
+    issuer        TEXT        NOT NULL,
+    subject       TEXT        NOT NULL,
+    email         TEXT        NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_login_at TIMESTAMPTZ,
+    UNIQUE (org_id, issuer, subject),
+    UNIQUE (user_id, issuer)
+);
+
+-- Начатые входы через внешних провайдеров, каждый завершается один раз.
+CREATE TABLE IF NOT EXISTS external_logins
+(
+    state         TEXT PRIMARY KEY,
+    provider_id   BIGINT      NOT NULL REFERENCES identity_providers (id) ON DELETE CASCADE,
+    app_id        INT         NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+    user_id       BIGINT REFERENCES users (id) ON DELETE CASCADE,
+    nonce         TEXT        NOT NULL,
+    code_verifier TEXT        NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_logins_expires_at ON external_logins (expires_at);
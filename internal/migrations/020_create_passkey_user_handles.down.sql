@@ -0,0 +1 @@
+DROP TABLE IF EXISTS passkey_user_handles;
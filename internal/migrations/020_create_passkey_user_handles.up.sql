@@ -0,0 +1,13 @@
+-- Случайный user handle WebAuthn пользователя: по нему нельзя узнать ID пользователя.
+CREATE TABLE IF NOT EXISTS passkey_user_handles
+(
+    user_id BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    handle  BYTEA NOT NULL UNIQUE
+);
+
+-- Уже зарегистрированные ключи хранят ID пользователя в десятичном виде,
+-- поэтому их владельцы сохраняют прежний handle.
+INSERT INTO passkey_user_handles (user_id, handle)
+SELECT DISTINCT user_id, convert_to(user_id::text, 'UTF8')
+FROM passkeys
+ON CONFLICT (user_id) DO NOTHING;
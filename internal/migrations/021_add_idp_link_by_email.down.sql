@@ -0,0 +1 @@
+ALTER TABLE identity_providers DROP COLUMN IF EXISTS link_by_email;
@@ -0,0 +1,3 @@
+-- Привязка существующих аккаунтов по email при первом входе через провайдер.
+-- Включает только глобальный администратор.
+ALTER TABLE identity_providers ADD COLUMN IF NOT EXISTS link_by_email BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,77 @@
+// Package migrations содержит SQL миграции схемы, встроенные в бинарник.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/lib/pq"
+)
+
+// DefaultTable - таблица, в которой migrate хранит текущую версию схемы.
+const DefaultTable = "migrations"
+
+// FS - встроенные файлы миграций.
+//
+//go:embed *.sql
+var FS embed.FS
+
+// New создаёт migrate.Migrate поверх уже открытого соединения.
+// Версия схемы хранится в таблице table.
+func New(fsys fs.FS, db *sql.DB, table string) (*migrate.Migrate, error) {
+	const op = "migrations.New"
+
+	src, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	driver, err := postgres.WithInstance(db, &postgres.Config{MigrationsTable: table})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return m, nil
+}
+
+// Up применяет все встроенные миграции, которых ещё нет в базе, и возвращает
+// итоговую версию схемы. Версия хранится в таблице table. Драйвер postgres выполняет миграции под
+// pg_advisory_lock, поэтому несколько реплик, стартующих одновременно,
+// применят их ровно один раз: остальные дождутся блокировки и ничего не сделают.
+func Up(dsn string, table string) (uint, error) {
+	const op = "migrations.Up"
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m, err := New(FS, db, table)
+	if err != nil {
+		_ = db.Close()
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	version, _, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, nil
+}
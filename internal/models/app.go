@@ -1,9 +1,44 @@
 package models
 
+import "time"
+
 // App представляет собой модель приложения, используемую в системе аутентификации.
 // Она содержит идентификатор приложения, его имя и секретный ключ.
 type App struct {
-	ID     int
-	Name   string
-	Secret string
+	ID           int
+	OrgID        int64
+	Name         string
+	Secret       string
+	AccessPolicy string // Access*, пусто - AccessOpen
+	Token        TokenSettings
+	Passkeys     PasskeySettings
+}
+
+// TokenSettings - параметры токенов, которые выдаются для приложения.
+// Нулевые значения означают глобальные настройки сервиса.
+type TokenSettings struct {
+	TTL      time.Duration
+	Issuer   string
+	Audience []string
+	Claims   map[string]string // имя claim -> атрибут пользователя (ClaimSource*)
+}
+
+// WithDefaults заполняет незаданные параметры значениями из defaults.
+func (s TokenSettings) WithDefaults(defaults TokenSettings) TokenSettings {
+	if s.TTL <= 0 {
+		s.TTL = defaults.TTL
+	}
+	if s.Issuer == "" {
+		s.Issuer = defaults.Issuer
+	}
+	if len(s.Audience) == 0 {
+		s.Audience = defaults.Audience
+	}
+
+	return s
+}
+
+// Restricted сообщает, что для входа в приложение нужен допуск.
+func (a App) Restricted() bool {
+	return a.AccessPolicy != "" && a.AccessPolicy != AccessOpen
 }
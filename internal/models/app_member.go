@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Политики доступа к приложению.
+const (
+	AccessOpen             = "open"              // любой пользователь организации
+	AccessInviteOnly       = "invite_only"       // только приглашённые администратором
+	AccessApprovalRequired = "approval_required" // заявка создаётся при входе и ждёт одобрения
+)
+
+// Статусы участника приложения.
+const (
+	MemberActive  = "active"
+	MemberPending = "pending"
+)
+
+// AppMember - допуск пользователя к приложению с ограниченным доступом.
+type AppMember struct {
+	AppID     int
+	UserID    int64
+	Email     string
+	Status    string
+	AddedBy   int64 // 0 - заявка создана самим пользователем при входе
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
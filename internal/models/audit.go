@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Типы событий аудита.
+const (
+	AuditRegister        = "register"
+	AuditLoginSuccess    = "login_success"
+	AuditLoginFailure    = "login_failure"
+	AuditLogout          = "logout"
+	AuditAdminCheck      = "admin_check"
+	AuditRoleChange      = "role_change"
+	AuditSessionRevoked  = "session_revoked"
+	AuditPasswordChanged = "password_changed"
+	AuditUserDeleted     = "user_deleted"
+	AuditUserLocked      = "user_locked"
+	AuditUserUnlocked    = "user_unlocked"
+	AuditAppCreated      = "app_created"
+	AuditAppSecretRotate = "app_secret_rotated"
+	AuditOrgCreated      = "org_created"
+	AuditOrgAdminChange  = "org_admin_change"
+	AuditAppPolicyChange = "app_policy_change"
+	AuditAppAccessAsked  = "app_access_requested"
+	AuditAppMemberAdded  = "app_member_added"
+	AuditAppMemberRemove = "app_member_removed"
+	AuditAppTokenChange  = "app_token_settings_change"
+	AuditProfileUpdated  = "profile_updated"
+	AuditUserDisabled    = "user_disabled"
+	AuditUserEnabled     = "user_enabled"
+	AuditEraseScheduled  = "user_erase_scheduled"
+	AuditUserExported    = "user_data_exported"
+	AuditUsersImported   = "users_imported"
+	AuditUsersDumped     = "users_exported"
+	AuditLoginCodeSent   = "login_code_sent"
+	AuditPasskeyAdded    = "passkey_added"
+	AuditAppPasskeySet   = "app_passkey_settings_change"
+	AuditIdentityLinked  = "identity_linked"
+	AuditAppIdPSet       = "app_idp_set"
+	AuditAppIdPDeleted   = "app_idp_deleted"
+)
+
+// AuditEvent - запись журнала событий безопасности.
+// UserID и AppID равны 0, если событие не относится к пользователю или приложению
+// (например, неудачный вход с несуществующим email).
+type AuditEvent struct {
+	ID        int64
+	Type      string
+	UserID    int64
+	AppID     int
+	Email     string
+	IP        string
+	UserAgent string
+	Metadata  map[string]string
+	CreatedAt time.Time
+}
+
+// AuditFilter - параметры выборки событий аудита.
+// Нулевые значения полей означают отсутствие фильтра.
+// Cursor - ID последнего события предыдущей страницы.
+type AuditFilter struct {
+	UserID int64
+	AppID  int
+	Types  []string
+	Since  time.Time
+	Until  time.Time
+	Cursor int64
+	Limit  int
+}
@@ -0,0 +1,142 @@
+package models
+
+import "time"
+
+// UserExport - всё, что сервис хранит о пользователе, в виде для выгрузки
+// субъекту данных. Хэш пароля не выгружается.
+type UserExport struct {
+	ExportedAt  time.Time           `json:"exported_at"`
+	User        ExportedUser        `json:"user"`
+	Metadata    map[string]any      `json:"app_metadata"` // ID приложения -> метаданные
+	Memberships []ExportedMember    `json:"app_memberships"`
+	Sessions    []ExportedSession   `json:"sessions"`
+	Audit       []ExportedAuditItem `json:"audit_events"`
+}
+
+type ExportedUser struct {
+	ID          int64      `json:"id"`
+	Email       string     `json:"email"`
+	OrgID       int64      `json:"org_id"`
+	State       string     `json:"state"`
+	IsAdmin     bool       `json:"is_admin"`
+	OrgAdmin    bool       `json:"org_admin"`
+	Roles       []string   `json:"roles"`
+	DisplayName string     `json:"display_name,omitempty"`
+	Locale      string     `json:"locale,omitempty"`
+	Timezone    string     `json:"timezone,omitempty"`
+	AvatarURL   string     `json:"avatar_url,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	LockedAt    *time.Time `json:"locked_at,omitempty"`
+	DisabledAt  *time.Time `json:"disabled_at,omitempty"`
+	DeleteAfter *time.Time `json:"delete_after,omitempty"`
+}
+
+type ExportedMember struct {
+	AppID     int       `json:"app_id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ExportedSession struct {
+	ID         string     `json:"id"`
+	AppID      int        `json:"app_id"`
+	IP         string     `json:"ip,omitempty"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type ExportedAuditItem struct {
+	ID        int64             `json:"id"`
+	Type      string            `json:"type"`
+	AppID     int               `json:"app_id,omitempty"`
+	IP        string            `json:"ip,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// NewUserExport собирает выгрузку из данных пользователя.
+func NewUserExport(
+	user User,
+	metadata map[string]any,
+	members []AppMember,
+	sessions []Session,
+	events []AuditEvent,
+	now time.Time,
+) UserExport {
+	export := UserExport{
+		ExportedAt: now,
+		User: ExportedUser{
+			ID:          user.ID,
+			Email:       user.Email,
+			OrgID:       user.OrgID,
+			State:       user.State(),
+			IsAdmin:     user.IsAdmin,
+			OrgAdmin:    user.OrgAdmin,
+			Roles:       user.Roles,
+			DisplayName: user.DisplayName,
+			Locale:      user.Locale,
+			Timezone:    user.Timezone,
+			AvatarURL:   user.AvatarURL,
+			CreatedAt:   user.CreatedAt,
+			UpdatedAt:   user.UpdatedAt,
+			LockedAt:    optionalTime(user.LockedAt),
+			DisabledAt:  optionalTime(user.DisabledAt),
+			DeleteAfter: optionalTime(user.DeleteAfter),
+		},
+		Metadata:    metadata,
+		Memberships: make([]ExportedMember, 0, len(members)),
+		Sessions:    make([]ExportedSession, 0, len(sessions)),
+		Audit:       make([]ExportedAuditItem, 0, len(events)),
+	}
+	if export.Metadata == nil {
+		export.Metadata = map[string]any{}
+	}
+
+	for _, m := range members {
+		export.Memberships = append(export.Memberships, ExportedMember{
+			AppID:     m.AppID,
+			Status:    m.Status,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		})
+	}
+	for _, s := range sessions {
+		export.Sessions = append(export.Sessions, ExportedSession{
+			ID:         s.ID,
+			AppID:      s.AppID,
+			IP:         s.IP,
+			UserAgent:  s.UserAgent,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			ExpiresAt:  s.ExpiresAt,
+			RevokedAt:  optionalTime(s.RevokedAt),
+		})
+	}
+	for _, e := range events {
+		export.Audit = append(export.Audit, ExportedAuditItem{
+			ID:        e.ID,
+			Type:      e.Type,
+			AppID:     e.AppID,
+			IP:        e.IP,
+			UserAgent: e.UserAgent,
+			Metadata:  e.Metadata,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	return export
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
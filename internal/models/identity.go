@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// IdentityProvider - внешний OIDC-провайдер, через которого входят в приложение.
+// Name уникально в пределах приложения.
+type IdentityProvider struct {
+	ID           int64
+	AppID        int
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string // пусто - публичный клиент
+	RedirectURI  string // страница приложения, куда провайдер возвращает code и state
+	Scopes       []string
+	Claims       ClaimMapping
+	TrustEmail   bool // провайдер сам проверяет email, claim email_verified не нужен
+	LinkByEmail  bool // привязывать аккаунт с тем же email при первом входе, ставит только глобальный администратор
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ClaimMapping - claims ID-токена провайдера, из которых берутся данные
+// пользователя. Пустые поля - стандартные claims OIDC.
+type ClaimMapping struct {
+	Email         string // email
+	EmailVerified string // email_verified
+	Name          string // name
+}
+
+// WithDefaults заполняет пустые поля стандартными claims.
+func (m ClaimMapping) WithDefaults() ClaimMapping {
+	if m.Email == "" {
+		m.Email = "email"
+	}
+	if m.EmailVerified == "" {
+		m.EmailVerified = "email_verified"
+	}
+	if m.Name == "" {
+		m.Name = "name"
+	}
+
+	return m
+}
+
+// UserIdentity - учётная запись пользователя у внешнего провайдера.
+// Издатель и subject уникальны в пределах организации.
+type UserIdentity struct {
+	ID          int64
+	UserID      int64
+	OrgID       int64
+	Issuer      string
+	Subject     string
+	Email       string // email у провайдера при последнем входе
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// ExternalLogin - начатый вход через внешнего провайдера. State передаётся
+// провайдеру и возвращается вместе с кодом.
+type ExternalLogin struct {
+	State        string
+	ProviderID   int64
+	AppID        int
+	UserID       int64 // не 0 - привязка провайдера к уже вошедшему пользователю
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// ExternalLoginStart - адрес страницы входа провайдера и state, который
+// провайдер вернёт на страницу приложения вместе с кодом.
+type ExternalLoginStart struct {
+	AuthURL   string
+	State     string
+	ExpiresAt time.Time
+}
+
+// ExternalLoginResult - итог входа через внешнего провайдера.
+type ExternalLoginResult struct {
+	Token   string
+	UserID  int64
+	Created bool // пользователь создан при этом входе
+	Linked  bool // учётная запись провайдера привязана при этом входе
+}
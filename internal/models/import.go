@@ -0,0 +1,41 @@
+package models
+
+// MaxImportErrors ограничивает число ошибок строк в результате импорта,
+// остальные учитываются только в Failed.
+const MaxImportErrors = 1000
+
+// ImportedUser - строка массового импорта пользователей.
+// Пароль передаётся только готовым хэшем: bcrypt, argon2id или PBKDF2.
+type ImportedUser struct {
+	Row         int64 // номер строки в источнике, для отчёта об ошибках и продолжения импорта
+	Email       string
+	PassHash    []byte
+	Roles       []string
+	DisplayName string
+}
+
+// ImportRowError - строка, которую не удалось импортировать.
+type ImportRowError struct {
+	Row    int64
+	Email  string
+	Reason string
+}
+
+// ImportResult - итог импорта. Пользователи, которые уже есть в организации,
+// пропускаются, поэтому импорт можно безопасно повторить с любой строки.
+type ImportResult struct {
+	Imported int64
+	Skipped  int64
+	Failed   int64
+	Errors   []ImportRowError // не больше MaxImportErrors
+	LastRow  int64            // наибольший номер обработанной строки
+}
+
+// Add добавляет к результату итог следующей пачки.
+func (r *ImportResult) Add(other ImportResult) {
+	r.Imported += other.Imported
+	r.Skipped += other.Skipped
+	r.Failed += other.Failed
+	r.Errors = append(r.Errors, other.Errors[:min(len(other.Errors), MaxImportErrors-len(r.Errors))]...)
+	r.LastRow = max(r.LastRow, other.LastRow)
+}
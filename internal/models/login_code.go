@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Способы входа без пароля.
+const (
+	LoginMethodCode = "code"       // короткий код из письма
+	LoginMethodLink = "magic_link" // ссылка из письма
+)
+
+// LoginCode - одноразовый код или ссылка входа без пароля.
+// Сам код не хранится, только его хэш.
+type LoginCode struct {
+	ID        string
+	UserID    int64
+	AppID     int
+	Method    string
+	CodeHash  []byte
+	Attempts  int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// LoginCodeLimits ограничивает ввод кодов: попытки на один код и неверные
+// коды пользователя с Since по всем его кодам.
+type LoginCodeLimits struct {
+	MaxAttempts int
+	MaxFailures int
+	Since       time.Time
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// DefaultOrgID - организация по умолчанию. Ей принадлежат пользователи
+// и приложения, созданные до появления организаций, и токены без claim org_id.
+const DefaultOrgID int64 = 1
+
+// DefaultOrgSlug - идентификатор организации по умолчанию в запросах.
+const DefaultOrgSlug = "default"
+
+// AnyOrg вместо ID организации снимает ограничение по организации.
+// Используется только для глобальных администраторов.
+const AnyOrg int64 = 0
+
+// Org - организация (арендатор). Пользователи и приложения принадлежат
+// ровно одной организации, email уникален в её пределах.
+type Org struct {
+	ID        int64
+	Slug      string
+	Name      string
+	CreatedAt time.Time
+}
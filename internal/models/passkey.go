@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Виды церемоний WebAuthn.
+const (
+	PasskeyRegistration = "registration"
+	PasskeyLogin        = "login"
+)
+
+// PasskeySettings - проверяющая сторона WebAuthn приложения: домен,
+// к которому привязаны ключи, и origin страниц, откуда с ними входят.
+// Пустой RPID - вход по ключам доступа в приложение отключён.
+type PasskeySettings struct {
+	RPID    string
+	RPName  string // пусто - имя приложения
+	Origins []string
+}
+
+func (s PasskeySettings) Enabled() bool {
+	return s.RPID != ""
+}
+
+// Passkey - ключ доступа пользователя. Ключ привязан к RP ID, поэтому
+// подходит для всех приложений организации с тем же RP ID.
+type Passkey struct {
+	ID         []byte
+	UserID     int64
+	RPID       string
+	Name       string
+	PublicKey  []byte // COSE_Key
+	Algorithm  int64
+	SignCount  uint32
+	Transports []string
+	AAGUID     []byte
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// PasskeyChallenge - вызов начатой регистрации или входа.
+// UserID равен 0 при входе без email.
+type PasskeyChallenge struct {
+	ID        string
+	Kind      string
+	UserID    int64
+	AppID     int
+	Challenge []byte
+	ExpiresAt time.Time
+}
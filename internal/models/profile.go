@@ -0,0 +1,18 @@
+package models
+
+// Profile - профиль пользователя вместе с метаданными одного приложения.
+// Каждое приложение видит и меняет только свои метаданные.
+type Profile struct {
+	User     User
+	AppID    int
+	Metadata map[string]any
+}
+
+// Поля профиля, которые можно менять через UpdateProfile.
+const (
+	ProfileDisplayName = "display_name"
+	ProfileLocale      = "locale"
+	ProfileTimezone    = "timezone"
+	ProfileAvatarURL   = "avatar_url"
+	ProfileMetadata    = "metadata" // целиком или по ключу: metadata.<key>
+)
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Session - сессия пользователя, выданная при входе.
+// ID сессии зашит в токен (claim sid), поэтому отзыв сессии
+// делает недействительными все её токены.
+type Session struct {
+	ID         string
+	UserID     int64
+	AppID      int
+	IP         string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  time.Time
+}
+
+// Active сообщает, что сессия не отозвана и не истекла.
+func (s Session) Active(now time.Time) bool {
+	return s.RevokedAt.IsZero() && now.Before(s.ExpiresAt)
+}
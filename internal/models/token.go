@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// TokenClaims - проверенные данные из токена доступа.
+type TokenClaims struct {
+	UserID    int64
+	Email     string
+	AppID     int
+	SessionID string
+	ExpiresAt time.Time
+	IsAdmin   bool
+	Roles     []string // на момент входа: изменения ролей видны после нового входа
+	OrgID     int64
+	OrgAdmin  bool
+	Issuer    string
+	Audience  []string
+	Custom    map[string]any // дополнительные claims приложения
+}
+
+// Атрибуты пользователя, которые можно выдать в дополнительных claims приложения.
+const (
+	ClaimSourceUserID   = "user_id"
+	ClaimSourceEmail    = "email"
+	ClaimSourceOrgID    = "org_id"
+	ClaimSourceRoles    = "roles"
+	ClaimSourceAdmin    = "admin"
+	ClaimSourceOrgAdmin = "org_admin"
+
+	ClaimSourceDisplayName = "display_name"
+	ClaimSourceLocale      = "locale"
+	ClaimSourceTimezone    = "timezone"
+	ClaimSourceAvatarURL   = "avatar_url"
+)
+
+// ClaimValue возвращает значение атрибута source пользователя.
+// ok = false, если такого атрибута нет.
+func (u User) ClaimValue(source string) (value any, ok bool) {
+	switch source {
+	case ClaimSourceUserID:
+		return u.ID, true
+	case ClaimSourceEmail:
+		return u.Email, true
+	case ClaimSourceOrgID:
+		return u.OrgID, true
+	case ClaimSourceRoles:
+		if u.Roles == nil {
+			return []string{}, true
+		}
+		return u.Roles, true
+	case ClaimSourceAdmin:
+		return u.IsAdmin, true
+	case ClaimSourceOrgAdmin:
+		return u.OrgAdmin, true
+	case ClaimSourceDisplayName:
+		return u.DisplayName, true
+	case ClaimSourceLocale:
+		return u.Locale, true
+	case ClaimSourceTimezone:
+		return u.Timezone, true
+	case ClaimSourceAvatarURL:
+		return u.AvatarURL, true
+	}
+
+	return nil, false
+}
@@ -1,7 +1,53 @@
 package models
 
+import "time"
+
+// Состояния учётной записи. Войти можно только в активную.
+const (
+	UserActive          = "active"
+	UserLocked          = "locked"           // заблокирована, обычно на время разбирательства
+	UserDisabled        = "disabled"         // отключена администратором
+	UserPendingDeletion = "pending_deletion" // будет удалена после DeleteAfter
+)
+
 type User struct {
-	ID       int64
-	Email    string
-	PassHash []byte
+	ID          int64
+	Email       string
+	PassHash    []byte
+	LockedAt    time.Time // нулевое значение - пользователь не заблокирован
+	DisabledAt  time.Time // нулевое значение - пользователь не отключён
+	DeleteAfter time.Time // нулевое значение - удаление не запрошено
+	IsAdmin     bool      // глобальный администратор
+	Roles       []string
+	OrgID       int64
+	OrgAdmin    bool // администратор своей организации
+
+	// Профиль
+	DisplayName string
+	Locale      string // BCP 47, например ru-RU
+	Timezone    string // IANA, например Europe/Moscow
+	AvatarURL   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Version     int64 // растёт при каждом изменении профиля
+}
+
+// Locked сообщает, заблокирован ли вход пользователю.
+func (u User) Locked() bool {
+	return !u.LockedAt.IsZero()
+}
+
+// State возвращает состояние учётной записи. Если признаков несколько,
+// важнее ожидание удаления, затем отключение, затем блокировка.
+func (u User) State() string {
+	switch {
+	case !u.DeleteAfter.IsZero():
+		return UserPendingDeletion
+	case !u.DisabledAt.IsZero():
+		return UserDisabled
+	case u.Locked():
+		return UserLocked
+	default:
+		return UserActive
+	}
 }
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Типы событий жизненного цикла пользователя.
+const (
+	UserRegistered   = "user.registered"
+	UserProfileSaved = "user.profile_updated"
+	UserDeleted      = "user.deleted"
+)
+
+// UserEvent - событие жизненного цикла пользователя.
+// Пишется в outbox в одной транзакции с изменением пользователя
+// и затем публикуется внешним подписчикам.
+type UserEvent struct {
+	ID         int64             `json:"id"`
+	Type       string            `json:"type"`
+	UserID     int64             `json:"user_id"`
+	Email      string            `json:"email,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Сортировки списка пользователей.
+const (
+	UserSortID        = "id"
+	UserSortEmail     = "email"
+	UserSortCreatedAt = "created_at"
+)
+
+// UserFilter - условия выборки пользователей для администраторов.
+// Нулевые значения полей не ограничивают выборку.
+type UserFilter struct {
+	OrgID         int64  // AnyOrg - все организации
+	Query         string // подстрока email или отображаемого имени, без учёта регистра
+	EmailPrefix   string // без учёта регистра
+	Admin         *bool
+	Locked        *bool
+	CreatedAfter  time.Time // включительно
+	CreatedBefore time.Time // не включительно
+	AppID         int       // только активные участники приложения
+
+	Sort  string // UserSort*, пусто - UserSortID
+	Desc  bool
+	After *UserCursor // nil - первая страница
+	Limit int
+}
+
+// UserCursor - позиция в списке пользователей: значение ключа сортировки
+// и ID последнего пользователя предыдущей страницы.
+type UserCursor struct {
+	Key string
+	ID  int64
+}
+
+// Cursor возвращает позицию пользователя u при сортировке sort.
+func (u User) Cursor(sort string) UserCursor {
+	switch sort {
+	case UserSortEmail:
+		return UserCursor{Key: u.Email, ID: u.ID}
+	case UserSortCreatedAt:
+		return UserCursor{Key: u.CreatedAt.UTC().Format(time.RFC3339Nano), ID: u.ID}
+	}
+
+	return UserCursor{ID: u.ID}
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Типы событий, на которые приложение может подписать вебхук.
+const (
+	WebhookLogin           = "login"
+	WebhookLogout          = "logout"
+	WebhookPasswordChanged = "password_changed"
+	WebhookUserDeleted     = "user_deleted"
+)
+
+// Статусы доставки вебхука.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	// DeliveryDead - доставка исчерпала попытки и лежит в dead-letter очереди
+	// до ручной повторной отправки.
+	DeliveryDead = "dead"
+)
+
+// Webhook - подписка приложения на события аутентификации его пользователей.
+type Webhook struct {
+	ID         int64
+	AppID      int
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  time.Time
+}
+
+// WebhookDelivery - одна попытка (с повторами) доставки события вебхуку.
+type WebhookDelivery struct {
+	ID           int64
+	WebhookID    int64
+	EventType    string
+	Payload      []byte
+	Status       string
+	Attempts     int
+	ResponseCode int
+	LastError    string
+	CreatedAt    time.Time
+	DeliveredAt  time.Time
+	// URL и Secret вебхука заполняются при выборке доставок на отправку.
+	URL    string
+	Secret string
+}
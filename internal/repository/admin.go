@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// AppByName ищет приложение по имени в организации orgID.
+func (s *repository) AppByName(ctx context.Context, orgID int64, name string) (models.App, error) {
+	const op = "repository.postgres.AppByName"
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`SELECT `+appColumns+` FROM apps WHERE org_id = $1 AND name = $2`)
+	if err != nil {
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	app, err := scanApp(stmt.QueryRowContext(ctx, orgID, name))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.App{}, fmt.Errorf("%s: %w", op, _error.ErrAppNotFound)
+		}
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if app.Secret, err = s.openAppSecret(app.Secret); err != nil {
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return app, nil
+}
+
+// SaveApp создаёт приложение в организации app.OrgID. Если app.ID не задан, он выдаётся базой.
+// Явный ID сдвигает последовательность, чтобы следующие приложения не конфликтовали с ним.
+func (s *repository) SaveApp(ctx context.Context, app models.App) (int, error) {
+	const op = "repository.postgres.SaveApp"
+
+	secret, err := s.sealAppSecret(app.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	ttl, issuer, audience, claims, err := tokenSettingsArgs(app.Token)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	if app.ID == 0 {
+		err = tx.QueryRowContext(ctx,
+			`INSERT INTO apps(org_id, name, secret, access_policy,
+				token_ttl_seconds, token_issuer, token_audience, token_claims,
+				passkey_rp_id, passkey_rp_name, passkey_origins)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`,
+			app.OrgID, app.Name, secret, accessPolicy(app), ttl, issuer, audience, claims,
+			app.Passkeys.RPID, app.Passkeys.RPName, passkeyOrigins(app.Passkeys),
+		).Scan(&id)
+	} else {
+		err = tx.QueryRowContext(ctx,
+			`INSERT INTO apps(id, org_id, name, secret, access_policy,
+				token_ttl_seconds, token_issuer, token_audience, token_claims,
+				passkey_rp_id, passkey_rp_name, passkey_origins)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`,
+			app.ID, app.OrgID, app.Name, secret, accessPolicy(app), ttl, issuer, audience, claims,
+			app.Passkeys.RPID, app.Passkeys.RPName, passkeyOrigins(app.Passkeys),
+		).Scan(&id)
+	}
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, fmt.Errorf("%s: %w", op, _error.ErrAppExists)
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return 0, fmt.Errorf("%s: %w", op, _error.ErrOrgNotFound)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if app.ID != 0 {
+		_, err = tx.ExecContext(ctx,
+			`SELECT setval(pg_get_serial_sequence('apps', 'id'), (SELECT MAX(id) FROM apps))`)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// UpdateAppSecret меняет секрет приложения организации orgID.
+func (s *repository) UpdateAppSecret(ctx context.Context, orgID int64, appID int, secret string) error {
+	const op = "repository.postgres.UpdateAppSecret"
+
+	secret, err := s.sealAppSecret(secret)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`UPDATE apps SET secret = $1 WHERE id = $2 AND `+inOrg(3))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, secret, appID, orgID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrAppNotFound)
+	}
+
+	return nil
+}
+
+// UserRoles возвращает флаг администратора и роли пользователя.
+func (s *repository) UserRoles(ctx context.Context, userID int64) (bool, []string, error) {
+	const op = "repository.postgres.UserRoles"
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`SELECT is_admin, roles FROM users WHERE id = $1`)
+	if err != nil {
+		return false, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var (
+		isAdmin bool
+		roles   []string
+	)
+	err = stmt.QueryRowContext(ctx, userID).Scan(&isAdmin, pq.Array(&roles))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil, fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+		}
+		return false, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return isAdmin, roles, nil
+}
+
+func (s *repository) SetUserRoles(ctx context.Context, userID int64, isAdmin bool, roles []string) error {
+	const op = "repository.postgres.SetUserRoles"
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`UPDATE users SET is_admin = $1, roles = $2 WHERE id = $3`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	if roles == nil {
+		roles = []string{}
+	}
+
+	res, err := stmt.ExecContext(ctx, isAdmin, pq.Array(roles), userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// Apps возвращает приложения организации orgID или всех организаций для models.AnyOrg.
+func (s *repository) Apps(ctx context.Context, orgID int64) ([]models.App, error) {
+	const op = "repository.postgres.Apps"
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+appColumns+` FROM apps WHERE `+inOrg(1)+` ORDER BY id`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var apps []models.App
+	for rows.Next() {
+		app, err := scanApp(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if app.Secret, err = s.openAppSecret(app.Secret); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		apps = append(apps, app)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return apps, nil
+}
+
+// UserByID ищет пользователя организации orgID. Пользователь другой
+// организации не находится; models.AnyOrg снимает ограничение.
+func (s *repository) UserByID(ctx context.Context, orgID int64, userID int64) (models.User, error) {
+	const op = "repository.postgres.UserByID"
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`SELECT `+userColumns+` FROM users WHERE id = $1 AND `+inOrg(2))
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	user, err := scanUser(stmt.QueryRowContext(ctx, userID, orgID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+		}
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// SetUserLocked блокирует (locked = true) или разблокирует пользователя
+// и возвращает время блокировки. Повторная блокировка не сдвигает время.
+func (s *repository) SetUserLocked(ctx context.Context, userID int64, locked bool) (time.Time, error) {
+	const op = "repository.postgres.SetUserLocked"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		UPDATE users
+		SET locked_at = CASE WHEN $1::boolean THEN COALESCE(locked_at, now()) END
+		WHERE id = $2
+		RETURNING locked_at`)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var lockedAt sql.NullTime
+	if err := stmt.QueryRowContext(ctx, locked, userID).Scan(&lockedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+		}
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lockedAt.Time, nil
+}
+
+const userColumns = `id, email, pass_hash, locked_at, disabled_at, delete_after, is_admin, roles, org_id, org_admin,
+	display_name, locale, timezone, avatar_url, created_at, updated_at, version`
+
+func scanUser(row rowScanner) (models.User, error) {
+	var (
+		user                              models.User
+		lockedAt, disabledAt, deleteAfter sql.NullTime
+	)
+
+	err := row.Scan(&user.ID, &user.Email, &user.PassHash, &lockedAt, &disabledAt, &deleteAfter,
+		&user.IsAdmin, pq.Array(&user.Roles), &user.OrgID, &user.OrgAdmin,
+		&user.DisplayName, &user.Locale, &user.Timezone, &user.AvatarURL, &user.CreatedAt, &user.UpdatedAt, &user.Version)
+	if err != nil {
+		return models.User{}, err
+	}
+	user.LockedAt = lockedAt.Time
+	user.DisabledAt = disabledAt.Time
+	user.DeleteAfter = deleteAfter.Time
+
+	return user, nil
+}
+
+const appColumns = `id, org_id, name, secret, access_policy,
+	token_ttl_seconds, token_issuer, token_audience, token_claims,
+	passkey_rp_id, passkey_rp_name, passkey_origins`
+
+func scanApp(row rowScanner) (models.App, error) {
+	var (
+		app    models.App
+		ttl    int64
+		claims []byte
+	)
+	err := row.Scan(&app.ID, &app.OrgID, &app.Name, &app.Secret, &app.AccessPolicy,
+		&ttl, &app.Token.Issuer, pq.Array(&app.Token.Audience), &claims,
+		&app.Passkeys.RPID, &app.Passkeys.RPName, pq.Array(&app.Passkeys.Origins))
+	if err != nil {
+		return models.App{}, err
+	}
+
+	app.Token.TTL = time.Duration(ttl) * time.Second
+	if err := json.Unmarshal(claims, &app.Token.Claims); err != nil {
+		return models.App{}, fmt.Errorf("token_claims: %w", err)
+	}
+	if len(app.Token.Audience) == 0 {
+		app.Token.Audience = nil
+	}
+	if len(app.Token.Claims) == 0 {
+		app.Token.Claims = nil
+	}
+	if len(app.Passkeys.Origins) == 0 {
+		app.Passkeys.Origins = nil
+	}
+
+	return app, nil
+}
+
+// inOrg возвращает условие принадлежности строки организации из параметра $n.
+// Это единственная проверка, отделяющая данные организаций друг от друга,
+// поэтому запросы к users и apps по ID должны её включать.
+func inOrg(n int) string {
+	p := "$" + strconv.Itoa(n)
+	return "(" + p + " = 0 OR org_id = " + p + ")"
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/secretbox"
+)
+
+// sealAppSecret шифрует секрет приложения перед записью, если задан ключ.
+func (s *repository) sealAppSecret(secret string) (string, error) {
+	if s.appSecrets == nil {
+		return secret, nil
+	}
+
+	return s.appSecrets.Seal(secret)
+}
+
+// openAppSecret расшифровывает секрет приложения. Открытые значения,
+// записанные до включения шифрования, возвращаются как есть.
+func (s *repository) openAppSecret(secret string) (string, error) {
+	if !secretbox.Sealed(secret) {
+		return secret, nil
+	}
+	if s.appSecrets == nil {
+		return "", errors.New("app secret is encrypted, but no key is configured")
+	}
+
+	return s.appSecrets.Open(secret)
+}
+
+// RewrapAppSecrets переводит секреты всех приложений, клиентские секреты
+// внешних провайдеров и ключи подписи вебхуков под текущий ключ: открытые значения шифруются,
+// зашифрованные прежними ключами перешифровываются.
+// Возвращает число секретов и число изменённых. С dryRun только считает, ничего не меняя.
+func (s *repository) RewrapAppSecrets(ctx context.Context, dryRun bool) (total, rewrapped int, err error) {
+	const op = "repository.postgres.RewrapAppSecrets"
+
+	if s.appSecrets == nil {
+		return 0, 0, fmt.Errorf("%s: no app secret key is configured", op)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	for _, col := range []struct{ table, column string }{
+		{"apps", "secret"},
+		{"identity_providers", "client_secret"},
+		{"webhooks", "secret"},
+	} {
+		n, changed, err := s.rewrapColumn(ctx, tx, col.table, col.column, dryRun)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%s: %w", op, err)
+		}
+		total, rewrapped = total+n, rewrapped+changed
+	}
+
+	if dryRun {
+		return total, rewrapped, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return total, rewrapped, nil
+}
+
+// rewrapColumn перешифровывает непустые секреты в столбце column таблицы table.
+func (s *repository) rewrapColumn(ctx context.Context, tx *sql.Tx, table, column string, dryRun bool) (total, rewrapped int, err error) {
+	// FOR UPDATE: секрет не должен смениться между чтением и записью
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, `+column+` FROM `+table+` WHERE `+column+` <> '' ORDER BY id FOR UPDATE`)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	type row struct {
+		id     int64
+		secret string
+	}
+	var secrets []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.id, &r.secret); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		secrets = append(secrets, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	for _, r := range secrets {
+		if !s.appSecrets.NeedsRewrap(r.secret) {
+			continue
+		}
+
+		secret, err := s.appSecrets.Rewrap(r.secret)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%s %d: %w", table, r.id, err)
+		}
+		rewrapped++
+
+		if dryRun {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE `+table+` SET `+column+` = $1 WHERE id = $2`, secret, r.id); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return len(secrets), rewrapped, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// SetAppTokenSettings заменяет параметры токенов приложения организации orgID.
+func (s *repository) SetAppTokenSettings(ctx context.Context, orgID int64, appID int, settings models.TokenSettings) error {
+	const op = "repository.postgres.SetAppTokenSettings"
+
+	ttl, issuer, audience, claims, err := tokenSettingsArgs(settings)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE apps SET token_ttl_seconds = $1, token_issuer = $2, token_audience = $3, token_claims = $4
+		WHERE id = $5 AND `+inOrg(6),
+		ttl, issuer, audience, claims, appID, orgID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrAppNotFound)
+	}
+
+	return nil
+}
+
+// tokenSettingsArgs возвращает параметры токенов в виде значений колонок apps.
+func tokenSettingsArgs(settings models.TokenSettings) (ttl int64, issuer string, audience any, claims []byte, err error) {
+	claimsMap := settings.Claims
+	if claimsMap == nil {
+		claimsMap = map[string]string{}
+	}
+	claims, err = json.Marshal(claimsMap)
+	if err != nil {
+		return 0, "", nil, nil, err
+	}
+
+	aud := settings.Audience
+	if aud == nil {
+		aud = []string{}
+	}
+
+	return int64(settings.TTL / time.Second), settings.Issuer, pq.Array(aud), claims, nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+)
+
+// SetAppAccessPolicy меняет политику доступа приложения организации orgID.
+func (s *repository) SetAppAccessPolicy(ctx context.Context, orgID int64, appID int, policy string) error {
+	const op = "repository.postgres.SetAppAccessPolicy"
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE apps SET access_policy = $1 WHERE id = $2 AND `+inOrg(3), policy, appID, orgID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrAppNotFound)
+	}
+
+	return nil
+}
+
+func (s *repository) AppMember(ctx context.Context, appID int, userID int64) (models.AppMember, error) {
+	const op = "repository.postgres.AppMember"
+
+	member, err := scanAppMember(s.db.QueryRowContext(ctx,
+		`SELECT `+appMemberColumns+` FROM app_users m JOIN users u ON u.id = m.user_id
+		WHERE m.app_id = $1 AND m.user_id = $2`, appID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.AppMember{}, fmt.Errorf("%s: %w", op, _error.ErrMemberNotFound)
+		}
+		return models.AppMember{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return member, nil
+}
+
+// AppMembers возвращает участников приложения, отфильтрованных по status (пусто - все).
+func (s *repository) AppMembers(ctx context.Context, appID int, status string) ([]models.AppMember, error) {
+	const op = "repository.postgres.AppMembers"
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+appMemberColumns+` FROM app_users m JOIN users u ON u.id = m.user_id
+		WHERE m.app_id = $1 AND ($2 = '' OR m.status = $2)
+		ORDER BY m.created_at, m.user_id`, appID, status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var members []models.AppMember
+	for rows.Next() {
+		member, err := scanAppMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return members, nil
+}
+
+// SaveAppMember добавляет участника или меняет статус существующего.
+func (s *repository) SaveAppMember(ctx context.Context, member models.AppMember) (models.AppMember, error) {
+	const op = "repository.postgres.SaveAppMember"
+
+	var addedBy sql.NullInt64
+	if member.AddedBy != 0 {
+		addedBy = sql.NullInt64{Int64: member.AddedBy, Valid: true}
+	}
+
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO app_users(app_id, user_id, status, added_by) VALUES($1, $2, $3, $4)
+		ON CONFLICT (app_id, user_id) DO UPDATE
+		SET status = EXCLUDED.status, added_by = EXCLUDED.added_by, updated_at = now()
+		RETURNING created_at, updated_at`,
+		member.AppID, member.UserID, member.Status, addedBy,
+	).Scan(&member.CreatedAt, &member.UpdatedAt)
+	if err != nil {
+		return models.AppMember{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return member, nil
+}
+
+// RequestAppAccess создаёт заявку на доступ, если пользователь ещё не участник
+// и не подавал заявку. Сообщает, создана ли новая заявка.
+func (s *repository) RequestAppAccess(ctx context.Context, appID int, userID int64) (bool, error) {
+	const op = "repository.postgres.RequestAppAccess"
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO app_users(app_id, user_id, status) VALUES($1, $2, $3)
+		ON CONFLICT (app_id, user_id) DO NOTHING`,
+		appID, userID, models.MemberPending)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return affected > 0, nil
+}
+
+func (s *repository) DeleteAppMember(ctx context.Context, appID int, userID int64) error {
+	const op = "repository.postgres.DeleteAppMember"
+
+	res, err := s.db.ExecContext(ctx,
+		`DELETE FROM app_users WHERE app_id = $1 AND user_id = $2`, appID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrMemberNotFound)
+	}
+
+	return nil
+}
+
+// RevokeAppSessions отзывает активные сессии пользователя в приложении
+// и возвращает число отозванных.
+func (s *repository) RevokeAppSessions(ctx context.Context, appID int, userID int64) (int64, error) {
+	const op = "repository.postgres.RevokeAppSessions"
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = now()
+		WHERE app_id = $1 AND user_id = $2 AND revoked_at IS NULL`, appID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return affected, nil
+}
+
+const appMemberColumns = `m.app_id, m.user_id, u.email, m.status, m.added_by, m.created_at, m.updated_at`
+
+func scanAppMember(row rowScanner) (models.AppMember, error) {
+	var (
+		member  models.AppMember
+		addedBy sql.NullInt64
+	)
+
+	err := row.Scan(&member.AppID, &member.UserID, &member.Email, &member.Status, &addedBy,
+		&member.CreatedAt, &member.UpdatedAt)
+	if err != nil {
+		return models.AppMember{}, err
+	}
+	member.AddedBy = addedBy.Int64
+
+	return member, nil
+}
+
+// accessPolicy возвращает политику приложения для записи в базу.
+func accessPolicy(app models.App) string {
+	if app.AccessPolicy == "" {
+		return models.AccessOpen
+	}
+
+	return app.AccessPolicy
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// SaveAuditEvents добавляет пачку событий в журнал аудита одной транзакцией.
+func (s *repository) SaveAuditEvents(ctx context.Context, events []models.AuditEvent) error {
+	const op = "repository.postgres.SaveAuditEvents"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO audit_events(event_type, user_id, app_id, email, ip, user_agent, metadata, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	for _, e := range events {
+		metadata, err := json.Marshal(e.Metadata)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if e.Metadata == nil {
+			metadata = []byte("{}")
+		}
+
+		_, err = stmt.ExecContext(ctx,
+			e.Type,
+			sql.NullInt64{Int64: e.UserID, Valid: e.UserID != 0},
+			sql.NullInt64{Int64: int64(e.AppID), Valid: e.AppID != 0},
+			nullString(e.Email),
+			nullString(e.IP),
+			nullString(e.UserAgent),
+			metadata,
+			e.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AuditEvents возвращает события аудита по фильтру, от новых к старым.
+// Пагинация - по курсору (ID последнего события предыдущей страницы).
+func (s *repository) AuditEvents(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, error) {
+	const op = "repository.postgres.AuditEvents"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		SELECT `+auditColumns+`
+		FROM audit_events
+		WHERE ($1 = 0 OR user_id = $1)
+		  AND ($2 = 0 OR app_id = $2)
+		  AND (cardinality($3::text[]) = 0 OR event_type = ANY($3))
+		  AND ($4::timestamptz IS NULL OR created_at >= $4)
+		  AND ($5::timestamptz IS NULL OR created_at < $5)
+		  AND ($6 = 0 OR id < $6)
+		ORDER BY id DESC
+		LIMIT $7`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx,
+		filter.UserID,
+		filter.AppID,
+		pq.Array(filter.Types),
+		sql.NullTime{Time: filter.Since, Valid: !filter.Since.IsZero()},
+		sql.NullTime{Time: filter.Until, Valid: !filter.Until.IsZero()},
+		filter.Cursor,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var events []models.AuditEvent
+	for rows.Next() {
+		e, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+const auditColumns = `id, event_type, user_id, app_id, email, ip, user_agent, metadata, created_at`
+
+func scanAuditEvent(row rowScanner) (models.AuditEvent, error) {
+	var (
+		e                models.AuditEvent
+		userID, appID    sql.NullInt64
+		email, ip, agent sql.NullString
+		metadata         []byte
+	)
+	if err := row.Scan(&e.ID, &e.Type, &userID, &appID, &email, &ip, &agent, &metadata, &e.CreatedAt); err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	e.UserID = userID.Int64
+	e.AppID = int(appID.Int64)
+	e.Email = email.String
+	e.IP = ip.String
+	e.UserAgent = agent.String
+	if err := json.Unmarshal(metadata, &e.Metadata); err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	return e, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
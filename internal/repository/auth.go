@@ -7,16 +7,20 @@ import (
 	"fmt"
 
 	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/secretbox"
 	"github.com/Artemiadze/gRPC-Service/internal/models"
 
 	"github.com/lib/pq"
 )
 
 type repository struct {
-	db *sql.DB
+	db         *sql.DB
+	appSecrets *secretbox.Keyring // nil - секреты приложений хранятся открыто
 }
 
-func New(dsn string) (*repository, error) {
+// New подключается к базе. Если appSecrets задан, секреты приложений
+// шифруются при записи и расшифровываются при чтении.
+func New(dsn string, appSecrets *secretbox.Keyring) (*repository, error) {
 	const op = "repository.postgres.New"
 
 	db, err := sql.Open("postgres", dsn)
@@ -29,48 +33,74 @@ func New(dsn string) (*repository, error) {
 		return nil, fmt.Errorf("%s: ping error: %w", op, err)
 	}
 
-	return &repository{db: db}, nil
+	return &repository{db: db, appSecrets: appSecrets}, nil
 }
 
 func (s *repository) Stop() error {
 	return s.db.Close()
 }
 
-func (s *repository) SaveUser(ctx context.Context, email string, passHash []byte) (int64, error) {
+// SaveUser создаёт пользователя в организации orgID и в той же транзакции пишет
+// событие user.registered в outbox.
+func (s *repository) SaveUser(ctx context.Context, orgID int64, email string, passHash []byte) (int64, error) {
 	const op = "repository.postgres.SaveUser"
 
-	stmt, err := s.db.PrepareContext(ctx,
-		`INSERT INTO users(email, pass_hash) VALUES($1, $2) RETURNING id`)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
+
+	id, err := insertUser(ctx, tx, orgID, email, passHash)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
 
+	return id, nil
+}
+
+// insertUser добавляет пользователя и событие user.registered в outbox в транзакции tx.
+func insertUser(ctx context.Context, tx *sql.Tx, orgID int64, email string, passHash []byte) (int64, error) {
 	var id int64
-	err = stmt.QueryRowContext(ctx, email, passHash).Scan(&id)
+	err := tx.QueryRowContext(ctx,
+		`INSERT INTO users(org_id, email, pass_hash) VALUES($1, $2, $3) RETURNING id`,
+		orgID, email, passHash,
+	).Scan(&id)
 	if err != nil {
 		var pgErr *pq.Error
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return 0, fmt.Errorf("%s: %w", op, _error.ErrUserExists)
+			return 0, _error.ErrUserExists
 		}
-		return 0, fmt.Errorf("%s: %w", op, err)
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return 0, _error.ErrOrgNotFound
+		}
+		return 0, err
+	}
+
+	if err := insertOutboxEvent(ctx, tx, registeredEvent(orgID, id, email)); err != nil {
+		return 0, err
 	}
 
 	return id, nil
 }
 
-func (s *repository) User(ctx context.Context, email string) (models.User, error) {
+// User ищет пользователя по email в организации orgID.
+// Email уникален только в пределах организации, поэтому orgID обязателен.
+func (s *repository) User(ctx context.Context, orgID int64, email string) (models.User, error) {
 	const op = "repository.postgres.User"
 
 	stmt, err := s.db.PrepareContext(ctx,
-		`SELECT id, email, pass_hash FROM users WHERE email = $1`)
+		`SELECT `+userColumns+` FROM users WHERE org_id = $1 AND email = $2`)
 	if err != nil {
 		return models.User{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
-	var user models.User
-	err = stmt.QueryRowContext(ctx, email).Scan(&user.ID, &user.Email, &user.PassHash)
+	user, err := scanUser(stmt.QueryRowContext(ctx, orgID, email))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.User{}, fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
@@ -81,18 +111,19 @@ func (s *repository) User(ctx context.Context, email string) (models.User, error
 	return user, nil
 }
 
-func (s *repository) App(ctx context.Context, id int) (models.App, error) {
+// App ищет приложение организации orgID. Приложение другой организации
+// не находится; models.AnyOrg снимает ограничение.
+func (s *repository) App(ctx context.Context, orgID int64, id int) (models.App, error) {
 	const op = "repository.postgres.App"
 
 	stmt, err := s.db.PrepareContext(ctx,
-		`SELECT id, name, secret FROM apps WHERE id = $1`)
+		`SELECT `+appColumns+` FROM apps WHERE id = $1 AND `+inOrg(2))
 	if err != nil {
 		return models.App{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
-	var app models.App
-	err = stmt.QueryRowContext(ctx, id).Scan(&app.ID, &app.Name, &app.Secret)
+	app, err := scanApp(stmt.QueryRowContext(ctx, id, orgID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.App{}, fmt.Errorf("%s: %w", op, _error.ErrAppNotFound)
@@ -100,6 +131,10 @@ func (s *repository) App(ctx context.Context, id int) (models.App, error) {
 		return models.App{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if app.Secret, err = s.openAppSecret(app.Secret); err != nil {
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return app, nil
 }
 
@@ -124,3 +159,29 @@ func (s *repository) IsAdmin(ctx context.Context, userID int64) (bool, error) {
 
 	return isAdmin, nil
 }
+
+func (s *repository) UpdatePassHash(ctx context.Context, userID int64, passHash []byte) error {
+	const op = "repository.postgres.UpdatePassHash"
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`UPDATE users SET pass_hash = $1 WHERE id = $2`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, passHash, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+	}
+
+	return nil
+}
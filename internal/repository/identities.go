@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/lib/pq"
+)
+
+const idpColumns = `id, app_id, name, issuer, client_id, client_secret, redirect_uri, scopes,
+	email_claim, verified_claim, name_claim, trust_email, link_by_email, created_at, updated_at`
+
+// SaveIdentityProvider создаёт провайдер приложения организации orgID
+// или заменяет провайдер с тем же именем.
+func (s *repository) SaveIdentityProvider(ctx context.Context, orgID int64, p models.IdentityProvider) (models.IdentityProvider, error) {
+	const op = "repository.postgres.SaveIdentityProvider"
+
+	secret, err := s.sealIdPSecret(p.ClientSecret)
+	if err != nil {
+		return models.IdentityProvider{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// SELECT из apps: приложение другой организации не найдётся и строка не вставится
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO identity_providers (app_id, name, issuer, client_id, client_secret, redirect_uri, scopes,
+			email_claim, verified_claim, name_claim, trust_email, link_by_email)
+		SELECT id, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13 FROM apps WHERE id = $1 AND `+inOrg(2)+`
+		ON CONFLICT (app_id, name) DO UPDATE
+		SET issuer = EXCLUDED.issuer, client_id = EXCLUDED.client_id, client_secret = EXCLUDED.client_secret,
+			redirect_uri = EXCLUDED.redirect_uri, scopes = EXCLUDED.scopes, email_claim = EXCLUDED.email_claim,
+			verified_claim = EXCLUDED.verified_claim, name_claim = EXCLUDED.name_claim,
+			trust_email = EXCLUDED.trust_email, link_by_email = EXCLUDED.link_by_email, updated_at = now()
+		RETURNING id, created_at, updated_at`,
+		p.AppID, orgID, p.Name, p.Issuer, p.ClientID, secret, p.RedirectURI, idpScopes(p),
+		p.Claims.Email, p.Claims.EmailVerified, p.Claims.Name, p.TrustEmail, p.LinkByEmail,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.IdentityProvider{}, fmt.Errorf("%s: %w", op, _error.ErrAppNotFound)
+		}
+		return models.IdentityProvider{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return p, nil
+}
+
+// IdentityProviders возвращает провайдеры приложения по имени.
+func (s *repository) IdentityProviders(ctx context.Context, appID int) ([]models.IdentityProvider, error) {
+	const op = "repository.postgres.IdentityProviders"
+
+	providers, err := collect(ctx, s.db, s.scanIdentityProvider,
+		`SELECT `+idpColumns+` FROM identity_providers WHERE app_id = $1 ORDER BY name`, appID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return providers, nil
+}
+
+// IdentityProvider ищет провайдер приложения по имени.
+func (s *repository) IdentityProvider(ctx context.Context, appID int, name string) (models.IdentityProvider, error) {
+	const op = "repository.postgres.IdentityProvider"
+
+	p, err := s.scanIdentityProvider(s.db.QueryRowContext(ctx,
+		`SELECT `+idpColumns+` FROM identity_providers WHERE app_id = $1 AND name = $2`, appID, name))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.IdentityProvider{}, fmt.Errorf("%s: %w", op, _error.ErrIdPNotFound)
+		}
+		return models.IdentityProvider{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return p, nil
+}
+
+// IdentityProviderByID ищет провайдер по ID.
+func (s *repository) IdentityProviderByID(ctx context.Context, id int64) (models.IdentityProvider, error) {
+	const op = "repository.postgres.IdentityProviderByID"
+
+	p, err := s.scanIdentityProvider(s.db.QueryRowContext(ctx,
+		`SELECT `+idpColumns+` FROM identity_providers WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.IdentityProvider{}, fmt.Errorf("%s: %w", op, _error.ErrIdPNotFound)
+		}
+		return models.IdentityProvider{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return p, nil
+}
+
+// DeleteIdentityProvider удаляет провайдер приложения. Привязанные учётные
+// записи пользователей остаются: они относятся к издателю, а не к провайдеру.
+func (s *repository) DeleteIdentityProvider(ctx context.Context, appID int, name string) error {
+	const op = "repository.postgres.DeleteIdentityProvider"
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM identity_providers WHERE app_id = $1 AND name = $2`, appID, name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrIdPNotFound)
+	}
+
+	return nil
+}
+
+// SaveExternalLogin сохраняет начатый вход и заодно удаляет истёкшие.
+func (s *repository) SaveExternalLogin(ctx context.Context, login models.ExternalLogin) error {
+	const op = "repository.postgres.SaveExternalLogin"
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM external_logins WHERE expires_at < now()`); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO external_logins (state, provider_id, app_id, user_id, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		login.State, login.ProviderID, login.AppID, sql.NullInt64{Int64: login.UserID, Valid: login.UserID != 0},
+		login.Nonce, login.CodeVerifier, login.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// TakeExternalLogin удаляет и возвращает действующий вход state,
+// поэтому завершить его можно только один раз.
+func (s *repository) TakeExternalLogin(ctx context.Context, state string) (models.ExternalLogin, error) {
+	const op = "repository.postgres.TakeExternalLogin"
+
+	login := models.ExternalLogin{State: state}
+	var userID sql.NullInt64
+	err := s.db.QueryRowContext(ctx, `
+		DELETE FROM external_logins
+		WHERE state = $1 AND expires_at > now()
+		RETURNING provider_id, app_id, user_id, nonce, code_verifier, expires_at`,
+		state,
+	).Scan(&login.ProviderID, &login.AppID, &userID, &login.Nonce, &login.CodeVerifier, &login.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ExternalLogin{}, fmt.Errorf("%s: %w", op, _error.ErrExternalLogin)
+		}
+		return models.ExternalLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+	login.UserID = userID.Int64
+
+	return login, nil
+}
+
+const identityColumns = `id, user_id, org_id, issuer, subject, email, created_at, last_login_at`
+
+// UserIdentity ищет учётную запись издателя issuer в организации orgID.
+func (s *repository) UserIdentity(ctx context.Context, orgID int64, issuer, subject string) (models.UserIdentity, error) {
+	const op = "repository.postgres.UserIdentity"
+
+	identity, err := scanIdentity(s.db.QueryRowContext(ctx,
+		`SELECT `+identityColumns+` FROM user_identities WHERE org_id = $1 AND issuer = $2 AND subject = $3`,
+		orgID, issuer, subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.UserIdentity{}, fmt.Errorf("%s: %w", op, _error.ErrIdentityNotFound)
+		}
+		return models.UserIdentity{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return identity, nil
+}
+
+// LinkIdentity привязывает внешнюю учётную запись к пользователю.
+// Если она уже привязана к кому-то или у пользователя есть другая
+// учётная запись того же издателя, возвращает ErrIdentityLinked.
+func (s *repository) LinkIdentity(ctx context.Context, identity models.UserIdentity) (int64, error) {
+	const op = "repository.postgres.LinkIdentity"
+
+	id, err := insertIdentity(ctx, s.db, identity)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// SaveExternalUser создаёт пользователя без пароля вместе с его внешней
+// учётной записью в одной транзакции.
+func (s *repository) SaveExternalUser(ctx context.Context, user models.User, identity models.UserIdentity) (int64, error) {
+	const op = "repository.postgres.SaveExternalUser"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	id, err := insertUser(ctx, tx, user.OrgID, user.Email, []byte{})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.DisplayName != "" {
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET display_name = $1 WHERE id = $2`, user.DisplayName, id); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	identity.UserID = id
+	if _, err := insertIdentity(ctx, tx, identity); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// TouchIdentity отмечает вход через внешнюю учётную запись и запоминает
+// её текущий email.
+func (s *repository) TouchIdentity(ctx context.Context, id int64, email string) error {
+	const op = "repository.postgres.TouchIdentity"
+
+	_, err := s.db.ExecContext(ctx, `UPDATE user_identities SET last_login_at = now(), email = $2 WHERE id = $1`, id, email)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func insertIdentity(ctx context.Context, q queryRower, identity models.UserIdentity) (int64, error) {
+	var id int64
+	err := q.QueryRowContext(ctx, `
+		INSERT INTO user_identities (user_id, org_id, issuer, subject, email, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, now())
+		RETURNING id`,
+		identity.UserID, identity.OrgID, identity.Issuer, identity.Subject, identity.Email,
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, _error.ErrIdentityLinked
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *repository) scanIdentityProvider(row rowScanner) (models.IdentityProvider, error) {
+	var p models.IdentityProvider
+	err := row.Scan(&p.ID, &p.AppID, &p.Name, &p.Issuer, &p.ClientID, &p.ClientSecret, &p.RedirectURI,
+		pq.Array(&p.Scopes), &p.Claims.Email, &p.Claims.EmailVerified, &p.Claims.Name, &p.TrustEmail, &p.LinkByEmail,
+		&p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return models.IdentityProvider{}, err
+	}
+
+	if p.ClientSecret, err = s.openAppSecret(p.ClientSecret); err != nil {
+		return models.IdentityProvider{}, err
+	}
+
+	return p, nil
+}
+
+func scanIdentity(row rowScanner) (models.UserIdentity, error) {
+	var (
+		identity  models.UserIdentity
+		lastLogin sql.NullTime
+	)
+
+	err := row.Scan(&identity.ID, &identity.UserID, &identity.OrgID, &identity.Issuer, &identity.Subject,
+		&identity.Email, &identity.CreatedAt, &lastLogin)
+	if err != nil {
+		return models.UserIdentity{}, err
+	}
+	identity.LastLoginAt = lastLogin.Time
+
+	return identity, nil
+}
+
+func idpScopes(p models.IdentityProvider) any {
+	scopes := p.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return pq.Array(scopes)
+}
+
+// sealIdPSecret шифрует клиентский секрет провайдера тем же ключом,
+// что и секреты приложений. Пустой секрет публичного клиента не шифруется.
+func (s *repository) sealIdPSecret(secret string) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+
+	return s.sealAppSecret(secret)
+}
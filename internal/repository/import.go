@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/lib/pq"
+)
+
+// ImportUsers создаёт пачку пользователей в организации orgID одной транзакцией.
+// Строки загружаются через COPY во временную таблицу, оттуда одним запросом
+// переносятся в users, а для созданных пользователей пишутся события
+// user.registered в outbox. Пользователи, email которых в организации уже
+// занят, пропускаются. Возвращает номера строк, по которым созданы пользователи.
+func (s *repository) ImportUsers(ctx context.Context, orgID int64, users []models.ImportedUser) ([]int64, error) {
+	const op = "repository.postgres.ImportUsers"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE TEMP TABLE import_users
+		(
+			row_num      BIGINT NOT NULL,
+			email        TEXT   NOT NULL,
+			pass_hash    BYTEA  NOT NULL,
+			roles        TEXT[],
+			display_name TEXT   NOT NULL
+		) ON COMMIT DROP`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("import_users", "row_num", "email", "pass_hash", "roles", "display_name"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, u := range users {
+		if _, err := stmt.ExecContext(ctx, u.Row, u.Email, u.PassHash, pq.StringArray(u.Roles), u.DisplayName); err != nil {
+			stmt.Close()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	// Пустой Exec завершает COPY
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := stmt.Close(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created, err := collect(ctx, tx, scanImported, `
+		WITH inserted AS (
+			INSERT INTO users (org_id, email, pass_hash, roles, display_name)
+			SELECT $1::bigint, email, pass_hash, COALESCE(roles, '{}'), display_name
+			FROM import_users
+			ORDER BY row_num
+			ON CONFLICT (org_id, email) DO NOTHING
+			RETURNING id, email
+		)
+		SELECT i.row_num, inserted.id, inserted.email
+		FROM import_users i
+		JOIN inserted USING (email)
+		ORDER BY i.row_num`, orgID)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, fmt.Errorf("%s: %w", op, _error.ErrOrgNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows := make([]int64, 0, len(created))
+	events := make([]models.UserEvent, 0, len(created))
+	for _, u := range created {
+		rows = append(rows, u.row)
+		events = append(events, registeredEvent(orgID, u.id, u.email))
+	}
+	if err := insertOutboxEvents(ctx, tx, events); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rows, nil
+}
+
+// importedUser - созданный при импорте пользователь и номер его строки.
+type importedUser struct {
+	row   int64
+	id    int64
+	email string
+}
+
+func scanImported(row rowScanner) (importedUser, error) {
+	var u importedUser
+	err := row.Scan(&u.row, &u.id, &u.email)
+	return u, err
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/lib/pq"
+)
+
+// SetUserDisabled отключает или включает учётную запись.
+// Возвращает время отключения (нулевое - запись включена).
+func (s *repository) SetUserDisabled(ctx context.Context, userID int64, disabled bool) (time.Time, error) {
+	const op = "repository.postgres.SetUserDisabled"
+
+	var disabledAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE users
+		SET disabled_at = CASE WHEN $1::boolean THEN COALESCE(disabled_at, now()) END
+		WHERE id = $2
+		RETURNING disabled_at`, disabled, userID).Scan(&disabledAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+		}
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return disabledAt.Time, nil
+}
+
+// SetUserDeleteAfter назначает удаление пользователя на deleteAfter.
+// Нулевое время отменяет удаление.
+func (s *repository) SetUserDeleteAfter(ctx context.Context, userID int64, deleteAfter time.Time) error {
+	const op = "repository.postgres.SetUserDeleteAfter"
+
+	res, err := s.db.ExecContext(ctx, `UPDATE users SET delete_after = $1 WHERE id = $2`,
+		sql.NullTime{Time: deleteAfter, Valid: !deleteAfter.IsZero()}, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// UserExport собирает всё, что хранится о пользователе: учётную запись,
+// метаданные приложений, допуски, сессии и журнал аудита.
+// Чтение идёт в одном снимке базы, поэтому части выгрузки согласованы.
+func (s *repository) UserExport(ctx context.Context, userID int64) (models.UserExport, error) {
+	const op = "repository.postgres.UserExport"
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var rawMetadata []byte
+	user, err := scanUser(scanWith(tx.QueryRowContext(ctx,
+		`SELECT `+userColumns+`, metadata FROM users WHERE id = $1`, userID), &rawMetadata))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.UserExport{}, fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+		}
+		return models.UserExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var metadata map[string]any
+	if err := json.Unmarshal(rawMetadata, &metadata); err != nil {
+		return models.UserExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	members, err := collect(ctx, tx, scanAppMember,
+		`SELECT `+appMemberColumns+` FROM app_users m JOIN users u ON u.id = m.user_id
+		WHERE m.user_id = $1 ORDER BY m.app_id`, userID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessions, err := collect(ctx, tx, scanSession,
+		`SELECT id, user_id, app_id, ip, user_agent, created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	events, err := collect(ctx, tx, scanAuditEvent,
+		`SELECT `+auditColumns+` FROM audit_events WHERE user_id = $1 ORDER BY id`, userID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return models.NewUserExport(user, metadata, members, sessions, events, time.Now().UTC()), nil
+}
+
+// UsersToErase возвращает пользователей, срок ожидания удаления которых истёк,
+// кроме skip. Время берётся из базы, как и в EraseUser.
+func (s *repository) UsersToErase(ctx context.Context, skip []int64, limit int) ([]int64, error) {
+	const op = "repository.postgres.UsersToErase"
+
+	// NULL вместо пустого массива отфильтровал бы всех
+	if skip == nil {
+		skip = []int64{}
+	}
+
+	ids, err := collect(ctx, s.db, scanInt64, `
+		SELECT id FROM users WHERE delete_after <= now() AND id <> ALL($1)
+		ORDER BY delete_after LIMIT $2`, pq.Array(skip), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// EraseUser удаляет пользователя, срок ожидания удаления которого истёк.
+// Записи журнала аудита остаются, но из них, из событий outbox и из доставок
+// вебхуков стираются email, IP и User-Agent. Сессии и допуски удаляются
+// вместе с пользователем. Событие user.deleted пишется в outbox в той же транзакции.
+// Возвращает приложения, в которые пользователь входил. Если удаление
+// отменено или пользователь уже удалён, возвращает ErrUserNotFound.
+func (s *repository) EraseUser(ctx context.Context, userID int64) ([]int, error) {
+	const op = "repository.postgres.EraseUser"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка строки не даёт параллельно отменить удаление
+	var id int64
+	err = tx.QueryRowContext(ctx,
+		`SELECT id FROM users WHERE id = $1 AND delete_after <= now() FOR UPDATE`, userID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	appIDs, err := collect(ctx, tx, func(row rowScanner) (int, error) {
+		var id int
+		return id, row.Scan(&id)
+	}, `SELECT DISTINCT app_id FROM sessions WHERE user_id = $1 ORDER BY app_id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, query := range []string{
+		`UPDATE audit_events SET email = NULL, ip = NULL, user_agent = NULL
+		WHERE user_id = $1 AND (email IS NOT NULL OR ip IS NOT NULL OR user_agent IS NOT NULL)`,
+		`UPDATE outbox SET payload = payload - 'email' WHERE user_id = $1 AND payload ? 'email'`,
+		`UPDATE webhook_deliveries SET payload = payload - 'email' - 'ip' - 'user_agent'
+		WHERE (payload ->> 'user_id')::bigint = $1`,
+		`DELETE FROM users WHERE id = $1`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := insertOutboxEvent(ctx, tx, models.UserEvent{Type: models.UserDeleted, UserID: userID}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return appIDs, nil
+}
+
+// querier - *sql.DB или *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// collect выполняет запрос и разбирает все строки функцией scan.
+func collect[T any](ctx context.Context, q querier, scan func(rowScanner) (T, error), query string, args ...any) ([]T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []T
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func scanInt64(row rowScanner) (int64, error) {
+	var v int64
+	err := row.Scan(&v)
+	return v, err
+}
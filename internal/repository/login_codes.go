@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+)
+
+// SaveLoginCode сохраняет код входа без пароля. Прежние коды пользователя
+// для того же приложения перестают действовать, но остаются вместе со
+// счётчиками попыток, чтобы новый код не сбрасывал ограничения.
+// Коды, созданные до purgeBefore, для ограничений уже не нужны и удаляются.
+func (s *repository) SaveLoginCode(ctx context.Context, code models.LoginCode, purgeBefore time.Time) error {
+	const op = "repository.postgres.SaveLoginCode"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM login_codes WHERE user_id = $1 AND created_at < $2`, code.UserID, purgeBefore)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE login_codes SET expires_at = now()
+		WHERE user_id = $1 AND app_id = $2 AND used_at IS NULL AND expires_at > now()`,
+		code.UserID, code.AppID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO login_codes (id, user_id, app_id, method, code_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		code.ID, code.UserID, code.AppID, code.Method, code.CodeHash, code.CreatedAt, code.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// LoginCodeStats возвращает, сколько кодов отправлено пользователю с since
+// по всем приложениям и сколько раз по ним введён неверный код.
+func (s *repository) LoginCodeStats(ctx context.Context, userID int64, since time.Time) (int, int, error) {
+	const op = "repository.postgres.LoginCodeStats"
+
+	var sent, failed int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT count(*), COALESCE(sum(attempts - CASE WHEN used_at IS NULL THEN 0 ELSE 1 END), 0)
+		FROM login_codes
+		WHERE user_id = $1 AND created_at >= $2`,
+		userID, since,
+	).Scan(&sent, &failed)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sent, failed, nil
+}
+
+// UseLoginCode погашает код id, если его хэш совпадает с codeHash.
+// Каждая попытка, удачная или нет, увеличивает счётчик; после
+// limits.MaxAttempts попыток, по истечении срока или после использования
+// код не принимается. Не принимаются и коды пользователя, который с
+// limits.Since ввёл limits.MaxFailures неверных кодов.
+// Проверка и погашение - один UPDATE, поэтому код нельзя использовать дважды
+// даже при параллельных запросах. При несовпадении возвращает код вместе
+// с ErrInvalidLoginCode, чтобы попытку можно было записать в аудит.
+func (s *repository) UseLoginCode(ctx context.Context, id string, codeHash []byte, limits models.LoginCodeLimits) (models.LoginCode, error) {
+	const op = "repository.postgres.UseLoginCode"
+
+	code := models.LoginCode{ID: id}
+	var used bool
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE login_codes c
+		SET attempts = c.attempts + 1,
+		    used_at = CASE WHEN c.code_hash = $2 THEN now() END
+		WHERE c.id = $1 AND c.used_at IS NULL AND c.expires_at > now() AND c.attempts < $3
+		  AND (
+			SELECT COALESCE(sum(f.attempts - CASE WHEN f.used_at IS NULL THEN 0 ELSE 1 END), 0)
+			FROM login_codes f
+			WHERE f.user_id = c.user_id AND f.created_at >= $4
+		  ) < $5
+		RETURNING c.user_id, c.app_id, c.method, c.attempts, c.created_at, c.expires_at, c.used_at IS NOT NULL`,
+		id, codeHash, limits.MaxAttempts, limits.Since, limits.MaxFailures,
+	).Scan(&code.UserID, &code.AppID, &code.Method, &code.Attempts, &code.CreatedAt, &code.ExpiresAt, &used)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.LoginCode{}, fmt.Errorf("%s: %w", op, _error.ErrInvalidLoginCode)
+		}
+		return models.LoginCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !used {
+		return code, fmt.Errorf("%s: %w", op, _error.ErrInvalidLoginCode)
+	}
+
+	return code, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+func (s *repository) OrgBySlug(ctx context.Context, slug string) (models.Org, error) {
+	const op = "repository.postgres.OrgBySlug"
+
+	var org models.Org
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, slug, name, created_at FROM orgs WHERE slug = $1`, slug,
+	).Scan(&org.ID, &org.Slug, &org.Name, &org.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Org{}, fmt.Errorf("%s: %w", op, _error.ErrOrgNotFound)
+		}
+		return models.Org{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return org, nil
+}
+
+func (s *repository) SaveOrg(ctx context.Context, org models.Org) (int64, error) {
+	const op = "repository.postgres.SaveOrg"
+
+	var id int64
+	err := s.db.QueryRowContext(ctx,
+		`INSERT INTO orgs(slug, name) VALUES($1, $2) RETURNING id`, org.Slug, org.Name,
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, fmt.Errorf("%s: %w", op, _error.ErrOrgExists)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *repository) Orgs(ctx context.Context) ([]models.Org, error) {
+	const op = "repository.postgres.Orgs"
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, slug, name, created_at FROM orgs ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var orgs []models.Org
+	for rows.Next() {
+		var org models.Org
+		if err := rows.Scan(&org.ID, &org.Slug, &org.Name, &org.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		orgs = append(orgs, org)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orgs, nil
+}
+
+// SetUserOrgAdmin выдаёт или отзывает права администратора организации.
+// Пользователь другой организации не находится; models.AnyOrg снимает ограничение.
+func (s *repository) SetUserOrgAdmin(ctx context.Context, orgID int64, userID int64, orgAdmin bool) error {
+	const op = "repository.postgres.SetUserOrgAdmin"
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE users SET org_admin = $1 WHERE id = $2 AND `+inOrg(3), orgAdmin, userID, orgID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/lib/pq"
+)
+
+// insertOutboxEvent пишет событие в outbox в рамках транзакции изменения пользователя.
+func insertOutboxEvent(ctx context.Context, tx *sql.Tx, event models.UserEvent) error {
+	event, payload, err := encodeOutboxEvent(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO outbox(event_type, user_id, payload, created_at) VALUES($1, $2, $3, $4)`,
+		event.Type, event.UserID, payload, event.OccurredAt,
+	)
+
+	return err
+}
+
+// insertOutboxEvents пишет пачку событий в outbox через COPY.
+func insertOutboxEvents(ctx context.Context, tx *sql.Tx, events []models.UserEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("outbox", "event_type", "user_id", "payload", "created_at"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, event := range events {
+		event, payload, err := encodeOutboxEvent(event)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx, event.Type, event.UserID, string(payload), event.OccurredAt); err != nil {
+			return err
+		}
+	}
+	// Пустой Exec завершает COPY
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return err
+	}
+
+	return stmt.Close()
+}
+
+// registeredEvent - событие user.registered пользователя организации orgID.
+func registeredEvent(orgID, userID int64, email string) models.UserEvent {
+	return models.UserEvent{
+		Type:   models.UserRegistered,
+		UserID: userID,
+		Email:  email,
+		Data:   map[string]string{"org_id": strconv.FormatInt(orgID, 10)},
+	}
+}
+
+// encodeOutboxEvent сериализует событие для колонки payload. Время события
+// по умолчанию - текущее.
+func encodeOutboxEvent(event models.UserEvent) (models.UserEvent, []byte, error) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return models.UserEvent{}, nil, err
+	}
+
+	return event, payload, nil
+}
+
+// ClaimOutboxEvents забирает пачку неопубликованных событий на время lease.
+// Пока lease не истёк, другие реплики эти события не увидят; если диспетчер
+// упадёт, не отметив их, события будут выданы повторно (at-least-once).
+func (s *repository) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.UserEvent, error) {
+	const op = "repository.postgres.ClaimOutboxEvents"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		UPDATE outbox SET next_attempt_at = now() + $2::interval, attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE published_at IS NULL AND next_attempt_at <= now()
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, payload`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, limit, lease.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	events, err := scanUserEvents(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+func (s *repository) MarkOutboxPublished(ctx context.Context, id int64) error {
+	const op = "repository.postgres.MarkOutboxPublished"
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`UPDATE outbox SET published_at = now(), last_error = NULL WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkOutboxFailed сохраняет ошибку публикации и откладывает следующую
+// попытку с экспоненциальной задержкой (2^attempts секунд, но не больше maxDelay).
+func (s *repository) MarkOutboxFailed(ctx context.Context, id int64, cause error, maxDelay time.Duration) error {
+	const op = "repository.postgres.MarkOutboxFailed"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		UPDATE outbox
+		SET last_error = $2,
+		    next_attempt_at = now() + LEAST(power(2, LEAST(attempts, 20)) * interval '1 second', $3::interval)
+		WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, id, cause.Error(), maxDelay.String()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UserEventsAfter возвращает события с ID больше afterID по возрастанию ID.
+// Используется подписчиками, чтобы догнать пропущенные события.
+func (s *repository) UserEventsAfter(ctx context.Context, afterID int64, limit int) ([]models.UserEvent, error) {
+	const op = "repository.postgres.UserEventsAfter"
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`SELECT id, payload FROM outbox WHERE id > $1 ORDER BY id LIMIT $2`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	events, err := scanUserEvents(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// LastUserEventID возвращает ID последнего события outbox или 0, если событий нет.
+func (s *repository) LastUserEventID(ctx context.Context) (int64, error) {
+	const op = "repository.postgres.LastUserEventID"
+
+	var id int64
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(max(id), 0) FROM outbox`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func scanUserEvents(rows *sql.Rows) ([]models.UserEvent, error) {
+	var events []models.UserEvent
+	for rows.Next() {
+		var (
+			id      int64
+			payload []byte
+			event   models.UserEvent
+		)
+		if err := rows.Scan(&id, &payload); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+		event.ID = id
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
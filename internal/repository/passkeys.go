@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/lib/pq"
+)
+
+// SetAppPasskeySettings заменяет проверяющую сторону WebAuthn приложения организации orgID.
+func (s *repository) SetAppPasskeySettings(ctx context.Context, orgID int64, appID int, settings models.PasskeySettings) error {
+	const op = "repository.postgres.SetAppPasskeySettings"
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE apps SET passkey_rp_id = $1, passkey_rp_name = $2, passkey_origins = $3
+		WHERE id = $4 AND `+inOrg(5),
+		settings.RPID, settings.RPName, passkeyOrigins(settings), appID, orgID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrAppNotFound)
+	}
+
+	return nil
+}
+
+// SavePasskeyChallenge сохраняет вызов новой церемонии и заодно удаляет
+// вызовы, срок которых истёк.
+func (s *repository) SavePasskeyChallenge(ctx context.Context, ch models.PasskeyChallenge) error {
+	const op = "repository.postgres.SavePasskeyChallenge"
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM passkey_challenges WHERE expires_at < now()`); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO passkey_challenges (id, kind, user_id, app_id, challenge, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		ch.ID, ch.Kind, sql.NullInt64{Int64: ch.UserID, Valid: ch.UserID != 0}, ch.AppID, ch.Challenge, ch.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// TakePasskeyChallenge удаляет и возвращает действующий вызов id вида kind,
+// поэтому на один вызов можно ответить только один раз.
+func (s *repository) TakePasskeyChallenge(ctx context.Context, id string, kind string) (models.PasskeyChallenge, error) {
+	const op = "repository.postgres.TakePasskeyChallenge"
+
+	ch := models.PasskeyChallenge{ID: id, Kind: kind}
+	var userID sql.NullInt64
+	err := s.db.QueryRowContext(ctx, `
+		DELETE FROM passkey_challenges
+		WHERE id = $1 AND kind = $2 AND expires_at > now()
+		RETURNING user_id, app_id, challenge, expires_at`,
+		id, kind,
+	).Scan(&userID, &ch.AppID, &ch.Challenge, &ch.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PasskeyChallenge{}, fmt.Errorf("%s: %w", op, _error.ErrInvalidPasskey)
+		}
+		return models.PasskeyChallenge{}, fmt.Errorf("%s: %w", op, err)
+	}
+	ch.UserID = userID.Int64
+
+	return ch, nil
+}
+
+// SavePasskey сохраняет новый ключ доступа.
+func (s *repository) SavePasskey(ctx context.Context, key models.Passkey) error {
+	const op = "repository.postgres.SavePasskey"
+
+	transports := key.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO passkeys (id, user_id, rp_id, name, public_key, algorithm, sign_count, transports, aaguid)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		key.ID, key.UserID, key.RPID, key.Name, key.PublicKey, key.Algorithm, int64(key.SignCount),
+		pq.Array(transports), key.AAGUID,
+	)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%s: %w", op, _error.ErrPasskeyExists)
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+const passkeyColumns = `id, user_id, rp_id, name, public_key, algorithm, sign_count, transports, aaguid,
+	created_at, last_used_at`
+
+// Passkey возвращает ключ доступа по ID учётных данных.
+func (s *repository) Passkey(ctx context.Context, id []byte) (models.Passkey, error) {
+	const op = "repository.postgres.Passkey"
+
+	key, err := scanPasskey(s.db.QueryRowContext(ctx, `SELECT `+passkeyColumns+` FROM passkeys WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Passkey{}, fmt.Errorf("%s: %w", op, _error.ErrInvalidPasskey)
+		}
+		return models.Passkey{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+// Passkeys возвращает ключи доступа пользователя для RP ID rpID.
+func (s *repository) Passkeys(ctx context.Context, userID int64, rpID string) ([]models.Passkey, error) {
+	const op = "repository.postgres.Passkeys"
+
+	keys, err := collect(ctx, s.db, scanPasskey,
+		`SELECT `+passkeyColumns+` FROM passkeys WHERE user_id = $1 AND rp_id = $2 ORDER BY created_at`,
+		userID, rpID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// UsePasskey записывает новый счётчик подписей после входа. Счётчик
+// меняется, только если вырос (или ключ его не ведёт): из двух одновременных
+// входов с одной подписью пройдёт один.
+func (s *repository) UsePasskey(ctx context.Context, id []byte, signCount uint32) error {
+	const op = "repository.postgres.UsePasskey"
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE passkeys SET sign_count = $2, last_used_at = now()
+		WHERE id = $1 AND (sign_count < $2 OR ($2 = 0 AND sign_count = 0))`,
+		id, int64(signCount))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrInvalidPasskey)
+	}
+
+	return nil
+}
+
+// PasskeyUserHandle возвращает user handle WebAuthn пользователя.
+// Если его ещё нет, сохраняет candidate. Пусто - у пользователя нет ключей.
+func (s *repository) PasskeyUserHandle(ctx context.Context, userID int64, candidate []byte) ([]byte, error) {
+	const op = "repository.postgres.PasskeyUserHandle"
+
+	if len(candidate) > 0 {
+		_, err := s.db.ExecContext(ctx,
+			`INSERT INTO passkey_user_handles (user_id, handle) VALUES ($1, $2) ON CONFLICT (user_id) DO NOTHING`,
+			userID, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	var handle []byte
+	err := s.db.QueryRowContext(ctx, `SELECT handle FROM passkey_user_handles WHERE user_id = $1`, userID).Scan(&handle)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return handle, nil
+}
+
+func scanPasskey(row rowScanner) (models.Passkey, error) {
+	var (
+		key       models.Passkey
+		signCount int64
+		lastUsed  sql.NullTime
+	)
+	err := row.Scan(&key.ID, &key.UserID, &key.RPID, &key.Name, &key.PublicKey, &key.Algorithm, &signCount,
+		pq.Array(&key.Transports), &key.AAGUID, &key.CreatedAt, &lastUsed)
+	if err != nil {
+		return models.Passkey{}, err
+	}
+	key.SignCount = uint32(signCount)
+	key.LastUsedAt = lastUsed.Time
+
+	return key, nil
+}
+
+// passkeyOrigins возвращает origin приложения для записи в базу.
+func passkeyOrigins(settings models.PasskeySettings) any {
+	origins := settings.Origins
+	if origins == nil {
+		origins = []string{}
+	}
+
+	return pq.Array(origins)
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+)
+
+// Profile возвращает профиль пользователя с метаданными приложения appID.
+func (s *repository) Profile(ctx context.Context, userID int64, appID int) (models.Profile, error) {
+	const op = "repository.postgres.Profile"
+
+	var metadata []byte
+	user, err := scanUser(scanWith(s.db.QueryRowContext(ctx,
+		`SELECT `+userColumns+`, COALESCE(metadata -> $2, '{}'::jsonb) FROM users WHERE id = $1`,
+		userID, strconv.Itoa(appID)), &metadata))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Profile{}, fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+		}
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	profile := models.Profile{User: user, AppID: appID}
+	if err := json.Unmarshal(metadata, &profile.Metadata); err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profile, nil
+}
+
+// UpdateProfile сохраняет поля профиля и метаданные приложения profile.AppID,
+// если версия профиля в базе равна version, и пишет событие user.profile_updated
+// в outbox. fields - изменённые поля, они попадают в событие.
+// Возвращает профиль с новой версией; при другой версии - ErrVersionConflict.
+func (s *repository) UpdateProfile(ctx context.Context, profile models.Profile, fields []string, version int64) (models.Profile, error) {
+	const op = "repository.postgres.UpdateProfile"
+
+	metadata, err := json.Marshal(profile.Metadata)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(profile.Metadata) == 0 {
+		metadata = []byte("{}")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	u := &profile.User
+	// Пустые метаданные удаляют пространство имён приложения целиком
+	err = tx.QueryRowContext(ctx, `
+		UPDATE users SET
+			display_name = $1, locale = $2, timezone = $3, avatar_url = $4,
+			metadata = CASE WHEN $5::jsonb = '{}'::jsonb THEN metadata - $6
+				ELSE jsonb_set(metadata, ARRAY[$6], $5::jsonb) END,
+			version = version + 1,
+			updated_at = now()
+		WHERE id = $7 AND version = $8
+		RETURNING version, updated_at`,
+		u.DisplayName, u.Locale, u.Timezone, u.AvatarURL,
+		metadata, strconv.Itoa(profile.AppID), u.ID, version,
+	).Scan(&u.Version, &u.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, u.ID).Scan(&exists); err != nil {
+			return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+		}
+		if !exists {
+			return models.Profile{}, fmt.Errorf("%s: %w", op, _error.ErrUserNotFound)
+		}
+		return models.Profile{}, fmt.Errorf("%s: %w", op, _error.ErrVersionConflict)
+	}
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertOutboxEvent(ctx, tx, models.UserEvent{
+		Type:   models.UserProfileSaved,
+		UserID: u.ID,
+		Email:  u.Email,
+		Data: map[string]string{
+			"fields":  strings.Join(fields, ","),
+			"version": strconv.FormatInt(u.Version, 10),
+			"app_id":  strconv.Itoa(profile.AppID),
+		},
+	})
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profile, nil
+}
+
+// extraScanner сканирует колонки, идущие после тех, что читает scan*-функция.
+type extraScanner struct {
+	row   rowScanner
+	extra []any
+}
+
+func (s extraScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
+func scanWith(row rowScanner, extra ...any) rowScanner {
+	return extraScanner{row: row, extra: extra}
+}
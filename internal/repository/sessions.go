@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+)
+
+// lastSeenGranularity - как часто обновляется last_seen_at одной сессии.
+// Без этого каждый проверенный токен порождал бы запись в базу.
+const lastSeenGranularity = time.Minute
+
+func (s *repository) SaveSession(ctx context.Context, session models.Session) error {
+	const op = "repository.postgres.SaveSession"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		INSERT INTO sessions(id, user_id, app_id, ip, user_agent, created_at, last_seen_at, expires_at)
+		VALUES($1, $2, $3, $4, $5, $6, $6, $7)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx,
+		session.ID,
+		session.UserID,
+		session.AppID,
+		nullString(session.IP),
+		nullString(session.UserAgent),
+		session.CreatedAt,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *repository) Session(ctx context.Context, id string) (models.Session, error) {
+	const op = "repository.postgres.Session"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		SELECT id, user_id, app_id, ip, user_agent, created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions WHERE id = $1`)
+	if err != nil {
+		return models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	session, err := scanSession(stmt.QueryRowContext(ctx, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Session{}, fmt.Errorf("%s: %w", op, _error.ErrSessionNotFound)
+		}
+		return models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+// ActiveSessions возвращает неотозванные и неистёкшие сессии пользователя.
+func (s *repository) ActiveSessions(ctx context.Context, userID int64) ([]models.Session, error) {
+	const op = "repository.postgres.ActiveSessions"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		SELECT id, user_id, app_id, ip, user_agent, created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > now()
+		ORDER BY last_seen_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var sessions []models.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessions, nil
+}
+
+// TouchSession обновляет last_seen_at не чаще раза в lastSeenGranularity.
+func (s *repository) TouchSession(ctx context.Context, id string) error {
+	const op = "repository.postgres.TouchSession"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		UPDATE sessions SET last_seen_at = now()
+		WHERE id = $1 AND last_seen_at < now() - $2::interval`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, id, lastSeenGranularity.String()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RevokeSession отзывает сессию. Повторный отзыв не считается ошибкой.
+func (s *repository) RevokeSession(ctx context.Context, id string) error {
+	const op = "repository.postgres.RevokeSession"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		UPDATE sessions SET revoked_at = COALESCE(revoked_at, now()) WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrSessionNotFound)
+	}
+
+	return nil
+}
+
+// RevokeUserSessions отзывает все активные сессии пользователя, кроме exceptID,
+// и возвращает число отозванных.
+func (s *repository) RevokeUserSessions(ctx context.Context, userID int64, exceptID string) (int64, error) {
+	const op = "repository.postgres.RevokeUserSessions"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		UPDATE sessions SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL AND id <> $2`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, userID, exceptID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return affected, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSession(row rowScanner) (models.Session, error) {
+	var (
+		session   models.Session
+		ip, agent sql.NullString
+		revokedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.AppID,
+		&ip,
+		&agent,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	session.IP = ip.String
+	session.UserAgent = agent.String
+	session.RevokedAt = revokedAt.Time
+
+	return session, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+)
+
+// userSorts - колонки сортировки списка пользователей. Для каждой
+// есть индекс (колонка, id), по нему же идёт пагинация курсором.
+var userSorts = map[string]string{
+	models.UserSortID:        "id",
+	models.UserSortEmail:     "email",
+	models.UserSortCreatedAt: "created_at",
+}
+
+// Users возвращает пользователей по фильтру. Пагинация - по курсору
+// (ключ сортировки, id), поэтому глубокие страницы не дороже первой.
+func (s *repository) Users(ctx context.Context, filter models.UserFilter) ([]models.User, error) {
+	const op = "repository.postgres.Users"
+
+	sort := filter.Sort
+	if sort == "" {
+		sort = models.UserSortID
+	}
+	column, ok := userSorts[sort]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown sort %q", op, sort)
+	}
+
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.OrgID != models.AnyOrg {
+		where = append(where, "org_id = "+arg(filter.OrgID))
+	}
+	if filter.Query != "" {
+		// lower(...) LIKE '%q%' обслуживают trigram-индексы
+		p := arg("%" + escapeLike(strings.ToLower(filter.Query)) + "%")
+		where = append(where, "(lower(email) LIKE "+p+" OR lower(display_name) LIKE "+p+")")
+	}
+	if filter.EmailPrefix != "" {
+		where = append(where, "lower(email) LIKE "+arg(escapeLike(strings.ToLower(filter.EmailPrefix))+"%"))
+	}
+	if filter.Admin != nil {
+		where = append(where, "is_admin = "+arg(*filter.Admin))
+	}
+	if filter.Locked != nil {
+		if *filter.Locked {
+			where = append(where, "locked_at IS NOT NULL")
+		} else {
+			where = append(where, "locked_at IS NULL")
+		}
+	}
+	if !filter.CreatedAfter.IsZero() {
+		where = append(where, "created_at >= "+arg(filter.CreatedAfter))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		where = append(where, "created_at < "+arg(filter.CreatedBefore))
+	}
+	if filter.AppID != 0 {
+		where = append(where, `EXISTS (SELECT 1 FROM app_users m
+			WHERE m.user_id = users.id AND m.app_id = `+arg(filter.AppID)+` AND m.status = 'active')`)
+	}
+
+	cmp, dir := ">", "ASC"
+	if filter.Desc {
+		cmp, dir = "<", "DESC"
+	}
+	if filter.After != nil {
+		switch sort {
+		case models.UserSortID:
+			where = append(where, "id "+cmp+" "+arg(filter.After.ID))
+		case models.UserSortCreatedAt:
+			where = append(where, "(created_at, id) "+cmp+" ("+arg(filter.After.Key)+"::timestamptz, "+arg(filter.After.ID)+")")
+		default:
+			where = append(where, "("+column+", id) "+cmp+" ("+arg(filter.After.Key)+", "+arg(filter.After.ID)+")")
+		}
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	if column == "id" {
+		query += ` ORDER BY id ` + dir
+	} else {
+		query += ` ORDER BY ` + column + ` ` + dir + `, id ` + dir
+	}
+	query += ` LIMIT ` + arg(filter.Limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,398 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_error "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// SaveWebhook сохраняет вебхук. Ключ подписи шифруется тем же ключом,
+// что и секреты приложений.
+func (s *repository) SaveWebhook(ctx context.Context, webhook models.Webhook) (int64, error) {
+	const op = "repository.postgres.SaveWebhook"
+
+	secret, err := s.sealAppSecret(webhook.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		INSERT INTO webhooks(app_id, url, event_types, secret, created_at) VALUES($1, $2, $3, $4, $5)
+		RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var id int64
+	err = stmt.QueryRowContext(ctx,
+		webhook.AppID,
+		webhook.URL,
+		pq.Array(webhook.EventTypes),
+		secret,
+		webhook.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return 0, fmt.Errorf("%s: %w", op, _error.ErrAppNotFound)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *repository) Webhook(ctx context.Context, id int64) (models.Webhook, error) {
+	const op = "repository.postgres.Webhook"
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`SELECT id, app_id, url, event_types, secret, created_at FROM webhooks WHERE id = $1`)
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	webhook, err := s.scanWebhook(stmt.QueryRowContext(ctx, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Webhook{}, fmt.Errorf("%s: %w", op, _error.ErrWebhookNotFound)
+		}
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+// Webhooks возвращает вебхуки приложения. Если eventType не пуст -
+// только подписанные на это событие.
+func (s *repository) Webhooks(ctx context.Context, appID int, eventType string) ([]models.Webhook, error) {
+	const op = "repository.postgres.Webhooks"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		SELECT id, app_id, url, event_types, secret, created_at FROM webhooks
+		WHERE app_id = $1 AND ($2 = '' OR $2 = ANY(event_types))
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, appID, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var webhooks []models.Webhook
+	for rows.Next() {
+		webhook, err := s.scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *repository) DeleteWebhook(ctx context.Context, id int64) error {
+	const op = "repository.postgres.DeleteWebhook"
+
+	stmt, err := s.db.PrepareContext(ctx, `DELETE FROM webhooks WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+// UserAppIDs возвращает приложения, в которые пользователь когда-либо входил.
+func (s *repository) UserAppIDs(ctx context.Context, userID int64) ([]int, error) {
+	const op = "repository.postgres.UserAppIDs"
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`SELECT DISTINCT app_id FROM sessions WHERE user_id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+func (s *repository) SaveWebhookDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	const op = "repository.postgres.SaveWebhookDeliveries"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT INTO webhook_deliveries(webhook_id, event_type, payload) VALUES($1, $2, $3)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	for _, d := range deliveries {
+		if _, err := stmt.ExecContext(ctx, d.WebhookID, d.EventType, d.Payload); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries забирает пачку доставок, которые пора отправить,
+// на время lease (см. ClaimOutboxEvents).
+func (s *repository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	const op = "repository.postgres.ClaimWebhookDeliveries"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		WITH claimed AS (
+			UPDATE webhook_deliveries SET next_attempt_at = now() + $2::interval, attempts = attempts + 1
+			WHERE id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status = 'pending' AND next_attempt_at <= now()
+				ORDER BY id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, webhook_id, event_type, payload, attempts
+		)
+		SELECT c.id, c.webhook_id, c.event_type, c.payload, c.attempts, w.url, w.secret
+		FROM claimed c JOIN webhooks w ON w.id = c.webhook_id
+		ORDER BY c.id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, limit, lease.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var d models.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventType, &d.Payload, &d.Attempts, &d.URL, &d.Secret); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if d.Secret, err = s.openAppSecret(d.Secret); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		d.Status = models.DeliveryPending
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+func (s *repository) MarkWebhookDelivered(ctx context.Context, id int64, responseCode int) error {
+	const op = "repository.postgres.MarkWebhookDelivered"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', response_code = $2, last_error = NULL, delivered_at = now()
+		WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, id, responseCode); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkWebhookFailed сохраняет результат неудачной попытки. После maxAttempts
+// попыток доставка переводится в dead-letter очередь (status = 'dead'),
+// иначе следующая попытка откладывается на 2^attempts секунд, но не больше maxDelay.
+func (s *repository) MarkWebhookFailed(
+	ctx context.Context,
+	id int64,
+	responseCode int,
+	cause error,
+	maxAttempts int,
+	maxDelay time.Duration,
+) error {
+	const op = "repository.postgres.MarkWebhookFailed"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		UPDATE webhook_deliveries
+		SET response_code = $2,
+		    last_error = $3,
+		    status = CASE WHEN attempts >= $4 THEN 'dead' ELSE 'pending' END,
+		    next_attempt_at = now() + LEAST(power(2, LEAST(attempts, 20)) * interval '1 second', $5::interval)
+		WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	code := sql.NullInt64{Int64: int64(responseCode), Valid: responseCode != 0}
+	if _, err := stmt.ExecContext(ctx, id, code, cause.Error(), maxAttempts, maxDelay.String()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// WebhookDeliveries возвращает историю доставок вебхука от новых к старым.
+// Пустой status - без фильтра по статусу. Cursor - ID последней доставки предыдущей страницы.
+func (s *repository) WebhookDeliveries(
+	ctx context.Context,
+	webhookID int64,
+	status string,
+	cursor int64,
+	limit int,
+) ([]models.WebhookDelivery, error) {
+	const op = "repository.postgres.WebhookDeliveries"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		SELECT id, webhook_id, event_type, payload, status, attempts, response_code, last_error, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2) AND ($3 = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, webhookID, status, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var (
+			d           models.WebhookDelivery
+			code        sql.NullInt64
+			lastErr     sql.NullString
+			deliveredAt sql.NullTime
+		)
+		err := rows.Scan(&d.ID, &d.WebhookID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+			&code, &lastErr, &d.CreatedAt, &deliveredAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		d.ResponseCode = int(code.Int64)
+		d.LastError = lastErr.String
+		d.DeliveredAt = deliveredAt.Time
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// RetryWebhookDelivery возвращает доставку из dead-letter очереди в работу.
+func (s *repository) RetryWebhookDelivery(ctx context.Context, webhookID int64, id int64) error {
+	const op = "repository.postgres.RetryWebhookDelivery"
+
+	stmt, err := s.db.PrepareContext(ctx, `
+		UPDATE webhook_deliveries SET status = 'pending', attempts = 0, next_attempt_at = now()
+		WHERE id = $1 AND webhook_id = $2 AND status = 'dead'`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, id, webhookID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, _error.ErrDeliveryNotFound)
+	}
+
+	return nil
+}
+
+func (s *repository) scanWebhook(row rowScanner) (models.Webhook, error) {
+	var webhook models.Webhook
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.AppID,
+		&webhook.URL,
+		pq.Array(&webhook.EventTypes),
+		&webhook.Secret,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	if webhook.Secret, err = s.openAppSecret(webhook.Secret); err != nil {
+		return models.Webhook{}, err
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,508 @@
+// Package admin содержит операции администрирования: управление организациями,
+// приложениями, флагами администратора и ролями пользователей.
+//
+// Глобальный администратор (users.is_admin) работает со всеми организациями,
+// администратор организации (users.org_admin) - только со своей.
+package admin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/password"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/random"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+const secretBytes = 32
+
+// Change - что сделала идемпотентная операция с объектом.
+type Change string
+
+const (
+	Created   Change = "created"
+	Updated   Change = "updated"
+	Unchanged Change = "unchanged"
+)
+
+// Storage - хранилище для административных операций. Методы с orgID
+// не видят строки других организаций; models.AnyOrg снимает ограничение.
+type Storage interface {
+	AppByName(ctx context.Context, orgID int64, name string) (models.App, error)
+	SaveApp(ctx context.Context, app models.App) (int, error)
+	UpdateAppSecret(ctx context.Context, orgID int64, appID int, secret string) error
+	User(ctx context.Context, orgID int64, email string) (models.User, error)
+	SaveUser(ctx context.Context, orgID int64, email string, passHash []byte) (int64, error)
+	UpdatePassHash(ctx context.Context, uid int64, passHash []byte) error
+	UserRoles(ctx context.Context, userID int64) (bool, []string, error)
+	SetUserRoles(ctx context.Context, userID int64, isAdmin bool, roles []string) error
+	SetUserOrgAdmin(ctx context.Context, orgID int64, userID int64, orgAdmin bool) error
+	Apps(ctx context.Context, orgID int64) ([]models.App, error)
+	UserByID(ctx context.Context, orgID int64, userID int64) (models.User, error)
+	SetUserLocked(ctx context.Context, userID int64, locked bool) (time.Time, error)
+	RevokeUserSessions(ctx context.Context, userID int64, exceptID string) (int64, error)
+	OrgBySlug(ctx context.Context, slug string) (models.Org, error)
+	SaveOrg(ctx context.Context, org models.Org) (int64, error)
+	Orgs(ctx context.Context) ([]models.Org, error)
+	App(ctx context.Context, orgID int64, appID int) (models.App, error)
+	SetAppAccessPolicy(ctx context.Context, orgID int64, appID int, policy string) error
+	SetAppTokenSettings(ctx context.Context, orgID int64, appID int, settings models.TokenSettings) error
+	SetAppPasskeySettings(ctx context.Context, orgID int64, appID int, settings models.PasskeySettings) error
+	SaveIdentityProvider(ctx context.Context, orgID int64, p models.IdentityProvider) (models.IdentityProvider, error)
+	IdentityProviders(ctx context.Context, appID int) ([]models.IdentityProvider, error)
+	DeleteIdentityProvider(ctx context.Context, appID int, name string) error
+	AppMember(ctx context.Context, appID int, userID int64) (models.AppMember, error)
+	AppMembers(ctx context.Context, appID int, status string) ([]models.AppMember, error)
+	SaveAppMember(ctx context.Context, member models.AppMember) (models.AppMember, error)
+	DeleteAppMember(ctx context.Context, appID int, userID int64) error
+	RevokeAppSessions(ctx context.Context, appID int, userID int64) (int64, error)
+	Users(ctx context.Context, filter models.UserFilter) ([]models.User, error)
+	SetUserDisabled(ctx context.Context, userID int64, disabled bool) (time.Time, error)
+	SetUserDeleteAfter(ctx context.Context, userID int64, deleteAfter time.Time) error
+	UserExport(ctx context.Context, userID int64) (models.UserExport, error)
+	ImportUsers(ctx context.Context, orgID int64, users []models.ImportedUser) ([]int64, error)
+}
+
+// AdminChecker проверяет, что пользователь - администратор.
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+}
+
+// Auditor записывает события безопасности в журнал аудита.
+type Auditor interface {
+	Record(ctx context.Context, event models.AuditEvent)
+}
+
+// Service выполняет административные операции.
+// Методы Ensure* предназначены для доверенных вызовов (ssoctl seed с доступом
+// к базе) и права не проверяют; остальные доступны только администраторам.
+type Service struct {
+	log        *zap.Logger
+	storage    Storage
+	hasher     password.Scheme
+	auditor    Auditor
+	admins     AdminChecker
+	eraseGrace time.Duration // срок между EraseUser и удалением пользователя
+}
+
+// New creates a new instance of admin Service.
+// hasher должен распознавать хэши всех поддерживаемых алгоритмов,
+// чтобы принимать заранее захэшированные пароли.
+func New(
+	log *zap.Logger,
+	storage Storage,
+	hasher password.Scheme,
+	auditor Auditor,
+	admins AdminChecker,
+	eraseGrace time.Duration,
+) *Service {
+	return &Service{
+		log:        log,
+		storage:    storage,
+		hasher:     hasher,
+		auditor:    auditor,
+		admins:     admins,
+		eraseGrace: eraseGrace,
+	}
+}
+
+// AppSpec - желаемое состояние приложения.
+// Пустой Secret означает "сгенерировать при создании и не трогать потом",
+// пустой Org - организацию по умолчанию, пустой AccessPolicy - открытый доступ
+// при создании и "не трогать" для существующего. Token == nil - глобальные
+// параметры токенов при создании и "не трогать" для существующего.
+type AppSpec struct {
+	ID           int
+	Org          string
+	Name         string
+	Secret       string
+	AccessPolicy string
+	Token        *models.TokenSettings
+	Passkeys     *models.PasskeySettings
+}
+
+// EnsureApp создаёт приложение или приводит секрет, политику доступа,
+// параметры токенов и ключей доступа существующего к spec.
+// Приложение ищется по имени в организации spec.Org.
+func (s *Service) EnsureApp(ctx context.Context, spec AppSpec) (models.App, Change, error) {
+	const op = "admin.Service.EnsureApp"
+	log := s.log.With(zap.String("method", op), zap.String("app", spec.Name))
+
+	if err := validateApp(spec); err != nil {
+		return models.App{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	orgID, err := s.resolveOrg(ctx, models.AnyOrg, spec.Org)
+	if err != nil {
+		return models.App{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	app, err := s.storage.AppByName(ctx, orgID, spec.Name)
+	if errors.Is(err, err_internal.ErrAppNotFound) {
+		app = newApp(orgID, spec)
+		app.ID = spec.ID
+		if app.Secret == "" {
+			app.Secret = random.Token(secretBytes)
+		}
+		if app.AccessPolicy == "" {
+			app.AccessPolicy = models.AccessOpen
+		}
+
+		app.ID, err = s.storage.SaveApp(ctx, app)
+		if err != nil {
+			return models.App{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		log.Info("app created", zap.Int("app_id", app.ID))
+		return app, Created, nil
+	}
+	if err != nil {
+		return models.App{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if spec.ID != 0 && spec.ID != app.ID {
+		return models.App{}, "", fmt.Errorf("%s: %w", op, err_internal.NewValidationError("id",
+			fmt.Sprintf("app %q already exists with id %d", app.Name, app.ID)))
+	}
+
+	change := Unchanged
+
+	if spec.Secret != "" && spec.Secret != app.Secret {
+		if err := s.storage.UpdateAppSecret(ctx, orgID, app.ID, spec.Secret); err != nil {
+			return models.App{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+		app.Secret = spec.Secret
+		log.Info("app secret updated", zap.Int("app_id", app.ID))
+		change = Updated
+	}
+
+	if spec.AccessPolicy != "" && spec.AccessPolicy != app.AccessPolicy {
+		if err := s.storage.SetAppAccessPolicy(ctx, orgID, app.ID, spec.AccessPolicy); err != nil {
+			return models.App{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+		app.AccessPolicy = spec.AccessPolicy
+		log.Info("app access policy updated", zap.Int("app_id", app.ID), zap.String("policy", app.AccessPolicy))
+		change = Updated
+	}
+
+	if spec.Token != nil && !reflect.DeepEqual(*spec.Token, app.Token) {
+		if err := s.storage.SetAppTokenSettings(ctx, orgID, app.ID, *spec.Token); err != nil {
+			return models.App{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+		app.Token = *spec.Token
+		log.Info("app token settings updated", zap.Int("app_id", app.ID))
+		change = Updated
+	}
+
+	if spec.Passkeys != nil && !reflect.DeepEqual(*spec.Passkeys, app.Passkeys) {
+		if err := s.storage.SetAppPasskeySettings(ctx, orgID, app.ID, *spec.Passkeys); err != nil {
+			return models.App{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+		app.Passkeys = *spec.Passkeys
+		log.Info("app passkey settings updated", zap.Int("app_id", app.ID))
+		change = Updated
+	}
+
+	return app, change, nil
+}
+
+// newApp возвращает новое приложение организации orgID по spec без ID.
+func newApp(orgID int64, spec AppSpec) models.App {
+	app := models.App{OrgID: orgID, Name: spec.Name, Secret: spec.Secret, AccessPolicy: spec.AccessPolicy}
+	if spec.Token != nil {
+		app.Token = *spec.Token
+	}
+	if spec.Passkeys != nil {
+		app.Passkeys = *spec.Passkeys
+	}
+
+	return app
+}
+
+func validateApp(spec AppSpec) error {
+	if strings.TrimSpace(spec.Name) == "" {
+		return err_internal.NewValidationError("name", "app name is required")
+	}
+	if err := validatePolicy(spec.AccessPolicy); err != nil {
+		return err
+	}
+	if spec.Token != nil {
+		if err := validateTokenSettings(*spec.Token); err != nil {
+			return err
+		}
+	}
+	if spec.Passkeys != nil {
+		return validatePasskeySettings(*spec.Passkeys)
+	}
+
+	return nil
+}
+
+// UserSpec - желаемое состояние пользователя.
+// Задаётся либо Password, либо уже готовый PassHash в формате PHC.
+// Пустой Org - организация по умолчанию.
+type UserSpec struct {
+	Org      string
+	Email    string
+	Password string
+	PassHash []byte
+	Admin    bool
+	OrgAdmin bool
+	Roles    []string
+}
+
+// EnsureUser создаёт пользователя или приводит пароль, флаги администратора
+// и роли существующего к spec. Пароль хэшируется текущим алгоритмом сервиса;
+// если пароль уже совпадает с сохранённым хэшем, хэш не меняется.
+func (s *Service) EnsureUser(ctx context.Context, spec UserSpec) (int64, Change, error) {
+	const op = "admin.Service.EnsureUser"
+	log := s.log.With(zap.String("method", op), zap.String("email", spec.Email))
+
+	if err := s.validateUser(spec); err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+	roles := normalizeRoles(spec.Roles)
+
+	orgID, err := s.resolveOrg(ctx, models.AnyOrg, spec.Org)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := s.storage.User(ctx, orgID, spec.Email)
+	if errors.Is(err, err_internal.ErrUserNotFound) {
+		passHash := spec.PassHash
+		if spec.Password != "" {
+			if passHash, err = s.hasher.Hash(spec.Password); err != nil {
+				return 0, "", fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		id, err := s.storage.SaveUser(ctx, orgID, spec.Email, passHash)
+		if err != nil {
+			return 0, "", fmt.Errorf("%s: %w", op, err)
+		}
+		s.auditor.Record(ctx, models.AuditEvent{Type: models.AuditRegister, UserID: id, Email: spec.Email})
+
+		if spec.Admin || len(roles) > 0 {
+			if err := s.setRoles(ctx, id, spec.Email, spec.Admin, roles); err != nil {
+				return 0, "", fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		if spec.OrgAdmin {
+			if err := s.setOrgAdmin(ctx, models.User{ID: id, Email: spec.Email, OrgID: orgID}, true); err != nil {
+				return 0, "", fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		log.Info("user created", zap.Int64("user_id", id))
+		return id, Created, nil
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	change := Unchanged
+
+	passHash, err := s.desiredPassHash(user, spec)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if passHash != nil {
+		if err := s.storage.UpdatePassHash(ctx, user.ID, passHash); err != nil {
+			return 0, "", fmt.Errorf("%s: %w", op, err)
+		}
+		s.auditor.Record(ctx, models.AuditEvent{Type: models.AuditPasswordChanged, UserID: user.ID, Email: user.Email})
+		change = Updated
+	}
+
+	isAdmin, current, err := s.storage.UserRoles(ctx, user.ID)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if isAdmin != spec.Admin || !slices.Equal(normalizeRoles(current), roles) {
+		if err := s.setRoles(ctx, user.ID, user.Email, spec.Admin, roles); err != nil {
+			return 0, "", fmt.Errorf("%s: %w", op, err)
+		}
+		change = Updated
+	}
+
+	if user.OrgAdmin != spec.OrgAdmin {
+		if err := s.setOrgAdmin(ctx, user, spec.OrgAdmin); err != nil {
+			return 0, "", fmt.Errorf("%s: %w", op, err)
+		}
+		change = Updated
+	}
+
+	if change == Updated {
+		log.Info("user updated", zap.Int64("user_id", user.ID))
+	}
+	return user.ID, change, nil
+}
+
+// desiredPassHash возвращает новый хэш пароля или nil, если менять его не нужно.
+func (s *Service) desiredPassHash(user models.User, spec UserSpec) ([]byte, error) {
+	if spec.PassHash != nil {
+		if bytes.Equal(spec.PassHash, user.PassHash) {
+			return nil, nil
+		}
+		return spec.PassHash, nil
+	}
+
+	err := s.hasher.Compare(user.PassHash, spec.Password)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, password.ErrMismatch) && !errors.Is(err, password.ErrUnknownAlgorithm) {
+		return nil, err
+	}
+
+	return s.hasher.Hash(spec.Password)
+}
+
+func (s *Service) setRoles(ctx context.Context, userID int64, email string, isAdmin bool, roles []string) error {
+	if err := s.storage.SetUserRoles(ctx, userID, isAdmin, roles); err != nil {
+		return err
+	}
+
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditRoleChange,
+		UserID: userID,
+		Email:  email,
+		Metadata: map[string]string{
+			"admin": strconv.FormatBool(isAdmin),
+			"roles": strings.Join(roles, ","),
+		},
+	})
+
+	return nil
+}
+
+func (s *Service) setOrgAdmin(ctx context.Context, user models.User, orgAdmin bool) error {
+	if err := s.storage.SetUserOrgAdmin(ctx, user.OrgID, user.ID, orgAdmin); err != nil {
+		return err
+	}
+
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditOrgAdminChange,
+		UserID: user.ID,
+		Email:  user.Email,
+		Metadata: map[string]string{
+			"org_id":    strconv.FormatInt(user.OrgID, 10),
+			"org_admin": strconv.FormatBool(orgAdmin),
+		},
+	})
+
+	return nil
+}
+
+func (s *Service) validateUser(spec UserSpec) error {
+	if strings.TrimSpace(spec.Email) == "" {
+		return err_internal.NewValidationError("email", "email is required")
+	}
+
+	switch {
+	case spec.Password == "" && spec.PassHash == nil:
+		return err_internal.NewValidationError("password", "password or pass_hash is required")
+	case spec.Password != "" && spec.PassHash != nil:
+		return err_internal.NewValidationError("password", "password and pass_hash are mutually exclusive")
+	case spec.PassHash != nil:
+		if err := s.validatePassHash(spec.PassHash); err != nil {
+			return err_internal.NewValidationError("pass_hash", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// validatePassHash проверяет готовый хэш пароля: формат должен быть известен,
+// а параметры - в пределах, при которых вход не займёт всю память или процессор.
+func (s *Service) validatePassHash(hash []byte) error {
+	err := s.hasher.Validate(hash)
+	if errors.Is(err, password.ErrUnknownAlgorithm) {
+		return errors.New("unknown password hash format")
+	}
+
+	return err
+}
+
+// normalizeRoles сортирует роли и убирает пустые и повторяющиеся,
+// чтобы сравнение с сохранёнными ролями не зависело от порядка.
+func normalizeRoles(roles []string) []string {
+	res := make([]string, 0, len(roles))
+	for _, r := range roles {
+		if r = strings.TrimSpace(r); r != "" {
+			res = append(res, r)
+		}
+	}
+	slices.Sort(res)
+
+	return slices.Compact(res)
+}
+
+// authorize проверяет права вызывающего и возвращает организацию, в пределах
+// которой он действует: models.AnyOrg для глобального администратора или его
+// собственную организацию для администратора организации.
+func (s *Service) authorize(ctx context.Context, callerID int64) (int64, error) {
+	isAdmin, err := s.admins.IsAdmin(ctx, callerID)
+	if err != nil {
+		return 0, err
+	}
+	if isAdmin {
+		return models.AnyOrg, nil
+	}
+
+	caller, err := s.storage.UserByID(ctx, models.AnyOrg, callerID)
+	if err != nil {
+		return 0, err
+	}
+	if !caller.OrgAdmin {
+		return 0, err_internal.ErrPermissionDenied
+	}
+
+	return caller.OrgID, nil
+}
+
+// authorizeGlobal пропускает только глобальных администраторов.
+func (s *Service) authorizeGlobal(ctx context.Context, callerID int64) error {
+	isAdmin, err := s.admins.IsAdmin(ctx, callerID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return err_internal.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// resolveOrg возвращает ID организации slug для вызывающего с областью scope.
+// Пустой slug означает организацию по умолчанию для глобального администратора
+// и собственную организацию для администратора организации.
+// Чужая организация для администратора организации не находится.
+func (s *Service) resolveOrg(ctx context.Context, scope int64, slug string) (int64, error) {
+	if slug == "" {
+		if scope == models.AnyOrg {
+			return models.DefaultOrgID, nil
+		}
+		return scope, nil
+	}
+
+	org, err := s.storage.OrgBySlug(ctx, slug)
+	if err != nil {
+		return 0, err
+	}
+	if scope != models.AnyOrg && org.ID != scope {
+		return 0, err_internal.ErrOrgNotFound
+	}
+
+	return org.ID, nil
+}
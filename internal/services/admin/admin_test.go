@@ -0,0 +1,871 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/password"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type memUser struct {
+	models.User
+	admin bool
+	roles []string
+}
+
+func (u *memUser) user() models.User {
+	user := u.User
+	user.IsAdmin, user.Roles = u.admin, u.roles
+	return user
+}
+
+type memStorage struct {
+	apps    []models.App
+	users   []*memUser
+	orgs    []models.Org
+	members map[[2]int64]models.AppMember
+	idps    []models.IdentityProvider
+}
+
+func inOrg(orgID, rowOrg int64) bool {
+	return orgID == models.AnyOrg || orgID == rowOrg
+}
+
+func (m *memStorage) AppByName(_ context.Context, orgID int64, name string) (models.App, error) {
+	for _, a := range m.apps {
+		if a.OrgID == orgID && a.Name == name {
+			return a, nil
+		}
+	}
+	return models.App{}, err_internal.ErrAppNotFound
+}
+
+func (m *memStorage) SaveApp(_ context.Context, app models.App) (int, error) {
+	if app.ID == 0 {
+		app.ID = len(m.apps) + 100
+	}
+	m.apps = append(m.apps, app)
+	return app.ID, nil
+}
+
+func (m *memStorage) UpdateAppSecret(_ context.Context, orgID int64, appID int, secret string) error {
+	for i := range m.apps {
+		if m.apps[i].ID == appID && inOrg(orgID, m.apps[i].OrgID) {
+			m.apps[i].Secret = secret
+			return nil
+		}
+	}
+	return err_internal.ErrAppNotFound
+}
+
+func (m *memStorage) User(_ context.Context, orgID int64, email string) (models.User, error) {
+	for _, u := range m.users {
+		if u.OrgID == orgID && u.Email == email {
+			return u.user(), nil
+		}
+	}
+	return models.User{}, err_internal.ErrUserNotFound
+}
+
+func (m *memStorage) SaveUser(_ context.Context, orgID int64, email string, passHash []byte) (int64, error) {
+	id := int64(len(m.users) + 1)
+	m.users = append(m.users, &memUser{User: models.User{ID: id, OrgID: orgID, Email: email, PassHash: passHash}})
+	return id, nil
+}
+
+func (m *memStorage) UpdatePassHash(_ context.Context, uid int64, passHash []byte) error {
+	m.users[uid-1].PassHash = passHash
+	return nil
+}
+
+func (m *memStorage) UserRoles(_ context.Context, uid int64) (bool, []string, error) {
+	return m.users[uid-1].admin, m.users[uid-1].roles, nil
+}
+
+func (m *memStorage) SetUserRoles(_ context.Context, uid int64, isAdmin bool, roles []string) error {
+	m.users[uid-1].admin, m.users[uid-1].roles = isAdmin, roles
+	return nil
+}
+
+func (m *memStorage) SetUserOrgAdmin(_ context.Context, orgID int64, uid int64, orgAdmin bool) error {
+	if !inOrg(orgID, m.users[uid-1].OrgID) {
+		return err_internal.ErrUserNotFound
+	}
+	m.users[uid-1].OrgAdmin = orgAdmin
+	return nil
+}
+
+func (m *memStorage) Apps(_ context.Context, orgID int64) ([]models.App, error) {
+	var apps []models.App
+	for _, a := range m.apps {
+		if inOrg(orgID, a.OrgID) {
+			apps = append(apps, a)
+		}
+	}
+	return apps, nil
+}
+
+func (m *memStorage) UserByID(_ context.Context, orgID int64, uid int64) (models.User, error) {
+	if uid < 1 || int(uid) > len(m.users) || !inOrg(orgID, m.users[uid-1].OrgID) {
+		return models.User{}, err_internal.ErrUserNotFound
+	}
+	return m.users[uid-1].user(), nil
+}
+
+func (m *memStorage) SetUserLocked(_ context.Context, uid int64, locked bool) (time.Time, error) {
+	m.users[uid-1].LockedAt = time.Time{}
+	if locked {
+		m.users[uid-1].LockedAt = time.Now()
+	}
+	return m.users[uid-1].LockedAt, nil
+}
+
+func (m *memStorage) SetUserDisabled(_ context.Context, uid int64, disabled bool) (time.Time, error) {
+	m.users[uid-1].DisabledAt = time.Time{}
+	if disabled {
+		m.users[uid-1].DisabledAt = time.Now()
+	}
+	return m.users[uid-1].DisabledAt, nil
+}
+
+func (m *memStorage) SetUserDeleteAfter(_ context.Context, uid int64, deleteAfter time.Time) error {
+	m.users[uid-1].DeleteAfter = deleteAfter
+	return nil
+}
+
+func (m *memStorage) UserExport(_ context.Context, uid int64) (models.UserExport, error) {
+	return models.NewUserExport(m.users[uid-1].user(), nil, nil, nil, nil, time.Now()), nil
+}
+
+func (m *memStorage) RevokeUserSessions(_ context.Context, uid int64, _ string) (int64, error) {
+	return 2, nil
+}
+
+func (m *memStorage) OrgBySlug(_ context.Context, slug string) (models.Org, error) {
+	for _, o := range m.orgs {
+		if o.Slug == slug {
+			return o, nil
+		}
+	}
+	return models.Org{}, err_internal.ErrOrgNotFound
+}
+
+func (m *memStorage) SaveOrg(_ context.Context, org models.Org) (int64, error) {
+	org.ID = int64(len(m.orgs) + 1)
+	m.orgs = append(m.orgs, org)
+	return org.ID, nil
+}
+
+func (m *memStorage) Orgs(_ context.Context) ([]models.Org, error) {
+	return append([]models.Org(nil), m.orgs...), nil
+}
+
+// IsAdmin делает memStorage проверкой прав для Service.
+func (m *memStorage) IsAdmin(_ context.Context, uid int64) (bool, error) {
+	if uid < 1 || int(uid) > len(m.users) {
+		return false, err_internal.ErrUserNotFound
+	}
+	return m.users[uid-1].admin, nil
+}
+
+func (m *memStorage) App(_ context.Context, orgID int64, appID int) (models.App, error) {
+	for _, a := range m.apps {
+		if a.ID == appID && inOrg(orgID, a.OrgID) {
+			return a, nil
+		}
+	}
+	return models.App{}, err_internal.ErrAppNotFound
+}
+
+func (m *memStorage) SetAppAccessPolicy(_ context.Context, orgID int64, appID int, policy string) error {
+	for i := range m.apps {
+		if m.apps[i].ID == appID && inOrg(orgID, m.apps[i].OrgID) {
+			m.apps[i].AccessPolicy = policy
+			return nil
+		}
+	}
+	return err_internal.ErrAppNotFound
+}
+
+func (m *memStorage) SetAppTokenSettings(_ context.Context, orgID int64, appID int, settings models.TokenSettings) error {
+	for i := range m.apps {
+		if m.apps[i].ID == appID && inOrg(orgID, m.apps[i].OrgID) {
+			m.apps[i].Token = settings
+			return nil
+		}
+	}
+	return err_internal.ErrAppNotFound
+}
+
+func (m *memStorage) SetAppPasskeySettings(_ context.Context, orgID int64, appID int, settings models.PasskeySettings) error {
+	for i := range m.apps {
+		if m.apps[i].ID == appID && inOrg(orgID, m.apps[i].OrgID) {
+			m.apps[i].Passkeys = settings
+			return nil
+		}
+	}
+	return err_internal.ErrAppNotFound
+}
+
+func (m *memStorage) SaveIdentityProvider(ctx context.Context, orgID int64, p models.IdentityProvider) (models.IdentityProvider, error) {
+	if _, err := m.App(ctx, orgID, p.AppID); err != nil {
+		return models.IdentityProvider{}, err
+	}
+	for i := range m.idps {
+		if m.idps[i].AppID == p.AppID && m.idps[i].Name == p.Name {
+			p.ID = m.idps[i].ID
+			m.idps[i] = p
+			return p, nil
+		}
+	}
+	p.ID = int64(len(m.idps) + 1)
+	m.idps = append(m.idps, p)
+	return p, nil
+}
+
+func (m *memStorage) IdentityProviders(_ context.Context, appID int) ([]models.IdentityProvider, error) {
+	var providers []models.IdentityProvider
+	for _, p := range m.idps {
+		if p.AppID == appID {
+			providers = append(providers, p)
+		}
+	}
+	return providers, nil
+}
+
+func (m *memStorage) DeleteIdentityProvider(_ context.Context, appID int, name string) error {
+	for i, p := range m.idps {
+		if p.AppID == appID && p.Name == name {
+			m.idps = append(m.idps[:i], m.idps[i+1:]...)
+			return nil
+		}
+	}
+	return err_internal.ErrIdPNotFound
+}
+
+func (m *memStorage) AppMember(_ context.Context, appID int, userID int64) (models.AppMember, error) {
+	member, ok := m.members[[2]int64{int64(appID), userID}]
+	if !ok {
+		return models.AppMember{}, err_internal.ErrMemberNotFound
+	}
+	return member, nil
+}
+
+func (m *memStorage) AppMembers(_ context.Context, appID int, status string) ([]models.AppMember, error) {
+	var members []models.AppMember
+	for _, member := range m.members {
+		if member.AppID == appID && (status == "" || member.Status == status) {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (m *memStorage) SaveAppMember(_ context.Context, member models.AppMember) (models.AppMember, error) {
+	if m.members == nil {
+		m.members = make(map[[2]int64]models.AppMember)
+	}
+	m.members[[2]int64{int64(member.AppID), member.UserID}] = member
+	return member, nil
+}
+
+func (m *memStorage) DeleteAppMember(_ context.Context, appID int, userID int64) error {
+	key := [2]int64{int64(appID), userID}
+	if _, ok := m.members[key]; !ok {
+		return err_internal.ErrMemberNotFound
+	}
+	delete(m.members, key)
+	return nil
+}
+
+func (m *memStorage) RevokeAppSessions(_ context.Context, _ int, _ int64) (int64, error) {
+	return 1, nil
+}
+
+// Users поддерживает только фильтры по организации и префиксу email
+// и сортировку по ID - остального тесты сервиса не используют.
+func (m *memStorage) Users(_ context.Context, filter models.UserFilter) ([]models.User, error) {
+	var users []models.User
+	for _, u := range m.users {
+		if !inOrg(filter.OrgID, u.OrgID) || !strings.HasPrefix(u.Email, filter.EmailPrefix) {
+			continue
+		}
+		if filter.After != nil && u.ID <= filter.After.ID {
+			continue
+		}
+		users = append(users, u.user())
+		if len(users) == filter.Limit {
+			break
+		}
+	}
+	return users, nil
+}
+
+func (m *memStorage) ImportUsers(ctx context.Context, orgID int64, users []models.ImportedUser) ([]int64, error) {
+	var created []int64
+	for _, u := range users {
+		if _, err := m.User(ctx, orgID, u.Email); err == nil {
+			continue
+		}
+		m.SaveUser(ctx, orgID, u.Email, u.PassHash)
+		created = append(created, u.Row)
+	}
+	return created, nil
+}
+
+type memAuditor []models.AuditEvent
+
+func (a *memAuditor) Record(_ context.Context, e models.AuditEvent) { *a = append(*a, e) }
+
+func newTestService() (*Service, *memStorage, *memAuditor) {
+	storage := &memStorage{orgs: []models.Org{{ID: models.DefaultOrgID, Slug: models.DefaultOrgSlug}}}
+	auditor := &memAuditor{}
+	hasher := password.New(password.NewBcrypt(4))
+
+	return New(zap.NewNop(), storage, hasher, auditor, storage, time.Hour), storage, auditor
+}
+
+func TestEnsureApp(t *testing.T) {
+	svc, storage, _ := newTestService()
+	ctx := context.Background()
+
+	app, change, err := svc.EnsureApp(ctx, AppSpec{ID: 1, Name: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, Created, change)
+	assert.Equal(t, 1, app.ID)
+	assert.NotEmpty(t, app.Secret, "secret is generated")
+
+	generated := app.Secret
+	_, change, err = svc.EnsureApp(ctx, AppSpec{ID: 1, Name: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, Unchanged, change)
+	assert.Equal(t, generated, storage.apps[0].Secret, "generated secret is kept")
+
+	_, change, err = svc.EnsureApp(ctx, AppSpec{Name: "test", Secret: "new-secret"})
+	require.NoError(t, err)
+	assert.Equal(t, Updated, change)
+	assert.Equal(t, "new-secret", storage.apps[0].Secret)
+
+	_, _, err = svc.EnsureApp(ctx, AppSpec{ID: 2, Name: "test"})
+	var validation *err_internal.ValidationError
+	assert.ErrorAs(t, err, &validation)
+}
+
+func TestAppTokenSettings(t *testing.T) {
+	svc, storage, _ := newTestService()
+	ctx := context.Background()
+
+	token := &models.TokenSettings{
+		TTL:      15 * time.Minute,
+		Audience: []string{"crm"},
+		Claims:   map[string]string{"mail": models.ClaimSourceEmail},
+	}
+	_, change, err := svc.EnsureApp(ctx, AppSpec{Name: "crm", Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, Created, change)
+	assert.Equal(t, *token, storage.apps[0].Token)
+
+	_, change, err = svc.EnsureApp(ctx, AppSpec{Name: "crm", Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, Unchanged, change)
+
+	_, change, err = svc.EnsureApp(ctx, AppSpec{Name: "crm"})
+	require.NoError(t, err)
+	assert.Equal(t, Unchanged, change, "nil token settings are not touched")
+	assert.Equal(t, 15*time.Minute, storage.apps[0].Token.TTL)
+
+	for _, bad := range []models.TokenSettings{
+		{TTL: -time.Minute},
+		{TTL: 1500 * time.Millisecond},
+		{Audience: []string{" "}},
+		{Claims: map[string]string{"exp": models.ClaimSourceEmail}},
+		{Claims: map[string]string{"mail": "password"}},
+	} {
+		_, _, err = svc.EnsureApp(ctx, AppSpec{Name: "crm", Token: &bad})
+		var validation *err_internal.ValidationError
+		assert.ErrorAs(t, err, &validation, "%+v", bad)
+	}
+}
+
+func TestAppPasskeySettings(t *testing.T) {
+	svc, storage, _ := newTestService()
+	ctx := context.Background()
+
+	passkeys := &models.PasskeySettings{RPID: "example.com", Origins: []string{"https://login.example.com"}}
+	_, change, err := svc.EnsureApp(ctx, AppSpec{Name: "crm", Passkeys: passkeys})
+	require.NoError(t, err)
+	assert.Equal(t, Created, change)
+	assert.Equal(t, *passkeys, storage.apps[0].Passkeys)
+
+	_, change, err = svc.EnsureApp(ctx, AppSpec{Name: "crm", Passkeys: &models.PasskeySettings{}})
+	require.NoError(t, err)
+	assert.Equal(t, Updated, change)
+	assert.False(t, storage.apps[0].Passkeys.Enabled())
+
+	for _, bad := range []models.PasskeySettings{
+		{Origins: []string{"https://example.com"}},
+		{RPID: "example.com"},
+		{RPID: "example.com", Origins: []string{"https://example.org"}},
+	} {
+		_, _, err = svc.EnsureApp(ctx, AppSpec{Name: "crm", Passkeys: &bad})
+		var validation *err_internal.ValidationError
+		assert.ErrorAs(t, err, &validation, "%+v", bad)
+	}
+}
+
+func TestAppIdentityProviders(t *testing.T) {
+	svc, storage, _ := newTestService()
+	ctx := context.Background()
+
+	adminID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "admin@example.com", Password: "secret", Admin: true})
+	require.NoError(t, err)
+	userID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "user@example.com", Password: "secret"})
+	require.NoError(t, err)
+	app, err := svc.CreateApp(ctx, adminID, AppSpec{Name: "crm"})
+	require.NoError(t, err)
+
+	corp := models.IdentityProvider{
+		AppID:        app.ID,
+		Name:         "corp",
+		Issuer:       "https://idp.example.com",
+		ClientID:     "crm",
+		ClientSecret: "client-secret",
+		RedirectURI:  "https://crm.example.com/callback",
+	}
+	saved, err := svc.SetAppIdentityProvider(ctx, adminID, corp)
+	require.NoError(t, err)
+	assert.Empty(t, saved.ClientSecret, "secret is not returned")
+	assert.Equal(t, "client-secret", storage.idps[0].ClientSecret)
+
+	corp.ClientID = "crm-2"
+	_, err = svc.SetAppIdentityProvider(ctx, adminID, corp)
+	require.NoError(t, err)
+	providers, err := svc.ListAppIdentityProviders(ctx, adminID, app.ID)
+	require.NoError(t, err)
+	require.Len(t, providers, 1, "same name replaces the provider")
+	assert.Equal(t, "crm-2", providers[0].ClientID)
+	assert.Empty(t, providers[0].ClientSecret)
+
+	_, err = svc.SetAppIdentityProvider(ctx, userID, corp)
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+
+	for _, bad := range []func(p *models.IdentityProvider){
+		func(p *models.IdentityProvider) { p.Name = "Corp IdP" },
+		func(p *models.IdentityProvider) { p.Issuer = "http://idp.example.com" },
+		func(p *models.IdentityProvider) { p.ClientID = "" },
+		func(p *models.IdentityProvider) { p.RedirectURI = "/callback" },
+		func(p *models.IdentityProvider) { p.Scopes = []string{"email profile"} },
+	} {
+		p := corp
+		bad(&p)
+		_, err := svc.SetAppIdentityProvider(ctx, adminID, p)
+		var validation *err_internal.ValidationError
+		assert.ErrorAs(t, err, &validation, "%+v", p)
+	}
+
+	// Локальный провайдер для разработки и тестов
+	local := corp
+	local.Name, local.Issuer, local.RedirectURI = "dev", "http://127.0.0.1:8080", "http://localhost:3000/callback"
+	_, err = svc.SetAppIdentityProvider(ctx, adminID, local)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.DeleteAppIdentityProvider(ctx, adminID, app.ID, "corp"))
+	err = svc.DeleteAppIdentityProvider(ctx, adminID, app.ID, "corp")
+	assert.ErrorIs(t, err, err_internal.ErrIdPNotFound)
+}
+
+func TestEnsureUser(t *testing.T) {
+	svc, storage, auditor := newTestService()
+	ctx := context.Background()
+
+	id, change, err := svc.EnsureUser(ctx, UserSpec{Email: "admin@example.com", Password: "secret", Admin: true})
+	require.NoError(t, err)
+	assert.Equal(t, Created, change)
+	assert.True(t, storage.users[0].admin)
+	assert.NotEqual(t, "secret", string(storage.users[0].PassHash), "password is hashed")
+
+	hash := storage.users[0].PassHash
+	_, change, err = svc.EnsureUser(ctx, UserSpec{Email: "admin@example.com", Password: "secret", Admin: true})
+	require.NoError(t, err)
+	assert.Equal(t, Unchanged, change)
+	assert.Equal(t, hash, storage.users[0].PassHash, "matching password is not rehashed")
+
+	_, change, err = svc.EnsureUser(ctx, UserSpec{Email: "admin@example.com", Password: "other", Roles: []string{"b", "a", "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, Updated, change)
+	assert.False(t, storage.users[0].admin)
+	assert.Equal(t, []string{"a", "b"}, storage.users[0].roles)
+	assert.NoError(t, password.New(password.NewBcrypt(4)).Compare(storage.users[0].PassHash, "other"))
+
+	var types []string
+	for _, e := range *auditor {
+		assert.Equal(t, id, e.UserID)
+		types = append(types, e.Type)
+	}
+	assert.Equal(t, []string{
+		models.AuditRegister, models.AuditRoleChange,
+		models.AuditPasswordChanged, models.AuditRoleChange,
+	}, types)
+}
+
+func TestEnsureUser_PassHash(t *testing.T) {
+	svc, storage, _ := newTestService()
+	ctx := context.Background()
+
+	hash, err := password.NewBcrypt(4).Hash("secret")
+	require.NoError(t, err)
+
+	_, change, err := svc.EnsureUser(ctx, UserSpec{Email: "legacy@example.com", PassHash: hash})
+	require.NoError(t, err)
+	assert.Equal(t, Created, change)
+	assert.Equal(t, hash, storage.users[0].PassHash)
+
+	_, _, err = svc.EnsureUser(ctx, UserSpec{Email: "bad@example.com", PassHash: []byte("plain")})
+	var validation *err_internal.ValidationError
+	require.ErrorAs(t, err, &validation)
+	assert.Equal(t, "pass_hash", validation.Field)
+
+	_, _, err = svc.EnsureUser(ctx, UserSpec{Email: "none@example.com"})
+	assert.ErrorAs(t, err, &validation)
+}
+
+func TestLockAndSetAdmin(t *testing.T) {
+	svc, storage, _ := newTestService()
+	ctx := context.Background()
+
+	adminID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "admin@example.com", Password: "secret", Admin: true})
+	require.NoError(t, err)
+	userID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "user@example.com", Password: "secret"})
+	require.NoError(t, err)
+
+	_, _, err = svc.LockUser(ctx, userID, UserRef{ID: adminID})
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+
+	user, revoked, err := svc.LockUser(ctx, adminID, UserRef{Email: "user@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, userID, user.ID)
+	assert.True(t, storage.users[userID-1].Locked())
+	assert.EqualValues(t, 2, revoked)
+
+	_, err = svc.UnlockUser(ctx, adminID, UserRef{ID: userID})
+	require.NoError(t, err)
+	assert.False(t, storage.users[userID-1].Locked())
+
+	var validation *err_internal.ValidationError
+	_, _, err = svc.LockUser(ctx, adminID, UserRef{ID: adminID})
+	assert.ErrorAs(t, err, &validation, "admins cannot lock themselves")
+	_, err = svc.SetAdmin(ctx, adminID, UserRef{ID: adminID}, false)
+	assert.ErrorAs(t, err, &validation, "admins cannot demote themselves")
+
+	_, err = svc.SetAdmin(ctx, adminID, UserRef{ID: userID}, true)
+	require.NoError(t, err)
+	assert.True(t, storage.users[userID-1].admin)
+}
+
+func TestOrgAdminScope(t *testing.T) {
+	svc, storage, _ := newTestService()
+	ctx := context.Background()
+
+	rootID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "root@example.com", Password: "secret", Admin: true})
+	require.NoError(t, err)
+
+	acme, err := svc.CreateOrg(ctx, rootID, OrgSpec{Slug: "acme", Name: "Acme"})
+	require.NoError(t, err)
+	_, err = svc.CreateOrg(ctx, rootID, OrgSpec{Slug: "Not Valid"})
+	var validation *err_internal.ValidationError
+	assert.ErrorAs(t, err, &validation)
+
+	ownerID, _, err := svc.EnsureUser(ctx, UserSpec{Org: "acme", Email: "owner@acme.com", Password: "secret", OrgAdmin: true})
+	require.NoError(t, err)
+	memberID, _, err := svc.EnsureUser(ctx, UserSpec{Org: "acme", Email: "user@example.com", Password: "secret"})
+	require.NoError(t, err)
+	outsiderID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "user@example.com", Password: "secret"})
+	require.NoError(t, err)
+	assert.NotEqual(t, memberID, outsiderID, "email is unique per org")
+
+	// Администратор организации создаёт приложения только в своей организации
+	app, err := svc.CreateApp(ctx, ownerID, AppSpec{Name: "crm"})
+	require.NoError(t, err)
+	assert.Equal(t, acme.ID, app.OrgID)
+	_, err = svc.CreateApp(ctx, ownerID, AppSpec{Org: models.DefaultOrgSlug, Name: "crm"})
+	assert.ErrorIs(t, err, err_internal.ErrOrgNotFound)
+	_, err = svc.CreateApp(ctx, rootID, AppSpec{Name: "portal"})
+	require.NoError(t, err)
+
+	apps, err := svc.ListApps(ctx, ownerID)
+	require.NoError(t, err)
+	assert.Len(t, apps, 1)
+	apps, err = svc.ListApps(ctx, rootID)
+	require.NoError(t, err)
+	assert.Len(t, apps, 2)
+
+	// Email ищется в организации вызывающего, ID чужой организации не находится
+	user, _, err := svc.LockUser(ctx, ownerID, UserRef{Email: "user@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, memberID, user.ID)
+	_, _, err = svc.LockUser(ctx, ownerID, UserRef{ID: outsiderID})
+	assert.ErrorIs(t, err, err_internal.ErrUserNotFound)
+	_, _, err = svc.LockUser(ctx, ownerID, UserRef{ID: rootID})
+	assert.ErrorIs(t, err, err_internal.ErrUserNotFound)
+
+	_, err = svc.SetOrgAdmin(ctx, ownerID, UserRef{ID: memberID}, true)
+	require.NoError(t, err)
+	assert.True(t, storage.users[memberID-1].OrgAdmin)
+
+	// Глобальные права и организации - только для глобальных администраторов
+	_, err = svc.SetAdmin(ctx, ownerID, UserRef{ID: memberID}, true)
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+	_, err = svc.ListOrgs(ctx, ownerID)
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+	_, err = svc.ListApps(ctx, outsiderID)
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+}
+
+func TestAppMembers(t *testing.T) {
+	svc, storage, _ := newTestService()
+	ctx := context.Background()
+
+	adminID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "admin@example.com", Password: "secret", Admin: true})
+	require.NoError(t, err)
+	userID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "user@example.com", Password: "secret"})
+	require.NoError(t, err)
+
+	_, err = svc.CreateApp(ctx, adminID, AppSpec{Name: "crm", AccessPolicy: "members"})
+	var validation *err_internal.ValidationError
+	assert.ErrorAs(t, err, &validation)
+
+	app, err := svc.CreateApp(ctx, adminID, AppSpec{Name: "crm"})
+	require.NoError(t, err)
+	assert.Equal(t, models.AccessOpen, app.AccessPolicy)
+	require.NoError(t, svc.SetAppAccessPolicy(ctx, adminID, app.ID, models.AccessApprovalRequired))
+
+	// Без заявки одобрять нечего
+	_, err = svc.ApproveAppMember(ctx, adminID, app.ID, UserRef{ID: userID})
+	assert.ErrorIs(t, err, err_internal.ErrMemberNotFound)
+
+	storage.members = map[[2]int64]models.AppMember{
+		{int64(app.ID), userID}: {AppID: app.ID, UserID: userID, Status: models.MemberPending},
+	}
+	pending, err := svc.ListAppMembers(ctx, adminID, app.ID, models.MemberPending)
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	member, err := svc.ApproveAppMember(ctx, adminID, app.ID, UserRef{Email: "user@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, models.MemberActive, member.Status)
+	assert.Equal(t, adminID, member.AddedBy)
+
+	revoked, err := svc.RemoveAppMember(ctx, adminID, app.ID, UserRef{ID: userID})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, revoked)
+	assert.Empty(t, storage.members)
+
+	_, err = svc.InviteAppMember(ctx, userID, app.ID, UserRef{ID: userID})
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+}
+
+func TestListUsers(t *testing.T) {
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+
+	rootID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "root@example.com", Password: "secret", Admin: true})
+	require.NoError(t, err)
+	_, err = svc.CreateOrg(ctx, rootID, OrgSpec{Slug: "acme", Name: "Acme"})
+	require.NoError(t, err)
+	ownerID, _, err := svc.EnsureUser(ctx, UserSpec{Org: "acme", Email: "owner@acme.com", Password: "secret", OrgAdmin: true})
+	require.NoError(t, err)
+	var memberID int64
+	for _, email := range []string{"a@acme.com", "b@acme.com", "c@acme.com"} {
+		memberID, _, err = svc.EnsureUser(ctx, UserSpec{Org: "acme", Email: email, Password: "secret"})
+		require.NoError(t, err)
+	}
+
+	// Страницы по курсору без пропусков и повторов
+	var seen []int64
+	filter := models.UserFilter{Limit: 2}
+	for {
+		users, next, err := svc.ListUsers(ctx, rootID, "", filter)
+		require.NoError(t, err)
+		for _, u := range users {
+			seen = append(seen, u.ID)
+		}
+		if next == nil {
+			break
+		}
+		filter.After = next
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, seen)
+
+	// Администратор организации видит только свою организацию
+	users, _, err := svc.ListUsers(ctx, ownerID, "", models.UserFilter{})
+	require.NoError(t, err)
+	assert.Len(t, users, 4)
+	_, _, err = svc.ListUsers(ctx, ownerID, models.DefaultOrgSlug, models.UserFilter{})
+	assert.ErrorIs(t, err, err_internal.ErrOrgNotFound)
+	users, _, err = svc.ListUsers(ctx, rootID, models.DefaultOrgSlug, models.UserFilter{})
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	var validation *err_internal.ValidationError
+	_, _, err = svc.ListUsers(ctx, rootID, "", models.UserFilter{Sort: "password"})
+	assert.ErrorAs(t, err, &validation)
+	_, _, err = svc.SearchUsers(ctx, rootID, "", models.UserFilter{Query: " a "})
+	assert.ErrorAs(t, err, &validation)
+
+	_, _, err = svc.ListUsers(ctx, memberID, "", models.UserFilter{})
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+}
+
+func TestUserLifecycle(t *testing.T) {
+	svc, storage, auditor := newTestService()
+	ctx := context.Background()
+
+	adminID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "admin@example.com", Password: "secret", Admin: true})
+	require.NoError(t, err)
+	userID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "user@example.com", Password: "secret"})
+	require.NoError(t, err)
+
+	user, revoked, err := svc.DisableUser(ctx, adminID, UserRef{ID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, models.UserDisabled, user.State())
+	assert.EqualValues(t, 2, revoked)
+
+	// Удаление важнее отключения и назначается через срок ожидания
+	user, _, err = svc.EraseUser(ctx, adminID, UserRef{ID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, models.UserPendingDeletion, user.State())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), user.DeleteAfter, time.Minute)
+
+	export, err := svc.ExportUserData(ctx, adminID, UserRef{ID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", export.User.Email)
+	assert.Equal(t, models.UserPendingDeletion, export.User.State)
+
+	// Включение отменяет и отключение, и удаление
+	user, err = svc.EnableUser(ctx, adminID, UserRef{ID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, models.UserActive, user.State())
+	assert.Equal(t, models.UserActive, storage.users[userID-1].State())
+
+	var validation *err_internal.ValidationError
+	_, _, err = svc.EraseUser(ctx, adminID, UserRef{ID: adminID})
+	assert.ErrorAs(t, err, &validation, "admins cannot erase themselves")
+	_, _, err = svc.DisableUser(ctx, userID, UserRef{ID: adminID})
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+
+	var types []string
+	for _, e := range *auditor {
+		types = append(types, e.Type)
+	}
+	assert.Subset(t, types, []string{models.AuditUserDisabled, models.AuditEraseScheduled, models.AuditUserExported, models.AuditUserEnabled})
+}
+
+type memEraser struct {
+	due    []int64
+	erased []int64
+}
+
+func (m *memEraser) UsersToErase(_ context.Context, skip []int64, limit int) ([]int64, error) {
+	var ids []int64
+	for _, id := range m.due {
+		if !slices.Contains(skip, id) && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (m *memEraser) EraseUser(_ context.Context, uid int64) ([]int, error) {
+	if uid == 4 {
+		return nil, errors.New("database is down") // удаление этого пользователя не проходит
+	}
+	m.due = slices.DeleteFunc(m.due, func(id int64) bool { return id == uid })
+	if uid == 2 {
+		return nil, err_internal.ErrUserNotFound // удаление отменено
+	}
+	m.erased = append(m.erased, uid)
+	if uid == 1 {
+		return []int{10, 11}, nil
+	}
+	return nil, nil
+}
+
+func TestEraser(t *testing.T) {
+	storage := &memEraser{due: []int64{4, 1, 2, 3}}
+	auditor := &memAuditor{}
+	e := NewEraser(zap.NewNop(), storage, auditor, time.Hour, 2)
+	ctx, now := context.Background(), time.Now()
+
+	// Пользователь с ошибкой удаления не задерживает остальных
+	assert.Equal(t, 2, e.eraseBatch(ctx, now))
+	assert.Equal(t, 2, e.eraseBatch(ctx, now))
+	assert.Equal(t, 0, e.eraseBatch(ctx, now))
+	assert.Equal(t, []int64{1, 3}, storage.erased)
+
+	// и снова пробуется через eraseRetryDelay
+	assert.Equal(t, 1, e.eraseBatch(ctx, now.Add(eraseRetryDelay)))
+	assert.Equal(t, []int64{4}, storage.due)
+
+	// Вебхуки получают событие для каждого приложения пользователя
+	require.Len(t, *auditor, 3)
+	for i, appID := range []int{10, 11, 0} {
+		assert.Equal(t, models.AuditUserDeleted, (*auditor)[i].Type)
+		assert.Equal(t, appID, (*auditor)[i].AppID)
+	}
+}
+
+func TestImportUsers(t *testing.T) {
+	svc, storage, _ := newTestService()
+	ctx := context.Background()
+
+	rootID, _, err := svc.EnsureUser(ctx, UserSpec{Email: "root@example.com", Password: "secret", Admin: true})
+	require.NoError(t, err)
+	hash := storage.users[0].PassHash
+
+	rows := []models.ImportedUser{
+		{Row: 1, Email: "a@example.com", PassHash: hash},
+		{Row: 2, Email: "root@example.com", PassHash: hash},
+		{Row: 3, Email: "b@example.com", PassHash: []byte("md5:abc")},
+		{Row: 4, Email: " ", PassHash: hash},
+		{Row: 5, Email: "a@example.com", PassHash: hash},
+		{Row: 6, Email: "c@example.com", PassHash: []byte("$pbkdf2-sha256$6400$0ZrzXitFSGltTQnBWOsdAw$Y11AchqV4b0sUisdZd0Xr97KWoymNE0LNNrnEgY4H9M")},
+		{Row: 7, Email: "d@example.com", PassHash: []byte("$2a$31$" + strings.Repeat("a", 53))},
+	}
+	res, err := svc.ImportUsers(ctx, rootID, "", rows)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Imported)
+	assert.EqualValues(t, 1, res.Skipped, "existing user")
+	assert.EqualValues(t, 5, res.Failed, "unknown hash, empty email, duplicate, pbkdf2 without the legacy scheme and too costly bcrypt")
+	assert.EqualValues(t, 7, res.LastRow)
+	assert.Equal(t, int64(3), res.Errors[0].Row)
+	assert.Equal(t, "unknown password hash format", res.Errors[0].Reason)
+	assert.Contains(t, res.Errors[4].Reason, "cost must be at most")
+
+	// Повторный импорт ничего не создаёт
+	res, err = svc.ImportUsers(ctx, rootID, "", rows[:1])
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, res.Imported)
+	assert.EqualValues(t, 1, res.Skipped)
+
+	var exported []string
+	err = svc.ExportUsers(ctx, rootID, "", 1, false, func(u models.User) error {
+		assert.Nil(t, u.PassHash)
+		exported = append(exported, u.Email)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com"}, exported)
+
+	memberID := storage.users[1].ID
+	err = svc.ExportUsers(ctx, memberID, "", 0, true, func(models.User) error { return nil })
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+}
@@ -0,0 +1,214 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/jwt"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/random"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/webauthn"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// ListApps возвращает приложения без секретов: все для глобального
+// администратора и своей организации для администратора организации.
+func (s *Service) ListApps(ctx context.Context, callerID int64) ([]models.App, error) {
+	const op = "admin.Service.ListApps"
+
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	apps, err := s.storage.Apps(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range apps {
+		apps[i].Secret = ""
+	}
+
+	return apps, nil
+}
+
+// CreateApp регистрирует приложение в организации spec.Org. Если секрет пуст, он генерируется.
+// spec.ID не учитывается. Возвращает приложение вместе с секретом: позже секрет не показывается.
+func (s *Service) CreateApp(ctx context.Context, callerID int64, spec AppSpec) (models.App, error) {
+	const op = "admin.Service.CreateApp"
+	log := s.log.With(zap.String("method", op), zap.String("app", spec.Name))
+
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+	orgID, err := s.resolveOrg(ctx, scope, spec.Org)
+	if err != nil {
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := validateApp(spec); err != nil {
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	app := newApp(orgID, spec)
+	if app.Secret == "" {
+		app.Secret = random.Token(secretBytes)
+	}
+	if app.AccessPolicy == "" {
+		app.AccessPolicy = models.AccessOpen
+	}
+
+	id, err := s.storage.SaveApp(ctx, app)
+	if err != nil {
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+	app.ID = id
+
+	log.Info("app created", zap.Int("app_id", id), zap.Int64("caller_id", callerID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditAppCreated,
+		UserID:   callerID,
+		AppID:    id,
+		Metadata: map[string]string{"name": app.Name, "org_id": strconv.FormatInt(orgID, 10)},
+	})
+
+	return app, nil
+}
+
+// RotateAppSecret заменяет секрет приложения новым случайным и возвращает его.
+// Токены, подписанные старым секретом, перестают приниматься.
+func (s *Service) RotateAppSecret(ctx context.Context, callerID int64, appID int) (string, error) {
+	const op = "admin.Service.RotateAppSecret"
+	log := s.log.With(zap.String("method", op), zap.Int("app_id", appID))
+
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	secret := random.Token(secretBytes)
+	if err := s.storage.UpdateAppSecret(ctx, scope, appID, secret); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("app secret rotated", zap.Int64("caller_id", callerID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditAppSecretRotate,
+		UserID: callerID,
+		AppID:  appID,
+	})
+
+	return secret, nil
+}
+
+// SetAppTokenSettings заменяет параметры токенов приложения. Нулевые значения
+// возвращают глобальные настройки. Уже выданные токены не меняются.
+func (s *Service) SetAppTokenSettings(ctx context.Context, callerID int64, appID int, settings models.TokenSettings) error {
+	const op = "admin.Service.SetAppTokenSettings"
+
+	if err := validateTokenSettings(settings); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.SetAppTokenSettings(ctx, scope, appID, settings); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("app token settings changed", zap.String("method", op), zap.Int("app_id", appID), zap.Int64("caller_id", callerID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditAppTokenChange,
+		UserID: callerID,
+		AppID:  appID,
+		Metadata: map[string]string{
+			"ttl":      settings.TTL.String(),
+			"issuer":   settings.Issuer,
+			"audience": strings.Join(settings.Audience, ","),
+		},
+	})
+
+	return nil
+}
+
+// SetAppPasskeySettings задаёт проверяющую сторону WebAuthn приложения.
+// Пустой RPID отключает вход по ключам доступа; сами ключи не удаляются
+// и снова подойдут, если вернуть тот же RP ID.
+func (s *Service) SetAppPasskeySettings(ctx context.Context, callerID int64, appID int, settings models.PasskeySettings) error {
+	const op = "admin.Service.SetAppPasskeySettings"
+
+	if err := validatePasskeySettings(settings); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.SetAppPasskeySettings(ctx, scope, appID, settings); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("app passkey settings changed", zap.String("method", op), zap.Int("app_id", appID), zap.Int64("caller_id", callerID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditAppPasskeySet,
+		UserID: callerID,
+		AppID:  appID,
+		Metadata: map[string]string{
+			"rp_id":   settings.RPID,
+			"origins": strings.Join(settings.Origins, ","),
+		},
+	})
+
+	return nil
+}
+
+func validatePasskeySettings(settings models.PasskeySettings) error {
+	if !settings.Enabled() {
+		if len(settings.Origins) > 0 {
+			return err_internal.NewValidationError("passkeys.rp_id", "rp_id is required with origins")
+		}
+		return nil
+	}
+
+	rp := webauthn.RelyingParty{ID: settings.RPID, Name: settings.RPName, Origins: settings.Origins}
+	if err := rp.Validate(); err != nil {
+		return err_internal.NewValidationError("passkeys", err.Error())
+	}
+
+	return nil
+}
+
+func validateTokenSettings(settings models.TokenSettings) error {
+	if settings.TTL < 0 || settings.TTL%time.Second != 0 {
+		return err_internal.NewValidationError("token.ttl", "ttl must be a whole number of seconds, 0 for the default")
+	}
+	for _, aud := range settings.Audience {
+		if strings.TrimSpace(aud) == "" {
+			return err_internal.NewValidationError("token.audience", "audience must not be empty")
+		}
+	}
+	for name, source := range settings.Claims {
+		if strings.TrimSpace(name) == "" {
+			return err_internal.NewValidationError("token.claims", "claim name is required")
+		}
+		if jwt.Reserved(name) {
+			return err_internal.NewValidationError("token.claims", fmt.Sprintf("claim %q is reserved", name))
+		}
+		if _, ok := (models.User{}).ClaimValue(source); !ok {
+			return err_internal.NewValidationError("token.claims", fmt.Sprintf("unknown user attribute %q for claim %q", source, name))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// EraserStorage - хранилище для фонового удаления пользователей.
+type EraserStorage interface {
+	UsersToErase(ctx context.Context, skip []int64, limit int) ([]int64, error)
+	EraseUser(ctx context.Context, userID int64) (appIDs []int, err error)
+}
+
+// eraseRetryDelay - через сколько снова пробовать удалить пользователя,
+// удаление которого завершилось ошибкой. До этого он не мешает остальным.
+const eraseRetryDelay = 10 * time.Minute
+
+// Eraser удаляет пользователей, у которых истёк срок ожидания удаления
+// (см. Service.EraseUser). Несколько реплик могут работать одновременно:
+// пользователь удаляется одной транзакцией, вторая её просто не найдёт.
+type Eraser struct {
+	log          *zap.Logger
+	storage      EraserStorage
+	auditor      Auditor
+	pollInterval time.Duration
+	batchSize    int
+
+	// failed - пользователи, удаление которых не удалось, и время следующей попытки
+	failed map[int64]time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewEraser(log *zap.Logger, storage EraserStorage, auditor Auditor, pollInterval time.Duration, batchSize int) *Eraser {
+	return &Eraser{
+		log:          log,
+		storage:      storage,
+		auditor:      auditor,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		failed:       make(map[int64]time.Time),
+	}
+}
+
+// Start запускает фоновое удаление.
+func (e *Eraser) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		e.run(ctx)
+	}()
+}
+
+// Stop останавливает удаление и дожидается завершения текущей пачки.
+func (e *Eraser) Stop() {
+	if e.cancel == nil {
+		return
+	}
+
+	e.cancel()
+	e.wg.Wait()
+}
+
+func (e *Eraser) run(ctx context.Context) {
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for e.eraseBatch(ctx, time.Now()) == e.batchSize {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// eraseBatch удаляет одну пачку пользователей и возвращает её размер.
+// Ошибка удаления одного пользователя не останавливает остальных:
+// его пропускают до eraseRetryDelay.
+func (e *Eraser) eraseBatch(ctx context.Context, now time.Time) int {
+	const op = "admin.Eraser.eraseBatch"
+	log := e.log.With(zap.String("op", op))
+
+	var skip []int64
+	for id, retryAt := range e.failed {
+		if now.Before(retryAt) {
+			skip = append(skip, id)
+		} else {
+			delete(e.failed, id)
+		}
+	}
+
+	ids, err := e.storage.UsersToErase(ctx, skip, e.batchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Error("failed to list users to erase", zap.Error(err))
+		}
+		return 0
+	}
+
+	for _, id := range ids {
+		appIDs, err := e.storage.EraseUser(ctx, id)
+		if errors.Is(err, err_internal.ErrUserNotFound) {
+			// Удаление отменено или пользователя уже удалила другая реплика
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0
+			}
+			log.Error("failed to erase user", zap.Int64("user_id", id), zap.Error(err))
+			e.failed[id] = now.Add(eraseRetryDelay)
+			continue
+		}
+
+		log.Info("user erased", zap.Int64("user_id", id))
+
+		// Сессии удалены вместе с пользователем, поэтому приложения
+		// указываются в событиях явно: вебхуки не смогли бы их найти.
+		if len(appIDs) == 0 {
+			appIDs = []int{0}
+		}
+		for _, appID := range appIDs {
+			e.auditor.Record(ctx, models.AuditEvent{Type: models.AuditUserDeleted, UserID: id, AppID: appID})
+		}
+	}
+
+	return len(ids)
+}
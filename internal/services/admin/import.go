@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxImportBatch - наибольшее число строк в одной пачке ImportUsers.
+	MaxImportBatch = 1000
+	exportPageSize = 1000
+)
+
+// ImportUsers создаёт пачку пользователей с готовыми хэшами паролей
+// в организации org. Строки с ошибками не прерывают импорт, а попадают
+// в результат; пользователи, которые уже есть, пропускаются.
+func (s *Service) ImportUsers(ctx context.Context, callerID int64, org string, users []models.ImportedUser) (models.ImportResult, error) {
+	const op = "admin.Service.ImportUsers"
+
+	if len(users) > MaxImportBatch {
+		return models.ImportResult{}, fmt.Errorf("%s: %w", op,
+			err_internal.NewValidationError("users", fmt.Sprintf("at most %d users per batch", MaxImportBatch)))
+	}
+
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return models.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	orgID, err := s.resolveOrg(ctx, scope, org)
+	if err != nil {
+		return models.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var res models.ImportResult
+	fail := func(u models.ImportedUser, reason string) {
+		res.Failed++
+		if len(res.Errors) < models.MaxImportErrors {
+			res.Errors = append(res.Errors, models.ImportRowError{Row: u.Row, Email: u.Email, Reason: reason})
+		}
+	}
+
+	valid := make([]models.ImportedUser, 0, len(users))
+	seen := make(map[string]bool, len(users))
+	for _, u := range users {
+		res.LastRow = max(res.LastRow, u.Row)
+
+		u.Email = strings.TrimSpace(u.Email)
+		hashErr := s.validatePassHash(u.PassHash)
+		switch {
+		case u.Email == "":
+			fail(u, "email is required")
+		case len(u.PassHash) == 0:
+			fail(u, "pass_hash is required")
+		case hashErr != nil:
+			fail(u, hashErr.Error())
+		case seen[u.Email]:
+			fail(u, "duplicate email in batch")
+		default:
+			seen[u.Email] = true
+			u.Roles = normalizeRoles(u.Roles)
+			valid = append(valid, u)
+		}
+	}
+
+	if len(valid) > 0 {
+		created, err := s.storage.ImportUsers(ctx, orgID, valid)
+		if err != nil {
+			return models.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+		}
+		res.Imported = int64(len(created))
+		res.Skipped = int64(len(valid) - len(created))
+	}
+
+	s.log.Info("users imported",
+		zap.String("method", op),
+		zap.Int64("caller_id", callerID),
+		zap.Int64("org_id", orgID),
+		zap.Int64("imported", res.Imported),
+		zap.Int64("skipped", res.Skipped),
+		zap.Int64("failed", res.Failed),
+	)
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditUsersImported,
+		UserID: callerID,
+		Metadata: map[string]string{
+			"org_id":   strconv.FormatInt(orgID, 10),
+			"imported": strconv.FormatInt(res.Imported, 10),
+			"skipped":  strconv.FormatInt(res.Skipped, 10),
+			"failed":   strconv.FormatInt(res.Failed, 10),
+			"last_row": strconv.FormatInt(res.LastRow, 10),
+		},
+	})
+
+	return res, nil
+}
+
+// ExportUsers отправляет пользователей организации org в порядке ID,
+// начиная после afterID, чтобы прерванную выгрузку можно было продолжить.
+// Без org глобальный администратор выгружает все организации.
+// Хэши паролей выгружаются только глобальному администратору и только
+// с withHashes, иначе PassHash пустой.
+func (s *Service) ExportUsers(
+	ctx context.Context,
+	callerID int64,
+	org string,
+	afterID int64,
+	withHashes bool,
+	send func(models.User) error,
+) error {
+	const op = "admin.Service.ExportUsers"
+
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if withHashes && scope != models.AnyOrg {
+		return fmt.Errorf("%s: %w", op, err_internal.ErrPermissionDenied)
+	}
+
+	filter := models.UserFilter{OrgID: scope, Sort: models.UserSortID, Limit: exportPageSize}
+	if org != "" {
+		if filter.OrgID, err = s.resolveOrg(ctx, scope, org); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if afterID > 0 {
+		filter.After = &models.UserCursor{ID: afterID}
+	}
+
+	var sent int64
+	for {
+		users, err := s.storage.Users(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, u := range users {
+			if !withHashes {
+				u.PassHash = nil
+			}
+			if err := send(u); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			sent++
+		}
+
+		if len(users) < filter.Limit {
+			break
+		}
+		cursor := users[len(users)-1].Cursor(filter.Sort)
+		filter.After = &cursor
+	}
+
+	s.log.Info("users exported", zap.String("method", op), zap.Int64("caller_id", callerID), zap.Int64("users", sent))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditUsersDumped,
+		UserID: callerID,
+		Metadata: map[string]string{
+			"org_id":      strconv.FormatInt(filter.OrgID, 10),
+			"users":       strconv.FormatInt(sent, 10),
+			"pass_hashes": strconv.FormatBool(withHashes),
+		},
+	})
+
+	return nil
+}
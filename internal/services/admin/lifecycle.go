@@ -0,0 +1,152 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// DisableUser отключает учётную запись и отзывает все её сессии.
+// В отличие от блокировки, отключение - долгосрочное решение администратора.
+func (s *Service) DisableUser(ctx context.Context, callerID int64, ref UserRef) (models.User, int64, error) {
+	const op = "admin.Service.DisableUser"
+
+	user, err := s.target(ctx, callerID, ref)
+	if err != nil {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.ID == callerID {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err_internal.NewValidationError("user", "you cannot disable yourself"))
+	}
+
+	if user.DisabledAt, err = s.storage.SetUserDisabled(ctx, user.ID, true); err != nil {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	revoked, err := s.storage.RevokeUserSessions(ctx, user.ID, "")
+	if err != nil {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("user disabled",
+		zap.String("method", op),
+		zap.Int64("user_id", user.ID),
+		zap.Int64("caller_id", callerID),
+		zap.Int64("revoked_sessions", revoked),
+	)
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditUserDisabled,
+		UserID:   user.ID,
+		Email:    user.Email,
+		Metadata: map[string]string{"by": strconv.FormatInt(callerID, 10)},
+	})
+
+	return user, revoked, nil
+}
+
+// EnableUser снова включает отключённую учётную запись и отменяет удаление,
+// если срок ожидания ещё не истёк. Блокировка снимается отдельно (UnlockUser).
+func (s *Service) EnableUser(ctx context.Context, callerID int64, ref UserRef) (models.User, error) {
+	const op = "admin.Service.EnableUser"
+
+	user, err := s.target(ctx, callerID, ref)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !user.DisabledAt.IsZero() {
+		if user.DisabledAt, err = s.storage.SetUserDisabled(ctx, user.ID, false); err != nil {
+			return models.User{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if !user.DeleteAfter.IsZero() {
+		if err := s.storage.SetUserDeleteAfter(ctx, user.ID, time.Time{}); err != nil {
+			return models.User{}, fmt.Errorf("%s: %w", op, err)
+		}
+		user.DeleteAfter = time.Time{}
+	}
+
+	s.log.Info("user enabled", zap.String("method", op), zap.Int64("user_id", user.ID), zap.Int64("caller_id", callerID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditUserEnabled,
+		UserID:   user.ID,
+		Email:    user.Email,
+		Metadata: map[string]string{"by": strconv.FormatInt(callerID, 10)},
+	})
+
+	return user, nil
+}
+
+// EraseUser назначает удаление пользователя по истечении срока ожидания
+// и сразу отзывает его сессии. Пока срок не истёк, удаление отменяет EnableUser;
+// затем пользователя удаляет Eraser, а записи аудита обезличиваются.
+func (s *Service) EraseUser(ctx context.Context, callerID int64, ref UserRef) (models.User, int64, error) {
+	const op = "admin.Service.EraseUser"
+
+	user, err := s.target(ctx, callerID, ref)
+	if err != nil {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.ID == callerID {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err_internal.NewValidationError("user", "you cannot erase yourself"))
+	}
+
+	if user.DeleteAfter.IsZero() {
+		user.DeleteAfter = time.Now().UTC().Add(s.eraseGrace).Truncate(time.Second)
+		if err := s.storage.SetUserDeleteAfter(ctx, user.ID, user.DeleteAfter); err != nil {
+			return models.User{}, 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	revoked, err := s.storage.RevokeUserSessions(ctx, user.ID, "")
+	if err != nil {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("user erase scheduled",
+		zap.String("method", op),
+		zap.Int64("user_id", user.ID),
+		zap.Int64("caller_id", callerID),
+		zap.Time("delete_after", user.DeleteAfter),
+	)
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditEraseScheduled,
+		UserID: user.ID,
+		Email:  user.Email,
+		Metadata: map[string]string{
+			"by":           strconv.FormatInt(callerID, 10),
+			"delete_after": user.DeleteAfter.Format(time.RFC3339),
+		},
+	})
+
+	return user, revoked, nil
+}
+
+// ExportUserData возвращает всё, что сервис хранит о пользователе.
+func (s *Service) ExportUserData(ctx context.Context, callerID int64, ref UserRef) (models.UserExport, error) {
+	const op = "admin.Service.ExportUserData"
+
+	user, err := s.target(ctx, callerID, ref)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	export, err := s.storage.UserExport(ctx, user.ID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("user data exported", zap.String("method", op), zap.Int64("user_id", user.ID), zap.Int64("caller_id", callerID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditUserExported,
+		UserID:   user.ID,
+		Metadata: map[string]string{"by": strconv.FormatInt(callerID, 10)},
+	})
+
+	return export, nil
+}
@@ -0,0 +1,202 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// SetAppAccessPolicy меняет политику доступа приложения. Уже выданные
+// допуски и сессии не меняются: при переходе на open они просто не проверяются.
+func (s *Service) SetAppAccessPolicy(ctx context.Context, callerID int64, appID int, policy string) error {
+	const op = "admin.Service.SetAppAccessPolicy"
+
+	if policy == "" {
+		return fmt.Errorf("%s: %w", op, err_internal.NewValidationError("access_policy", "access policy is required"))
+	}
+	if err := validatePolicy(policy); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.SetAppAccessPolicy(ctx, scope, appID, policy); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("app access policy changed", zap.String("method", op), zap.Int("app_id", appID), zap.String("policy", policy))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditAppPolicyChange,
+		UserID:   callerID,
+		AppID:    appID,
+		Metadata: map[string]string{"access_policy": policy},
+	})
+
+	return nil
+}
+
+// ListAppMembers возвращает участников приложения и заявки на доступ.
+// status фильтрует по статусу, пусто - все.
+func (s *Service) ListAppMembers(ctx context.Context, callerID int64, appID int, status string) ([]models.AppMember, error) {
+	const op = "admin.Service.ListAppMembers"
+
+	if status != "" && status != models.MemberActive && status != models.MemberPending {
+		return nil, fmt.Errorf("%s: %w", op, err_internal.NewValidationError("status", "status must be active or pending"))
+	}
+
+	app, err := s.app(ctx, callerID, appID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	members, err := s.storage.AppMembers(ctx, app.ID, status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return members, nil
+}
+
+// InviteAppMember сразу допускает пользователя в приложение, в том числе
+// одобряет его заявку, если она есть. Пользователь должен быть из организации приложения.
+func (s *Service) InviteAppMember(ctx context.Context, callerID int64, appID int, ref UserRef) (models.AppMember, error) {
+	const op = "admin.Service.InviteAppMember"
+
+	member, err := s.grant(ctx, callerID, appID, ref, false)
+	if err != nil {
+		return models.AppMember{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return member, nil
+}
+
+// ApproveAppMember одобряет заявку на доступ. Без заявки возвращает ErrMemberNotFound.
+func (s *Service) ApproveAppMember(ctx context.Context, callerID int64, appID int, ref UserRef) (models.AppMember, error) {
+	const op = "admin.Service.ApproveAppMember"
+
+	member, err := s.grant(ctx, callerID, appID, ref, true)
+	if err != nil {
+		return models.AppMember{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return member, nil
+}
+
+// RemoveAppMember отзывает допуск или отклоняет заявку и отзывает сессии
+// пользователя в приложении. Возвращает число отозванных сессий.
+func (s *Service) RemoveAppMember(ctx context.Context, callerID int64, appID int, ref UserRef) (int64, error) {
+	const op = "admin.Service.RemoveAppMember"
+
+	app, user, err := s.appAndUser(ctx, callerID, appID, ref)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.DeleteAppMember(ctx, app.ID, user.ID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	revoked, err := s.storage.RevokeAppSessions(ctx, app.ID, user.ID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("app member removed",
+		zap.String("method", op),
+		zap.Int("app_id", app.ID),
+		zap.Int64("user_id", user.ID),
+		zap.Int64("caller_id", callerID),
+		zap.Int64("revoked_sessions", revoked),
+	)
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditAppMemberRemove,
+		UserID:   user.ID,
+		AppID:    app.ID,
+		Email:    user.Email,
+		Metadata: map[string]string{"by": strconv.FormatInt(callerID, 10)},
+	})
+
+	return revoked, nil
+}
+
+// grant делает пользователя активным участником. С onlyPending - только если
+// у него есть заявка.
+func (s *Service) grant(ctx context.Context, callerID int64, appID int, ref UserRef, onlyPending bool) (models.AppMember, error) {
+	app, user, err := s.appAndUser(ctx, callerID, appID, ref)
+	if err != nil {
+		return models.AppMember{}, err
+	}
+
+	if onlyPending {
+		current, err := s.storage.AppMember(ctx, app.ID, user.ID)
+		if err != nil {
+			return models.AppMember{}, err
+		}
+		if current.Status != models.MemberPending {
+			return current, nil
+		}
+	}
+
+	member, err := s.storage.SaveAppMember(ctx, models.AppMember{
+		AppID:   app.ID,
+		UserID:  user.ID,
+		Status:  models.MemberActive,
+		AddedBy: callerID,
+	})
+	if err != nil {
+		return models.AppMember{}, err
+	}
+	member.Email = user.Email
+
+	s.log.Info("app member added", zap.Int("app_id", app.ID), zap.Int64("user_id", user.ID), zap.Int64("caller_id", callerID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditAppMemberAdded,
+		UserID:   user.ID,
+		AppID:    app.ID,
+		Email:    user.Email,
+		Metadata: map[string]string{"by": strconv.FormatInt(callerID, 10)},
+	})
+
+	return member, nil
+}
+
+// app проверяет права вызывающего и находит приложение в его области.
+func (s *Service) app(ctx context.Context, callerID int64, appID int) (models.App, error) {
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return models.App{}, err
+	}
+
+	return s.storage.App(ctx, scope, appID)
+}
+
+// appAndUser находит приложение и пользователя из той же организации.
+func (s *Service) appAndUser(ctx context.Context, callerID int64, appID int, ref UserRef) (models.App, models.User, error) {
+	app, err := s.app(ctx, callerID, appID)
+	if err != nil {
+		return models.App{}, models.User{}, err
+	}
+
+	user, err := s.findUser(ctx, app.OrgID, ref)
+	if err != nil {
+		return models.App{}, models.User{}, err
+	}
+
+	return app, user, nil
+}
+
+func validatePolicy(policy string) error {
+	switch policy {
+	case "", models.AccessOpen, models.AccessInviteOnly, models.AccessApprovalRequired:
+		return nil
+	}
+
+	return err_internal.NewValidationError("access_policy", "access policy must be open, invite_only or approval_required")
+}
@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// slugRe - допустимый идентификатор организации: он передаётся в Login и Register.
+var slugRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// OrgSpec - желаемое состояние организации.
+type OrgSpec struct {
+	Slug string
+	Name string
+}
+
+// ListOrgs возвращает все организации. Только для глобальных администраторов.
+func (s *Service) ListOrgs(ctx context.Context, callerID int64) ([]models.Org, error) {
+	const op = "admin.Service.ListOrgs"
+
+	if err := s.authorizeGlobal(ctx, callerID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	orgs, err := s.storage.Orgs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orgs, nil
+}
+
+// CreateOrg создаёт организацию. Только для глобальных администраторов.
+func (s *Service) CreateOrg(ctx context.Context, callerID int64, spec OrgSpec) (models.Org, error) {
+	const op = "admin.Service.CreateOrg"
+
+	if err := s.authorizeGlobal(ctx, callerID); err != nil {
+		return models.Org{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	org, err := s.createOrg(ctx, spec)
+	if err != nil {
+		return models.Org{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("org created", zap.String("method", op), zap.String("org", org.Slug), zap.Int64("caller_id", callerID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditOrgCreated,
+		UserID:   callerID,
+		Metadata: map[string]string{"org": org.Slug},
+	})
+
+	return org, nil
+}
+
+// EnsureOrg создаёт организацию, если её ещё нет. Название существующей не меняется.
+func (s *Service) EnsureOrg(ctx context.Context, spec OrgSpec) (models.Org, Change, error) {
+	const op = "admin.Service.EnsureOrg"
+
+	org, err := s.storage.OrgBySlug(ctx, spec.Slug)
+	if err == nil {
+		return org, Unchanged, nil
+	}
+	if !errors.Is(err, err_internal.ErrOrgNotFound) {
+		return models.Org{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if org, err = s.createOrg(ctx, spec); err != nil {
+		return models.Org{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("org created", zap.String("method", op), zap.String("org", org.Slug))
+	return org, Created, nil
+}
+
+func (s *Service) createOrg(ctx context.Context, spec OrgSpec) (models.Org, error) {
+	if !slugRe.MatchString(spec.Slug) {
+		return models.Org{}, err_internal.NewValidationError("slug",
+			"slug must be 1-63 lowercase letters, digits or dashes and start with a letter or digit")
+	}
+
+	org := models.Org{Slug: spec.Slug, Name: strings.TrimSpace(spec.Name), CreatedAt: time.Now().UTC()}
+	if org.Name == "" {
+		org.Name = org.Slug
+	}
+
+	id, err := s.storage.SaveOrg(ctx, org)
+	if err != nil {
+		return models.Org{}, err
+	}
+	org.ID = id
+
+	return org, nil
+}
@@ -0,0 +1,274 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// UserRef указывает пользователя по ID или, если ID равен 0, по email
+// в организации Org (см. resolveOrg).
+type UserRef struct {
+	ID    int64
+	Email string
+	Org   string
+}
+
+// LockUser запрещает пользователю вход и отзывает все его сессии.
+// Возвращает время блокировки и число отозванных сессий.
+func (s *Service) LockUser(ctx context.Context, callerID int64, ref UserRef) (models.User, int64, error) {
+	const op = "admin.Service.LockUser"
+
+	user, err := s.target(ctx, callerID, ref)
+	if err != nil {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.ID == callerID {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err_internal.NewValidationError("user", "you cannot lock yourself"))
+	}
+
+	if user.LockedAt, err = s.storage.SetUserLocked(ctx, user.ID, true); err != nil {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	revoked, err := s.storage.RevokeUserSessions(ctx, user.ID, "")
+	if err != nil {
+		return models.User{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("user locked",
+		zap.String("method", op),
+		zap.Int64("user_id", user.ID),
+		zap.Int64("caller_id", callerID),
+		zap.Int64("revoked_sessions", revoked),
+	)
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditUserLocked,
+		UserID:   user.ID,
+		Email:    user.Email,
+		Metadata: map[string]string{"by": strconv.FormatInt(callerID, 10)},
+	})
+
+	return user, revoked, nil
+}
+
+// UnlockUser снова разрешает пользователю вход.
+func (s *Service) UnlockUser(ctx context.Context, callerID int64, ref UserRef) (int64, error) {
+	const op = "admin.Service.UnlockUser"
+
+	user, err := s.target(ctx, callerID, ref)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := s.storage.SetUserLocked(ctx, user.ID, false); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("user unlocked", zap.String("method", op), zap.Int64("user_id", user.ID), zap.Int64("caller_id", callerID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditUserUnlocked,
+		UserID:   user.ID,
+		Email:    user.Email,
+		Metadata: map[string]string{"by": strconv.FormatInt(callerID, 10)},
+	})
+
+	return user.ID, nil
+}
+
+// SetAdmin выдаёт или отзывает права глобального администратора. Роли пользователя не меняются.
+// Снять права с самого себя нельзя, чтобы не остаться без администраторов.
+func (s *Service) SetAdmin(ctx context.Context, callerID int64, ref UserRef, isAdmin bool) (int64, error) {
+	const op = "admin.Service.SetAdmin"
+
+	if err := s.authorizeGlobal(ctx, callerID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	user, err := s.findUser(ctx, models.AnyOrg, ref)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.ID == callerID && !isAdmin {
+		return 0, fmt.Errorf("%s: %w", op, err_internal.NewValidationError("user", "you cannot revoke your own admin rights"))
+	}
+
+	current, roles, err := s.storage.UserRoles(ctx, user.ID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if current == isAdmin {
+		return user.ID, nil
+	}
+
+	if err := s.setRoles(ctx, user.ID, user.Email, isAdmin, normalizeRoles(roles)); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("admin rights changed",
+		zap.String("method", op),
+		zap.Int64("user_id", user.ID),
+		zap.Int64("caller_id", callerID),
+		zap.Bool("is_admin", isAdmin),
+	)
+
+	return user.ID, nil
+}
+
+// SetOrgAdmin выдаёт или отзывает права администратора организации пользователя.
+// Снять права с самого себя нельзя.
+func (s *Service) SetOrgAdmin(ctx context.Context, callerID int64, ref UserRef, orgAdmin bool) (int64, error) {
+	const op = "admin.Service.SetOrgAdmin"
+
+	user, err := s.target(ctx, callerID, ref)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.ID == callerID && !orgAdmin {
+		return 0, fmt.Errorf("%s: %w", op, err_internal.NewValidationError("user", "you cannot revoke your own admin rights"))
+	}
+	if user.OrgAdmin == orgAdmin {
+		return user.ID, nil
+	}
+
+	if err := s.setOrgAdmin(ctx, user, orgAdmin); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("org admin rights changed",
+		zap.String("method", op),
+		zap.Int64("user_id", user.ID),
+		zap.Int64("org_id", user.OrgID),
+		zap.Int64("caller_id", callerID),
+		zap.Bool("org_admin", orgAdmin),
+	)
+
+	return user.ID, nil
+}
+
+// target проверяет права вызывающего и находит пользователя, над которым выполняется операция.
+// Администратор организации видит только пользователей своей организации
+// и не может управлять глобальными администраторами.
+func (s *Service) target(ctx context.Context, callerID int64, ref UserRef) (models.User, error) {
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	user, err := s.findUser(ctx, scope, ref)
+	if err != nil {
+		return models.User{}, err
+	}
+	if scope != models.AnyOrg && user.IsAdmin {
+		return models.User{}, err_internal.ErrPermissionDenied
+	}
+
+	return user, nil
+}
+
+func (s *Service) findUser(ctx context.Context, scope int64, ref UserRef) (models.User, error) {
+	switch {
+	case ref.ID != 0:
+		return s.storage.UserByID(ctx, scope, ref.ID)
+	case ref.Email != "":
+		orgID, err := s.resolveOrg(ctx, scope, ref.Org)
+		if err != nil {
+			return models.User{}, err
+		}
+		return s.storage.User(ctx, orgID, ref.Email)
+	default:
+		return models.User{}, err_internal.NewValidationError("user", "user_id or email is required")
+	}
+}
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+	minQueryLen     = 3
+)
+
+// ListUsers возвращает страницу пользователей по фильтру и курсор следующей
+// страницы (nil - страниц больше нет). Пустой org - все организации, доступные
+// вызывающему: глобальному администратору все, администратору организации своя.
+func (s *Service) ListUsers(ctx context.Context, callerID int64, org string, filter models.UserFilter) ([]models.User, *models.UserCursor, error) {
+	const op = "admin.Service.ListUsers"
+
+	users, next, err := s.listUsers(ctx, callerID, org, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, next, nil
+}
+
+// SearchUsers - ListUsers по подстроке email или отображаемого имени.
+func (s *Service) SearchUsers(ctx context.Context, callerID int64, org string, filter models.UserFilter) ([]models.User, *models.UserCursor, error) {
+	const op = "admin.Service.SearchUsers"
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	if utf8.RuneCountInString(filter.Query) < minQueryLen {
+		return nil, nil, fmt.Errorf("%s: %w", op, err_internal.NewValidationError("query",
+			fmt.Sprintf("query must be at least %d characters", minQueryLen)))
+	}
+
+	users, next, err := s.listUsers(ctx, callerID, org, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, next, nil
+}
+
+func (s *Service) listUsers(ctx context.Context, callerID int64, org string, filter models.UserFilter) ([]models.User, *models.UserCursor, error) {
+	switch filter.Sort {
+	case "":
+		filter.Sort = models.UserSortID
+	case models.UserSortID, models.UserSortEmail, models.UserSortCreatedAt:
+	default:
+		return nil, nil, err_internal.NewValidationError("order_by", "order_by must be id, email or created_at")
+	}
+	if !filter.CreatedAfter.IsZero() && !filter.CreatedBefore.IsZero() && !filter.CreatedAfter.Before(filter.CreatedBefore) {
+		return nil, nil, err_internal.NewValidationError("created_before", "created_before must be after created_after")
+	}
+
+	scope, err := s.authorize(ctx, callerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter.OrgID = scope
+	if org != "" {
+		if filter.OrgID, err = s.resolveOrg(ctx, scope, org); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = defaultPageSize
+	case filter.Limit > maxPageSize:
+		filter.Limit = maxPageSize
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница.
+	limit := filter.Limit
+	filter.Limit++
+
+	users, err := s.storage.Users(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *models.UserCursor
+	if len(users) > limit {
+		users = users[:limit]
+		cursor := users[limit-1].Cursor(filter.Sort)
+		next = &cursor
+	}
+
+	return users, next, nil
+}
@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+type EventProvider interface {
+	AuditEvents(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, error)
+}
+
+// AdminChecker проверяет, что пользователь - администратор.
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+}
+
+// Service отдаёт журнал аудита администраторам.
+type Service struct {
+	log    *zap.Logger
+	events EventProvider
+	admins AdminChecker
+}
+
+// New creates a new instance of audit Service.
+func New(
+	log *zap.Logger,
+	events EventProvider,
+	admins AdminChecker,
+) *Service {
+	return &Service{
+		log:    log,
+		events: events,
+		admins: admins,
+	}
+}
+
+// ListEvents возвращает страницу событий и курсор следующей страницы (0 - страниц больше нет).
+// Доступно только администраторам.
+func (s *Service) ListEvents(
+	ctx context.Context,
+	callerID int64,
+	filter models.AuditFilter,
+) ([]models.AuditEvent, int64, error) {
+	const op = "audit.Service.ListEvents"
+	log := s.log.With(zap.String("method", op), zap.Int64("caller", callerID))
+
+	isAdmin, err := s.admins.IsAdmin(ctx, callerID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if !isAdmin {
+		log.Warn("non-admin tried to read audit log")
+		return nil, 0, fmt.Errorf("%s: %w", op, err_internal.ErrPermissionDenied)
+	}
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = defaultPageSize
+	case filter.Limit > maxPageSize:
+		filter.Limit = maxPageSize
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница.
+	limit := filter.Limit
+	filter.Limit++
+
+	events, err := s.events.AuditEvents(ctx, filter)
+	if err != nil {
+		log.Error("failed to list audit events", zap.Error(err))
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var next int64
+	if len(events) > limit {
+		events = events[:limit]
+		next = events[limit-1].ID
+	}
+
+	return events, next, nil
+}
@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/clientinfo"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+type EventSaver interface {
+	SaveAuditEvents(ctx context.Context, events []models.AuditEvent) error
+}
+
+// Recorder асинхронно пишет события аудита.
+// Record не блокирует вызывающего: событие кладётся в буфер,
+// а фоновая горутина сохраняет события пачками. Если буфер переполнен,
+// событие отбрасывается с предупреждением в лог, чтобы аудит
+// не замедлял вход пользователей.
+type Recorder struct {
+	log           *zap.Logger
+	saver         EventSaver
+	events        chan models.AuditEvent
+	batchSize     int
+	flushInterval time.Duration
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewRecorder creates a Recorder and starts its background writer.
+func NewRecorder(
+	log *zap.Logger,
+	saver EventSaver,
+	bufferSize int,
+	batchSize int,
+	flushInterval time.Duration,
+) *Recorder {
+	r := &Recorder{
+		log:           log,
+		saver:         saver,
+		events:        make(chan models.AuditEvent, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+
+	go r.run()
+
+	return r
+}
+
+// Record ставит событие в очередь на запись.
+// IP и user agent берутся из контекста запроса, если не заданы явно.
+func (r *Recorder) Record(ctx context.Context, event models.AuditEvent) {
+	info := clientinfo.FromContext(ctx)
+	if event.IP == "" {
+		event.IP = info.IP
+	}
+	if event.UserAgent == "" {
+		event.UserAgent = info.UserAgent
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	select {
+	case r.events <- event:
+	default:
+		r.log.Warn("audit buffer is full, event dropped",
+			zap.String("type", event.Type),
+			zap.Int64("uid", event.UserID),
+		)
+	}
+}
+
+// Stop дожидается записи всех событий из буфера.
+func (r *Recorder) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.events)
+		<-r.done
+	})
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.AuditEvent, 0, r.batchSize)
+	for {
+		select {
+		case e, ok := <-r.events:
+			if !ok {
+				r.flush(batch)
+				return
+			}
+			batch = append(batch, e)
+			if len(batch) >= r.batchSize {
+				r.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			r.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (r *Recorder) flush(batch []models.AuditEvent) {
+	const op = "audit.Recorder.flush"
+
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := r.saver.SaveAuditEvents(ctx, batch); err != nil {
+		r.log.Error("failed to save audit events",
+			zap.String("op", op),
+			zap.Int("count", len(batch)),
+			zap.Error(err),
+		)
+	}
+}
+
+// Sink получает события безопасности.
+type Sink interface {
+	Record(ctx context.Context, event models.AuditEvent)
+}
+
+// Tee передаёт каждое событие всем получателям: журналу аудита,
+// вебхукам приложений и т.п.
+type Tee []Sink
+
+func (t Tee) Record(ctx context.Context, event models.AuditEvent) {
+	for _, s := range t {
+		s.Record(ctx, event)
+	}
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/clientinfo"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeSaver struct {
+	mu      sync.Mutex
+	batches [][]models.AuditEvent
+}
+
+func (f *fakeSaver) SaveAuditEvents(_ context.Context, events []models.AuditEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.batches = append(f.batches, append([]models.AuditEvent(nil), events...))
+	return nil
+}
+
+func (f *fakeSaver) events() []models.AuditEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var all []models.AuditEvent
+	for _, b := range f.batches {
+		all = append(all, b...)
+	}
+	return all
+}
+
+func TestRecorder_FlushesOnStop(t *testing.T) {
+	saver := &fakeSaver{}
+	r := NewRecorder(zap.NewNop(), saver, 10, 2, time.Hour)
+
+	ctx := clientinfo.NewContext(context.Background(), clientinfo.Info{IP: "10.0.0.1", UserAgent: "grpc-go"})
+	for i := 0; i < 3; i++ {
+		r.Record(ctx, models.AuditEvent{Type: models.AuditLoginSuccess, UserID: int64(i + 1)})
+	}
+	r.Stop()
+
+	events := saver.events()
+	require.Len(t, events, 3)
+	assert.Len(t, saver.batches, 2)
+	assert.Equal(t, "10.0.0.1", events[0].IP)
+	assert.Equal(t, "grpc-go", events[0].UserAgent)
+	assert.False(t, events[0].CreatedAt.IsZero())
+}
+
+func TestRecorder_FlushesByInterval(t *testing.T) {
+	saver := &fakeSaver{}
+	r := NewRecorder(zap.NewNop(), saver, 10, 100, 10*time.Millisecond)
+	defer r.Stop()
+
+	r.Record(context.Background(), models.AuditEvent{Type: models.AuditRegister, UserID: 1})
+
+	assert.Eventually(t, func() bool { return len(saver.events()) == 1 }, time.Second, 5*time.Millisecond)
+}
@@ -4,30 +4,57 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/clientinfo"
 	"github.com/Artemiadze/gRPC-Service/internal/lib/jwt"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/password"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/random"
 	"github.com/Artemiadze/gRPC-Service/internal/models"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService struct {
 	// Add any dependencies or configurations needed for the AuthService
-	log         *zap.Logger
-	usrSaver    Storage
-	usrProvider Storage
-	appProvider Storage
-	tokenTTL    time.Duration
+	log          *zap.Logger
+	usrSaver     Storage
+	usrProvider  Storage
+	appProvider  Storage
+	hasher       password.PasswordHasher
+	auditor      Auditor
+	sessions     SessionStorage
+	tokens       atomic.Pointer[models.TokenSettings] // меняются при перечитывании конфига
+	passwordless *passwordless                        // nil - вход без пароля не настроен
+	passkeys     *passkeys                            // nil - ключи доступа не настроены
+	federation   *federation                          // nil - вход через внешних провайдеров не настроен
+}
+
+// SessionStorage хранит сессии, выданные при входе.
+type SessionStorage interface {
+	SaveSession(ctx context.Context, session models.Session) error
+	Session(ctx context.Context, id string) (models.Session, error)
+	TouchSession(ctx context.Context, id string) error
+	RevokeSession(ctx context.Context, id string) error
+}
+
+// Auditor записывает события безопасности в журнал аудита.
+type Auditor interface {
+	Record(ctx context.Context, event models.AuditEvent)
 }
 
 type Storage interface {
 	// Define methods that the storage layer should implement
-	User(ctx context.Context, email string) (user models.User, err error)
+	User(ctx context.Context, orgID int64, email string) (user models.User, err error)
 	IsAdmin(ctx context.Context, uid int64) (isAdmin bool, err error)
-	SaveUser(ctx context.Context, email string, passHash []byte) (uid int64, err error)
-	App(ctx context.Context, appID int) (models.App, error)
+	SaveUser(ctx context.Context, orgID int64, email string, passHash []byte) (uid int64, err error)
+	UpdatePassHash(ctx context.Context, uid int64, passHash []byte) error
+	App(ctx context.Context, orgID int64, appID int) (models.App, error)
+	OrgBySlug(ctx context.Context, slug string) (models.Org, error)
+	AppMember(ctx context.Context, appID int, userID int64) (models.AppMember, error)
+	RequestAppAccess(ctx context.Context, appID int, userID int64) (created bool, err error)
 }
 
 // New creates a new instance of AuthService with the provided dependencies.
@@ -36,63 +63,280 @@ func New(
 	userSaver Storage,
 	userProvider Storage,
 	appProvider Storage,
-	tokenTTL time.Duration,
+	hasher password.PasswordHasher,
+	auditor Auditor,
+	sessions SessionStorage,
+	tokenDefaults models.TokenSettings,
 ) *AuthService {
-	return &AuthService{
+	a := &AuthService{
 		usrSaver:    userSaver,
 		usrProvider: userProvider,
 		log:         log,
 		appProvider: appProvider,
-		tokenTTL:    tokenTTL,
+		hasher:      hasher,
+		auditor:     auditor,
+		sessions:    sessions,
 	}
+	a.SetTokenDefaults(tokenDefaults)
+
+	return a
+}
+
+// SetTokenDefaults меняет глобальные параметры новых токенов, которые
+// действуют, если у приложения они не заданы. Уже выданные токены не меняются.
+func (a *AuthService) SetTokenDefaults(defaults models.TokenSettings) {
+	a.tokens.Store(&defaults)
 }
 
-func (a *AuthService) Login(ctx context.Context, email string, password string, appID int) (string, error) {
+// Login выдаёт токен пользователю организации org. Пустой org - организация по умолчанию.
+// Приложение appID должно принадлежать той же организации.
+func (a *AuthService) Login(ctx context.Context, org string, email string, password string, appID int) (string, error) {
 	const op = "AuthService.Login"
-	log := a.log.With(zap.String("method", op), zap.String("email", email))
+	log := a.log.With(zap.String("method", op), zap.String("org", org), zap.String("email", email))
 
 	log.Info("attempting to login user")
-	user, err := a.usrProvider.User(ctx, email)
+	orgID, err := a.orgID(ctx, org)
+	if err != nil {
+		if errors.Is(err, err_internal.ErrOrgNotFound) {
+			// Не раскрываем, какие организации существуют
+			log.Warn("login to unknown org")
+			a.recordLoginFailure(ctx, models.User{Email: email}, appID, "org_not_found")
+			return "", fmt.Errorf("%s: %w", op, err_internal.ErrInvalidCredentials)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := a.usrProvider.User(ctx, orgID, email)
 	if err != nil {
 		if errors.Is(err, err_internal.ErrUserNotFound) {
 			a.log.Error("user not found", zap.Error(err))
+			a.recordLoginFailure(ctx, models.User{Email: email}, appID, "user_not_found")
 			return "", fmt.Errorf("user not found: %w", err_internal.ErrInvalidCredentials)
 		}
+		log.Error("failed to get user", zap.Error(err))
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := bcrypt.CompareHashAndPassword(user.PassHash, []byte(password)); err != nil {
+	if err := a.hasher.Compare(user.PassHash, password); err != nil {
 		a.log.Error("password mismatch", zap.Error(err))
-		return "", fmt.Errorf("password mismatch: %w", err)
+		a.recordLoginFailure(ctx, user, appID, "password_mismatch")
+		return "", fmt.Errorf("password mismatch: %w", err_internal.ErrInvalidCredentials)
+	}
+
+	// Состояние проверяем после пароля, чтобы не раскрывать его по одному email
+	if err := stateError(user.State()); err != nil {
+		log.Warn("inactive user tried to login", zap.String("state", user.State()))
+		a.recordLoginFailure(ctx, user, appID, "account_"+user.State())
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	app, err := a.appProvider.App(ctx, appID)
+	a.rehashIfNeeded(ctx, log, user, password)
+
+	app, err := a.appProvider.App(ctx, orgID, appID)
 	if err != nil {
 		return "", fmt.Errorf("failed to get app: %s %w", op, err)
 	}
 
-	log.Info("user logged in successfully")
+	if err := a.checkAppAccess(ctx, user, app); err != nil {
+		if errors.Is(err, err_internal.ErrAppAccessDenied) || errors.Is(err, err_internal.ErrAppAccessPending) {
+			log.Warn("user is not allowed into app", zap.Int("app_id", appID), zap.Error(err))
+			a.recordLoginFailure(ctx, user, appID, "not_a_member")
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
 
-	token, err := jwt.GenerateToken(user, app, a.tokenTTL)
+	token, session, err := a.issueToken(ctx, user, app)
 	if err != nil {
-		return "", fmt.Errorf("failed to get app: %s %w", op, err)
+		log.Error("failed to issue token", zap.Error(err))
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
+	log.Info("user logged in successfully")
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditLoginSuccess,
+		UserID:   user.ID,
+		AppID:    appID,
+		Email:    user.Email,
+		Metadata: map[string]string{"session_id": session.ID},
+	})
+
 	return token, nil
 }
 
-func (a *AuthService) RegisterNewUser(ctx context.Context, email string, password string) (int64, error) {
+// stateError возвращает ошибку входа для неактивной учётной записи.
+func stateError(state string) error {
+	switch state {
+	case models.UserLocked:
+		return err_internal.ErrAccountLocked
+	case models.UserDisabled:
+		return err_internal.ErrAccountDisabled
+	case models.UserPendingDeletion:
+		return err_internal.ErrAccountDeleted
+	}
+
+	return nil
+}
+
+// checkAppAccess проверяет, что пользователь допущен в приложение.
+// Для приложений с одобрением первый вход без допуска создаёт заявку.
+func (a *AuthService) checkAppAccess(ctx context.Context, user models.User, app models.App) error {
+	if !app.Restricted() {
+		return nil
+	}
+
+	member, err := a.appProvider.AppMember(ctx, app.ID, user.ID)
+	switch {
+	case err == nil && member.Status == models.MemberActive:
+		return nil
+	case err == nil && member.Status == models.MemberPending:
+		return err_internal.ErrAppAccessPending
+	case !errors.Is(err, err_internal.ErrMemberNotFound):
+		return err
+	case app.AccessPolicy != models.AccessApprovalRequired:
+		return err_internal.ErrAppAccessDenied
+	}
+
+	created, err := a.appProvider.RequestAppAccess(ctx, app.ID, user.ID)
+	if err != nil {
+		return err
+	}
+	if created {
+		a.auditor.Record(ctx, models.AuditEvent{
+			Type:   models.AuditAppAccessAsked,
+			UserID: user.ID,
+			AppID:  app.ID,
+			Email:  user.Email,
+		})
+	}
+
+	return err_internal.ErrAppAccessPending
+}
+
+// issueToken создаёт сессию и выдаёт токен с параметрами приложения,
+// дополненными глобальными.
+func (a *AuthService) issueToken(ctx context.Context, user models.User, app models.App) (string, models.Session, error) {
+	app.Token = app.Token.WithDefaults(*a.tokens.Load())
+
+	session, err := a.newSession(ctx, user, app, app.Token.TTL)
+	if err != nil {
+		return "", models.Session{}, err
+	}
+
+	token, err := jwt.GenerateToken(user, app, session.ID, app.Token.TTL)
+	if err != nil {
+		return "", models.Session{}, err
+	}
+
+	return token, session, nil
+}
+
+// newSession сохраняет сессию, которая будет зашита в токен.
+// Сессия живёт столько же, сколько токен.
+func (a *AuthService) newSession(ctx context.Context, user models.User, app models.App, ttl time.Duration) (models.Session, error) {
+	info := clientinfo.FromContext(ctx)
+	now := time.Now().UTC()
+
+	session := models.Session{
+		ID:        random.Hex(16),
+		UserID:    user.ID,
+		AppID:     app.ID,
+		IP:        info.IP,
+		UserAgent: info.UserAgent,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	if err := a.sessions.SaveSession(ctx, session); err != nil {
+		return models.Session{}, err
+	}
+
+	return session, nil
+}
+
+// Logout отзывает сессию, которой принадлежит токен.
+func (a *AuthService) Logout(ctx context.Context, token string) error {
+	const op = "AuthService.Logout"
+	log := a.log.With(zap.String("method", op))
+
+	claims, err := a.ValidateToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if claims.SessionID == "" {
+		// Токен выдан до появления сессий: отзывать нечего, он истечёт сам.
+		log.Warn("logout with token without session", zap.Int64("uid", claims.UserID))
+		return fmt.Errorf("%s: %w", op, err_internal.ErrInvalidToken)
+	}
+
+	if err := a.sessions.RevokeSession(ctx, claims.SessionID); err != nil {
+		log.Error("failed to revoke session", zap.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user logged out", zap.Int64("uid", claims.UserID))
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditLogout,
+		UserID:   claims.UserID,
+		AppID:    claims.AppID,
+		Email:    claims.Email,
+		Metadata: map[string]string{"session_id": claims.SessionID},
+	})
+
+	return nil
+}
+
+func (a *AuthService) recordLoginFailure(ctx context.Context, user models.User, appID int, reason string) {
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditLoginFailure,
+		UserID:   user.ID,
+		AppID:    appID,
+		Email:    user.Email,
+		Metadata: map[string]string{"reason": reason},
+	})
+}
+
+// rehashIfNeeded пересчитывает хэш пароля, если он получен устаревшим
+// алгоритмом или параметрами. Ошибка обновления не мешает входу:
+// хэш будет обновлён при следующей попытке.
+func (a *AuthService) rehashIfNeeded(ctx context.Context, log *zap.Logger, user models.User, password string) {
+	if !a.hasher.NeedsRehash(user.PassHash) {
+		return
+	}
+
+	passHash, err := a.hasher.Hash(password)
+	if err != nil {
+		log.Warn("failed to rehash password", zap.Error(err))
+		return
+	}
+
+	if err := a.usrSaver.UpdatePassHash(ctx, user.ID, passHash); err != nil {
+		log.Warn("failed to save rehashed password", zap.Error(err))
+		return
+	}
+
+	log.Info("password hash upgraded")
+}
+
+// RegisterNewUser создаёт пользователя в организации org. Пустой org - организация по умолчанию.
+func (a *AuthService) RegisterNewUser(ctx context.Context, org string, email string, password string) (int64, error) {
 	const op = "AuthService.RegisterNewUser"
-	log := a.log.With(zap.String("method", op), zap.String("email", email))
+	log := a.log.With(zap.String("method", op), zap.String("org", org), zap.String("email", email))
 
 	log.Info("registering new user")
 
-	passHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	orgID, err := a.orgID(ctx, org)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	passHash, err := a.hasher.Hash(password)
 	if err != nil {
 		log.Error("failed to hash password", zap.Error(err))
 		return 0, err
 	}
 
-	id, err := a.usrSaver.SaveUser(ctx, email, passHash)
+	id, err := a.usrSaver.SaveUser(ctx, orgID, email, passHash)
 	if err != nil {
 		if errors.Is(err, err_internal.ErrUserExists) {
 			log.Error("user already exists", zap.Error(err))
@@ -104,9 +348,17 @@ func (a *AuthService) RegisterNewUser(ctx context.Context, email string, passwor
 	}
 
 	log.Info("user registered successfully", zap.Int64("userID", id))
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditRegister,
+		UserID: id,
+		Email:  email,
+	})
+
 	return id, nil
 }
 
+// IsAdmin отвечает на публичный RPC IsAdmin и пишет проверку в журнал аудита.
+// Сервисы проверяют права по хранилищу, чтобы не засорять журнал.
 func (a *AuthService) IsAdmin(ctx context.Context, userID int64) (bool, error) {
 	const op = "AuthService.IsAdmin"
 	log := a.log.With(zap.String("method", op), zap.Int64("ID", userID))
@@ -115,13 +367,80 @@ func (a *AuthService) IsAdmin(ctx context.Context, userID int64) (bool, error) {
 
 	isAdmin, err := a.usrProvider.IsAdmin(ctx, userID)
 	if err != nil {
-		if errors.Is(err, err_internal.ErrAppNotFound) {
+		if errors.Is(err, err_internal.ErrUserNotFound) {
 			a.log.Error("user not found", zap.Error(err))
-			return false, fmt.Errorf("user not found: %w", err_internal.ErrInvalidAppID)
+			return false, fmt.Errorf("user not found: %w", err_internal.ErrUserNotFound)
 		}
 		return false, fmt.Errorf("failed to check admin status: %s %w", op, err)
 	}
 
 	log.Info("checked admin status successfully", zap.Bool("isAdmin", isAdmin))
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditAdminCheck,
+		UserID:   userID,
+		Metadata: map[string]string{"is_admin": strconv.FormatBool(isAdmin)},
+	})
+
 	return isAdmin, nil
 }
+
+// ValidateToken проверяет подпись и срок действия токена.
+// Секрет для проверки берётся из приложения, указанного в токене;
+// приложение должно принадлежать организации из claim org_id.
+func (a *AuthService) ValidateToken(ctx context.Context, token string) (models.TokenClaims, error) {
+	const op = "AuthService.ValidateToken"
+
+	var app models.App
+	claims, err := jwt.ParseToken(token, func(appID int) (string, error) {
+		var err error
+		if app, err = a.appProvider.App(ctx, models.AnyOrg, appID); err != nil {
+			return "", err
+		}
+		return app.Secret, nil
+	})
+	if err == nil && app.OrgID != claims.OrgID {
+		err = fmt.Errorf("app %d does not belong to org %d", app.ID, claims.OrgID)
+	}
+	if err != nil {
+		a.log.Debug("token validation failed", zap.String("method", op), zap.Error(err))
+		return models.TokenClaims{}, fmt.Errorf("%s: %w", op, err_internal.ErrInvalidToken)
+	}
+
+	// Токены без сессии выданы до появления таблицы sessions
+	// и принимаются, пока не истекут.
+	if claims.SessionID == "" {
+		return claims, nil
+	}
+
+	session, err := a.sessions.Session(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, err_internal.ErrSessionNotFound) {
+			return models.TokenClaims{}, fmt.Errorf("%s: %w", op, err_internal.ErrInvalidToken)
+		}
+		return models.TokenClaims{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !session.Active(time.Now()) || session.UserID != claims.UserID {
+		return models.TokenClaims{}, fmt.Errorf("%s: %w", op, err_internal.ErrSessionRevoked)
+	}
+
+	if err := a.sessions.TouchSession(ctx, session.ID); err != nil {
+		a.log.Warn("failed to update session last seen", zap.String("method", op), zap.Error(err))
+	}
+
+	return claims, nil
+}
+
+// orgID возвращает ID организации по её идентификатору (slug).
+// Пустой идентификатор означает организацию по умолчанию.
+func (a *AuthService) orgID(ctx context.Context, slug string) (int64, error) {
+	if slug == "" || slug == models.DefaultOrgSlug {
+		return models.DefaultOrgID, nil
+	}
+
+	org, err := a.usrProvider.OrgBySlug(ctx, slug)
+	if err != nil {
+		return 0, err
+	}
+
+	return org.ID, nil
+}
@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/oidc"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/random"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// FederationStorage хранит внешних провайдеров приложений, привязанные
+// к пользователям учётные записи и начатые входы.
+type FederationStorage interface {
+	IdentityProvider(ctx context.Context, appID int, name string) (models.IdentityProvider, error)
+	IdentityProviderByID(ctx context.Context, id int64) (models.IdentityProvider, error)
+	SaveExternalLogin(ctx context.Context, login models.ExternalLogin) error
+	TakeExternalLogin(ctx context.Context, state string) (models.ExternalLogin, error)
+	UserIdentity(ctx context.Context, orgID int64, issuer, subject string) (models.UserIdentity, error)
+	LinkIdentity(ctx context.Context, identity models.UserIdentity) (int64, error)
+	SaveExternalUser(ctx context.Context, user models.User, identity models.UserIdentity) (int64, error)
+	TouchIdentity(ctx context.Context, id int64, email string) error
+	UserByID(ctx context.Context, orgID int64, userID int64) (models.User, error)
+}
+
+type federation struct {
+	storage  FederationStorage
+	client   *oidc.Client
+	loginTTL time.Duration // сколько ждать возврата от провайдера
+}
+
+// EnableFederation включает вход через внешних OIDC-провайдеров приложений.
+func (a *AuthService) EnableFederation(storage FederationStorage, client *oidc.Client, loginTTL time.Duration) {
+	a.federation = &federation{storage: storage, client: client, loginTTL: loginTTL}
+}
+
+// StartExternalLogin начинает вход в приложение appID через его провайдер provider.
+func (a *AuthService) StartExternalLogin(ctx context.Context, appID int, provider string) (models.ExternalLoginStart, error) {
+	const op = "AuthService.StartExternalLogin"
+
+	start, err := a.startExternal(ctx, appID, provider, 0)
+	if err != nil {
+		return models.ExternalLoginStart{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return start, nil
+}
+
+// StartExternalLink начинает привязку учётной записи провайдера provider
+// приложения appID к уже вошедшему пользователю userID.
+func (a *AuthService) StartExternalLink(ctx context.Context, userID int64, appID int, provider string) (models.ExternalLoginStart, error) {
+	const op = "AuthService.StartExternalLink"
+
+	start, err := a.startExternal(ctx, appID, provider, userID)
+	if err != nil {
+		return models.ExternalLoginStart{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return start, nil
+}
+
+func (a *AuthService) startExternal(ctx context.Context, appID int, name string, userID int64) (models.ExternalLoginStart, error) {
+	f := a.federation
+	if f == nil {
+		return models.ExternalLoginStart{}, err_internal.ErrIdPNotFound
+	}
+
+	app, err := a.appProvider.App(ctx, models.AnyOrg, appID)
+	if err != nil {
+		return models.ExternalLoginStart{}, err
+	}
+
+	p, err := f.storage.IdentityProvider(ctx, app.ID, name)
+	if err != nil {
+		return models.ExternalLoginStart{}, err
+	}
+
+	if userID != 0 {
+		user, err := f.storage.UserByID(ctx, app.OrgID, userID)
+		if err != nil {
+			return models.ExternalLoginStart{}, err
+		}
+		if err := stateError(user.State()); err != nil {
+			return models.ExternalLoginStart{}, err
+		}
+	}
+
+	login := models.ExternalLogin{
+		State:        random.Token(32),
+		ProviderID:   p.ID,
+		AppID:        app.ID,
+		UserID:       userID,
+		Nonce:        random.Token(16),
+		CodeVerifier: oidc.NewVerifier(),
+		ExpiresAt:    time.Now().UTC().Add(f.loginTTL).Truncate(time.Second),
+	}
+
+	authURL, err := f.client.AuthCodeURL(ctx, oidcConfig(p), login.State, login.Nonce, login.CodeVerifier)
+	if err != nil {
+		return models.ExternalLoginStart{}, err
+	}
+
+	if err := f.storage.SaveExternalLogin(ctx, login); err != nil {
+		return models.ExternalLoginStart{}, err
+	}
+
+	return models.ExternalLoginStart{AuthURL: authURL, State: login.State, ExpiresAt: login.ExpiresAt}, nil
+}
+
+// FinishExternalLogin обменивает код провайдера на ID-токен, находит по нему
+// пользователя, привязывает или создаёт его и выдаёт такой же токен, как Login.
+//
+// Учётная запись провайдера, ещё не привязанная ни к кому, создаёт нового
+// пользователя без пароля, если email подтверждён и не занят. Если пользователь
+// с таким email уже есть, возвращается ErrIdentityNotLinked: он сам привязывает
+// учётную запись через StartExternalLink. Сразу привязать её по подтверждённому
+// email можно, только если провайдеру включён LinkByEmail, и никогда
+// для глобальных администраторов и администраторов организаций.
+func (a *AuthService) FinishExternalLogin(ctx context.Context, state string, code string) (models.ExternalLoginResult, error) {
+	const op = "AuthService.FinishExternalLogin"
+	log := a.log.With(zap.String("method", op))
+
+	f := a.federation
+	if f == nil {
+		return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err_internal.ErrExternalLogin)
+	}
+
+	login, err := f.storage.TakeExternalLogin(ctx, state)
+	if err != nil {
+		return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	p, err := f.storage.IdentityProviderByID(ctx, login.ProviderID)
+	if err != nil {
+		if errors.Is(err, err_internal.ErrIdPNotFound) {
+			return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err_internal.ErrExternalLogin)
+		}
+		return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	log = log.With(zap.Int("app_id", login.AppID), zap.String("provider", p.Name))
+
+	app, err := a.appProvider.App(ctx, models.AnyOrg, login.AppID)
+	if err != nil {
+		return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	claims, err := f.client.Exchange(ctx, oidcConfig(p), code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrExchange) || errors.Is(err, oidc.ErrInvalidToken) {
+			log.Warn("external login rejected", zap.Error(err))
+			a.recordLoginFailure(ctx, models.User{ID: login.UserID}, app.ID, "external_login_rejected")
+			return models.ExternalLoginResult{}, fmt.Errorf("%s: %w: %w", op, err_internal.ErrExternalLogin, err)
+		}
+		log.Error("failed to exchange code", zap.Error(err))
+		return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, result, err := a.externalUser(ctx, f, login, app, p, claims)
+	if err != nil {
+		if errors.Is(err, err_internal.ErrIdentityNotLinked) || errors.Is(err, err_internal.ErrIdentityLinked) {
+			log.Warn("external identity not accepted", zap.String("subject", claims.Subject()), zap.Error(err))
+			a.recordLoginFailure(ctx, user, app.ID, "identity_not_linked")
+		}
+		return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	log = log.With(zap.Int64("uid", user.ID))
+
+	if err := stateError(user.State()); err != nil {
+		log.Warn("inactive user tried to login", zap.String("state", user.State()))
+		a.recordLoginFailure(ctx, user, app.ID, "account_"+user.State())
+		return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := a.checkAppAccess(ctx, user, app); err != nil {
+		if errors.Is(err, err_internal.ErrAppAccessDenied) || errors.Is(err, err_internal.ErrAppAccessPending) {
+			log.Warn("user is not allowed into app", zap.Error(err))
+			a.recordLoginFailure(ctx, user, app.ID, "not_a_member")
+		}
+		return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, session, err := a.issueToken(ctx, user, app)
+	if err != nil {
+		log.Error("failed to issue token", zap.Error(err))
+		return models.ExternalLoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user logged in through external provider")
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditLoginSuccess,
+		UserID: user.ID,
+		AppID:  app.ID,
+		Email:  user.Email,
+		Metadata: map[string]string{
+			"session_id":   session.ID,
+			"login_method": "oidc",
+			"provider":     p.Name,
+		},
+	})
+
+	result.Token = token
+	return result, nil
+}
+
+// externalUser находит пользователя, к которому привязана учётная запись
+// провайдера, или привязывает её. Существующий аккаунт по одному email
+// привязывается, только если это разрешено провайдеру (LinkByEmail), и никогда
+// для администраторов, иначе вход через провайдер давал бы их права.
+func (a *AuthService) externalUser(
+	ctx context.Context,
+	f *federation,
+	login models.ExternalLogin,
+	app models.App,
+	p models.IdentityProvider,
+	claims oidc.Claims,
+) (models.User, models.ExternalLoginResult, error) {
+	mapping := p.Claims.WithDefaults()
+	email := claims.String(mapping.Email)
+	verified := p.TrustEmail || claims.Bool(mapping.EmailVerified)
+
+	identity, err := f.storage.UserIdentity(ctx, app.OrgID, p.Issuer, claims.Subject())
+	switch {
+	case err == nil:
+		if login.UserID != 0 && identity.UserID != login.UserID {
+			return models.User{ID: login.UserID}, models.ExternalLoginResult{}, err_internal.ErrIdentityLinked
+		}
+		user, err := f.storage.UserByID(ctx, app.OrgID, identity.UserID)
+		if err != nil {
+			return models.User{}, models.ExternalLoginResult{}, err
+		}
+		if err := f.storage.TouchIdentity(ctx, identity.ID, email); err != nil {
+			a.log.Warn("failed to touch identity", zap.Int64("identity_id", identity.ID), zap.Error(err))
+		}
+		return user, models.ExternalLoginResult{UserID: user.ID}, nil
+
+	case !errors.Is(err, err_internal.ErrIdentityNotFound):
+		return models.User{}, models.ExternalLoginResult{}, err
+	}
+
+	identity = models.UserIdentity{OrgID: app.OrgID, Issuer: p.Issuer, Subject: claims.Subject(), Email: email}
+
+	// Привязка к пользователю, который начал её сам
+	if login.UserID != 0 {
+		user, err := f.storage.UserByID(ctx, app.OrgID, login.UserID)
+		if err != nil {
+			return models.User{}, models.ExternalLoginResult{}, err
+		}
+		return a.linkIdentity(ctx, f, user, identity, p)
+	}
+
+	if email == "" {
+		return models.User{}, models.ExternalLoginResult{}, fmt.Errorf("%w: provider returned no email", err_internal.ErrExternalLogin)
+	}
+
+	user, err := a.usrProvider.User(ctx, app.OrgID, email)
+	switch {
+	case err == nil && verified && p.LinkByEmail && !user.IsAdmin && !user.OrgAdmin:
+		return a.linkIdentity(ctx, f, user, identity, p)
+	case err == nil:
+		return user, models.ExternalLoginResult{}, err_internal.ErrIdentityNotLinked
+	case !errors.Is(err, err_internal.ErrUserNotFound):
+		return models.User{}, models.ExternalLoginResult{}, err
+	case !verified:
+		return models.User{}, models.ExternalLoginResult{}, fmt.Errorf("%w: email is not verified", err_internal.ErrExternalLogin)
+	}
+
+	userID, err := f.storage.SaveExternalUser(ctx, models.User{
+		OrgID:       app.OrgID,
+		Email:       email,
+		DisplayName: claims.String(mapping.Name),
+	}, identity)
+	if err != nil {
+		if errors.Is(err, err_internal.ErrUserExists) {
+			// Пользователь с таким email появился параллельно
+			return models.User{}, models.ExternalLoginResult{}, err_internal.ErrIdentityNotLinked
+		}
+		return models.User{}, models.ExternalLoginResult{}, err
+	}
+
+	user, err = f.storage.UserByID(ctx, app.OrgID, userID)
+	if err != nil {
+		return models.User{}, models.ExternalLoginResult{}, err
+	}
+
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditRegister,
+		UserID:   user.ID,
+		AppID:    app.ID,
+		Email:    user.Email,
+		Metadata: map[string]string{"provider": p.Name},
+	})
+	a.recordIdentityLinked(ctx, user, identity, p)
+
+	return user, models.ExternalLoginResult{UserID: user.ID, Created: true, Linked: true}, nil
+}
+
+func (a *AuthService) linkIdentity(
+	ctx context.Context,
+	f *federation,
+	user models.User,
+	identity models.UserIdentity,
+	p models.IdentityProvider,
+) (models.User, models.ExternalLoginResult, error) {
+	identity.UserID = user.ID
+	if _, err := f.storage.LinkIdentity(ctx, identity); err != nil {
+		return user, models.ExternalLoginResult{}, err
+	}
+
+	a.recordIdentityLinked(ctx, user, identity, p)
+
+	return user, models.ExternalLoginResult{UserID: user.ID, Linked: true}, nil
+}
+
+func (a *AuthService) recordIdentityLinked(ctx context.Context, user models.User, identity models.UserIdentity, p models.IdentityProvider) {
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditIdentityLinked,
+		UserID: user.ID,
+		AppID:  p.AppID,
+		Email:  user.Email,
+		Metadata: map[string]string{
+			"provider": p.Name,
+			"issuer":   identity.Issuer,
+			"subject":  identity.Subject,
+		},
+	})
+}
+
+func oidcConfig(p models.IdentityProvider) oidc.Config {
+	return oidc.Config{
+		Issuer:       p.Issuer,
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURI:  p.RedirectURI,
+		Scopes:       p.Scopes,
+	}
+}
@@ -0,0 +1,133 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/publisher"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// claimLease - на сколько событие скрывается от других реплик, пока публикуется.
+	claimLease = time.Minute
+	// maxRetryDelay ограничивает экспоненциальную задержку между попытками.
+	maxRetryDelay = time.Hour
+)
+
+type Storage interface {
+	ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.UserEvent, error)
+	MarkOutboxPublished(ctx context.Context, id int64) error
+	MarkOutboxFailed(ctx context.Context, id int64, cause error, maxDelay time.Duration) error
+}
+
+// Dispatcher периодически забирает события из outbox и публикует их.
+// Неопубликованные события повторяются с экспоненциальной задержкой.
+type Dispatcher struct {
+	log          *zap.Logger
+	storage      Storage
+	publisher    publisher.Publisher
+	pollInterval time.Duration
+	batchSize    int
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher creates a Dispatcher. Call Start to run it.
+func NewDispatcher(
+	log *zap.Logger,
+	storage Storage,
+	publisher publisher.Publisher,
+	pollInterval time.Duration,
+	batchSize int,
+) *Dispatcher {
+	return &Dispatcher{
+		log:          log,
+		storage:      storage,
+		publisher:    publisher,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+	}
+}
+
+// Start запускает фоновую горутину диспетчера.
+func (d *Dispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(ctx)
+	}()
+}
+
+// Stop останавливает диспетчер и дожидается завершения текущей пачки.
+func (d *Dispatcher) Stop() {
+	if d.cancel == nil {
+		return
+	}
+
+	d.cancel()
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Пока есть полные пачки, разбираем их без ожидания.
+		for d.dispatch(ctx) == d.batchSize {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch публикует одну пачку событий и возвращает её размер.
+func (d *Dispatcher) dispatch(ctx context.Context) int {
+	const op = "outbox.Dispatcher.dispatch"
+	log := d.log.With(zap.String("op", op))
+
+	events, err := d.storage.ClaimOutboxEvents(ctx, d.batchSize, claimLease)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Error("failed to claim outbox events", zap.Error(err))
+		}
+		return 0
+	}
+
+	for _, event := range events {
+		// Отметки пишем без ctx диспетчера, чтобы остановка
+		// не оставила опубликованное событие неотмеченным.
+		markCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+
+		if err := d.publisher.Publish(ctx, event); err != nil {
+			log.Warn("failed to publish event",
+				zap.Int64("id", event.ID),
+				zap.String("type", event.Type),
+				zap.Error(err),
+			)
+			if err := d.storage.MarkOutboxFailed(markCtx, event.ID, err, maxRetryDelay); err != nil {
+				log.Error("failed to mark event as failed", zap.Int64("id", event.ID), zap.Error(err))
+			}
+			cancel()
+			continue
+		}
+
+		if err := d.storage.MarkOutboxPublished(markCtx, event.ID); err != nil {
+			log.Error("failed to mark event as published", zap.Int64("id", event.ID), zap.Error(err))
+		}
+		cancel()
+	}
+
+	return len(events)
+}
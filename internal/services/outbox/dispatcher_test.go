@@ -0,0 +1,175 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memOutbox - outbox в памяти, общий для нескольких реплик.
+// Видны только закоммиченные события, ID могут идти с пропусками.
+type memOutbox struct {
+	mu        sync.Mutex
+	events    map[int64]models.UserEvent
+	published map[int64]bool
+	failed    map[int64]string
+	claimed   map[int64]bool
+}
+
+func newMemOutbox() *memOutbox {
+	return &memOutbox{
+		events:    make(map[int64]models.UserEvent),
+		published: make(map[int64]bool),
+		failed:    make(map[int64]string),
+		claimed:   make(map[int64]bool),
+	}
+}
+
+// commit делает видимыми события с заданными ID.
+func (m *memOutbox) commit(ids ...int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		m.events[id] = models.UserEvent{ID: id, Type: models.UserRegistered, UserID: id}
+	}
+}
+
+func (m *memOutbox) sorted() []models.UserEvent {
+	events := make([]models.UserEvent, 0, len(m.events))
+	for _, e := range m.events {
+		events = append(events, e)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	return events
+}
+
+func (m *memOutbox) ClaimOutboxEvents(_ context.Context, limit int, _ time.Duration) ([]models.UserEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var res []models.UserEvent
+	for _, e := range m.sorted() {
+		if !m.published[e.ID] && !m.claimed[e.ID] && len(res) < limit {
+			m.claimed[e.ID] = true
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (m *memOutbox) MarkOutboxPublished(_ context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.published[id] = true
+	delete(m.failed, id)
+	return nil
+}
+
+func (m *memOutbox) MarkOutboxFailed(_ context.Context, id int64, cause error, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failed[id] = cause.Error()
+	return nil
+}
+
+// expireLeases возвращает неопубликованные события в очередь.
+func (m *memOutbox) expireLeases() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.claimed = make(map[int64]bool)
+}
+
+func (m *memOutbox) UserEventsAfter(_ context.Context, afterID int64, limit int) ([]models.UserEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var res []models.UserEvent
+	for _, e := range m.sorted() {
+		if e.ID > afterID && len(res) < limit {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (m *memOutbox) LastUserEventID(context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var last int64
+	for id := range m.events {
+		last = max(last, id)
+	}
+	return last, nil
+}
+
+// recorder запоминает опубликованные события и отказывает в публикации fail.
+type recorder struct {
+	mu   sync.Mutex
+	ids  []int64
+	fail map[int64]bool
+}
+
+func (r *recorder) Publish(_ context.Context, event models.UserEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fail[event.ID] {
+		return errors.New("subscriber is down")
+	}
+	r.ids = append(r.ids, event.ID)
+	return nil
+}
+
+func TestDispatcher_PublishesOnce(t *testing.T) {
+	storage := newMemOutbox()
+	storage.commit(1, 2, 3, 4, 5)
+
+	pub := &recorder{}
+	first := NewDispatcher(zap.NewNop(), storage, pub, time.Second, 2)
+	second := NewDispatcher(zap.NewNop(), storage, pub, time.Second, 2)
+
+	// Реплики забирают разные события
+	assert.Equal(t, 2, first.dispatch(context.Background()))
+	assert.Equal(t, 2, second.dispatch(context.Background()))
+	assert.Equal(t, 1, first.dispatch(context.Background()))
+	assert.Equal(t, 0, second.dispatch(context.Background()))
+
+	assert.ElementsMatch(t, []int64{1, 2, 3, 4, 5}, pub.ids)
+	assert.Len(t, storage.published, 5)
+}
+
+func TestDispatcher_RetriesFailed(t *testing.T) {
+	storage := newMemOutbox()
+	storage.commit(1, 2)
+
+	pub := &recorder{fail: map[int64]bool{2: true}}
+	d := NewDispatcher(zap.NewNop(), storage, pub, time.Second, 10)
+
+	require.Equal(t, 2, d.dispatch(context.Background()))
+	assert.Equal(t, []int64{1}, pub.ids)
+	assert.True(t, storage.published[1])
+	assert.False(t, storage.published[2])
+	assert.Equal(t, "subscriber is down", storage.failed[2])
+
+	// После истечения lease событие публикуется снова
+	storage.expireLeases()
+	pub.fail = nil
+	require.Equal(t, 1, d.dispatch(context.Background()))
+	assert.Equal(t, []int64{1, 2}, pub.ids)
+	assert.True(t, storage.published[2])
+	assert.Empty(t, storage.failed)
+}
@@ -0,0 +1,202 @@
+package outbox
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// subscriberBuffer - сколько событий может накопить подписчик,
+	// прежде чем его отключат как отстающего.
+	subscriberBuffer = 256
+	// gapWait - сколько ждать событие с пропущенным ID. ID выдаются при вставке,
+	// а видны события после коммита, поэтому более позднее событие может
+	// появиться раньше. Пропуск от отменённой транзакции так и не заполнится.
+	gapWait = 10 * time.Second
+)
+
+// FeedStorage - outbox, из которого Hub читает события по позиции.
+type FeedStorage interface {
+	EventProvider
+	LastUserEventID(ctx context.Context) (int64, error)
+}
+
+// Hub рассылает события outbox подписчикам WatchUserEvents этой реплики.
+// Каждая реплика сама читает outbox по ID событий, поэтому подписчики видят
+// все события, а не только опубликованные диспетчером этой реплики.
+type Hub struct {
+	log          *zap.Logger
+	storage      FeedStorage
+	pollInterval time.Duration
+	gapWait      time.Duration
+
+	mu   sync.Mutex
+	subs map[chan models.UserEvent]struct{}
+
+	// Позиция чтения: события с ID не больше cursor разосланы или пропущены,
+	// seen - уже разосланные события после cursor и время их появления.
+	cursor int64
+	seen   map[int64]time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewHub(log *zap.Logger, storage FeedStorage, pollInterval time.Duration) *Hub {
+	return &Hub{
+		log:          log,
+		storage:      storage,
+		pollInterval: pollInterval,
+		gapWait:      gapWait,
+		subs:         make(map[chan models.UserEvent]struct{}),
+		seen:         make(map[int64]time.Time),
+	}
+}
+
+// Start запоминает текущий конец outbox и начинает читать события после него.
+// Более ранние события подписчики получают из истории.
+func (h *Hub) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cursor, err := h.storage.LastUserEventID(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+	h.cursor = cursor
+	h.cancel = cancel
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.run(ctx)
+	}()
+
+	return nil
+}
+
+// Stop останавливает чтение outbox и отключает подписчиков.
+func (h *Hub) Stop() {
+	if h.cancel == nil {
+		return
+	}
+
+	h.cancel()
+	h.wg.Wait()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+func (h *Hub) run(ctx context.Context) {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.poll(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			h.log.Error("failed to read user events", zap.String("op", "outbox.Hub.poll"), zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll рассылает новые события и сдвигает позицию чтения.
+func (h *Hub) poll(ctx context.Context, now time.Time) error {
+	after := h.cursor
+	for {
+		events, err := h.storage.UserEventsAfter(ctx, after, replayBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if _, ok := h.seen[event.ID]; ok {
+				continue
+			}
+			h.seen[event.ID] = now
+			h.publish(event)
+		}
+		if len(events) < replayBatchSize {
+			break
+		}
+		after = events[len(events)-1].ID
+	}
+
+	h.advance(now)
+
+	return nil
+}
+
+// advance сдвигает cursor через непрерывную цепочку разосланных событий.
+// Пропуск в ID ждёт gapWait с момента, когда появилось событие после него.
+func (h *Hub) advance(now time.Time) {
+	if len(h.seen) == 0 {
+		return
+	}
+
+	ids := make([]int64, 0, len(h.seen))
+	for id := range h.seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		if id != h.cursor+1 && now.Sub(h.seen[id]) < h.gapWait {
+			return
+		}
+		h.cursor = id
+		delete(h.seen, id)
+	}
+}
+
+// publish отправляет событие всем подписчикам без блокировки.
+// Подписчик с переполненным буфером отключается: его канал закрывается,
+// и он должен переподключиться, догнав пропущенное по ID.
+func (h *Hub) publish(event models.UserEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subs {
+		select {
+		case ch <- event:
+		default:
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe возвращает канал событий. Подписка снимается, когда ctx завершён.
+func (h *Hub) Subscribe(ctx context.Context) <-chan models.UserEvent {
+	ch := make(chan models.UserEvent, subscriberBuffer)
+
+	h.mu.Lock()
+	h.subs[ch] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[ch]; ok {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,107 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// received забирает из канала всё, что в нём уже есть.
+func received(ch <-chan models.UserEvent) []int64 {
+	var ids []int64
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, e.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestHub_EveryReplicaSeesEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	storage := newMemOutbox()
+	storage.commit(1)
+
+	// Две реплики, а событие публикует диспетчер только одной из них
+	first := NewHub(zap.NewNop(), storage, 10*time.Millisecond)
+	second := NewHub(zap.NewNop(), storage, 10*time.Millisecond)
+	require.NoError(t, first.Start())
+	require.NoError(t, second.Start())
+	defer first.Stop()
+	defer second.Stop()
+
+	firstSub, secondSub := first.Subscribe(ctx), second.Subscribe(ctx)
+
+	storage.commit(2, 3)
+	NewDispatcher(zap.NewNop(), storage, &recorder{}, time.Hour, 10).dispatch(ctx)
+
+	// Событие 1 было до старта и отдаётся из истории, а не hub
+	for _, sub := range []<-chan models.UserEvent{firstSub, secondSub} {
+		var ids []int64
+		require.Eventually(t, func() bool {
+			ids = append(ids, received(sub)...)
+			return len(ids) >= 2
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []int64{2, 3}, ids)
+	}
+}
+
+func TestHub_WaitsForGap(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	storage := newMemOutbox()
+	hub := NewHub(zap.NewNop(), storage, time.Hour)
+	sub := hub.Subscribe(ctx)
+
+	// Транзакция с событием 2 коммитится позже транзакции с событием 3
+	now := time.Now()
+	storage.commit(1, 3)
+	require.NoError(t, hub.poll(ctx, now))
+	assert.Equal(t, []int64{1, 3}, received(sub))
+	assert.Equal(t, int64(1), hub.cursor)
+
+	storage.commit(2)
+	require.NoError(t, hub.poll(ctx, now.Add(time.Second)))
+	assert.Equal(t, []int64{2}, received(sub))
+	assert.Equal(t, int64(3), hub.cursor)
+	assert.Empty(t, hub.seen)
+
+	// Пропуск от отменённой транзакции не заполнится, его пропускают через gapWait
+	storage.commit(5)
+	require.NoError(t, hub.poll(ctx, now))
+	assert.Equal(t, int64(3), hub.cursor)
+	require.NoError(t, hub.poll(ctx, now.Add(gapWait)))
+	assert.Equal(t, int64(5), hub.cursor)
+	assert.Equal(t, []int64{5}, received(sub))
+}
+
+func TestHub_DropsLaggingSubscriber(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	storage := newMemOutbox()
+	hub := NewHub(zap.NewNop(), storage, time.Hour)
+	sub := hub.Subscribe(ctx)
+
+	for id := int64(1); id <= subscriberBuffer+1; id++ {
+		storage.commit(id)
+	}
+	require.NoError(t, hub.poll(ctx, time.Now()))
+
+	assert.Len(t, received(sub), subscriberBuffer)
+	_, ok := <-sub
+	assert.False(t, ok)
+}
@@ -0,0 +1,124 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	replayBatchSize = 500
+	maxTrackedIDs   = 10000
+)
+
+type EventProvider interface {
+	UserEventsAfter(ctx context.Context, afterID int64, limit int) ([]models.UserEvent, error)
+}
+
+// AdminChecker проверяет, что пользователь - администратор.
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+}
+
+// Watcher отдаёт поток событий пользователей подписчикам.
+type Watcher struct {
+	log    *zap.Logger
+	events EventProvider
+	hub    *Hub
+	admins AdminChecker
+}
+
+func NewWatcher(log *zap.Logger, events EventProvider, hub *Hub, admins AdminChecker) *Watcher {
+	return &Watcher{
+		log:    log,
+		events: events,
+		hub:    hub,
+		admins: admins,
+	}
+}
+
+// Watch вызывает send для каждого события с ID больше afterID, сначала
+// догоняя историю из outbox, затем в реальном времени. Если types не пуст,
+// отдаются только события этих типов. Доступно только администраторам.
+func (w *Watcher) Watch(
+	ctx context.Context,
+	callerID int64,
+	afterID int64,
+	types []string,
+	send func(models.UserEvent) error,
+) error {
+	const op = "outbox.Watcher.Watch"
+
+	isAdmin, err := w.admins.IsAdmin(ctx, callerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !isAdmin {
+		return fmt.Errorf("%s: %w", op, err_internal.ErrPermissionDenied)
+	}
+
+	// Подписываемся до чтения истории, чтобы не потерять события между ними.
+	live := w.hub.Subscribe(ctx)
+
+	// События могут прийти из истории и в реальном времени одновременно,
+	// а повторно опубликованное событие - не по порядку ID,
+	// поэтому дедуплицируем по множеству отправленных ID.
+	sent := make(map[int64]struct{})
+	last := afterID
+	emit := func(event models.UserEvent) error {
+		if event.ID <= afterID {
+			return nil
+		}
+		if _, ok := sent[event.ID]; ok {
+			return nil
+		}
+		sent[event.ID] = struct{}{}
+		last = max(last, event.ID)
+		if len(sent) > maxTrackedIDs {
+			for id := range sent {
+				if id < last-maxTrackedIDs/2 {
+					delete(sent, id)
+				}
+			}
+		}
+
+		if len(types) > 0 && !slices.Contains(types, event.Type) {
+			return nil
+		}
+		return send(event)
+	}
+
+	for {
+		history, err := w.events.UserEventsAfter(ctx, last, replayBatchSize)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		for _, event := range history {
+			if err := emit(event); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		if len(history) < replayBatchSize {
+			break
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-live:
+			if !ok {
+				w.log.Warn("user events subscriber dropped", zap.Int64("caller", callerID), zap.Int64("last_id", last))
+				return fmt.Errorf("%s: %w", op, err_internal.ErrSubscriberLagging)
+			}
+			if err := emit(event); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type admins map[int64]bool
+
+func (a admins) IsAdmin(_ context.Context, userID int64) (bool, error) {
+	return a[userID], nil
+}
+
+var errEnough = errors.New("enough events")
+
+func TestWatcher_HistoryThenLive(t *testing.T) {
+	storage := newMemOutbox()
+	storage.commit(1, 2, 3)
+
+	hub := NewHub(zap.NewNop(), storage, 10*time.Millisecond)
+	require.NoError(t, hub.Start())
+	defer hub.Stop()
+
+	w := NewWatcher(zap.NewNop(), storage, hub, admins{1: true})
+
+	var ids []int64
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Watch(context.Background(), 1, 1, nil, func(e models.UserEvent) error {
+			ids = append(ids, e.ID)
+			if e.ID == 5 {
+				return errEnough
+			}
+			return nil
+		})
+	}()
+
+	// События после подписки приходят из hub без дублей с историей
+	storage.commit(4, 5)
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, errEnough)
+	case <-time.After(5 * time.Second):
+		t.Fatal("events were not delivered")
+	}
+	assert.Equal(t, []int64{2, 3, 4, 5}, ids)
+}
+
+func TestWatcher_FiltersTypes(t *testing.T) {
+	storage := newMemOutbox()
+	storage.commit(1, 2)
+	storage.events[2] = models.UserEvent{ID: 2, Type: models.UserDeleted, UserID: 2}
+
+	hub := NewHub(zap.NewNop(), storage, time.Hour)
+	w := NewWatcher(zap.NewNop(), storage, hub, admins{1: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var types []string
+	err := w.Watch(ctx, 1, 0, []string{models.UserDeleted}, func(e models.UserEvent) error {
+		types = append(types, e.Type)
+		cancel()
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{models.UserDeleted}, types)
+}
+
+func TestWatcher_AdminOnly(t *testing.T) {
+	storage := newMemOutbox()
+	w := NewWatcher(zap.NewNop(), storage, NewHub(zap.NewNop(), storage, time.Hour), admins{})
+
+	err := w.Watch(context.Background(), 2, 0, nil, func(models.UserEvent) error { return nil })
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+}
@@ -0,0 +1,368 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/random"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/webauthn"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// PasskeyStorage хранит ключи доступа и вызовы церемоний WebAuthn.
+type PasskeyStorage interface {
+	SavePasskeyChallenge(ctx context.Context, ch models.PasskeyChallenge) error
+	TakePasskeyChallenge(ctx context.Context, id string, kind string) (models.PasskeyChallenge, error)
+	SavePasskey(ctx context.Context, key models.Passkey) error
+	Passkey(ctx context.Context, id []byte) (models.Passkey, error)
+	Passkeys(ctx context.Context, userID int64, rpID string) ([]models.Passkey, error)
+	UsePasskey(ctx context.Context, id []byte, signCount uint32) error
+	PasskeyUserHandle(ctx context.Context, userID int64, candidate []byte) ([]byte, error)
+	UserByID(ctx context.Context, orgID int64, userID int64) (models.User, error)
+}
+
+type passkeys struct {
+	storage PasskeyStorage
+	timeout time.Duration // сколько ждать ответа аутентификатора
+}
+
+// EnablePasskeys включает регистрацию и вход по ключам доступа в приложениях,
+// для которых задана проверяющая сторона WebAuthn.
+func (a *AuthService) EnablePasskeys(storage PasskeyStorage, timeout time.Duration) {
+	a.passkeys = &passkeys{storage: storage, timeout: timeout}
+}
+
+// BeginPasskeyRegistration начинает регистрацию ключа доступа пользователя
+// userID для приложения appID. Возвращает ID церемонии и параметры
+// для navigator.credentials.create в JSON.
+func (a *AuthService) BeginPasskeyRegistration(ctx context.Context, userID int64, appID int) (string, []byte, error) {
+	const op = "AuthService.BeginPasskeyRegistration"
+
+	p := a.passkeys
+	if p == nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err_internal.ErrPasskeysOff)
+	}
+
+	user, app, err := a.passkeyUserApp(ctx, p, userID, appID)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	existing, err := p.storage.Passkeys(ctx, user.ID, app.Passkeys.RPID)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	handle, err := p.storage.PasskeyUserHandle(ctx, user.ID, random.Bytes(userHandleLen))
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ch, err := p.newChallenge(ctx, models.PasskeyRegistration, user.ID, app.ID)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	options, err := json.Marshal(relyingParty(app).CreationOptions(
+		ch.Challenge,
+		webauthn.User{ID: handle, Name: user.Email, DisplayName: user.DisplayName},
+		credentials(existing),
+		p.timeout,
+	))
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ch.ID, options, nil
+}
+
+// FinishPasskeyRegistration проверяет ответ аутентификатора на церемонию
+// ceremonyID и сохраняет ключ под именем name. Возвращает ID ключа.
+func (a *AuthService) FinishPasskeyRegistration(
+	ctx context.Context,
+	userID int64,
+	ceremonyID string,
+	response []byte,
+	name string,
+) ([]byte, error) {
+	const op = "AuthService.FinishPasskeyRegistration"
+	log := a.log.With(zap.String("method", op), zap.Int64("uid", userID))
+
+	p := a.passkeys
+	if p == nil {
+		return nil, fmt.Errorf("%s: %w", op, err_internal.ErrPasskeysOff)
+	}
+
+	ch, err := p.storage.TakePasskeyChallenge(ctx, ceremonyID, models.PasskeyRegistration)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if ch.UserID != userID {
+		return nil, fmt.Errorf("%s: %w", op, err_internal.ErrInvalidPasskey)
+	}
+
+	user, app, err := a.passkeyUserApp(ctx, p, userID, ch.AppID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cred, err := relyingParty(app).VerifyRegistration(ch.Challenge, response)
+	if err != nil {
+		log.Warn("passkey registration rejected", zap.Error(err))
+		return nil, fmt.Errorf("%s: %w: %w", op, err_internal.ErrInvalidPasskey, err)
+	}
+
+	err = p.storage.SavePasskey(ctx, models.Passkey{
+		ID:         cred.ID,
+		UserID:     user.ID,
+		RPID:       app.Passkeys.RPID,
+		Name:       name,
+		PublicKey:  cred.PublicKey,
+		Algorithm:  cred.Algorithm,
+		SignCount:  cred.SignCount,
+		Transports: cred.Transports,
+		AAGUID:     cred.AAGUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("passkey registered", zap.Int("app_id", app.ID))
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditPasskeyAdded,
+		UserID: user.ID,
+		AppID:  app.ID,
+		Email:  user.Email,
+		Metadata: map[string]string{
+			"credential_id": base64.RawURLEncoding.EncodeToString(cred.ID),
+			"rp_id":         app.Passkeys.RPID,
+			"name":          name,
+		},
+	})
+
+	return cred.ID, nil
+}
+
+// BeginPasskeyLogin начинает вход по ключу доступа в приложение appID.
+// Браузеру всегда предлагается любой ключ RP ID, сохранённый на
+// аутентификаторе, поэтому ответ не выдаёт, есть ли пользователь и его ключи.
+// С email войти можно только ключом этого пользователя.
+func (a *AuthService) BeginPasskeyLogin(ctx context.Context, org string, email string, appID int) (string, []byte, error) {
+	const op = "AuthService.BeginPasskeyLogin"
+
+	p := a.passkeys
+	if p == nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err_internal.ErrPasskeysOff)
+	}
+
+	orgID, err := a.orgID(ctx, org)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+	app, err := a.appProvider.App(ctx, orgID, appID)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !app.Passkeys.Enabled() {
+		return "", nil, fmt.Errorf("%s: %w", op, err_internal.ErrPasskeysOff)
+	}
+
+	var userID int64
+	if email != "" {
+		user, err := a.usrProvider.User(ctx, orgID, email)
+		if err != nil && !errors.Is(err, err_internal.ErrUserNotFound) {
+			return "", nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err == nil {
+			userID = user.ID
+		}
+	}
+
+	ch, err := p.newChallenge(ctx, models.PasskeyLogin, userID, app.ID)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	options, err := json.Marshal(relyingParty(app).RequestOptions(ch.Challenge, nil, p.timeout))
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ch.ID, options, nil
+}
+
+// FinishPasskeyLogin проверяет подпись аутентификатора на церемонию
+// ceremonyID и выдаёт такой же токен, как Login.
+func (a *AuthService) FinishPasskeyLogin(ctx context.Context, ceremonyID string, response []byte) (string, error) {
+	const op = "AuthService.FinishPasskeyLogin"
+	log := a.log.With(zap.String("method", op))
+
+	p := a.passkeys
+	if p == nil {
+		return "", fmt.Errorf("%s: %w", op, err_internal.ErrPasskeysOff)
+	}
+
+	ch, err := p.storage.TakePasskeyChallenge(ctx, ceremonyID, models.PasskeyLogin)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	assertion, err := webauthn.ParseAssertion(response)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w: %w", op, err_internal.ErrInvalidPasskey, err)
+	}
+
+	key, err := p.storage.Passkey(ctx, assertion.CredentialID)
+	if err != nil {
+		log.Warn("unknown passkey", zap.Error(err))
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	log = log.With(zap.Int64("uid", key.UserID))
+
+	handle, err := p.storage.PasskeyUserHandle(ctx, key.UserID, nil)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Ключ должен принадлежать тому, для кого начат вход, и совпадать с user handle
+	if (ch.UserID != 0 && ch.UserID != key.UserID) ||
+		(len(assertion.UserHandle) > 0 && !bytes.Equal(assertion.UserHandle, handle)) {
+		log.Warn("passkey belongs to another user")
+		return "", fmt.Errorf("%s: %w", op, err_internal.ErrInvalidPasskey)
+	}
+
+	user, err := p.storage.UserByID(ctx, models.AnyOrg, key.UserID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Приложение ищется в организации пользователя: чужой ключ в него не подойдёт
+	app, err := a.appProvider.App(ctx, user.OrgID, ch.AppID)
+	if errors.Is(err, err_internal.ErrAppNotFound) || (err == nil && app.Passkeys.RPID != key.RPID) {
+		log.Warn("passkey of another organization or relying party", zap.Int("app_id", ch.AppID))
+		a.recordLoginFailure(ctx, user, ch.AppID, "invalid_passkey")
+		return "", fmt.Errorf("%s: %w", op, err_internal.ErrInvalidPasskey)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	signCount, err := relyingParty(app).VerifyAssertion(ch.Challenge, assertion, webauthn.Credential{
+		ID:        key.ID,
+		PublicKey: key.PublicKey,
+		Algorithm: key.Algorithm,
+		SignCount: key.SignCount,
+	})
+	if err != nil {
+		reason := "invalid_passkey"
+		if errors.Is(err, webauthn.ErrClonedAuthenticator) {
+			reason = "passkey_cloned"
+		}
+		log.Warn("passkey login rejected", zap.Error(err))
+		a.recordLoginFailure(ctx, user, app.ID, reason)
+		return "", fmt.Errorf("%s: %w: %w", op, err_internal.ErrInvalidPasskey, err)
+	}
+
+	if err := stateError(user.State()); err != nil {
+		log.Warn("inactive user tried to login", zap.String("state", user.State()))
+		a.recordLoginFailure(ctx, user, app.ID, "account_"+user.State())
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := a.checkAppAccess(ctx, user, app); err != nil {
+		if errors.Is(err, err_internal.ErrAppAccessDenied) || errors.Is(err, err_internal.ErrAppAccessPending) {
+			log.Warn("user is not allowed into app", zap.Int("app_id", app.ID), zap.Error(err))
+			a.recordLoginFailure(ctx, user, app.ID, "not_a_member")
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Счётчик обновляется условно: повтор той же подписи параллельно не пройдёт
+	if err := p.storage.UsePasskey(ctx, key.ID, signCount); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, session, err := a.issueToken(ctx, user, app)
+	if err != nil {
+		log.Error("failed to issue token", zap.Error(err))
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user logged in with passkey")
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:   models.AuditLoginSuccess,
+		UserID: user.ID,
+		AppID:  app.ID,
+		Email:  user.Email,
+		Metadata: map[string]string{
+			"session_id":    session.ID,
+			"login_method":  "passkey",
+			"credential_id": base64.RawURLEncoding.EncodeToString(key.ID),
+		},
+	})
+
+	return token, nil
+}
+
+// passkeyUserApp возвращает активного пользователя и его приложение с включёнными ключами доступа.
+func (a *AuthService) passkeyUserApp(ctx context.Context, p *passkeys, userID int64, appID int) (models.User, models.App, error) {
+	user, err := p.storage.UserByID(ctx, models.AnyOrg, userID)
+	if err != nil {
+		return models.User{}, models.App{}, err
+	}
+	if err := stateError(user.State()); err != nil {
+		return models.User{}, models.App{}, err
+	}
+
+	app, err := a.appProvider.App(ctx, user.OrgID, appID)
+	if err != nil {
+		return models.User{}, models.App{}, err
+	}
+	if !app.Passkeys.Enabled() {
+		return models.User{}, models.App{}, err_internal.ErrPasskeysOff
+	}
+
+	return user, app, nil
+}
+
+func (p *passkeys) newChallenge(ctx context.Context, kind string, userID int64, appID int) (models.PasskeyChallenge, error) {
+	ch := models.PasskeyChallenge{
+		ID:        random.Hex(16),
+		Kind:      kind,
+		UserID:    userID,
+		AppID:     appID,
+		Challenge: webauthn.NewChallenge(),
+		ExpiresAt: time.Now().Add(p.timeout),
+	}
+
+	return ch, p.storage.SavePasskeyChallenge(ctx, ch)
+}
+
+func relyingParty(app models.App) webauthn.RelyingParty {
+	name := app.Passkeys.RPName
+	if name == "" {
+		name = app.Name
+	}
+
+	return webauthn.RelyingParty{ID: app.Passkeys.RPID, Name: name, Origins: app.Passkeys.Origins}
+}
+
+func credentials(keys []models.Passkey) []webauthn.Credential {
+	creds := make([]webauthn.Credential, 0, len(keys))
+	for _, k := range keys {
+		creds = append(creds, webauthn.Credential{ID: k.ID, Transports: k.Transports})
+	}
+
+	return creds
+}
+
+// userHandleLen - длина случайного user handle. WebAuthn запрещает
+// выводить из него что-либо о пользователе, поэтому это не ID.
+const userHandleLen = 32
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/mailer"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/random"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// LoginCodeStorage хранит одноразовые коды входа без пароля.
+type LoginCodeStorage interface {
+	SaveLoginCode(ctx context.Context, code models.LoginCode, purgeBefore time.Time) error
+	LoginCodeStats(ctx context.Context, userID int64, since time.Time) (sent int, failed int, err error)
+	UseLoginCode(ctx context.Context, id string, codeHash []byte, limits models.LoginCodeLimits) (models.LoginCode, error)
+	UserByID(ctx context.Context, orgID int64, userID int64) (models.User, error)
+}
+
+// PasswordlessSettings - параметры входа без пароля.
+type PasswordlessSettings struct {
+	CodeTTL    time.Duration
+	LinkTTL    time.Duration
+	CodeLength int
+	LinkURL    string // страница приложения, которой токен ссылки передаётся в параметре token; пусто - ссылки отключены
+	PasswordlessLimits
+}
+
+// PasswordlessLimits - ограничения входа без пароля, меняются без перезапуска.
+type PasswordlessLimits struct {
+	MaxAttempts int // попыток ввода на один код
+	MaxSends    int // писем одному пользователю за LimitWindow
+	MaxFailures int // неверных кодов пользователя за LimitWindow
+	LimitWindow time.Duration
+}
+
+type passwordless struct {
+	codes    LoginCodeStorage
+	mailer   mailer.Mailer
+	settings atomic.Pointer[PasswordlessSettings] // ограничения меняются при перечитывании конфига
+}
+
+// EnablePasswordless включает вход по одноразовым кодам и ссылкам из письма.
+func (a *AuthService) EnablePasswordless(codes LoginCodeStorage, m mailer.Mailer, settings PasswordlessSettings) {
+	p := &passwordless{codes: codes, mailer: m}
+	p.settings.Store(&settings)
+	a.passwordless = p
+}
+
+// SetPasswordlessLimits меняет ограничения входа без пароля.
+// Если вход без пароля не настроен, ничего не делает.
+// Вызывается из одной горутины, перечитывающей конфиг.
+func (a *AuthService) SetPasswordlessLimits(limits PasswordlessLimits) {
+	p := a.passwordless
+	if p == nil {
+		return
+	}
+
+	settings := *p.settings.Load()
+	settings.PasswordlessLimits = limits
+	p.settings.Store(&settings)
+}
+
+// StartPasswordlessLogin отправляет пользователю код или ссылку для входа
+// в приложение appID и возвращает ID попытки входа, который нужен для ввода кода.
+// Ответ не зависит от того, есть ли такой пользователь: для неизвестного
+// или неактивного пользователя письмо просто не отправляется. Так же молча
+// не отправляются письма сверх MaxSends и после MaxFailures неверных кодов
+// пользователя за LimitWindow. Ошибка отправки письма только пишется в журнал.
+func (a *AuthService) StartPasswordlessLogin(
+	ctx context.Context,
+	org string,
+	email string,
+	appID int,
+	method string,
+) (loginID string, expiresAt time.Time, err error) {
+	const op = "AuthService.StartPasswordlessLogin"
+	log := a.log.With(zap.String("method", op), zap.String("org", org), zap.String("email", email))
+
+	p := a.passwordless
+	if p == nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err_internal.ErrPasswordlessOff)
+	}
+	settings := p.settings.Load()
+
+	var ttl time.Duration
+	switch method {
+	case models.LoginMethodCode:
+		ttl = settings.CodeTTL
+	case models.LoginMethodLink:
+		if settings.LinkURL == "" {
+			return "", time.Time{}, fmt.Errorf("%s: %w", op, err_internal.ErrPasswordlessOff)
+		}
+		ttl = settings.LinkTTL
+	default:
+		return "", time.Time{}, fmt.Errorf("%s: %w", op,
+			err_internal.NewValidationError("method", "method must be code or magic_link"))
+	}
+
+	now := time.Now().UTC()
+	loginID, expiresAt = random.Hex(16), now.Add(ttl).Truncate(time.Second)
+
+	orgID, err := a.orgID(ctx, org)
+	if errors.Is(err, err_internal.ErrOrgNotFound) {
+		log.Warn("passwordless login to unknown org")
+		a.recordLoginFailure(ctx, models.User{Email: email}, appID, "org_not_found")
+		return loginID, expiresAt, nil
+	}
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	app, err := a.appProvider.App(ctx, orgID, appID)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := a.usrProvider.User(ctx, orgID, email)
+	if errors.Is(err, err_internal.ErrUserNotFound) {
+		log.Warn("passwordless login of unknown user")
+		a.recordLoginFailure(ctx, models.User{Email: email}, appID, "user_not_found")
+		return loginID, expiresAt, nil
+	}
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if stateError(user.State()) != nil {
+		log.Warn("inactive user tried to login", zap.String("state", user.State()))
+		a.recordLoginFailure(ctx, user, appID, "account_"+user.State())
+		return loginID, expiresAt, nil
+	}
+
+	since := now.Add(-settings.LimitWindow)
+	sent, failed, err := p.codes.LoginCodeStats(ctx, user.ID, since)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if sent >= settings.MaxSends || failed >= settings.MaxFailures {
+		log.Warn("passwordless login rate limited", zap.Int("sent", sent), zap.Int("failed", failed))
+		a.recordLoginFailure(ctx, user, appID, "passwordless_rate_limited")
+		return loginID, expiresAt, nil
+	}
+
+	secret := random.Digits(settings.CodeLength)
+	if method == models.LoginMethodLink {
+		secret = loginID + "." + random.Token(32)
+	}
+
+	err = p.codes.SaveLoginCode(ctx, models.LoginCode{
+		ID:        loginID,
+		UserID:    user.ID,
+		AppID:     appID,
+		Method:    method,
+		CodeHash:  loginCodeHash(loginID, secret),
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}, since)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Ошибка отправки не возвращается: иначе ответ отличал бы существующих пользователей
+	if err := p.mailer.Send(ctx, p.message(method, user.Email, app, secret, ttl)); err != nil {
+		log.Error("failed to send login code", zap.Error(err))
+		a.recordLoginFailure(ctx, user, appID, "login_code_not_sent")
+		return loginID, expiresAt, nil
+	}
+
+	log.Info("login code sent", zap.String("login_method", method))
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditLoginCodeSent,
+		UserID:   user.ID,
+		AppID:    appID,
+		Email:    user.Email,
+		Metadata: map[string]string{"login_method": method, "login_id": loginID},
+	})
+
+	return loginID, expiresAt, nil
+}
+
+// CompletePasswordlessLogin погашает код из письма или токен ссылки и выдаёт
+// такой же токен, как Login. Для кода нужен loginID из StartPasswordlessLogin,
+// токен ссылки содержит его сам.
+func (a *AuthService) CompletePasswordlessLogin(ctx context.Context, loginID string, codeOrToken string) (string, error) {
+	const op = "AuthService.CompletePasswordlessLogin"
+	log := a.log.With(zap.String("method", op))
+
+	p := a.passwordless
+	if p == nil {
+		return "", fmt.Errorf("%s: %w", op, err_internal.ErrPasswordlessOff)
+	}
+	settings := p.settings.Load()
+
+	secret := strings.TrimSpace(codeOrToken)
+	if id, _, ok := strings.Cut(secret, "."); ok {
+		if loginID != "" && loginID != id {
+			return "", fmt.Errorf("%s: %w", op, err_internal.ErrInvalidLoginCode)
+		}
+		loginID = id
+	}
+	if loginID == "" {
+		return "", fmt.Errorf("%s: %w", op, err_internal.NewValidationError("login_id", "login_id is required for a code"))
+	}
+
+	code, err := p.codes.UseLoginCode(ctx, loginID, loginCodeHash(loginID, secret), models.LoginCodeLimits{
+		MaxAttempts: settings.MaxAttempts,
+		MaxFailures: settings.MaxFailures,
+		Since:       time.Now().Add(-settings.LimitWindow),
+	})
+	if err != nil {
+		if errors.Is(err, err_internal.ErrInvalidLoginCode) {
+			log.Warn("invalid login code", zap.String("login_id", loginID))
+			if code.UserID != 0 {
+				a.recordLoginFailure(ctx, models.User{ID: code.UserID}, code.AppID, "invalid_login_code")
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := p.codes.UserByID(ctx, models.AnyOrg, code.UserID)
+	if err != nil {
+		if errors.Is(err, err_internal.ErrUserNotFound) {
+			return "", fmt.Errorf("%s: %w", op, err_internal.ErrInvalidLoginCode)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	log = log.With(zap.Int64("uid", user.ID))
+
+	// Пользователя могли заблокировать, пока письмо шло
+	if err := stateError(user.State()); err != nil {
+		log.Warn("inactive user tried to login", zap.String("state", user.State()))
+		a.recordLoginFailure(ctx, user, code.AppID, "account_"+user.State())
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	app, err := a.appProvider.App(ctx, user.OrgID, code.AppID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := a.checkAppAccess(ctx, user, app); err != nil {
+		if errors.Is(err, err_internal.ErrAppAccessDenied) || errors.Is(err, err_internal.ErrAppAccessPending) {
+			log.Warn("user is not allowed into app", zap.Int("app_id", app.ID), zap.Error(err))
+			a.recordLoginFailure(ctx, user, app.ID, "not_a_member")
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, session, err := a.issueToken(ctx, user, app)
+	if err != nil {
+		log.Error("failed to issue token", zap.Error(err))
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user logged in without password", zap.String("login_method", code.Method))
+	a.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditLoginSuccess,
+		UserID:   user.ID,
+		AppID:    app.ID,
+		Email:    user.Email,
+		Metadata: map[string]string{"session_id": session.ID, "login_method": code.Method},
+	})
+
+	return token, nil
+}
+
+// message собирает письмо с кодом или ссылкой входа.
+func (p *passwordless) message(method, email string, app models.App, secret string, ttl time.Duration) mailer.Message {
+	if method == models.LoginMethodLink {
+		link := p.settings.Load().LinkURL
+		if u, err := url.Parse(link); err == nil {
+			q := u.Query()
+			q.Set("token", secret)
+			u.RawQuery = q.Encode()
+			link = u.String()
+		}
+
+		return mailer.Message{
+			To:      email,
+			Subject: "Log in to " + app.Name,
+			Body: fmt.Sprintf("Open this link to log in to %s:\n\n%s\n\nThe link works once and expires in %s. "+
+				"If you did not try to log in, ignore this email.\n", app.Name, link, ttl),
+		}
+	}
+
+	return mailer.Message{
+		To:      email,
+		Subject: "Your login code for " + app.Name,
+		Body: fmt.Sprintf("Your code to log in to %s: %s\n\nThe code expires in %s. "+
+			"If you did not try to log in, ignore this email.\n", app.Name, secret, ttl),
+	}
+}
+
+// loginCodeHash - хэш кода, который хранится в базе. ID попытки входа
+// служит солью, чтобы одинаковые коды давали разные хэши.
+func loginCodeHash(loginID, secret string) []byte {
+	sum := sha256.Sum256([]byte(loginID + ":" + secret))
+	return sum[:]
+}
@@ -0,0 +1,265 @@
+// Package profile - профили пользователей и метаданные приложений.
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // проверка часовых поясов не зависит от системной базы
+	"unicode/utf8"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+)
+
+const (
+	maxDisplayName  = 128
+	maxAvatarURL    = 2048
+	maxMetadataSize = 16 << 10 // байт JSON на пространство имён приложения
+)
+
+type Storage interface {
+	UserByID(ctx context.Context, orgID int64, userID int64) (models.User, error)
+	Profile(ctx context.Context, userID int64, appID int) (models.Profile, error)
+	UpdateProfile(ctx context.Context, profile models.Profile, fields []string, version int64) (models.Profile, error)
+}
+
+// Auditor записывает события безопасности в журнал аудита.
+type Auditor interface {
+	Record(ctx context.Context, event models.AuditEvent)
+}
+
+// Service читает и меняет профили. Пользователь работает со своим профилем,
+// глобальный администратор - с любым, администратор организации - с профилями
+// своей организации. Метаданные берутся из пространства имён приложения,
+// для которого выдан токен вызывающего.
+type Service struct {
+	log     *zap.Logger
+	storage Storage
+	auditor Auditor
+}
+
+// New creates a new instance of profile Service.
+func New(log *zap.Logger, storage Storage, auditor Auditor) *Service {
+	return &Service{
+		log:     log,
+		storage: storage,
+		auditor: auditor,
+	}
+}
+
+// Update - изменения профиля. Меняются только поля из Fields, остальные
+// значения не учитываются. Путь metadata заменяет метаданные приложения
+// целиком, metadata.<key> - один ключ (если его нет в Metadata, он удаляется).
+type Update struct {
+	DisplayName string
+	Locale      string
+	Timezone    string
+	AvatarURL   string
+	Metadata    map[string]any
+	Fields      []string
+	Version     int64 // ожидаемая версия профиля, 0 - без проверки
+}
+
+// Get возвращает профиль пользователя userID.
+func (s *Service) Get(ctx context.Context, caller models.TokenClaims, userID int64) (models.Profile, error) {
+	const op = "profile.Service.Get"
+
+	profile, err := s.profile(ctx, caller, userID)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profile, nil
+}
+
+// Update меняет поля профиля из upd.Fields. Если upd.Version задана и не совпадает
+// с текущей, возвращает ErrVersionConflict: профиль успел измениться.
+func (s *Service) Update(ctx context.Context, caller models.TokenClaims, userID int64, upd Update) (models.Profile, error) {
+	const op = "profile.Service.Update"
+	log := s.log.With(zap.String("method", op), zap.Int64("uid", userID))
+
+	fields, err := normalizeFields(upd.Fields)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	profile, err := s.profile(ctx, caller, userID)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if upd.Version != 0 && upd.Version != profile.User.Version {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err_internal.ErrVersionConflict)
+	}
+
+	if err := apply(&profile, upd, fields); err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Версия из базы защищает и от гонки между чтением и записью здесь
+	profile, err = s.storage.UpdateProfile(ctx, profile, fields, profile.User.Version)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	metadata := map[string]string{
+		"fields":  strings.Join(fields, ","),
+		"version": strconv.FormatInt(profile.User.Version, 10),
+	}
+	if caller.UserID != userID {
+		metadata["updated_by"] = strconv.FormatInt(caller.UserID, 10)
+	}
+
+	log.Info("profile updated", zap.Strings("fields", fields), zap.Int64("by", caller.UserID))
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditProfileUpdated,
+		UserID:   userID,
+		AppID:    caller.AppID,
+		Email:    profile.User.Email,
+		Metadata: metadata,
+	})
+
+	return profile, nil
+}
+
+// profile загружает профиль и проверяет, что вызывающему он доступен.
+func (s *Service) profile(ctx context.Context, caller models.TokenClaims, userID int64) (models.Profile, error) {
+	profile, err := s.storage.Profile(ctx, userID, caller.AppID)
+	if err != nil {
+		return models.Profile{}, err
+	}
+
+	if err := s.authorize(ctx, caller, profile.User); err != nil {
+		return models.Profile{}, err
+	}
+
+	return profile, nil
+}
+
+func (s *Service) authorize(ctx context.Context, caller models.TokenClaims, target models.User) error {
+	if caller.UserID == target.ID {
+		return nil
+	}
+
+	// Права проверяются по базе, а не по claims: они могли измениться после входа
+	user, err := s.storage.UserByID(ctx, models.AnyOrg, caller.UserID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case user.IsAdmin:
+		return nil
+	case user.OrgAdmin && user.OrgID == target.OrgID && !target.IsAdmin:
+		return nil
+	}
+
+	return err_internal.ErrPermissionDenied
+}
+
+// normalizeFields проверяет пути маски и убирает повторы.
+func normalizeFields(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, err_internal.NewValidationError("update_mask", "update_mask is required")
+	}
+
+	var fields []string
+	for _, path := range paths {
+		switch {
+		case path == models.ProfileDisplayName, path == models.ProfileLocale,
+			path == models.ProfileTimezone, path == models.ProfileAvatarURL,
+			path == models.ProfileMetadata:
+		case strings.HasPrefix(path, models.ProfileMetadata+".") && len(path) > len(models.ProfileMetadata)+1:
+		default:
+			return nil, err_internal.NewValidationError("update_mask", fmt.Sprintf("unknown field %q", path))
+		}
+		if !slices.Contains(fields, path) {
+			fields = append(fields, path)
+		}
+	}
+
+	return fields, nil
+}
+
+// apply переносит в профиль поля из маски и проверяет получившиеся значения.
+func apply(profile *models.Profile, upd Update, fields []string) error {
+	u := &profile.User
+
+	for _, field := range fields {
+		switch field {
+		case models.ProfileDisplayName:
+			u.DisplayName = strings.TrimSpace(upd.DisplayName)
+			if utf8.RuneCountInString(u.DisplayName) > maxDisplayName {
+				return err_internal.NewValidationError(field, fmt.Sprintf("must be at most %d characters", maxDisplayName))
+			}
+
+		case models.ProfileLocale:
+			u.Locale = ""
+			if upd.Locale != "" {
+				tag, err := language.Parse(upd.Locale)
+				if err != nil {
+					return err_internal.NewValidationError(field, "must be a BCP 47 language tag, e.g. en-US")
+				}
+				u.Locale = tag.String()
+			}
+
+		case models.ProfileTimezone:
+			u.Timezone = upd.Timezone
+			if upd.Timezone != "" {
+				if _, err := time.LoadLocation(upd.Timezone); err != nil || upd.Timezone == "Local" {
+					return err_internal.NewValidationError(field, "must be an IANA time zone, e.g. Europe/Berlin")
+				}
+			}
+
+		case models.ProfileAvatarURL:
+			u.AvatarURL = upd.AvatarURL
+			if err := validateAvatarURL(upd.AvatarURL); err != nil {
+				return err
+			}
+
+		case models.ProfileMetadata:
+			profile.Metadata = upd.Metadata
+
+		default: // metadata.<key>
+			key := strings.TrimPrefix(field, models.ProfileMetadata+".")
+			if value, ok := upd.Metadata[key]; ok {
+				if profile.Metadata == nil {
+					profile.Metadata = make(map[string]any)
+				}
+				profile.Metadata[key] = value
+			} else {
+				delete(profile.Metadata, key)
+			}
+		}
+	}
+
+	raw, err := json.Marshal(profile.Metadata)
+	if err != nil {
+		return err_internal.NewValidationError(models.ProfileMetadata, "must be a JSON object")
+	}
+	if len(raw) > maxMetadataSize {
+		return err_internal.NewValidationError(models.ProfileMetadata, fmt.Sprintf("must be at most %d bytes of JSON", maxMetadataSize))
+	}
+
+	return nil
+}
+
+func validateAvatarURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || len(raw) > maxAvatarURL {
+		return err_internal.NewValidationError(models.ProfileAvatarURL, "must be an absolute http(s) URL")
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package profile
+
+import (
+	"context"
+	"testing"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type memStorage struct {
+	users    map[int64]models.User
+	metadata map[[2]int64]map[string]any
+}
+
+func (m *memStorage) UserByID(_ context.Context, _ int64, userID int64) (models.User, error) {
+	user, ok := m.users[userID]
+	if !ok {
+		return models.User{}, err_internal.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (m *memStorage) Profile(_ context.Context, userID int64, appID int) (models.Profile, error) {
+	user, ok := m.users[userID]
+	if !ok {
+		return models.Profile{}, err_internal.ErrUserNotFound
+	}
+	metadata := map[string]any{}
+	for k, v := range m.metadata[[2]int64{userID, int64(appID)}] {
+		metadata[k] = v
+	}
+	return models.Profile{User: user, AppID: appID, Metadata: metadata}, nil
+}
+
+func (m *memStorage) UpdateProfile(_ context.Context, p models.Profile, _ []string, version int64) (models.Profile, error) {
+	if m.users[p.User.ID].Version != version {
+		return models.Profile{}, err_internal.ErrVersionConflict
+	}
+	p.User.Version++
+	m.users[p.User.ID] = p.User
+	m.metadata[[2]int64{p.User.ID, int64(p.AppID)}] = p.Metadata
+	return p, nil
+}
+
+type nopAuditor struct{}
+
+func (nopAuditor) Record(context.Context, models.AuditEvent) {}
+
+func newTestService() (*Service, *memStorage) {
+	storage := &memStorage{
+		users: map[int64]models.User{
+			1: {ID: 1, OrgID: 1, Version: 1},
+			2: {ID: 2, OrgID: 1, Version: 1},
+			3: {ID: 3, OrgID: 2, Version: 1, OrgAdmin: true},
+		},
+		metadata: map[[2]int64]map[string]any{},
+	}
+	return New(zap.NewNop(), storage, nopAuditor{}), storage
+}
+
+func TestUpdate_FieldMask(t *testing.T) {
+	svc, storage := newTestService()
+	ctx := context.Background()
+	caller := models.TokenClaims{UserID: 1, AppID: 10}
+
+	p, err := svc.Update(ctx, caller, 1, Update{
+		DisplayName: " Alice ",
+		Locale:      "en-us",
+		Timezone:    "Europe/Berlin",
+		Metadata:    map[string]any{"theme": "dark", "ignored": true},
+		Fields:      []string{"display_name", "locale", "metadata.theme"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", p.User.DisplayName)
+	assert.Equal(t, "en-US", p.User.Locale)
+	assert.Empty(t, p.User.Timezone, "not in the mask")
+	assert.Equal(t, map[string]any{"theme": "dark"}, p.Metadata)
+	assert.EqualValues(t, 2, p.User.Version)
+
+	// Метаданные другого приложения не видны
+	other, err := svc.Get(ctx, models.TokenClaims{UserID: 1, AppID: 11}, 1)
+	require.NoError(t, err)
+	assert.Empty(t, other.Metadata)
+
+	// Ключ без значения удаляется
+	p, err = svc.Update(ctx, caller, 1, Update{Fields: []string{"metadata.theme"}, Version: 2})
+	require.NoError(t, err)
+	assert.Empty(t, p.Metadata)
+	assert.Empty(t, storage.metadata[[2]int64{1, 10}])
+
+	for _, upd := range []Update{
+		{},
+		{Fields: []string{"email"}},
+		{Fields: []string{"locale"}, Locale: "not a locale!"},
+		{Fields: []string{"timezone"}, Timezone: "Mars/Olympus"},
+		{Fields: []string{"avatar_url"}, AvatarURL: "javascript:alert(1)"},
+	} {
+		_, err := svc.Update(ctx, caller, 1, upd)
+		var validation *err_internal.ValidationError
+		assert.ErrorAs(t, err, &validation, "%+v", upd)
+	}
+}
+
+func TestUpdate_VersionConflict(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	caller := models.TokenClaims{UserID: 1, AppID: 10}
+
+	_, err := svc.Update(ctx, caller, 1, Update{DisplayName: "A", Fields: []string{"display_name"}, Version: 1})
+	require.NoError(t, err)
+
+	_, err = svc.Update(ctx, caller, 1, Update{DisplayName: "B", Fields: []string{"display_name"}, Version: 1})
+	assert.ErrorIs(t, err, err_internal.ErrVersionConflict)
+}
+
+func TestAuthorize(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	_, err := svc.Get(ctx, models.TokenClaims{UserID: 2}, 1)
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+
+	// Администратор другой организации
+	_, err = svc.Get(ctx, models.TokenClaims{UserID: 3}, 1)
+	assert.ErrorIs(t, err, err_internal.ErrPermissionDenied)
+}
@@ -0,0 +1,151 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+type Storage interface {
+	Session(ctx context.Context, id string) (models.Session, error)
+	ActiveSessions(ctx context.Context, userID int64) ([]models.Session, error)
+	RevokeSession(ctx context.Context, id string) error
+	RevokeUserSessions(ctx context.Context, userID int64, exceptID string) (int64, error)
+}
+
+// AdminChecker проверяет, что пользователь - администратор.
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+}
+
+// Auditor записывает события безопасности в журнал аудита.
+type Auditor interface {
+	Record(ctx context.Context, event models.AuditEvent)
+}
+
+// Service управляет сессиями пользователей.
+// Пользователь видит и отзывает только свои сессии, администратор - любые.
+type Service struct {
+	log     *zap.Logger
+	storage Storage
+	admins  AdminChecker
+	auditor Auditor
+}
+
+// New creates a new instance of sessions Service.
+func New(
+	log *zap.Logger,
+	storage Storage,
+	admins AdminChecker,
+	auditor Auditor,
+) *Service {
+	return &Service{
+		log:     log,
+		storage: storage,
+		admins:  admins,
+		auditor: auditor,
+	}
+}
+
+// List возвращает активные сессии пользователя userID.
+func (s *Service) List(ctx context.Context, caller models.TokenClaims, userID int64) ([]models.Session, error) {
+	const op = "sessions.Service.List"
+
+	if err := s.authorize(ctx, caller, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessions, err := s.storage.ActiveSessions(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to list sessions", zap.String("method", op), zap.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessions, nil
+}
+
+// Revoke отзывает одну сессию.
+func (s *Service) Revoke(ctx context.Context, caller models.TokenClaims, sessionID string) error {
+	const op = "sessions.Service.Revoke"
+	log := s.log.With(zap.String("method", op), zap.String("session_id", sessionID))
+
+	session, err := s.storage.Session(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.authorize(ctx, caller, session.UserID); err != nil {
+		// Не раскрываем существование чужой сессии.
+		return fmt.Errorf("%s: %w", op, err_internal.ErrSessionNotFound)
+	}
+
+	if err := s.storage.RevokeSession(ctx, sessionID); err != nil {
+		log.Error("failed to revoke session", zap.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("session revoked", zap.Int64("uid", session.UserID), zap.Int64("by", caller.UserID))
+	s.recordRevoked(ctx, caller, session.UserID, session.AppID, map[string]string{"session_id": sessionID})
+
+	return nil
+}
+
+// RevokeAll отзывает все сессии пользователя userID.
+// Если keepCurrent, сессия, из которой пришёл запрос, остаётся активной.
+func (s *Service) RevokeAll(ctx context.Context, caller models.TokenClaims, userID int64, keepCurrent bool) (int64, error) {
+	const op = "sessions.Service.RevokeAll"
+	log := s.log.With(zap.String("method", op), zap.Int64("uid", userID))
+
+	if err := s.authorize(ctx, caller, userID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var except string
+	if keepCurrent && caller.UserID == userID {
+		except = caller.SessionID
+	}
+
+	revoked, err := s.storage.RevokeUserSessions(ctx, userID, except)
+	if err != nil {
+		log.Error("failed to revoke sessions", zap.Error(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("sessions revoked", zap.Int64("count", revoked), zap.Int64("by", caller.UserID))
+	s.recordRevoked(ctx, caller, userID, 0, map[string]string{"all": "true"})
+
+	return revoked, nil
+}
+
+// authorize разрешает доступ к сессиям владельцу и администраторам.
+func (s *Service) authorize(ctx context.Context, caller models.TokenClaims, userID int64) error {
+	if caller.UserID == userID {
+		return nil
+	}
+
+	isAdmin, err := s.admins.IsAdmin(ctx, caller.UserID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return err_internal.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+func (s *Service) recordRevoked(ctx context.Context, caller models.TokenClaims, userID int64, appID int, metadata map[string]string) {
+	if caller.UserID != userID {
+		metadata["revoked_by"] = fmt.Sprint(caller.UserID)
+	}
+
+	s.auditor.Record(ctx, models.AuditEvent{
+		Type:     models.AuditSessionRevoked,
+		UserID:   userID,
+		AppID:    appID,
+		Metadata: metadata,
+	})
+}
@@ -0,0 +1,162 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/publisher"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	claimLease    = time.Minute
+	maxRetryDelay = time.Hour
+)
+
+type DelivererStorage interface {
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, id int64, responseCode int) error
+	MarkWebhookFailed(ctx context.Context, id int64, responseCode int, cause error, maxAttempts int, maxDelay time.Duration) error
+}
+
+// Deliverer отправляет доставки вебхуков. Неудачные доставки повторяются
+// с экспоненциальной задержкой, после maxAttempts попыток доставка
+// попадает в dead-letter очередь. Запросы подписываются так же,
+// как вебхуки событий пользователей (см. publisher.Sign).
+type Deliverer struct {
+	log          *zap.Logger
+	storage      DelivererStorage
+	client       *http.Client
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDeliverer(
+	log *zap.Logger,
+	storage DelivererStorage,
+	timeout time.Duration,
+	pollInterval time.Duration,
+	batchSize int,
+	maxAttempts int,
+) *Deliverer {
+	return &Deliverer{
+		log:          log,
+		storage:      storage,
+		client:       &http.Client{Timeout: timeout},
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		maxAttempts:  maxAttempts,
+	}
+}
+
+// Start запускает фоновую горутину доставки.
+func (d *Deliverer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(ctx)
+	}()
+}
+
+// Stop останавливает доставку и дожидается завершения текущей пачки.
+func (d *Deliverer) Stop() {
+	if d.cancel == nil {
+		return
+	}
+
+	d.cancel()
+	d.wg.Wait()
+}
+
+func (d *Deliverer) run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for d.deliverBatch(ctx) == d.batchSize {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverBatch отправляет одну пачку доставок и возвращает её размер.
+func (d *Deliverer) deliverBatch(ctx context.Context) int {
+	const op = "webhooks.Deliverer.deliverBatch"
+	log := d.log.With(zap.String("op", op))
+
+	deliveries, err := d.storage.ClaimWebhookDeliveries(ctx, d.batchSize, claimLease)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Error("failed to claim webhook deliveries", zap.Error(err))
+		}
+		return 0
+	}
+
+	for _, delivery := range deliveries {
+		code, err := d.send(ctx, delivery)
+
+		markCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err != nil {
+			log.Warn("webhook delivery failed",
+				zap.Int64("delivery_id", delivery.ID),
+				zap.Int("attempt", delivery.Attempts),
+				zap.Error(err),
+			)
+			if err := d.storage.MarkWebhookFailed(markCtx, delivery.ID, code, err, d.maxAttempts, maxRetryDelay); err != nil {
+				log.Error("failed to mark delivery as failed", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+			}
+		} else if err := d.storage.MarkWebhookDelivered(markCtx, delivery.ID, code); err != nil {
+			log.Error("failed to mark delivery as delivered", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+		}
+		cancel()
+	}
+
+	return len(deliveries)
+}
+
+// send отправляет доставку и возвращает код ответа (0, если ответа не было).
+func (d *Deliverer) send(ctx context.Context, delivery models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(publisher.HeaderTimestamp, ts)
+	req.Header.Set(publisher.HeaderEventID, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(publisher.HeaderEventType, delivery.EventType)
+	req.Header.Set(publisher.HeaderSignature, "sha256="+publisher.Sign([]byte(delivery.Secret), ts, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,183 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/publisher"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memStorage - хранилище доставок в памяти, повторяющее семантику repository.
+type memStorage struct {
+	mu         sync.Mutex
+	webhooks   []models.Webhook
+	appIDs     map[int64][]int
+	deliveries []*models.WebhookDelivery
+}
+
+func (m *memStorage) Webhooks(_ context.Context, appID int, eventType string) ([]models.Webhook, error) {
+	var res []models.Webhook
+	for _, w := range m.webhooks {
+		for _, t := range w.EventTypes {
+			if w.AppID == appID && t == eventType {
+				res = append(res, w)
+			}
+		}
+	}
+	return res, nil
+}
+
+func (m *memStorage) UserAppIDs(_ context.Context, userID int64) ([]int, error) {
+	return m.appIDs[userID], nil
+}
+
+func (m *memStorage) SaveWebhookDeliveries(_ context.Context, deliveries []models.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range deliveries {
+		d.ID = int64(len(m.deliveries) + 1)
+		d.Status = models.DeliveryPending
+		for _, w := range m.webhooks {
+			if w.ID == d.WebhookID {
+				d.URL, d.Secret = w.URL, w.Secret
+			}
+		}
+		m.deliveries = append(m.deliveries, &d)
+	}
+	return nil
+}
+
+func (m *memStorage) ClaimWebhookDeliveries(_ context.Context, limit int, _ time.Duration) ([]models.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var res []models.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.Status == models.DeliveryPending && len(res) < limit {
+			d.Attempts++
+			res = append(res, *d)
+		}
+	}
+	return res, nil
+}
+
+func (m *memStorage) MarkWebhookDelivered(_ context.Context, id int64, code int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deliveries[id-1].Status = models.DeliveryDelivered
+	m.deliveries[id-1].ResponseCode = code
+	return nil
+}
+
+func (m *memStorage) MarkWebhookFailed(_ context.Context, id int64, code int, cause error, maxAttempts int, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d := m.deliveries[id-1]
+	d.ResponseCode, d.LastError = code, cause.Error()
+	if d.Attempts >= maxAttempts {
+		d.Status = models.DeliveryDead
+	}
+	return nil
+}
+
+func (m *memStorage) delivery(id int64) models.WebhookDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return *m.deliveries[id-1]
+}
+
+func TestNotifierAndDeliverer(t *testing.T) {
+	const secret = "app-secret"
+
+	var (
+		mu       sync.Mutex
+		received []Payload
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		sig := "sha256=" + publisher.Sign([]byte(secret), r.Header.Get(publisher.HeaderTimestamp), body)
+		if r.Header.Get(publisher.HeaderSignature) != sig {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var p Payload
+		require.NoError(t, json.Unmarshal(body, &p))
+
+		mu.Lock()
+		received = append(received, p)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	storage := &memStorage{
+		webhooks: []models.Webhook{
+			{ID: 1, AppID: 1, URL: receiver.URL, EventTypes: []string{models.WebhookLogin, models.WebhookUserDeleted}, Secret: secret},
+			{ID: 2, AppID: 2, URL: receiver.URL, EventTypes: []string{models.WebhookLogout}, Secret: secret},
+		},
+		appIDs: map[int64][]int{7: {1, 2}},
+	}
+
+	notifier := NewNotifier(zap.NewNop(), storage, 10)
+	ctx := context.Background()
+	notifier.Record(ctx, models.AuditEvent{Type: models.AuditLoginSuccess, UserID: 7, AppID: 1, Email: "u@example.com"})
+	notifier.Record(ctx, models.AuditEvent{Type: models.AuditLoginSuccess, UserID: 7, AppID: 2}) // нет подписки
+	notifier.Record(ctx, models.AuditEvent{Type: models.AuditUserDeleted, UserID: 7})            // всем приложениям пользователя
+	notifier.Record(ctx, models.AuditEvent{Type: models.AuditAdminCheck, UserID: 7})             // не событие вебхуков
+	notifier.Stop()
+
+	require.Len(t, storage.deliveries, 2)
+
+	deliverer := NewDeliverer(zap.NewNop(), storage, time.Second, time.Hour, 10, 3)
+	assert.Equal(t, 2, deliverer.deliverBatch(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 2)
+	assert.Equal(t, models.WebhookLogin, received[0].Type)
+	assert.Equal(t, "u@example.com", received[0].Email)
+	assert.Equal(t, models.WebhookUserDeleted, received[1].Type)
+	assert.Equal(t, models.DeliveryDelivered, storage.delivery(1).Status)
+	assert.Equal(t, http.StatusOK, storage.delivery(1).ResponseCode)
+}
+
+func TestDeliverer_DeadLetter(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	storage := &memStorage{
+		webhooks: []models.Webhook{{ID: 1, AppID: 1, URL: receiver.URL, EventTypes: []string{models.WebhookLogout}, Secret: "s"}},
+	}
+	require.NoError(t, storage.SaveWebhookDeliveries(context.Background(), []models.WebhookDelivery{
+		{WebhookID: 1, EventType: models.WebhookLogout, Payload: []byte(`{}`)},
+	}))
+
+	deliverer := NewDeliverer(zap.NewNop(), storage, time.Second, time.Hour, 10, 2)
+	deliverer.deliverBatch(context.Background())
+	assert.Equal(t, models.DeliveryPending, storage.delivery(1).Status)
+
+	deliverer.deliverBatch(context.Background())
+	d := storage.delivery(1)
+	assert.Equal(t, models.DeliveryDead, d.Status)
+	assert.Equal(t, http.StatusInternalServerError, d.ResponseCode)
+	assert.Contains(t, d.LastError, "500")
+}
@@ -0,0 +1,157 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+// eventTypes сопоставляет события аудита событиям вебхуков.
+var eventTypes = map[string]string{
+	models.AuditLoginSuccess:    models.WebhookLogin,
+	models.AuditLogout:          models.WebhookLogout,
+	models.AuditPasswordChanged: models.WebhookPasswordChanged,
+	models.AuditUserDeleted:     models.WebhookUserDeleted,
+}
+
+type NotifierStorage interface {
+	Webhooks(ctx context.Context, appID int, eventType string) ([]models.Webhook, error)
+	UserAppIDs(ctx context.Context, userID int64) ([]int, error)
+	SaveWebhookDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+}
+
+// Payload - тело запроса вебхука.
+type Payload struct {
+	Type       string    `json:"type"`
+	AppID      int       `json:"app_id"`
+	UserID     int64     `json:"user_id"`
+	Email      string    `json:"email,omitempty"`
+	IP         string    `json:"ip,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Notifier превращает события аудита в доставки вебхуков.
+// Реализует интерфейс Auditor, поэтому подключается рядом с журналом аудита.
+// Как и аудит, работает асинхронно и не замедляет запросы.
+type Notifier struct {
+	log     *zap.Logger
+	storage NotifierStorage
+	events  chan models.AuditEvent
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func NewNotifier(log *zap.Logger, storage NotifierStorage, bufferSize int) *Notifier {
+	n := &Notifier{
+		log:     log,
+		storage: storage,
+		events:  make(chan models.AuditEvent, bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	go n.run()
+
+	return n
+}
+
+// Record ставит событие в очередь, если на него бывают вебхуки.
+func (n *Notifier) Record(_ context.Context, event models.AuditEvent) {
+	if _, ok := eventTypes[event.Type]; !ok {
+		return
+	}
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	select {
+	case n.events <- event:
+	default:
+		n.log.Warn("webhook buffer is full, event dropped",
+			zap.String("type", event.Type),
+			zap.Int64("uid", event.UserID),
+		)
+	}
+}
+
+// Stop дожидается постановки в очередь всех принятых событий.
+func (n *Notifier) Stop() {
+	n.stopOnce.Do(func() {
+		close(n.events)
+		<-n.done
+	})
+}
+
+func (n *Notifier) run() {
+	defer close(n.done)
+
+	for event := range n.events {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := n.enqueue(ctx, event); err != nil {
+			n.log.Error("failed to enqueue webhook deliveries",
+				zap.String("type", event.Type),
+				zap.Int64("uid", event.UserID),
+				zap.Error(err),
+			)
+		}
+		cancel()
+	}
+}
+
+func (n *Notifier) enqueue(ctx context.Context, event models.AuditEvent) error {
+	webhookType := eventTypes[event.Type]
+
+	// События приложения уходят ему, события пользователя - всем
+	// приложениям, в которые он входил.
+	appIDs := []int{event.AppID}
+	if event.AppID == 0 {
+		var err error
+		if appIDs, err = n.storage.UserAppIDs(ctx, event.UserID); err != nil {
+			return err
+		}
+	}
+
+	var deliveries []models.WebhookDelivery
+	for _, appID := range appIDs {
+		webhooks, err := n.storage.Webhooks(ctx, appID, webhookType)
+		if err != nil {
+			return err
+		}
+		if len(webhooks) == 0 {
+			continue
+		}
+
+		payload, err := json.Marshal(Payload{
+			Type:       webhookType,
+			AppID:      appID,
+			UserID:     event.UserID,
+			Email:      event.Email,
+			IP:         event.IP,
+			UserAgent:  event.UserAgent,
+			OccurredAt: event.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, w := range webhooks {
+			deliveries = append(deliveries, models.WebhookDelivery{
+				WebhookID: w.ID,
+				EventType: webhookType,
+				Payload:   payload,
+			})
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return n.storage.SaveWebhookDeliveries(ctx, deliveries)
+}
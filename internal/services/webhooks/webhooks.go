@@ -0,0 +1,211 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	err_internal "github.com/Artemiadze/gRPC-Service/internal/errors"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/random"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+	secretBytes     = 32
+)
+
+// EventTypes - события, на которые можно подписать вебхук.
+var EventTypes = []string{
+	models.WebhookLogin,
+	models.WebhookLogout,
+	models.WebhookPasswordChanged,
+	models.WebhookUserDeleted,
+}
+
+type Storage interface {
+	SaveWebhook(ctx context.Context, webhook models.Webhook) (int64, error)
+	Webhook(ctx context.Context, id int64) (models.Webhook, error)
+	Webhooks(ctx context.Context, appID int, eventType string) ([]models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	WebhookDeliveries(ctx context.Context, webhookID int64, status string, cursor int64, limit int) ([]models.WebhookDelivery, error)
+	RetryWebhookDelivery(ctx context.Context, webhookID int64, id int64) error
+}
+
+// AdminChecker проверяет, что пользователь - администратор.
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+}
+
+// Service управляет подписками приложений на события аутентификации.
+// Подписками управляют администраторы.
+type Service struct {
+	log     *zap.Logger
+	storage Storage
+	admins  AdminChecker
+}
+
+// New creates a new instance of webhooks Service.
+func New(log *zap.Logger, storage Storage, admins AdminChecker) *Service {
+	return &Service{
+		log:     log,
+		storage: storage,
+		admins:  admins,
+	}
+}
+
+// Create регистрирует вебхук. Если secret пуст, он генерируется.
+// Возвращает вебхук вместе с секретом: позже секрет не показывается.
+func (s *Service) Create(ctx context.Context, callerID int64, webhook models.Webhook) (models.Webhook, error) {
+	const op = "webhooks.Service.Create"
+	log := s.log.With(zap.String("method", op), zap.Int("app_id", webhook.AppID))
+
+	if err := s.authorize(ctx, callerID); err != nil {
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := validate(webhook); err != nil {
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if webhook.Secret == "" {
+		webhook.Secret = random.Token(secretBytes)
+	}
+	webhook.CreatedAt = time.Now().UTC()
+
+	id, err := s.storage.SaveWebhook(ctx, webhook)
+	if err != nil {
+		log.Error("failed to save webhook", zap.Error(err))
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+	webhook.ID = id
+
+	log.Info("webhook created", zap.Int64("webhook_id", id), zap.Strings("events", webhook.EventTypes))
+	return webhook, nil
+}
+
+// List возвращает вебхуки приложения без секретов.
+func (s *Service) List(ctx context.Context, callerID int64, appID int) ([]models.Webhook, error) {
+	const op = "webhooks.Service.List"
+
+	if err := s.authorize(ctx, callerID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	webhooks, err := s.storage.Webhooks(ctx, appID, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+func (s *Service) Delete(ctx context.Context, callerID int64, webhookID int64) error {
+	const op = "webhooks.Service.Delete"
+
+	if err := s.authorize(ctx, callerID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.DeleteWebhook(ctx, webhookID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("webhook deleted", zap.String("method", op), zap.Int64("webhook_id", webhookID))
+	return nil
+}
+
+// Deliveries возвращает страницу истории доставок и курсор следующей страницы (0 - страниц больше нет).
+// status = models.DeliveryDead показывает dead-letter очередь.
+func (s *Service) Deliveries(
+	ctx context.Context,
+	callerID int64,
+	webhookID int64,
+	status string,
+	cursor int64,
+	limit int,
+) ([]models.WebhookDelivery, int64, error) {
+	const op = "webhooks.Service.Deliveries"
+
+	if err := s.authorize(ctx, callerID); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := s.storage.Webhook(ctx, webhookID); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch {
+	case limit <= 0:
+		limit = defaultPageSize
+	case limit > maxPageSize:
+		limit = maxPageSize
+	}
+
+	deliveries, err := s.storage.WebhookDeliveries(ctx, webhookID, status, cursor, limit+1)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var next int64
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+		next = deliveries[limit-1].ID
+	}
+
+	return deliveries, next, nil
+}
+
+// Redeliver возвращает доставку из dead-letter очереди в очередь отправки.
+func (s *Service) Redeliver(ctx context.Context, callerID int64, webhookID int64, deliveryID int64) error {
+	const op = "webhooks.Service.Redeliver"
+
+	if err := s.authorize(ctx, callerID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.RetryWebhookDelivery(ctx, webhookID, deliveryID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("webhook delivery requeued", zap.String("method", op), zap.Int64("delivery_id", deliveryID))
+	return nil
+}
+
+func (s *Service) authorize(ctx context.Context, callerID int64) error {
+	isAdmin, err := s.admins.IsAdmin(ctx, callerID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return err_internal.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+func validate(webhook models.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return err_internal.NewValidationError("url", "url must be an absolute http(s) URL")
+	}
+
+	if len(webhook.EventTypes) == 0 {
+		return err_internal.NewValidationError("event_types", "at least one event type is required")
+	}
+	for _, t := range webhook.EventTypes {
+		if !slices.Contains(EventTypes, t) {
+			return err_internal.NewValidationError("event_types", fmt.Sprintf("unknown event type %q", t))
+		}
+	}
+
+	return nil
+}
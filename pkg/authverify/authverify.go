@@ -0,0 +1,92 @@
+// Package authverify проверяет токены SSO на стороне сервисов, которым
+// пользователь их предъявляет: по секрету приложения (Static), по набору
+// ключей JWKS (NewJWKS) или через RPC Introspect (NewIntrospection).
+// Результат проверки кэшируется обёрткой NewCache.
+//
+// Static и JWKS проверяют только подпись и срок действия: отозванная сессия
+// остаётся действующей до истечения токена. Если это важно, нужен Introspection.
+package authverify
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMissingToken - в запросе нет bearer токена.
+	ErrMissingToken = errors.New("authverify: missing bearer token")
+	// ErrInvalidToken - токен не прошёл проверку, истёк или отозван.
+	ErrInvalidToken = errors.New("authverify: invalid token")
+	// ErrPermissionDenied - у пользователя нет нужных прав.
+	ErrPermissionDenied = errors.New("authverify: permission denied")
+)
+
+// Claims - данные проверенного токена.
+type Claims struct {
+	UserID    int64
+	Email     string
+	AppID     int64
+	SessionID string
+	ExpiresAt time.Time
+	IsAdmin   bool
+	Roles     []string
+	OrgID     int64
+	OrgAdmin  bool
+	Issuer    string
+	Audience  []string
+	Custom    map[string]any // дополнительные claims, настроенные для приложения
+}
+
+// HasPermissions сообщает, есть ли у пользователя все роли perms.
+// Администратору разрешено всё.
+func (c Claims) HasPermissions(perms ...string) bool {
+	if c.IsAdmin {
+		return true
+	}
+	for _, p := range perms {
+		if !slices.Contains(c.Roles, p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Verifier проверяет токен. Недействительный токен - ErrInvalidToken,
+// прочие ошибки означают, что проверить токен не удалось.
+type Verifier interface {
+	Verify(ctx context.Context, token string) (Claims, error)
+}
+
+// VerifierFunc позволяет использовать функцию как Verifier.
+type VerifierFunc func(ctx context.Context, token string) (Claims, error)
+
+func (f VerifierFunc) Verify(ctx context.Context, token string) (Claims, error) {
+	return f(ctx, token)
+}
+
+type claimsKey struct{}
+
+// NewContext возвращает контекст с данными токена.
+func NewContext(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext возвращает данные токена, проверенного middleware или интерсептором.
+func FromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
+
+// parseBearer извлекает токен из значения заголовка Authorization.
+func parseBearer(header string) string {
+	const prefix = "bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(header[len(prefix):])
+}
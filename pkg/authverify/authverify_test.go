@@ -0,0 +1,260 @@
+package authverify
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ssojwt "github.com/Artemiadze/gRPC-Service/internal/lib/jwt"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func ssoToken(t *testing.T, app models.App, user models.User, ttl time.Duration) string {
+	t.Helper()
+
+	token, err := ssojwt.GenerateToken(user, app, "session", ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func TestStatic(t *testing.T) {
+	app := models.App{ID: 1, Secret: "secret"}
+	user := models.User{ID: 7, Email: "user@example.com", Roles: []string{"editor"}}
+	v := Static("secret", 1)
+
+	claims, err := v.Verify(context.Background(), ssoToken(t, app, user, time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != 7 || claims.AppID != 1 || !claims.HasPermissions("editor") || claims.HasPermissions("billing") {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	other := models.App{ID: 2, Secret: "secret"}
+	if _, err := v.Verify(context.Background(), ssoToken(t, other, user, time.Hour)); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("token of another app: expected ErrInvalidToken, got %v", err)
+	}
+	if _, err := v.Verify(context.Background(), ssoToken(t, app, user, -time.Minute)); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expired token: expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	enc := base64.RawURLEncoding.EncodeToString
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"n":   enc(key.N.Bytes()),
+		"e":   enc(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func TestJWKSRotation(t *testing.T) {
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	var fetches atomic.Int32
+	current := []map[string]string{rsaJWK("old", oldKey)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"keys": current})
+	}))
+	defer srv.Close()
+
+	sign := func(kid string, key *rsa.PrivateKey) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"uid": 7, "app_id": 1, "exp": time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	v := NewJWKS(srv.URL)
+	v.MinRefreshInterval = time.Nanosecond
+
+	if _, err := v.Verify(context.Background(), sign("old", oldKey)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Незнакомый kid приводит к перечитыванию набора
+	current = []map[string]string{rsaJWK("old", oldKey), rsaJWK("new", newKey)}
+	if _, err := v.Verify(context.Background(), sign("new", newKey)); err != nil {
+		t.Fatal(err)
+	}
+	if fetches.Load() != 2 {
+		t.Fatalf("expected 2 fetches, got %d", fetches.Load())
+	}
+
+	// Подпись чужим ключом с известным kid
+	if _, err := v.Verify(context.Background(), sign("old", newKey)); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestJWKSRSAAlgorithms(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	pinned := rsaJWK("pinned", key)
+	pinned["alg"] = "RS256"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{rsaJWK("any", key), pinned}})
+	}))
+	defer srv.Close()
+
+	sign := func(kid string, method jwt.SigningMethod) string {
+		token := jwt.NewWithClaims(method, jwt.MapClaims{
+			"uid": 7, "app_id": 1, "exp": time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	v := NewJWKS(srv.URL)
+
+	// Ключ RSA без alg проверяет любой RS*
+	for _, method := range []jwt.SigningMethod{jwt.SigningMethodRS256, jwt.SigningMethodRS384, jwt.SigningMethodRS512} {
+		if _, err := v.Verify(context.Background(), sign("any", method)); err != nil {
+			t.Fatalf("%s: %v", method.Alg(), err)
+		}
+	}
+
+	// alg в JWK ограничивает ключ одним алгоритмом
+	if _, err := v.Verify(context.Background(), sign("pinned", jwt.SigningMethodRS512)); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestCache(t *testing.T) {
+	var calls atomic.Int32
+	next := VerifierFunc(func(_ context.Context, token string) (Claims, error) {
+		calls.Add(1)
+		if token == "bad" {
+			return Claims{}, ErrInvalidToken
+		}
+		return Claims{UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil
+	})
+
+	c := NewCache(next, time.Minute, 10)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Verify(context.Background(), "good")
+	c.Verify(context.Background(), "good")
+	if calls.Load() != 1 {
+		t.Fatalf("result was not cached: %d calls", calls.Load())
+	}
+
+	now = now.Add(2 * time.Minute)
+	c.Verify(context.Background(), "good")
+	if calls.Load() != 2 {
+		t.Fatalf("cached result outlived ttl: %d calls", calls.Load())
+	}
+
+	c.Verify(context.Background(), "bad")
+	c.Verify(context.Background(), "bad")
+	if calls.Load() != 4 {
+		t.Fatalf("rejected token was cached: %d calls", calls.Load())
+	}
+}
+
+var testVerifier = VerifierFunc(func(_ context.Context, token string) (Claims, error) {
+	switch token {
+	case "editor":
+		return Claims{UserID: 1, Roles: []string{"editor"}}, nil
+	case "admin":
+		return Claims{UserID: 2, IsAdmin: true}, nil
+	case "down":
+		return Claims{}, errors.New("sso is down")
+	}
+	return Claims{}, ErrInvalidToken
+})
+
+func TestMiddleware(t *testing.T) {
+	handler := Middleware(testVerifier)(RequirePermissions("billing")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := FromContext(r.Context())
+		json.NewEncoder(w).Encode(claims.UserID)
+	})))
+
+	tests := []struct {
+		token string
+		code  int
+	}{
+		{"", http.StatusUnauthorized},
+		{"bad", http.StatusUnauthorized},
+		{"down", http.StatusServiceUnavailable},
+		{"editor", http.StatusForbidden},
+		{"admin", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.code {
+			t.Errorf("token %q: got status %d, want %d", tt.token, rec.Code, tt.code)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	intercept := UnaryServerInterceptor(testVerifier, Policy{
+		Public:      []string{"/svc/Public"},
+		Permissions: map[string][]string{"/svc/Edit": {"editor"}, "/svc/Bill": {"billing"}},
+	})
+	handler := func(ctx context.Context, _ any) (any, error) { return nil, nil }
+
+	call := func(method, token string) codes.Code {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return status.Code(err)
+	}
+
+	tests := []struct {
+		method, token string
+		code          codes.Code
+	}{
+		{"/svc/Public", "", codes.OK},
+		{"/svc/Edit", "", codes.Unauthenticated},
+		{"/svc/Edit", "bad", codes.Unauthenticated},
+		{"/svc/Edit", "editor", codes.OK},
+		{"/svc/Bill", "editor", codes.PermissionDenied},
+		{"/svc/Bill", "admin", codes.OK},
+		{"/svc/Edit", "down", codes.Unavailable},
+	}
+	for _, tt := range tests {
+		if got := call(tt.method, tt.token); got != tt.code {
+			t.Errorf("%s with %q: got %s, want %s", tt.method, tt.token, got, tt.code)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package authverify
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Cache запоминает результат проверки токена на ttl, но не дольше срока
+// действия токена. Отклонённые токены не кэшируются. С Introspection это
+// значит, что отзыв сессии замечается с задержкой до ttl.
+type Cache struct {
+	next    Verifier
+	ttl     time.Duration
+	maxSize int
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]cacheEntry
+}
+
+type cacheEntry struct {
+	claims    Claims
+	expiresAt time.Time
+}
+
+// NewCache кэширует до maxSize результатов next.
+func NewCache(next Verifier, ttl time.Duration, maxSize int) *Cache {
+	return &Cache{
+		next:    next,
+		ttl:     ttl,
+		maxSize: maxSize,
+		now:     time.Now,
+		entries: make(map[[sha256.Size]byte]cacheEntry),
+	}
+}
+
+func (c *Cache) Verify(ctx context.Context, token string) (Claims, error) {
+	// В памяти хранится хэш, а не сам токен
+	key := sha256.Sum256([]byte(token))
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.claims, nil
+	}
+
+	claims, err := c.next.Verify(ctx, token)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	expiresAt := now.Add(c.ttl)
+	if !claims.ExpiresAt.IsZero() && claims.ExpiresAt.Before(expiresAt) {
+		expiresAt = claims.ExpiresAt
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.maxSize {
+		c.evict(now)
+	}
+	c.entries[key] = cacheEntry{claims: claims, expiresAt: expiresAt}
+
+	return claims, nil
+}
+
+// evict удаляет истёкшие записи, а если их нет - произвольную.
+func (c *Cache) evict(now time.Time) {
+	removed := false
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+			removed = true
+		}
+	}
+	if removed {
+		return
+	}
+
+	for k := range c.entries {
+		delete(c.entries, k)
+		return
+	}
+}
@@ -0,0 +1,104 @@
+package authverify
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Policy задаёт правила доступа к методам gRPC сервера.
+// Ключи - полные имена методов вида "/pkg.Service/Method".
+type Policy struct {
+	// Public - методы, доступные без токена. Токен, если он есть, всё равно проверяется.
+	Public []string
+	// Permissions - роли, нужные для вызова метода.
+	Permissions map[string][]string
+}
+
+// UnaryServerInterceptor проверяет bearer токен входящих запросов и права
+// на вызов метода по policy. Данные токена доступны через FromContext.
+func UnaryServerInterceptor(v Verifier, policy Policy) grpc.UnaryServerInterceptor {
+	public := make(map[string]bool, len(policy.Public))
+	for _, m := range policy.Public {
+		public[m] = true
+	}
+
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, err := authorize(ctx, v, info.FullMethod, public[info.FullMethod], policy.Permissions)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor - UnaryServerInterceptor для потоковых RPC.
+func StreamServerInterceptor(v Verifier, policy Policy) grpc.StreamServerInterceptor {
+	public := make(map[string]bool, len(policy.Public))
+	for _, m := range policy.Public {
+		public[m] = true
+	}
+
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authorize(ss.Context(), v, info.FullMethod, public[info.FullMethod], policy.Permissions)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authorize(ctx context.Context, v Verifier, method string, public bool, perms map[string][]string) (context.Context, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			token = parseBearer(values[0])
+		}
+	}
+
+	if token == "" {
+		if public {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, ErrMissingToken.Error())
+	}
+
+	claims, err := v.Verify(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, ErrInvalidToken.Error())
+		}
+		return nil, status.Error(codes.Unavailable, "authverify: failed to verify token")
+	}
+
+	if !claims.HasPermissions(perms[method]...) {
+		return nil, status.Error(codes.PermissionDenied, ErrPermissionDenied.Error())
+	}
+
+	return NewContext(ctx, claims), nil
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
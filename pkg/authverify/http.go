@@ -0,0 +1,56 @@
+package authverify
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Middleware проверяет bearer токен из заголовка Authorization и кладёт
+// его данные в контекст запроса. Без действительного токена отвечает 401,
+// а если проверить токен не удалось - 503.
+func Middleware(v Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := parseBearer(r.Header.Get("Authorization"))
+			if token == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := v.Verify(r.Context(), token)
+			if err != nil {
+				if errors.Is(err, ErrInvalidToken) {
+					w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+					http.Error(w, "invalid token", http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, "failed to verify token", http.StatusServiceUnavailable)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), claims)))
+		})
+	}
+}
+
+// RequirePermissions пропускает запрос, только если у пользователя есть все
+// роли perms, иначе отвечает 403. Ставится после Middleware.
+func RequirePermissions(perms ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := FromContext(r.Context())
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+			if !claims.HasPermissions(perms...) {
+				http.Error(w, "permission denied", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
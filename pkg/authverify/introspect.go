@@ -0,0 +1,38 @@
+package authverify
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Artemiadze/gRPC-Service/pkg/ssoclient"
+)
+
+// NewIntrospection проверяет токены через RPC Introspect, например
+// *ssoclient.Client. В отличие от Static и JWKS замечает отозванные сессии,
+// но стоит запроса к SSO на каждую проверку, поэтому обычно оборачивается в NewCache.
+func NewIntrospection(in ssoclient.Introspector) Verifier {
+	return VerifierFunc(func(ctx context.Context, token string) (Claims, error) {
+		c, err := in.Introspect(ctx, token)
+		if err != nil {
+			if errors.Is(err, ssoclient.ErrInactiveToken) {
+				return Claims{}, invalid(err)
+			}
+			return Claims{}, err
+		}
+
+		return Claims{
+			UserID:    c.UserID,
+			Email:     c.Email,
+			AppID:     c.AppID,
+			SessionID: c.SessionID,
+			ExpiresAt: c.ExpiresAt,
+			IsAdmin:   c.IsAdmin,
+			Roles:     c.Roles,
+			OrgID:     c.OrgID,
+			OrgAdmin:  c.OrgAdmin,
+			Issuer:    c.Issuer,
+			Audience:  c.Audience,
+			Custom:    c.Custom,
+		}, nil
+	})
+}
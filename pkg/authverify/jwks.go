@@ -0,0 +1,277 @@
+package authverify
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/jwt"
+)
+
+// JWKS проверяет токены ключами из набора JWKS (RFC 7517), загруженного по URL.
+// Поддерживаются ключи oct (HS256), RSA (RS256/384/512) и EC (ES256/384/512).
+// Ключ выбирается по kid из заголовка токена, без kid - по app_id токена,
+// а если ключ в наборе один, то он.
+//
+// Набор перечитывается раз в RefreshInterval и при встрече незнакомого kid,
+// но не чаще MinRefreshInterval. Если загрузить набор не удалось,
+// используется прежний.
+type JWKS struct {
+	url string
+
+	// HTTPClient - клиент для загрузки набора, http.DefaultClient, если nil.
+	HTTPClient *http.Client
+	// RefreshInterval - 1 час, если 0.
+	RefreshInterval time.Duration
+	// MinRefreshInterval - 1 минута, если 0.
+	MinRefreshInterval time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]jwk
+	fetchedAt time.Time
+	triedAt   time.Time
+}
+
+// NewJWKS создаёт проверку по набору ключей с адреса url.
+// Набор загружается при первой проверке.
+func NewJWKS(url string) *JWKS {
+	return &JWKS{url: url}
+}
+
+var errUnknownKey = errors.New("no matching key in JWKS")
+
+func (j *JWKS) Verify(ctx context.Context, token string) (Claims, error) {
+	keys, err := j.keySet(ctx, false)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	claims, err := verifyJWKS(token, keys)
+	if errors.Is(err, errUnknownKey) {
+		// Ключи могли смениться: перечитываем набор и пробуем ещё раз
+		if keys, err = j.keySet(ctx, true); err != nil {
+			return Claims{}, err
+		}
+		claims, err = verifyJWKS(token, keys)
+	}
+	if err != nil {
+		return Claims{}, invalid(err)
+	}
+
+	return claims, nil
+}
+
+func verifyJWKS(token string, keys map[string]jwk) (Claims, error) {
+	claims, err := jwt.VerifyToken(token, func(header, claims map[string]any) (any, error) {
+		key, ok := selectKey(keys, header, claims)
+		if !ok {
+			return nil, errUnknownKey
+		}
+		if alg, _ := header["alg"].(string); !slices.Contains(key.algs, alg) {
+			return nil, fmt.Errorf("key %q is for %s, token is signed with %s", key.Kid, strings.Join(key.algs, ", "), alg)
+		}
+
+		return key.key, nil
+	}, supportedAlgs...)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	return fromModel(claims), nil
+}
+
+func selectKey(keys map[string]jwk, header, claims map[string]any) (jwk, bool) {
+	if kid, ok := header["kid"].(string); ok && kid != "" {
+		key, ok := keys[kid]
+		return key, ok
+	}
+
+	if appID, ok := claims["app_id"].(float64); ok {
+		if key, ok := keys[strconv.FormatInt(int64(appID), 10)]; ok {
+			return key, true
+		}
+	}
+
+	if len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+
+	return jwk{}, false
+}
+
+// keySet возвращает текущий набор ключей и перечитывает его, если он устарел
+// или force и с прошлой попытки прошло не меньше MinRefreshInterval.
+func (j *JWKS) keySet(ctx context.Context, force bool) (map[string]jwk, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	refresh := j.RefreshInterval
+	if refresh == 0 {
+		refresh = time.Hour
+	}
+	minRefresh := j.MinRefreshInterval
+	if minRefresh == 0 {
+		minRefresh = time.Minute
+	}
+
+	now := time.Now()
+	stale := j.keys == nil || now.Sub(j.fetchedAt) >= refresh || force
+	if !stale || (j.keys != nil && now.Sub(j.triedAt) < minRefresh) {
+		return j.keys, nil
+	}
+
+	j.triedAt = now
+	keys, err := j.fetch(ctx)
+	if err != nil {
+		if j.keys != nil {
+			return j.keys, nil
+		}
+		return nil, err
+	}
+
+	j.keys, j.fetchedAt = keys, now
+
+	return keys, nil
+}
+
+func (j *JWKS) fetch(ctx context.Context) (map[string]jwk, error) {
+	const op = "authverify.JWKS.fetch"
+
+	client := j.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status %s", op, resp.Status)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	keys := make(map[string]jwk, len(set.Keys))
+	for i, key := range set.Keys {
+		// Ключи для шифрования и неизвестных типов пропускаются
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if err := key.parse(); err != nil {
+			continue
+		}
+		kid := key.Kid
+		if kid == "" {
+			kid = "#" + strconv.Itoa(i)
+		}
+		keys[kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s: no usable keys", op)
+	}
+
+	return keys, nil
+}
+
+var supportedAlgs = []string{"HS256", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+
+	algs []string // алгоритмы, которыми можно проверять подпись этим ключом
+	key  any
+}
+
+func (k *jwk) parse() error {
+	switch k.Kty {
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil || len(secret) == 0 {
+			return errors.New("invalid oct key")
+		}
+		k.key, k.algs = secret, []string{"HS256"}
+
+	case "RSA":
+		n, err1 := decodeInt(k.N)
+		e, err2 := decodeInt(k.E)
+		if err1 != nil || err2 != nil || !e.IsInt64() {
+			return errors.New("invalid RSA key")
+		}
+		// Без alg ключ RSA подходит для любого RS*
+		k.key, k.algs = &rsa.PublicKey{N: n, E: int(e.Int64())}, []string{"RS256", "RS384", "RS512"}
+
+	case "EC":
+		curves := map[string]struct {
+			curve elliptic.Curve
+			alg   string
+		}{
+			"P-256": {elliptic.P256(), "ES256"},
+			"P-384": {elliptic.P384(), "ES384"},
+			"P-521": {elliptic.P521(), "ES512"},
+		}
+		c, ok := curves[k.Crv]
+		if !ok {
+			return fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err1 := decodeInt(k.X)
+		y, err2 := decodeInt(k.Y)
+		if err1 != nil || err2 != nil || !c.curve.IsOnCurve(x, y) {
+			return errors.New("invalid EC key")
+		}
+		k.key, k.algs = &ecdsa.PublicKey{Curve: c.curve, X: x, Y: y}, []string{c.alg}
+
+	default:
+		return fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+
+	if k.Alg != "" {
+		k.algs = []string{k.Alg}
+	}
+
+	return nil
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(raw) == 0 {
+		return nil, errors.New("invalid integer")
+	}
+
+	return new(big.Int).SetBytes(raw), nil
+}
@@ -0,0 +1,54 @@
+package authverify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Artemiadze/gRPC-Service/internal/lib/jwt"
+	"github.com/Artemiadze/gRPC-Service/internal/models"
+)
+
+// Static проверяет токены секретом приложения, которым их подписывает SSO.
+// Токены других приложений отклоняются.
+func Static(secret string, appID int64) Verifier {
+	return VerifierFunc(func(_ context.Context, token string) (Claims, error) {
+		claims, err := jwt.ParseToken(token, func(id int) (string, error) {
+			if int64(id) != appID {
+				return "", fmt.Errorf("token is issued for app %d", id)
+			}
+			return secret, nil
+		})
+		if err != nil {
+			return Claims{}, invalid(err)
+		}
+
+		return fromModel(claims), nil
+	})
+}
+
+func fromModel(c models.TokenClaims) Claims {
+	return Claims{
+		UserID:    c.UserID,
+		Email:     c.Email,
+		AppID:     int64(c.AppID),
+		SessionID: c.SessionID,
+		ExpiresAt: c.ExpiresAt,
+		IsAdmin:   c.IsAdmin,
+		Roles:     c.Roles,
+		OrgID:     c.OrgID,
+		OrgAdmin:  c.OrgAdmin,
+		Issuer:    c.Issuer,
+		Audience:  c.Audience,
+		Custom:    c.Custom,
+	}
+}
+
+// invalid оборачивает ошибку проверки токена в ErrInvalidToken.
+func invalid(err error) error {
+	if errors.Is(err, ErrInvalidToken) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidToken, err)
+}
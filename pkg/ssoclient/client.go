@@ -0,0 +1,135 @@
+// Package ssoclient - клиент SSO для других сервисов: типизированные вызовы
+// Auth API, кэш токена с обновлением до истечения, повтор запросов
+// при Unavailable и интерсепторы, которые добавляют или проверяют токен.
+package ssoclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"google.golang.org/grpc"
+)
+
+// ErrInactiveToken возвращает Introspect, если токен недействителен,
+// истёк или его сессия отозвана.
+var ErrInactiveToken = errors.New("ssoclient: token is not active")
+
+// Claims - данные действующего токена.
+type Claims struct {
+	UserID    int64
+	Email     string
+	AppID     int64
+	SessionID string
+	ExpiresAt time.Time
+	IsAdmin   bool
+	Roles     []string
+	OrgID     int64
+	OrgAdmin  bool
+	Issuer    string
+	Audience  []string
+	Custom    map[string]any // дополнительные claims приложения
+}
+
+// Client - клиент Auth API.
+type Client struct {
+	conn *grpc.ClientConn // nil, если соединение передано снаружи
+	auth ssov1.AuthClient
+	org  string // пусто - организация по умолчанию
+}
+
+// New подключается к SSO по адресу target. Запросы, завершившиеся
+// с Unavailable, повторяются согласно политике из WithRetry.
+func New(target string, opts ...Option) (*Client, error) {
+	o := newOptions(opts)
+
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(o.creds),
+		grpc.WithChainUnaryInterceptor(UnaryRetryInterceptor(o.retry)),
+	}, o.dialOpts...)
+
+	conn, err := grpc.NewClient(target, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("ssoclient: failed to connect to %s: %w", target, err)
+	}
+
+	return &Client{conn: conn, auth: ssov1.NewAuthClient(conn)}, nil
+}
+
+// NewFromConn создаёт клиент поверх существующего соединения.
+// Close такого клиента соединение не закрывает.
+func NewFromConn(conn grpc.ClientConnInterface) *Client {
+	return &Client{auth: ssov1.NewAuthClient(conn)}
+}
+
+// Close закрывает соединение, открытое New.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// WithOrg возвращает клиент, который регистрирует и входит в организации org.
+// Клиенты разделяют соединение; закрывать достаточно исходный.
+func (c *Client) WithOrg(org string) *Client {
+	return &Client{auth: c.auth, org: org}
+}
+
+// Register регистрирует пользователя и возвращает его ID.
+// Если первая попытка дошла до сервера, но ответ потерялся (Unavailable),
+// повтор вернёт AlreadyExists, хотя пользователь создан этим вызовом.
+func (c *Client) Register(ctx context.Context, email, password string) (int64, error) {
+	resp, err := c.auth.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: password, Org: c.org})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.GetUserId(), nil
+}
+
+// Login выдаёт токен пользователя для приложения appID.
+func (c *Client) Login(ctx context.Context, email, password string, appID int64) (string, error) {
+	resp, err := c.auth.Login(ctx, &ssov1.LoginRequest{Email: email, Password: password, AppId: appID, Org: c.org})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetToken(), nil
+}
+
+// Logout отзывает сессию токена.
+func (c *Client) Logout(ctx context.Context, token string) error {
+	_, err := c.auth.Logout(ctx, &ssov1.LogoutRequest{Token: token})
+	return err
+}
+
+// Introspect проверяет токен на сервере. Для недействительного токена
+// возвращается ErrInactiveToken.
+func (c *Client) Introspect(ctx context.Context, token string) (Claims, error) {
+	resp, err := c.auth.Introspect(ctx, &ssov1.IntrospectRequest{Token: token})
+	if err != nil {
+		return Claims{}, err
+	}
+	if !resp.GetActive() {
+		return Claims{}, ErrInactiveToken
+	}
+
+	return Claims{
+		UserID:    resp.GetUserId(),
+		Email:     resp.GetEmail(),
+		AppID:     resp.GetAppId(),
+		SessionID: resp.GetSessionId(),
+		ExpiresAt: resp.GetExpiresAt().AsTime(),
+		IsAdmin:   resp.GetIsAdmin(),
+		Roles:     resp.GetRoles(),
+		OrgID:     resp.GetOrgId(),
+		OrgAdmin:  resp.GetOrgAdmin(),
+		Issuer:    resp.GetIssuer(),
+		Audience:  resp.GetAudience(),
+		Custom:    resp.GetCustomClaims().AsMap(),
+	}, nil
+}
@@ -0,0 +1,184 @@
+package ssoclient
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeAuth struct {
+	ssov1.UnimplementedAuthServer
+
+	logins      atomic.Int32
+	unavailable atomic.Int32 // сколько следующих вызовов Register ответят Unavailable
+	ttl         time.Duration
+}
+
+func (f *fakeAuth) Login(_ context.Context, req *ssov1.LoginRequest) (*ssov1.LoginResponse, error) {
+	n := f.logins.Add(1)
+	return &ssov1.LoginResponse{Token: fakeJWT(time.Now().Add(f.ttl), fmt.Sprintf("token-%d", n))}, nil
+}
+
+func (f *fakeAuth) Register(context.Context, *ssov1.RegisterRequest) (*ssov1.RegisterResponse, error) {
+	if f.unavailable.Add(-1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	return &ssov1.RegisterResponse{UserId: 42}, nil
+}
+
+func (f *fakeAuth) Introspect(_ context.Context, req *ssov1.IntrospectRequest) (*ssov1.IntrospectResponse, error) {
+	if req.GetToken() != "good" {
+		return &ssov1.IntrospectResponse{Active: false}, nil
+	}
+	return &ssov1.IntrospectResponse{Active: true, UserId: 7, Email: "user@example.com", AppId: 1}, nil
+}
+
+func fakeJWT(exp time.Time, id string) string {
+	enc := base64.RawURLEncoding.EncodeToString
+	payload := fmt.Sprintf(`{"exp":%d,"jti":%q}`, exp.Unix(), id)
+	return enc([]byte(`{"alg":"none"}`)) + "." + enc([]byte(payload)) + ".sig"
+}
+
+func newTestClient(t *testing.T, srv *fakeAuth, opts ...Option) *Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	ssov1.RegisterAuthServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	opts = append(opts, WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})))
+	client, err := New("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestLoginTokenSourceCachesAndRefreshes(t *testing.T) {
+	srv := &fakeAuth{ttl: time.Hour}
+	ts := NewLoginTokenSource(newTestClient(t, srv), "svc@example.com", "secret", 1)
+
+	now := time.Now()
+	ts.now = func() time.Time { return now }
+
+	first, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := ts.Token(context.Background())
+	if first != second || srv.logins.Load() != 1 {
+		t.Fatalf("token was not cached: %d logins", srv.logins.Load())
+	}
+
+	// За минуту до истечения токен обновляется
+	now = now.Add(time.Hour - 30*time.Second)
+	third, _ := ts.Token(context.Background())
+	if third == first || srv.logins.Load() != 2 {
+		t.Fatalf("token was not refreshed before expiry: %d logins", srv.logins.Load())
+	}
+
+	ts.Invalidate()
+	ts.Token(context.Background())
+	if srv.logins.Load() != 3 {
+		t.Fatalf("Invalidate did not drop the cache: %d logins", srv.logins.Load())
+	}
+}
+
+func TestRetryOnUnavailable(t *testing.T) {
+	srv := &fakeAuth{}
+	srv.unavailable.Store(2)
+	client := newTestClient(t, srv, WithRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}))
+
+	id, err := client.Register(context.Background(), "a@example.com", "password")
+	if err != nil || id != 42 {
+		t.Fatalf("Register = %d, %v", id, err)
+	}
+
+	srv.unavailable.Store(3)
+	_, err = client.Register(context.Background(), "a@example.com", "password")
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable after the last attempt, got %v", err)
+	}
+}
+
+func TestServerInterceptor(t *testing.T) {
+	client := newTestClient(t, &fakeAuth{})
+	intercept := UnaryServerInterceptor(client, "/public/Method")
+
+	var got Claims
+	handler := func(ctx context.Context, _ any) (any, error) {
+		got, _ = ClaimsFromContext(ctx)
+		return nil, nil
+	}
+	call := func(method, token string) error {
+		ctx := context.Background()
+		if token != "" {
+			ctx = incoming(ctx, "Bearer "+token)
+		}
+		_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	if err := call("/svc/Method", "good"); err != nil || got.UserID != 7 {
+		t.Fatalf("valid token: claims %+v, err %v", got, err)
+	}
+	if err := call("/svc/Method", "bad"); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("invalid token: expected Unauthenticated, got %v", err)
+	}
+	if err := call("/svc/Method", ""); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("no token: expected Unauthenticated, got %v", err)
+	}
+	if err := call("/public/Method", ""); err != nil {
+		t.Fatalf("skipped method: %v", err)
+	}
+}
+
+func TestClientInterceptorInvalidatesOnUnauthenticated(t *testing.T) {
+	srv := &fakeAuth{ttl: time.Hour}
+	ts := NewLoginTokenSource(newTestClient(t, srv), "svc@example.com", "secret", 1)
+	intercept := UnaryClientInterceptor(ts)
+
+	reject := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.Unauthenticated, "session revoked")
+	}
+	for i := 0; i < 2; i++ {
+		err := intercept(context.Background(), "/svc/Method", nil, nil, nil, reject)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if srv.logins.Load() != 2 {
+		t.Fatalf("expected a new login after Unauthenticated, got %d logins", srv.logins.Load())
+	}
+}
+
+func TestIntrospectInactive(t *testing.T) {
+	client := newTestClient(t, &fakeAuth{})
+
+	if _, err := client.Introspect(context.Background(), "bad"); !errors.Is(err, ErrInactiveToken) {
+		t.Fatalf("expected ErrInactiveToken, got %v", err)
+	}
+}
+
+func incoming(ctx context.Context, authorization string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization))
+}
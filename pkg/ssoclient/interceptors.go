@@ -0,0 +1,198 @@
+package ssoclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// invalidator - TokenSource с кэшем, который можно сбросить.
+type invalidator interface {
+	Invalidate()
+}
+
+// UnaryClientInterceptor добавляет к запросам токен из ts. Если сервер
+// ответил Unauthenticated, кэш ts сбрасывается, и следующий запрос
+// пойдёт с новым токеном.
+func UnaryClientInterceptor(ts TokenSource) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		ctx, err := withToken(ctx, ts)
+		if err != nil {
+			return err
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		invalidateOn(ts, err)
+
+		return err
+	}
+}
+
+// StreamClientInterceptor - UnaryClientInterceptor для потоковых RPC.
+func StreamClientInterceptor(ts TokenSource) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		ctx, err := withToken(ctx, ts)
+		if err != nil {
+			return nil, err
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		invalidateOn(ts, err)
+
+		return stream, err
+	}
+}
+
+func withToken(ctx context.Context, ts TokenSource) (context.Context, error) {
+	token, err := ts.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), nil
+}
+
+func invalidateOn(ts TokenSource, err error) {
+	if status.Code(err) != codes.Unauthenticated {
+		return
+	}
+	if inv, ok := ts.(invalidator); ok {
+		inv.Invalidate()
+	}
+}
+
+// Introspector проверяет токен. Его реализует *Client.
+type Introspector interface {
+	Introspect(ctx context.Context, token string) (Claims, error)
+}
+
+type claimsKey struct{}
+
+// ClaimsFromContext возвращает данные токена, проверенного серверным интерсептором.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
+
+// UnaryServerInterceptor проверяет bearer токен входящих запросов через SSO
+// и кладёт его данные в контекст. Запросы без токена или с недействительным
+// токеном отклоняются с Unauthenticated. Методы из skip (полные имена вида
+// "/pkg.Service/Method") пропускаются без проверки.
+func UnaryServerInterceptor(v Introspector, skip ...string) grpc.UnaryServerInterceptor {
+	skipped := skipSet(skip)
+
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if skipped[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		ctx, err := verify(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor - UnaryServerInterceptor для потоковых RPC.
+func StreamServerInterceptor(v Introspector, skip ...string) grpc.StreamServerInterceptor {
+	skipped := skipSet(skip)
+
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if skipped[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		ctx, err := verify(ss.Context(), v)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func verify(ctx context.Context, v Introspector) (context.Context, error) {
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	claims, err := v.Introspect(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrInactiveToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		// Ошибку SSO не отдаём клиенту как есть: это не его запрос
+		return nil, status.Error(codes.Unavailable, "failed to verify token")
+	}
+
+	return context.WithValue(ctx, claimsKey{}, claims), nil
+}
+
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+
+	const prefix = "bearer "
+	if len(values[0]) < len(prefix) || !strings.EqualFold(values[0][:len(prefix)], prefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(values[0][len(prefix):])
+}
+
+func skipSet(methods []string) map[string]bool {
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[m] = true
+	}
+
+	return set
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,44 @@
+package ssoclient
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Option настраивает клиент, создаваемый New.
+type Option func(*options)
+
+type options struct {
+	creds    credentials.TransportCredentials
+	retry    RetryPolicy
+	dialOpts []grpc.DialOption
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		creds: insecure.NewCredentials(),
+		retry: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithTransportCredentials задаёт TLS. По умолчанию соединение без шифрования.
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+	return func(o *options) { o.creds = creds }
+}
+
+// WithRetry задаёт политику повторов. RetryPolicy{} отключает повторы.
+func WithRetry(p RetryPolicy) Option {
+	return func(o *options) { o.retry = p }
+}
+
+// WithDialOptions добавляет произвольные опции соединения,
+// например интерсепторы трассировки.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOpts = append(o.dialOpts, opts...) }
+}
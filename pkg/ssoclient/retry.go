@@ -0,0 +1,75 @@
+package ssoclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy - повтор запросов, завершившихся с Unavailable.
+// Пауза между попытками растёт от InitialBackoff в Multiplier раз,
+// но не больше MaxBackoff, и случайно уменьшается до половины,
+// чтобы клиенты не повторяли запросы одновременно.
+type RetryPolicy struct {
+	MaxAttempts    int // вместе с первой попыткой, 0 или 1 - без повторов
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+}
+
+// backoff возвращает паузу перед повтором с номером retry (с нуля).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 0; i < retry; i++ {
+		d *= p.Multiplier
+		if p.MaxBackoff > 0 && d >= float64(p.MaxBackoff) {
+			d = float64(p.MaxBackoff)
+			break
+		}
+	}
+
+	return time.Duration(d/2 + rand.Float64()*d/2)
+}
+
+// UnaryRetryInterceptor повторяет запрос по политике p.
+// Unavailable не гарантирует, что сервер запрос не обработал: соединение
+// может оборваться (reset, GOAWAY) уже после обработчика. Тогда повтор
+// неидемпотентного метода выполнит его ещё раз: Register вернёт AlreadyExists,
+// а Login создаст лишнюю сессию.
+func UnaryRetryInterceptor(p RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		var err error
+		for attempt := 0; ; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt+1 >= p.MaxAttempts {
+				return err
+			}
+
+			timer := time.NewTimer(p.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package ssoclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource выдаёт токен для исходящих запросов.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken - токен, который не обновляется.
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// DefaultRefreshBefore - за сколько до истечения токен обновляется.
+const DefaultRefreshBefore = time.Minute
+
+// LoginTokenSource входит под учётной записью сервиса и кэширует токен
+// в памяти. Новый токен запрашивается за RefreshBefore до истечения
+// старого или после Invalidate. Безопасен для конкурентного использования.
+type LoginTokenSource struct {
+	client   *Client
+	email    string
+	password string
+	appID    int64
+
+	// RefreshBefore - запас до истечения токена, DefaultRefreshBefore, если 0.
+	RefreshBefore time.Duration
+
+	now func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func NewLoginTokenSource(client *Client, email, password string, appID int64) *LoginTokenSource {
+	return &LoginTokenSource{
+		client:   client,
+		email:    email,
+		password: password,
+		appID:    appID,
+		now:      time.Now,
+	}
+}
+
+// Token возвращает токен из кэша или входит заново. Одновременные
+// вызовы ждут один вход, а не делают каждый свой.
+func (s *LoginTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refreshBefore := s.RefreshBefore
+	if refreshBefore == 0 {
+		refreshBefore = DefaultRefreshBefore
+	}
+
+	if s.token != "" && s.now().Add(refreshBefore).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	token, err := s.client.Login(ctx, s.email, s.password, s.appID)
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt, err := tokenExpiry(token)
+	if err != nil {
+		return "", err
+	}
+
+	s.token, s.expiresAt = token, expiresAt
+
+	return token, nil
+}
+
+// Invalidate сбрасывает кэш, например после отзыва сессии.
+func (s *LoginTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token, s.expiresAt = "", time.Time{}
+}
+
+// tokenExpiry читает exp из JWT без проверки подписи: токен только что
+// получен от сервера, а секрета приложения у клиента нет.
+func tokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("ssoclient: token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ssoclient: failed to decode token payload: %w", err)
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("ssoclient: failed to decode token payload: %w", err)
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, errors.New("ssoclient: token has no exp claim")
+	}
+
+	return time.Unix(claims.Exp, 0), nil
+}
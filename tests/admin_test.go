@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"testing"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Администратор из tests/fixtures.yaml.
+const (
+	adminEmail    = "admin@example.com"
+	adminPassword = "admin-password"
+)
+
+func TestAdmin_NonAdminDenied(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email, pass := loginNewUser(ctx, t, st)
+	token := login(ctx, t, st, email, pass)
+
+	_, err := st.AdminClient.ListApps(suite.WithToken(ctx, token), &ssov1.ListAppsRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAdmin_LockUser(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	email, pass := loginNewUser(ctx, t, st)
+	userToken := login(ctx, t, st, email, pass)
+
+	locked, err := st.AdminClient.LockUser(adminCtx, &ssov1.LockUserRequest{User: &ssov1.UserRef{Email: email}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, locked.GetRevokedSessions())
+
+	// Сессии заблокированного пользователя отозваны, а войти снова нельзя
+	_, err = st.SessionsClient.ListSessions(suite.WithToken(ctx, userToken), &ssov1.ListSessionsRequest{})
+	assert.Equal(t, errmap.ReasonSessionRevoked, errmap.Reason(err))
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID})
+	assert.Equal(t, errmap.ReasonAccountLocked, errmap.Reason(err))
+
+	_, err = st.AdminClient.UnlockUser(adminCtx, &ssov1.UnlockUserRequest{User: &ssov1.UserRef{UserId: locked.GetUserId()}})
+	require.NoError(t, err)
+
+	login(ctx, t, st, email, pass)
+}
+
+func TestAdmin_CreateAndRotateApp(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	created, err := st.AdminClient.CreateApp(adminCtx, &ssov1.CreateAppRequest{Name: "app-" + randomFakePassword()})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.GetSecret())
+
+	rotated, err := st.AdminClient.RotateAppSecret(adminCtx, &ssov1.RotateAppSecretRequest{AppId: created.GetApp().GetId()})
+	require.NoError(t, err)
+	assert.NotEqual(t, created.GetSecret(), rotated.GetSecret())
+
+	list, err := st.AdminClient.ListApps(adminCtx, &ssov1.ListAppsRequest{})
+	require.NoError(t, err)
+
+	var found bool
+	for _, app := range list.GetApps() {
+		found = found || app.GetId() == created.GetApp().GetId()
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"testing"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListAuditEvents_RequiresToken(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, err := st.AuditClient.ListAuditEvents(ctx, &ssov1.ListAuditEventsRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, errmap.ReasonUnauthenticated, errmap.Reason(err))
+}
+
+func TestListAuditEvents_NonAdminDenied(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email, pass := gofakeit.Email(), randomFakePassword()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: pass})
+	require.NoError(t, err)
+
+	loginResp, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID})
+	require.NoError(t, err)
+
+	_, err = st.AuditClient.ListAuditEvents(suite.WithToken(ctx, loginResp.GetToken()), &ssov1.ListAuditEventsRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, errmap.ReasonPermissionDenied, errmap.Reason(err))
+}
+
+func TestListAuditEvents_InvalidToken(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, err := st.AuditClient.ListAuditEvents(suite.WithToken(ctx, "not-a-token"), &ssov1.ListAuditEventsRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, errmap.ReasonInvalidToken, errmap.Reason(err))
+}
@@ -7,10 +7,13 @@ import (
 	"github.com/Artemiadze/gRPC-Service/tests/suite"
 
 	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -131,6 +134,28 @@ func TestLogin_FailCases(t *testing.T) {
 	}
 }
 
+func TestLogin_ErrorReason(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: randomFakePassword()})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: randomFakePassword(),
+		AppId:    appID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, errmap.ReasonInvalidCredentials, errmap.Reason(err))
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, AppId: appID})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, errmap.ReasonValidationFailed, errmap.Reason(err))
+}
+
 func TestIsAdmin_FalseByDefault(t *testing.T) {
 	ctx, st := suite.New(t)
 
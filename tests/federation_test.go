@@ -0,0 +1,223 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/oidc/oidctest"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const idpName = "corp"
+
+// federationApp создаёт приложение с провайдером corp, который смотрит на fake.
+func federationApp(ctx context.Context, t *testing.T, st *suite.Suite, fake *oidctest.Provider) int64 {
+	t.Helper()
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	app, err := st.AdminClient.CreateApp(adminCtx, &ssov1.CreateAppRequest{Name: "federation-" + gofakeit.LetterN(8)})
+	require.NoError(t, err)
+	appID := app.GetApp().GetId()
+
+	resp, err := st.AdminClient.SetAppIdentityProvider(adminCtx, &ssov1.SetAppIdentityProviderRequest{
+		AppId:    appID,
+		Provider: fakeProvider(fake),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetProvider().GetClientSecret())
+
+	t.Cleanup(func() {
+		_, _ = st.AdminClient.DeleteAppIdentityProvider(adminCtx, &ssov1.DeleteAppIdentityProviderRequest{AppId: appID, Name: idpName})
+	})
+
+	return appID
+}
+
+// fakeProvider - провайдер corp, который смотрит на fake.
+func fakeProvider(fake *oidctest.Provider) *ssov1.IdentityProvider {
+	return &ssov1.IdentityProvider{
+		Name:         idpName,
+		Issuer:       fake.Issuer(),
+		ClientId:     fake.ClientID,
+		ClientSecret: fake.ClientSecret,
+		RedirectUri:  "http://localhost:3000/callback",
+		Scopes:       []string{"email", "profile"},
+	}
+}
+
+// externalLogin проходит вход у fake с claims и возвращает state и code.
+func externalLogin(ctx context.Context, t *testing.T, st *suite.Suite, fake *oidctest.Provider, appID int64, claims map[string]any) (state, code string) {
+	t.Helper()
+
+	start, err := st.AuthClient.StartExternalLogin(ctx, &ssov1.StartExternalLoginRequest{AppId: appID, Provider: idpName})
+	require.NoError(t, err)
+
+	redirect, err := fake.Authorize(start.GetAuthorizationUrl(), claims)
+	require.NoError(t, err)
+	require.Equal(t, start.GetState(), redirect.Query().Get("state"))
+
+	return start.GetState(), redirect.Query().Get("code")
+}
+
+func TestFederation_CreateAndLogin(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	fake, err := oidctest.New("sso", "secret")
+	require.NoError(t, err)
+	defer fake.Close()
+
+	appID := federationApp(ctx, t, st, fake)
+	claims := map[string]any{
+		"sub":            gofakeit.UUID(),
+		"email":          gofakeit.Email(),
+		"email_verified": true,
+		"name":           gofakeit.Name(),
+	}
+
+	state, code := externalLogin(ctx, t, st, fake, appID, claims)
+	first, err := st.AuthClient.FinishExternalLogin(ctx, &ssov1.FinishExternalLoginRequest{State: state, Code: code})
+	require.NoError(t, err)
+	assert.True(t, first.GetCreated())
+	assert.True(t, first.GetLinked())
+
+	introspect, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{Token: first.GetToken()})
+	require.NoError(t, err)
+	assert.True(t, introspect.GetActive())
+	assert.Equal(t, first.GetUserId(), introspect.GetUserId())
+	assert.Equal(t, claims["email"], introspect.GetEmail())
+	assert.Equal(t, appID, introspect.GetAppId())
+
+	// state завершается один раз
+	_, err = st.AuthClient.FinishExternalLogin(ctx, &ssov1.FinishExternalLoginRequest{State: state, Code: code})
+	assert.Equal(t, errmap.ReasonExternalLogin, errmap.Reason(err))
+
+	// Повторный вход находит того же пользователя по subject
+	state, code = externalLogin(ctx, t, st, fake, appID, claims)
+	second, err := st.AuthClient.FinishExternalLogin(ctx, &ssov1.FinishExternalLoginRequest{State: state, Code: code})
+	require.NoError(t, err)
+	assert.Equal(t, first.GetUserId(), second.GetUserId())
+	assert.False(t, second.GetCreated())
+	assert.False(t, second.GetLinked())
+}
+
+func TestFederation_ExistingUser(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	fake, err := oidctest.New("sso", "secret")
+	require.NoError(t, err)
+	defer fake.Close()
+
+	appID := federationApp(ctx, t, st, fake)
+	email, pass := loginNewUser(ctx, t, st)
+	claims := map[string]any{"sub": gofakeit.UUID(), "email": email, "email_verified": true}
+
+	// Существующий аккаунт не привязывается по одному email
+	state, code := externalLogin(ctx, t, st, fake, appID, claims)
+	_, err = st.AuthClient.FinishExternalLogin(ctx, &ssov1.FinishExternalLoginRequest{State: state, Code: code})
+	assert.Equal(t, errmap.ReasonIdentityNotLinked, errmap.Reason(err))
+
+	// Вошедший пользователь привязывает учётную запись сам
+	loginResp, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID})
+	require.NoError(t, err)
+	userCtx := suite.WithToken(ctx, loginResp.GetToken())
+
+	start, err := st.AuthClient.StartExternalLink(userCtx, &ssov1.StartExternalLinkRequest{Provider: idpName})
+	require.NoError(t, err)
+	redirect, err := fake.Authorize(start.GetAuthorizationUrl(), claims)
+	require.NoError(t, err)
+
+	linked, err := st.AuthClient.FinishExternalLogin(ctx, &ssov1.FinishExternalLoginRequest{
+		State: start.GetState(),
+		Code:  redirect.Query().Get("code"),
+	})
+	require.NoError(t, err)
+	assert.True(t, linked.GetLinked())
+	assert.False(t, linked.GetCreated())
+
+	// Теперь вход через провайдер приводит в тот же аккаунт
+	state, code = externalLogin(ctx, t, st, fake, appID, claims)
+	resp, err := st.AuthClient.FinishExternalLogin(ctx, &ssov1.FinishExternalLoginRequest{State: state, Code: code})
+	require.NoError(t, err)
+	assert.Equal(t, linked.GetUserId(), resp.GetUserId())
+}
+
+func TestFederation_NoTakeoverByEmail(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	fake, err := oidctest.New("sso", "secret")
+	require.NoError(t, err)
+	defer fake.Close()
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	// Администратор организации заводит приложение со своим провайдером
+	orgAdminEmail, orgAdminPass := loginNewUser(ctx, t, st)
+	_, err = st.AdminClient.SetOrgAdmin(adminCtx, &ssov1.SetOrgAdminRequest{
+		User:     &ssov1.UserRef{Email: orgAdminEmail},
+		OrgAdmin: true,
+	})
+	require.NoError(t, err)
+	orgAdminCtx := suite.WithToken(ctx, login(ctx, t, st, orgAdminEmail, orgAdminPass))
+
+	app, err := st.AdminClient.CreateApp(orgAdminCtx, &ssov1.CreateAppRequest{Name: "takeover-" + gofakeit.LetterN(8)})
+	require.NoError(t, err)
+	appID := app.GetApp().GetId()
+
+	provider := fakeProvider(fake)
+	provider.TrustEmail = true
+	provider.LinkByEmail = true
+
+	// Привязку по email может включить только глобальный администратор
+	_, err = st.AdminClient.SetAppIdentityProvider(orgAdminCtx, &ssov1.SetAppIdentityProviderRequest{AppId: appID, Provider: provider})
+	assert.Equal(t, errmap.ReasonPermissionDenied, errmap.Reason(err))
+
+	provider.LinkByEmail = false
+	_, err = st.AdminClient.SetAppIdentityProvider(orgAdminCtx, &ssov1.SetAppIdentityProviderRequest{AppId: appID, Provider: provider})
+	require.NoError(t, err)
+
+	victimEmail := gofakeit.Email()
+	victim, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: victimEmail, Password: randomFakePassword()})
+	require.NoError(t, err)
+
+	finish := func(email string) (*ssov1.FinishExternalLoginResponse, error) {
+		claims := map[string]any{"sub": gofakeit.UUID(), "email": email, "email_verified": true}
+		state, code := externalLogin(ctx, t, st, fake, appID, claims)
+		return st.AuthClient.FinishExternalLogin(ctx, &ssov1.FinishExternalLoginRequest{State: state, Code: code})
+	}
+
+	// Провайдер администратора организации не входит в чужие аккаунты
+	for _, email := range []string{adminEmail, victimEmail} {
+		_, err = finish(email)
+		assert.Equal(t, errmap.ReasonIdentityNotLinked, errmap.Reason(err), email)
+	}
+
+	// С привязкой по email, включённой глобальным администратором,
+	// привязывается обычный пользователь, но не администраторы
+	provider.LinkByEmail = true
+	set, err := st.AdminClient.SetAppIdentityProvider(adminCtx, &ssov1.SetAppIdentityProviderRequest{AppId: appID, Provider: provider})
+	require.NoError(t, err)
+	assert.True(t, set.GetProvider().GetLinkByEmail())
+
+	for _, email := range []string{adminEmail, orgAdminEmail} {
+		_, err = finish(email)
+		assert.Equal(t, errmap.ReasonIdentityNotLinked, errmap.Reason(err), email)
+	}
+
+	linked, err := finish(victimEmail)
+	require.NoError(t, err)
+	assert.Equal(t, victim.GetUserId(), linked.GetUserId())
+	assert.True(t, linked.GetLinked())
+}
+
+func TestFederation_UnknownProvider(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, err := st.AuthClient.StartExternalLogin(ctx, &ssov1.StartExternalLoginRequest{AppId: appID, Provider: "missing"})
+	assert.Equal(t, errmap.ReasonIdPNotFound, errmap.Reason(err))
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyHash - хэш пароля "password" в формате passlib pbkdf2_sha256.
+const legacyHash = "$pbkdf2-sha256$6400$0ZrzXitFSGltTQnBWOsdAw$Y11AchqV4b0sUisdZd0Xr97KWoymNE0LNNrnEgY4H9M"
+
+func TestAdmin_ImportUsers(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	email := gofakeit.Email()
+	users := []*ssov1.ImportedUser{
+		{Row: 1, Email: email, PassHash: legacyHash, Roles: []string{"editor"}},
+		{Row: 2, Email: adminEmail, PassHash: legacyHash},
+		{Row: 3, Email: gofakeit.Email(), PassHash: "plain-text"},
+	}
+
+	stream, err := st.AdminClient.ImportUsers(adminCtx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&ssov1.ImportUsersRequest{Users: users[:2]}))
+	require.NoError(t, stream.Send(&ssov1.ImportUsersRequest{Users: users[2:]}))
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1, resp.GetImported())
+	assert.EqualValues(t, 1, resp.GetSkipped())
+	assert.EqualValues(t, 1, resp.GetFailed())
+	assert.EqualValues(t, 3, resp.GetLastRow())
+	require.Len(t, resp.GetErrors(), 1)
+	assert.EqualValues(t, 3, resp.GetErrors()[0].GetRow())
+
+	// Устаревший хэш проверяется и пересчитывается при входе
+	login(ctx, t, st, email, "password")
+	login(ctx, t, st, email, "password")
+
+	export, err := st.AdminClient.ExportUsers(adminCtx, &ssov1.ExportUsersRequest{IncludePassHash: true})
+	require.NoError(t, err)
+
+	var found *ssov1.ExportUsersResponse
+	for {
+		u, err := export.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		if u.GetUser().GetEmail() == email {
+			found = u
+		}
+	}
+	require.NotNil(t, found)
+	assert.Equal(t, []string{"editor"}, found.GetUser().GetRoles())
+	assert.NotEqual(t, legacyHash, found.GetPassHash(), "hash is upgraded")
+	assert.NotEmpty(t, found.GetPassHash())
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin_DisableAndEnableUser(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	email, pass := loginNewUser(ctx, t, st)
+	ref := &ssov1.UserRef{Email: email}
+
+	disabled, err := st.AdminClient.DisableUser(adminCtx, &ssov1.DisableUserRequest{User: ref})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, disabled.GetRevokedSessions())
+
+	loginReq := &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID}
+	_, err = st.AuthClient.Login(ctx, loginReq)
+	assert.Equal(t, errmap.ReasonAccountDisabled, errmap.Reason(err))
+
+	enabled, err := st.AdminClient.EnableUser(adminCtx, &ssov1.EnableUserRequest{User: ref})
+	require.NoError(t, err)
+	assert.Equal(t, "active", enabled.GetState())
+
+	login(ctx, t, st, email, pass)
+}
+
+func TestAdmin_EraseUser(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	email, pass := loginNewUser(ctx, t, st)
+	ref := &ssov1.UserRef{Email: email}
+
+	erased, err := st.AdminClient.EraseUser(adminCtx, &ssov1.EraseUserRequest{User: ref})
+	require.NoError(t, err)
+	assert.True(t, erased.GetDeleteAfter().AsTime().After(time.Now()))
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID})
+	assert.Equal(t, errmap.ReasonAccountDeleted, errmap.Reason(err))
+
+	// До истечения срока удаление можно отменить
+	_, err = st.AdminClient.EnableUser(adminCtx, &ssov1.EnableUserRequest{User: ref})
+	require.NoError(t, err)
+	login(ctx, t, st, email, pass)
+}
+
+func TestAdmin_ExportUserData(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	email, _ := loginNewUser(ctx, t, st)
+
+	resp, err := st.AdminClient.ExportUserData(adminCtx, &ssov1.ExportUserDataRequest{User: &ssov1.UserRef{Email: email}})
+	require.NoError(t, err)
+
+	var export struct {
+		User struct {
+			Email string `json:"email"`
+			State string `json:"state"`
+		} `json:"user"`
+		Sessions []struct {
+			AppID int64 `json:"app_id"`
+		} `json:"sessions"`
+		Audit []struct {
+			Type string `json:"type"`
+		} `json:"audit_events"`
+	}
+	require.NoError(t, json.Unmarshal(resp.GetData(), &export))
+	assert.Equal(t, email, export.User.Email)
+	assert.Equal(t, "active", export.User.State)
+	require.Len(t, export.Sessions, 1)
+	assert.EqualValues(t, appID, export.Sessions[0].AppID)
+	assert.NotEmpty(t, export.Audit)
+	assert.NotContains(t, string(resp.GetData()), "pass_hash")
+}
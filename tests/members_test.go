@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"testing"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppMembers_InviteOnly(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	app, err := st.AdminClient.CreateApp(adminCtx, &ssov1.CreateAppRequest{
+		Name:         "invite-" + gofakeit.LetterN(8),
+		AccessPolicy: "invite_only",
+	})
+	require.NoError(t, err)
+	appID := app.GetApp().GetId()
+
+	email, pass := gofakeit.Email(), randomFakePassword()
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: pass})
+	require.NoError(t, err)
+
+	loginReq := &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID}
+	_, err = st.AuthClient.Login(ctx, loginReq)
+	assert.Equal(t, errmap.ReasonAppAccessDenied, errmap.Reason(err))
+
+	ref := &ssov1.UserRef{Email: email}
+	_, err = st.AdminClient.InviteAppMember(adminCtx, &ssov1.InviteAppMemberRequest{AppId: appID, User: ref})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Login(ctx, loginReq)
+	require.NoError(t, err)
+
+	removed, err := st.AdminClient.RemoveAppMember(adminCtx, &ssov1.RemoveAppMemberRequest{AppId: appID, User: ref})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, removed.GetRevokedSessions())
+
+	_, err = st.AuthClient.Login(ctx, loginReq)
+	assert.Equal(t, errmap.ReasonAppAccessDenied, errmap.Reason(err))
+}
+
+func TestAppMembers_ApprovalRequired(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	app, err := st.AdminClient.CreateApp(adminCtx, &ssov1.CreateAppRequest{
+		Name:         "approval-" + gofakeit.LetterN(8),
+		AccessPolicy: "approval_required",
+	})
+	require.NoError(t, err)
+	appID := app.GetApp().GetId()
+
+	email, pass := gofakeit.Email(), randomFakePassword()
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: pass})
+	require.NoError(t, err)
+
+	// Первый вход создаёт заявку
+	loginReq := &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID}
+	_, err = st.AuthClient.Login(ctx, loginReq)
+	assert.Equal(t, errmap.ReasonAppAccessPending, errmap.Reason(err))
+
+	pending, err := st.AdminClient.ListAppMembers(adminCtx, &ssov1.ListAppMembersRequest{AppId: appID, Status: "pending"})
+	require.NoError(t, err)
+	require.Len(t, pending.GetMembers(), 1)
+	assert.Equal(t, email, pending.GetMembers()[0].GetEmail())
+
+	_, err = st.AdminClient.ApproveAppMember(adminCtx, &ssov1.ApproveAppMemberRequest{AppId: appID, User: &ssov1.UserRef{Email: email}})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Login(ctx, loginReq)
+	require.NoError(t, err)
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgs_Isolation(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	slug := "org-" + strings.ToLower(gofakeit.LetterN(10))
+	org, err := st.AdminClient.CreateOrg(adminCtx, &ssov1.CreateOrgRequest{Slug: slug})
+	require.NoError(t, err)
+	app, err := st.AdminClient.CreateApp(adminCtx, &ssov1.CreateAppRequest{Org: slug, Name: "app"})
+	require.NoError(t, err)
+	assert.Equal(t, org.GetOrg().GetId(), app.GetApp().GetOrgId())
+
+	// Один email - разные пользователи в разных организациях
+	email, pass := gofakeit.Email(), randomFakePassword()
+	inDefault, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: pass})
+	require.NoError(t, err)
+	inOrg, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Org: slug, Email: email, Password: pass})
+	require.NoError(t, err)
+	assert.NotEqual(t, inDefault.GetUserId(), inOrg.GetUserId())
+
+	resp, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{Org: slug, Email: email, Password: pass, AppId: app.GetApp().GetId()})
+	require.NoError(t, err)
+	claims, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{Token: resp.GetToken()})
+	require.NoError(t, err)
+	assert.Equal(t, inOrg.GetUserId(), claims.GetUserId())
+	assert.Equal(t, org.GetOrg().GetId(), claims.GetOrgId())
+
+	// Приложение другой организации не находится
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Org: slug, Email: email, Password: pass, AppId: appID})
+	assert.Equal(t, errmap.ReasonAppNotFound, errmap.Reason(err))
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Org: slug + "-missing", Email: email, Password: pass, AppId: appID})
+	assert.Equal(t, errmap.ReasonInvalidCredentials, errmap.Reason(err))
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"testing"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/internal/lib/webauthn/webauthntest"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Приложение из fixtures.yaml принимает ключи доступа с этого origin.
+const passkeyOrigin = "http://localhost:3000"
+
+func TestPasskeys_RegisterAndLogin(t *testing.T) {
+	ctx, st := suite.New(t)
+	authenticator := webauthntest.New(passkeyOrigin)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+	reg, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: password})
+	require.NoError(t, err)
+	login, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: password, AppId: appID})
+	require.NoError(t, err)
+	userCtx := suite.WithToken(ctx, login.GetToken())
+
+	begin, err := st.AuthClient.BeginPasskeyRegistration(userCtx, &ssov1.BeginPasskeyRegistrationRequest{})
+	require.NoError(t, err)
+	created, err := authenticator.Create([]byte(begin.GetOptionsJson()))
+	require.NoError(t, err)
+
+	finish, err := st.AuthClient.FinishPasskeyRegistration(userCtx, &ssov1.FinishPasskeyRegistrationRequest{
+		CeremonyId:     begin.GetCeremonyId(),
+		CredentialJson: string(created),
+		Name:           "laptop",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, finish.GetCredentialId())
+
+	// Без email аутентификатор сам предлагает ключ пользователя
+	start, err := st.AuthClient.BeginPasskeyLogin(ctx, &ssov1.BeginPasskeyLoginRequest{AppId: appID})
+	require.NoError(t, err)
+	assertion, err := authenticator.Get([]byte(start.GetOptionsJson()))
+	require.NoError(t, err)
+
+	resp, err := st.AuthClient.FinishPasskeyLogin(ctx, &ssov1.FinishPasskeyLoginRequest{
+		CeremonyId:     start.GetCeremonyId(),
+		CredentialJson: string(assertion),
+	})
+	require.NoError(t, err)
+
+	parsed, err := jwt.Parse(resp.GetToken(), func(*jwt.Token) (interface{}, error) { return []byte(appSecret), nil })
+	require.NoError(t, err)
+	claims := parsed.Claims.(jwt.MapClaims)
+	assert.Equal(t, reg.GetUserId(), int64(claims["uid"].(float64)))
+
+	// Challenge одноразовый
+	_, err = st.AuthClient.FinishPasskeyLogin(ctx, &ssov1.FinishPasskeyLoginRequest{
+		CeremonyId:     start.GetCeremonyId(),
+		CredentialJson: string(assertion),
+	})
+	assert.Equal(t, errmap.ReasonInvalidPasskey, errmap.Reason(err))
+}
+
+func TestPasskeys_WrongOrigin(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: password})
+	require.NoError(t, err)
+	login, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: password, AppId: appID})
+	require.NoError(t, err)
+	userCtx := suite.WithToken(ctx, login.GetToken())
+
+	begin, err := st.AuthClient.BeginPasskeyRegistration(userCtx, &ssov1.BeginPasskeyRegistrationRequest{})
+	require.NoError(t, err)
+	created, err := webauthntest.New("https://evil.example").Create([]byte(begin.GetOptionsJson()))
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.FinishPasskeyRegistration(userCtx, &ssov1.FinishPasskeyRegistrationRequest{
+		CeremonyId:     begin.GetCeremonyId(),
+		CredentialJson: string(created),
+	})
+	assert.Equal(t, errmap.ReasonInvalidPasskey, errmap.Reason(err))
+}
@@ -0,0 +1,254 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sentMail ждёт письмо на адрес to в файле mail.file из конфига.
+// Тест пропускается, если письма пишутся не в файл или файл недоступен.
+func sentMail(ctx context.Context, st *suite.Suite, to string) string {
+	st.Helper()
+
+	if st.Cfg.Mail.File == "" {
+		st.Skip("mail.file is not configured")
+	}
+
+	for {
+		f, err := os.Open(st.Cfg.Mail.File)
+		if err != nil {
+			st.Skipf("mail file is not readable: %v", err)
+		}
+
+		var body string
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var msg struct {
+				To   string `json:"to"`
+				Body string `json:"body"`
+			}
+			if json.Unmarshal(scanner.Bytes(), &msg) == nil && msg.To == to {
+				body = msg.Body
+			}
+		}
+		_ = f.Close()
+		if body != "" {
+			return body
+		}
+
+		select {
+		case <-ctx.Done():
+			st.Fatalf("no mail sent to %s", to)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// mailCount считает письма на адрес to в файле mail.file из конфига.
+func mailCount(st *suite.Suite, to string) int {
+	st.Helper()
+
+	if st.Cfg.Mail.File == "" {
+		st.Skip("mail.file is not configured")
+	}
+	f, err := os.Open(st.Cfg.Mail.File)
+	if err != nil {
+		st.Skipf("mail file is not readable: %v", err)
+	}
+	defer f.Close()
+
+	var n int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var msg struct {
+			To string `json:"to"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &msg) == nil && msg.To == to {
+			n++
+		}
+	}
+
+	return n
+}
+
+var (
+	loginCodeRe = regexp.MustCompile(`: (\d+)\n`)
+	loginLinkRe = regexp.MustCompile(`https?://\S+`)
+)
+
+func TestPasswordlessLogin_Code(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	reg, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: randomFakePassword()})
+	require.NoError(t, err)
+
+	start, err := st.AuthClient.StartPasswordlessLogin(ctx, &ssov1.StartPasswordlessLoginRequest{Email: email, AppId: appID})
+	require.NoError(t, err)
+	require.NotEmpty(t, start.GetLoginId())
+	assert.True(t, start.GetExpiresAt().AsTime().After(time.Now()))
+
+	m := loginCodeRe.FindStringSubmatch(sentMail(ctx, st, email))
+	require.Len(t, m, 2, "mail contains the code")
+	code := m[1]
+
+	_, err = st.AuthClient.CompletePasswordlessLogin(ctx, &ssov1.CompletePasswordlessLoginRequest{
+		LoginId:     start.GetLoginId(),
+		CodeOrToken: "x" + code,
+	})
+	assert.Equal(t, errmap.ReasonInvalidLoginCode, errmap.Reason(err))
+
+	resp, err := st.AuthClient.CompletePasswordlessLogin(ctx, &ssov1.CompletePasswordlessLoginRequest{
+		LoginId:     start.GetLoginId(),
+		CodeOrToken: code,
+	})
+	require.NoError(t, err)
+
+	parsed, err := jwt.Parse(resp.GetToken(), func(*jwt.Token) (interface{}, error) { return []byte(appSecret), nil })
+	require.NoError(t, err)
+	claims := parsed.Claims.(jwt.MapClaims)
+	assert.Equal(t, reg.GetUserId(), int64(claims["uid"].(float64)))
+	assert.Equal(t, email, claims["email"])
+
+	// Код одноразовый
+	_, err = st.AuthClient.CompletePasswordlessLogin(ctx, &ssov1.CompletePasswordlessLoginRequest{
+		LoginId:     start.GetLoginId(),
+		CodeOrToken: code,
+	})
+	assert.Equal(t, errmap.ReasonInvalidLoginCode, errmap.Reason(err))
+}
+
+func TestPasswordlessLogin_MagicLink(t *testing.T) {
+	ctx, st := suite.New(t)
+	if st.Cfg.Passwordless.LinkURL == "" {
+		t.Skip("passwordless.link_url is not configured")
+	}
+
+	email := gofakeit.Email()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: randomFakePassword()})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.StartPasswordlessLogin(ctx, &ssov1.StartPasswordlessLoginRequest{
+		Email:  email,
+		AppId:  appID,
+		Method: "magic_link",
+	})
+	require.NoError(t, err)
+
+	link, err := url.Parse(loginLinkRe.FindString(sentMail(ctx, st, email)))
+	require.NoError(t, err)
+	token := link.Query().Get("token")
+	require.NotEmpty(t, token)
+
+	// Токен ссылки сам содержит ID попытки входа
+	resp, err := st.AuthClient.CompletePasswordlessLogin(ctx, &ssov1.CompletePasswordlessLoginRequest{CodeOrToken: token})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetToken())
+}
+
+func TestPasswordlessLogin_AttemptsLimited(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: randomFakePassword()})
+	require.NoError(t, err)
+
+	start, err := st.AuthClient.StartPasswordlessLogin(ctx, &ssov1.StartPasswordlessLoginRequest{Email: email, AppId: appID})
+	require.NoError(t, err)
+	code := loginCodeRe.FindStringSubmatch(sentMail(ctx, st, email))[1]
+
+	for range st.Cfg.Passwordless.MaxAttempts {
+		_, err := st.AuthClient.CompletePasswordlessLogin(ctx, &ssov1.CompletePasswordlessLoginRequest{
+			LoginId:     start.GetLoginId(),
+			CodeOrToken: "wrong",
+		})
+		require.Equal(t, errmap.ReasonInvalidLoginCode, errmap.Reason(err))
+	}
+
+	// После исчерпания попыток не подходит и верный код
+	_, err = st.AuthClient.CompletePasswordlessLogin(ctx, &ssov1.CompletePasswordlessLoginRequest{
+		LoginId:     start.GetLoginId(),
+		CodeOrToken: code,
+	})
+	assert.Equal(t, errmap.ReasonInvalidLoginCode, errmap.Reason(err))
+}
+
+func TestPasswordlessLogin_UnknownUser(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	// Ответ не выдаёт, что пользователя нет
+	start, err := st.AuthClient.StartPasswordlessLogin(ctx, &ssov1.StartPasswordlessLoginRequest{
+		Email: gofakeit.Email(),
+		AppId: appID,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, start.GetLoginId())
+
+	_, err = st.AuthClient.CompletePasswordlessLogin(ctx, &ssov1.CompletePasswordlessLoginRequest{
+		LoginId:     start.GetLoginId(),
+		CodeOrToken: "000000",
+	})
+	assert.Equal(t, errmap.ReasonInvalidLoginCode, errmap.Reason(err))
+}
+
+func TestPasswordlessLogin_SendsLimited(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: randomFakePassword()})
+	require.NoError(t, err)
+
+	// Сверх лимита письма не отправляются, а ответ остаётся прежним
+	for range st.Cfg.Passwordless.MaxSends + 2 {
+		start, err := st.AuthClient.StartPasswordlessLogin(ctx, &ssov1.StartPasswordlessLoginRequest{Email: email, AppId: appID})
+		require.NoError(t, err)
+		require.NotEmpty(t, start.GetLoginId())
+	}
+	assert.Equal(t, st.Cfg.Passwordless.MaxSends, mailCount(st, email))
+}
+
+func TestPasswordlessLogin_FailuresLimited(t *testing.T) {
+	ctx, st := suite.New(t)
+	cfg := st.Cfg.Passwordless
+	if starts := (cfg.MaxFailures + cfg.MaxAttempts - 1) / cfg.MaxAttempts; starts >= cfg.MaxSends {
+		t.Skip("passwordless.max_sends is reached before max_failures")
+	}
+
+	email := gofakeit.Email()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: randomFakePassword()})
+	require.NoError(t, err)
+
+	// Новый код не сбрасывает неверные попытки прежних
+	for failures := 0; failures < cfg.MaxFailures; {
+		start, err := st.AuthClient.StartPasswordlessLogin(ctx, &ssov1.StartPasswordlessLoginRequest{Email: email, AppId: appID})
+		require.NoError(t, err)
+
+		for range min(cfg.MaxAttempts, cfg.MaxFailures-failures) {
+			_, err := st.AuthClient.CompletePasswordlessLogin(ctx, &ssov1.CompletePasswordlessLoginRequest{
+				LoginId:     start.GetLoginId(),
+				CodeOrToken: "wrong",
+			})
+			require.Equal(t, errmap.ReasonInvalidLoginCode, errmap.Reason(err))
+			failures++
+		}
+	}
+	sent := mailCount(st, email)
+
+	_, err = st.AuthClient.StartPasswordlessLogin(ctx, &ssov1.StartPasswordlessLoginRequest{Email: email, AppId: appID})
+	require.NoError(t, err)
+	assert.Equal(t, sent, mailCount(st, email), "no code is sent after max_failures wrong codes")
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"testing"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestProfile_Update(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email, pass := gofakeit.Email(), randomFakePassword()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: pass})
+	require.NoError(t, err)
+	userCtx := suite.WithToken(ctx, login(ctx, t, st, email, pass))
+
+	got, err := st.ProfileClient.GetProfile(userCtx, &ssov1.GetProfileRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, email, got.GetProfile().GetEmail())
+	version := got.GetProfile().GetVersion()
+
+	metadata, err := structpb.NewStruct(map[string]any{"theme": "dark"})
+	require.NoError(t, err)
+	updated, err := st.ProfileClient.UpdateProfile(userCtx, &ssov1.UpdateProfileRequest{
+		Profile: &ssov1.UserProfile{
+			DisplayName: "Alice",
+			Locale:      "de-DE",
+			Timezone:    "Europe/Berlin",
+			Metadata:    metadata,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"display_name", "timezone", "metadata.theme"}},
+		Version:    version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", updated.GetProfile().GetDisplayName())
+	assert.Empty(t, updated.GetProfile().GetLocale(), "not in the mask")
+	assert.Equal(t, "dark", updated.GetProfile().GetMetadata().AsMap()["theme"])
+	assert.Equal(t, version+1, updated.GetProfile().GetVersion())
+
+	// Изменение на основе устаревшей версии отклоняется
+	_, err = st.ProfileClient.UpdateProfile(userCtx, &ssov1.UpdateProfileRequest{
+		Profile:    &ssov1.UserProfile{DisplayName: "Bob"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"display_name"}},
+		Version:    version,
+	})
+	assert.Equal(t, errmap.ReasonVersionConflict, errmap.Reason(err))
+
+	// Чужой профиль обычному пользователю недоступен
+	otherEmail, otherPass := gofakeit.Email(), randomFakePassword()
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: otherEmail, Password: otherPass})
+	require.NoError(t, err)
+	otherCtx := suite.WithToken(ctx, login(ctx, t, st, otherEmail, otherPass))
+	_, err = st.ProfileClient.GetProfile(otherCtx, &ssov1.GetProfileRequest{UserId: got.GetProfile().GetUserId()})
+	assert.Equal(t, errmap.ReasonPermissionDenied, errmap.Reason(err))
+}
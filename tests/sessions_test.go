@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func loginNewUser(ctx context.Context, t *testing.T, st *suite.Suite) (email, pass string) {
+	t.Helper()
+
+	email, pass = gofakeit.Email(), randomFakePassword()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: pass})
+	require.NoError(t, err)
+
+	return email, pass
+}
+
+func login(ctx context.Context, t *testing.T, st *suite.Suite, email, pass string) string {
+	t.Helper()
+
+	resp, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID})
+	require.NoError(t, err)
+
+	return resp.GetToken()
+}
+
+func TestSessions_ListAndRevoke(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email, pass := loginNewUser(ctx, t, st)
+	first := login(ctx, t, st, email, pass)
+	second := login(ctx, t, st, email, pass)
+
+	list, err := st.SessionsClient.ListSessions(suite.WithToken(ctx, first), &ssov1.ListSessionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetSessions(), 2)
+
+	var current, other string
+	for _, s := range list.GetSessions() {
+		if s.GetCurrent() {
+			current = s.GetId()
+		} else {
+			other = s.GetId()
+		}
+	}
+	require.NotEmpty(t, current)
+	require.NotEmpty(t, other)
+
+	_, err = st.SessionsClient.RevokeSession(suite.WithToken(ctx, first), &ssov1.RevokeSessionRequest{SessionId: other})
+	require.NoError(t, err)
+
+	_, err = st.SessionsClient.ListSessions(suite.WithToken(ctx, second), &ssov1.ListSessionsRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, errmap.ReasonSessionRevoked, errmap.Reason(err))
+}
+
+func TestSessions_RevokeAllKeepCurrent(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email, pass := loginNewUser(ctx, t, st)
+	current := login(ctx, t, st, email, pass)
+	login(ctx, t, st, email, pass)
+	login(ctx, t, st, email, pass)
+
+	resp, err := st.SessionsClient.RevokeAllSessions(suite.WithToken(ctx, current), &ssov1.RevokeAllSessionsRequest{KeepCurrent: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.GetRevoked())
+
+	list, err := st.SessionsClient.ListSessions(suite.WithToken(ctx, current), &ssov1.ListSessionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetSessions(), 1)
+	assert.True(t, list.GetSessions()[0].GetCurrent())
+}
+
+func TestSessions_OtherUserDenied(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email, pass := loginNewUser(ctx, t, st)
+	token := login(ctx, t, st, email, pass)
+
+	other, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: gofakeit.Email(), Password: randomFakePassword()})
+	require.NoError(t, err)
+
+	_, err = st.SessionsClient.ListSessions(suite.WithToken(ctx, token), &ssov1.ListSessionsRequest{UserId: other.GetUserId()})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestLogout_RevokesToken(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email, pass := loginNewUser(ctx, t, st)
+	token := login(ctx, t, st, email, pass)
+
+	_, err := st.AuthClient.Logout(ctx, &ssov1.LogoutRequest{Token: token})
+	require.NoError(t, err)
+
+	_, err = st.SessionsClient.ListSessions(suite.WithToken(ctx, token), &ssov1.ListSessionsRequest{})
+	require.Error(t, err)
+	assert.Equal(t, errmap.ReasonSessionRevoked, errmap.Reason(err))
+}
+
+func TestIntrospect_ActiveAndRevoked(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email, pass := loginNewUser(ctx, t, st)
+	token := login(ctx, t, st, email, pass)
+
+	resp, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{Token: token})
+	require.NoError(t, err)
+	assert.True(t, resp.GetActive())
+	assert.Equal(t, email, resp.GetEmail())
+	assert.EqualValues(t, appID, resp.GetAppId())
+	assert.NotEmpty(t, resp.GetSessionId())
+
+	_, err = st.AuthClient.Logout(ctx, &ssov1.LogoutRequest{Token: token})
+	require.NoError(t, err)
+
+	resp, err = st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{Token: token})
+	require.NoError(t, err)
+	assert.False(t, resp.GetActive())
+}
+
+func TestLogin_WithExpiredBearer(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email, pass := loginNewUser(ctx, t, st)
+	token := login(ctx, t, st, email, pass)
+
+	// Тот же токен, но с истёкшим сроком действия
+	parsed, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return []byte(appSecret), nil })
+	require.NoError(t, err)
+	claims := parsed.Claims.(jwt.MapClaims)
+	claims["exp"] = time.Now().Add(-time.Hour).Unix()
+	expiredToken := jwt.NewWithClaims(parsed.Method, claims)
+	expiredToken.Header = parsed.Header
+	expired, err := expiredToken.SignedString([]byte(appSecret))
+	require.NoError(t, err)
+
+	expiredCtx := suite.WithToken(ctx, expired)
+
+	// Публичные RPC не смотрят на старый токен в заголовке
+	resp, err := st.AuthClient.Login(expiredCtx, &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetToken())
+
+	// А RPC, которым нужен пользователь, его отклоняют
+	_, err = st.SessionsClient.ListSessions(expiredCtx, &ssov1.ListSessionsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -11,12 +11,17 @@ import (
 	"github.com/Artemiadze/gRPC-Service/internal/config"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 type Suite struct {
-	*testing.T                  // Потребуется для вызова методов *testing.T внутри Suite
-	Cfg        *config.Config   // Конфигурация приложения
-	AuthClient ssov1.AuthClient // Клиент для взаимодействия с gRPC-сервером
+	*testing.T                          // Потребуется для вызова методов *testing.T внутри Suite
+	Cfg            *config.Config       // Конфигурация приложения
+	AuthClient     ssov1.AuthClient     // Клиент для взаимодействия с gRPC-сервером
+	AuditClient    ssov1.AuditClient    // Клиент журнала аудита
+	SessionsClient ssov1.SessionsClient // Клиент управления сессиями
+	AdminClient    ssov1.AdminClient    // Клиент администрирования
+	ProfileClient  ssov1.ProfileClient  // Клиент профилей
 }
 
 const (
@@ -47,9 +52,13 @@ func New(t *testing.T) (context.Context, *Suite) {
 	}
 
 	return ctx, &Suite{
-		T:          t,
-		Cfg:        cfg,
-		AuthClient: ssov1.NewAuthClient(cc),
+		T:              t,
+		Cfg:            cfg,
+		AuthClient:     ssov1.NewAuthClient(cc),
+		AuditClient:    ssov1.NewAuditClient(cc),
+		SessionsClient: ssov1.NewSessionsClient(cc),
+		AdminClient:    ssov1.NewAdminClient(cc),
+		ProfileClient:  ssov1.NewProfileClient(cc),
 	}
 }
 
@@ -63,6 +72,11 @@ func configPath() string {
 	return "../config/local.yaml"
 }
 
+// WithToken возвращает контекст, в котором запросы отправляются с bearer токеном.
+func WithToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
 func grpcAddress(cfg *config.Config) string {
 	return net.JoinHostPort(grpcHost, strconv.Itoa(cfg.GRPC.Port))
 }
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppTokenSettings(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	app, err := st.AdminClient.CreateApp(adminCtx, &ssov1.CreateAppRequest{
+		Name: "tokens-" + gofakeit.LetterN(8),
+		Token: &ssov1.TokenSettings{
+			TtlSeconds: 300,
+			Issuer:     "https://sso.example.com",
+			Audience:   []string{"crm"},
+			Claims:     map[string]string{"mail": "email"},
+		},
+	})
+	require.NoError(t, err)
+	appID := app.GetApp().GetId()
+
+	email, pass := gofakeit.Email(), randomFakePassword()
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: pass})
+	require.NoError(t, err)
+
+	loginAt := time.Now()
+	resp, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID})
+	require.NoError(t, err)
+
+	claims, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{Token: resp.GetToken()})
+	require.NoError(t, err)
+	assert.Equal(t, "https://sso.example.com", claims.GetIssuer())
+	assert.Equal(t, []string{"crm"}, claims.GetAudience())
+	assert.Equal(t, email, claims.GetCustomClaims().AsMap()["mail"])
+	assert.InDelta(t, loginAt.Add(5*time.Minute).Unix(), claims.GetExpiresAt().AsTime().Unix(), 2)
+
+	// Без настроек приложения действуют глобальные
+	_, err = st.AdminClient.SetAppTokenSettings(adminCtx, &ssov1.SetAppTokenSettingsRequest{AppId: appID})
+	require.NoError(t, err)
+
+	resp, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: pass, AppId: appID})
+	require.NoError(t, err)
+	claims, err = st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{Token: resp.GetToken()})
+	require.NoError(t, err)
+	assert.Empty(t, claims.GetAudience())
+	assert.Empty(t, claims.GetCustomClaims().AsMap())
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	ssov1 "github.com/Artemiadze/gRPC-Service/gen/go/sso"
+	"github.com/Artemiadze/gRPC-Service/internal/grpc/errmap"
+	"github.com/Artemiadze/gRPC-Service/tests/suite"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUsers_Pagination(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	prefix := strings.ToLower(gofakeit.LetterN(10))
+	var emails []string
+	for i := 0; i < 5; i++ {
+		email := prefix + gofakeit.LetterN(4) + "@example.com"
+		_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: randomFakePassword()})
+		require.NoError(t, err)
+		emails = append(emails, email)
+	}
+
+	// Страницы по email в обратном порядке без пропусков и повторов
+	var got []string
+	req := &ssov1.ListUsersRequest{
+		Filter:   &ssov1.UserFilter{EmailPrefix: strings.ToUpper(prefix)},
+		OrderBy:  "email desc",
+		PageSize: 2,
+	}
+	for {
+		resp, err := st.AdminClient.ListUsers(adminCtx, req)
+		require.NoError(t, err)
+		for _, u := range resp.GetUsers() {
+			got = append(got, u.GetEmail())
+		}
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	assert.Len(t, got, len(emails))
+	assert.IsNonIncreasing(t, got)
+
+	// Токен страницы привязан к сортировке
+	first, err := st.AdminClient.ListUsers(adminCtx, &ssov1.ListUsersRequest{
+		Filter:   &ssov1.UserFilter{EmailPrefix: prefix},
+		PageSize: 2,
+	})
+	require.NoError(t, err)
+	_, err = st.AdminClient.ListUsers(adminCtx, &ssov1.ListUsersRequest{
+		OrderBy:   "email",
+		PageToken: first.GetNextPageToken(),
+	})
+	assert.Equal(t, errmap.ReasonValidationFailed, errmap.Reason(err))
+}
+
+func TestSearchUsers(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := suite.WithToken(ctx, login(ctx, t, st, adminEmail, adminPassword))
+
+	needle := strings.ToLower(gofakeit.LetterN(12))
+	email, pass := "user."+needle+"@example.com", randomFakePassword()
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{Email: email, Password: pass})
+	require.NoError(t, err)
+
+	locked := true
+	resp, err := st.AdminClient.SearchUsers(adminCtx, &ssov1.SearchUsersRequest{Query: strings.ToUpper(needle)})
+	require.NoError(t, err)
+	require.Len(t, resp.GetUsers(), 1)
+	assert.Equal(t, email, resp.GetUsers()[0].GetEmail())
+
+	resp, err = st.AdminClient.SearchUsers(adminCtx, &ssov1.SearchUsersRequest{
+		Query:  needle,
+		Filter: &ssov1.UserFilter{Locked: &locked},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetUsers())
+
+	_, err = st.AdminClient.SearchUsers(adminCtx, &ssov1.SearchUsersRequest{Query: "ab"})
+	assert.Equal(t, errmap.ReasonValidationFailed, errmap.Reason(err))
+
+	// Обычный пользователь списка не видит
+	userCtx := suite.WithToken(ctx, login(ctx, t, st, email, pass))
+	_, err = st.AdminClient.SearchUsers(userCtx, &ssov1.SearchUsersRequest{Query: needle})
+	assert.Equal(t, errmap.ReasonPermissionDenied, errmap.Reason(err))
+}